@@ -1,23 +1,30 @@
 package main
 
 import (
-	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/token"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 
+	"github.com/ninja0404/pump-go-sdk/pkg/autofill"
 	sdkconfig "github.com/ninja0404/pump-go-sdk/pkg/config"
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
 	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
 	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpfees"
 	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
 )
 
 func newAccountCmd(opts *globalOpts) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "account [pubkey]",
 		Short: "Inspect an account (pump / pump_amm)",
 		Args:  cobra.ExactArgs(1),
@@ -26,7 +33,10 @@ func newAccountCmd(opts *globalOpts) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			cfg := sdkconfigFromOpts(opts, cmd)
+			cfg, err := sdkconfigFromOpts(opts, cmd)
+			if err != nil {
+				return err
+			}
 			client := sdkrpc.NewClient(cfg)
 
 			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
@@ -49,66 +59,185 @@ func newAccountCmd(opts *globalOpts) *cobra.Command {
 			return nil
 		},
 	}
+	cmd.AddCommand(
+		newAccountDecodeCmd(opts),
+		newAccountSignOfflineCmd(opts),
+		newAccountBroadcastCmd(opts),
+		newAccountCleanupCmd(opts),
+	)
+	return cmd
 }
 
-func decodeKnownAccount(data []byte) (string, interface{}, error) {
-	if len(data) < 8 {
-		return "", nil, fmt.Errorf("account data too short")
-	}
-	decoders := []struct {
-		name string
-		disc []byte
-		new  func() interface {
-			Unmarshal([]byte) error
-		}
-	}{
-		{"pump.BondingCurve", pump.BondingCurveDiscriminator, func() interface{ Unmarshal([]byte) error } { return &pump.BondingCurve{} }},
-		{"pump.FeeConfig", pump.FeeConfigDiscriminator, func() interface{ Unmarshal([]byte) error } { return &pump.FeeConfig{} }},
-		{"pump.Global", pump.GlobalDiscriminator, func() interface{ Unmarshal([]byte) error } { return &pump.Global{} }},
-		{"pump.GlobalVolumeAccumulator", pump.GlobalVolumeAccumulatorDiscriminator, func() interface{ Unmarshal([]byte) error } { return &pump.GlobalVolumeAccumulator{} }},
-		{"pump.UserVolumeAccumulator", pump.UserVolumeAccumulatorDiscriminator, func() interface{ Unmarshal([]byte) error } { return &pump.UserVolumeAccumulator{} }},
-		{"pumpamm.BondingCurve", pumpamm.BondingCurveDiscriminator, func() interface{ Unmarshal([]byte) error } { return &pumpamm.BondingCurve{} }},
-		{"pumpamm.FeeConfig", pumpamm.FeeConfigDiscriminator, func() interface{ Unmarshal([]byte) error } { return &pumpamm.FeeConfig{} }},
-		{"pumpamm.GlobalConfig", pumpamm.GlobalConfigDiscriminator, func() interface{ Unmarshal([]byte) error } { return &pumpamm.GlobalConfig{} }},
-		{"pumpamm.GlobalVolumeAccumulator", pumpamm.GlobalVolumeAccumulatorDiscriminator, func() interface{ Unmarshal([]byte) error } { return &pumpamm.GlobalVolumeAccumulator{} }},
-		{"pumpamm.Pool", pumpamm.PoolDiscriminator, func() interface{ Unmarshal([]byte) error } { return &pumpamm.Pool{} }},
-		{"pumpamm.UserVolumeAccumulator", pumpamm.UserVolumeAccumulatorDiscriminator, func() interface{ Unmarshal([]byte) error } { return &pumpamm.UserVolumeAccumulator{} }},
+func newAccountCleanupCmd(opts *globalOpts) *cobra.Command {
+	var (
+		preview     bool
+		priorityFee uint64
+	)
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Close the fee payer's empty token accounts (SPL and Token-2022) to reclaim rent",
+		Long: "Finds every zero-balance token account owned by the fee payer and closes them,\n" +
+			"batched into as few transactions as fit. Accounts with a foreign close authority\n" +
+			"or withheld Token-2022 transfer fees are listed and left open.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
+			defer cancel()
+
+			deps, err := newBuilder(cmd, opts)
+			if err != nil {
+				return err
+			}
+			owner := deps.signer.PublicKey()
+			cleanup, err := autofill.CleanupEmptyATAs(ctx, deps.rpc, owner, autofill.WithPriorityFee(priorityFee))
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			for _, s := range cleanup.Skipped {
+				fmt.Fprintf(out, "skip %s: %s\n", s.Address, s.Reason)
+			}
+			fmt.Fprintf(out, "%d empty accounts, %d lamports of rent, %d transactions\n", len(cleanup.Closed), cleanup.ReclaimedLamports, len(cleanup.Transactions))
+			if preview {
+				for _, addr := range cleanup.Closed {
+					fmt.Fprintf(out, "close %s\n", addr)
+				}
+				return nil
+			}
+			for i, instrs := range cleanup.Transactions {
+				sig, err := deps.builder.BuildSignSendAndConfirm(ctx, deps.signer, nil, confirmationConfirmed, instrs...)
+				if err != nil {
+					return fmt.Errorf("transaction %d of %d: %w", i+1, len(cleanup.Transactions), err)
+				}
+				fmt.Fprintf(out, "confirmed: %s\n", sig)
+			}
+			return nil
+		},
 	}
+	cmd.Flags().BoolVar(&preview, "preview", false, "only list the accounts to close")
+	cmd.Flags().Uint64Var(&priorityFee, "priority-fee", 0, "priority fee per transaction in lamports")
+	return cmd
+}
 
-	for _, d := range decoders {
-		if bytes.Equal(data[:8], d.disc) {
-			inst := d.new()
-			if err := inst.Unmarshal(data); err != nil {
-				return d.name, nil, err
+func newAccountDecodeCmd(opts *globalOpts) *cobra.Command {
+	return &cobra.Command{
+		Use:   "decode [pubkey]",
+		Short: "Identify and decode any account (pump, pump_amm, pump_fees, SPL token)",
+		Long: "Fetches the account, identifies it by discriminator (or as an SPL token account/mint)\n" +
+			"and prints it as JSON. Unknown data is printed as a hex dump.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pub, err := parsePubkey("account", args[0])
+			if err != nil {
+				return err
 			}
-			return d.name, inst, nil
-		}
+			cfg, err := sdkconfigFromOpts(opts, cmd)
+			if err != nil {
+				return err
+			}
+			client := sdkrpc.NewClient(cfg)
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			defer cancel()
+
+			acc, err := client.Raw().GetAccountInfo(ctx, pub)
+			if err != nil {
+				return fmt.Errorf("fetch account: %w", err)
+			}
+			if acc == nil || acc.Value == nil {
+				return fmt.Errorf("account not found")
+			}
+			var data []byte
+			if acc.Value.Data != nil {
+				data = acc.Value.Data.GetBinary()
+			}
+
+			out := cmd.OutOrStdout()
+			name, decoded, err := decodeAnyAccount(acc.Value.Owner, data)
+			if err != nil {
+				fmt.Fprintf(out, "account=%s owner=%s lamports=%d type=unknown (%v)\n%s", pub, acc.Value.Owner, acc.Value.Lamports, err, hex.Dump(data))
+				return nil
+			}
+			payload := struct {
+				Address  solana.PublicKey `json:"address"`
+				Owner    solana.PublicKey `json:"owner"`
+				Lamports uint64           `json:"lamports"`
+				Type     string           `json:"type"`
+				Data     interface{}      `json:"data"`
+			}{pub, acc.Value.Owner, acc.Value.Lamports, name, decoded}
+			bz, _ := json.MarshalIndent(payload, "", "  ")
+			fmt.Fprintln(out, string(bz))
+			return nil
+		},
 	}
-	return "", nil, fmt.Errorf("unknown discriminator")
 }
 
-func sdkconfigFromOpts(opts *globalOpts, cmd *cobra.Command) sdkconfig.RPCConfig {
-	cfg := sdkconfig.DefaultRPCConfig()
-	if opts != nil {
-		if opts.rpcURL != "" {
-			cfg.RPCURL = opts.rpcURL
-		}
-		if opts.commitment != "" {
-			cfg.Commitment = opts.commitment
-		}
-		if opts.rateLimitRPS > 0 {
-			cfg.RateLimit.RPS = opts.rateLimitRPS
-		}
-		if opts.retryAttempts > 0 {
-			cfg.Retry.MaxAttempts = opts.retryAttempts
+// Base SPL token layout sizes.
+const (
+	tokenMintSize    = 82
+	tokenAccountSize = 165
+)
+
+// decodeAnyAccount decodes program accounts by discriminator, falling back
+// to SPL token accounts and mints owned by either token program.
+func decodeAnyAccount(owner solana.PublicKey, data []byte) (string, interface{}, error) {
+	name, decoded, err := decodeKnownAccount(data)
+	if err == nil || name != "" {
+		return name, decoded, err
+	}
+	if !owner.Equals(constants.TokenProgramID) && !owner.Equals(constants.Token2022ProgramID) {
+		return "", nil, err
+	}
+	// Token-2022 extensions follow the base layout plus an account-type byte.
+	isMint := len(data) == tokenMintSize || (len(data) > tokenAccountSize && data[tokenAccountSize] == 1)
+	switch {
+	case isMint:
+		var mint token.Mint
+		if err := bin.NewBinDecoder(data[:tokenMintSize]).Decode(&mint); err != nil {
+			return "spl-token.Mint", nil, err
 		}
-		if opts.retryBackoffMs > 0 {
-			cfg.Retry.InitialBackoff = time.Duration(opts.retryBackoffMs) * time.Millisecond
+		return "spl-token.Mint", &mint, nil
+	case len(data) >= tokenAccountSize:
+		var ta token.Account
+		if err := bin.NewBinDecoder(data[:tokenAccountSize]).Decode(&ta); err != nil {
+			return "spl-token.Account", nil, err
 		}
-		if opts.timeoutSec > 0 {
-			cfg.Timeout = time.Duration(opts.timeoutSec) * time.Second
+		return "spl-token.Account", &ta, nil
+	}
+	return "", nil, err
+}
+
+// accountDecoders are the generated per-program account registries, tried in
+// order by decodeKnownAccount.
+var accountDecoders = []struct {
+	pkg    string
+	decode func([]byte) (string, interface{}, error)
+}{
+	{"pump", pump.DecodeAccount},
+	{"pumpamm", pumpamm.DecodeAccount},
+	{"pumpfees", pumpfees.DecodeAccount},
+}
+
+func decodeKnownAccount(data []byte) (string, interface{}, error) {
+	if len(data) < 8 {
+		return "", nil, fmt.Errorf("account data too short")
+	}
+	for _, d := range accountDecoders {
+		name, decoded, err := d.decode(data)
+		if errors.Is(err, pump.ErrUnknownAccount) || errors.Is(err, pumpamm.ErrUnknownAccount) || errors.Is(err, pumpfees.ErrUnknownAccount) {
+			continue
 		}
+		return d.pkg + "." + name, decoded, err
+	}
+	return "", nil, fmt.Errorf("unknown discriminator")
+}
+
+func sdkconfigFromOpts(opts *globalOpts, cmd *cobra.Command) (sdkconfig.RPCConfig, error) {
+	cfg, err := loadRPCConfig(cmd, opts)
+	if err != nil {
+		return cfg, err
 	}
 	cfg.Logger = zerolog.New(cmd.ErrOrStderr()).Level(parseLogLevel(opts.logLevel))
-	return cfg
+	return cfg, nil
 }
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/spf13/cobra"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/jito"
+	"github.com/ninja0404/pump-go-sdk/pkg/txbuilder"
+	"github.com/ninja0404/pump-go-sdk/pkg/wallet"
+)
+
+func newJitoCmd(opts *globalOpts) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jito",
+		Short: "Jito block engine interactions",
+	}
+	cmd.AddCommand(
+		newJitoBundleCmd(opts),
+	)
+	return cmd
+}
+
+func newJitoBundleCmd(opts *globalOpts) *cobra.Command {
+	var (
+		txFiles  []string
+		ixFiles  []string
+		tip      uint64
+		jitoURL  string
+		jitoUUID string
+		waitSec  int
+	)
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Send signed transactions and/or instruction files as one atomic Jito bundle",
+		Long: `Assembles a Jito bundle in order: every --tx-file (a signed, base64-encoded
+transaction), then one transaction per --ix-file (a JSON array of instructions,
+signed by the fee payer), then the --tip transfer from the fee payer to a tip
+account. Bundles hold at most 5 transactions. The command waits for the bundle
+to land and prints the slot.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			count := len(txFiles) + len(ixFiles)
+			if tip > 0 {
+				count++
+			}
+			if count == 0 {
+				return fmt.Errorf("at least one --tx-file or --ix-file is required")
+			}
+			if count > jito.MaxBundleTransactions {
+				return fmt.Errorf("bundle has %d transactions, max %d", count, jito.MaxBundleTransactions)
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), time.Duration(waitSec)*time.Second)
+			defer cancel()
+
+			txs := make([]*solana.Transaction, 0, count)
+			for _, path := range txFiles {
+				tx, err := loadSignedTx(path)
+				if err != nil {
+					return err
+				}
+				txs = append(txs, tx)
+			}
+
+			jitoClient := jito.NewClient(jitoURL, jitoUUID)
+			if len(ixFiles) > 0 || tip > 0 {
+				deps, err := newBuilder(cmd, opts)
+				if err != nil {
+					return err
+				}
+				payer := deps.signer.PublicKey()
+				batches := make([][]solana.Instruction, 0, len(ixFiles)+1)
+				for _, path := range ixFiles {
+					ixs, err := loadInstructionsJSON(path)
+					if err != nil {
+						return err
+					}
+					batches = append(batches, ixs)
+				}
+				if tip > 0 {
+					batches = append(batches, []solana.Instruction{
+						system.NewTransferInstruction(tip, payer, jito.GetRandomTipAccountLocal()).Build(),
+					})
+				}
+				for _, ixs := range batches {
+					tx, err := deps.builder.BuildTransaction(ctx, payer, ixs...)
+					if err != nil {
+						return err
+					}
+					if err := txbuilder.SignTransaction(ctx, tx, deps.signer); err != nil {
+						return err
+					}
+					txs = append(txs, tx)
+				}
+			}
+
+			bundleID, err := jitoClient.SendBundle(ctx, txs)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "bundle: %s (%d transactions)\n", bundleID, len(txs))
+			for i, tx := range txs {
+				fmt.Fprintf(out, "  tx[%d]: %s\n", i, tx.Signatures[0])
+			}
+			slot, err := jitoClient.WaitForBundleLanded(ctx, bundleID)
+			if err != nil {
+				return fmt.Errorf("bundle %s: %w", bundleID, err)
+			}
+			fmt.Fprintf(out, "landed in slot %d\n", slot)
+			return nil
+		},
+	}
+	cmd.Flags().StringArrayVar(&txFiles, "tx-file", nil, "file with a signed base64 transaction (repeatable)")
+	cmd.Flags().StringArrayVar(&ixFiles, "ix-file", nil, "JSON instructions file to sign with the fee payer as one transaction (repeatable)")
+	cmd.Flags().Uint64Var(&tip, "tip", 0, "tip in lamports, sent as the last bundle transaction (0 to omit)")
+	cmd.Flags().StringVar(&jitoURL, "jito-url", jito.MainnetBlockEngine, "Jito block engine URL")
+	cmd.Flags().StringVar(&jitoUUID, "jito-uuid", "", "Jito UUID (optional)")
+	cmd.Flags().IntVar(&waitSec, "wait-sec", 60, "seconds to wait for the bundle to land")
+	return cmd
+}
+
+// loadSignedTx reads a base64-encoded transaction and checks it is signed.
+func loadSignedTx(path string) (*solana.Transaction, error) {
+	tx, err := wallet.ReadTransactionFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(tx.Signatures) == 0 || tx.Signatures[0].IsZero() {
+		return nil, fmt.Errorf("tx file %s: transaction is not signed", path)
+	}
+	return tx, nil
+}
+
+// instructionJSON is one entry of an --ix-file.
+type instructionJSON struct {
+	ProgramID string `json:"program_id"`
+	Accounts  []struct {
+		Pubkey     string `json:"pubkey"`
+		IsSigner   bool   `json:"is_signer"`
+		IsWritable bool   `json:"is_writable"`
+	} `json:"accounts"`
+	// Data is the base64-encoded instruction data.
+	Data string `json:"data"`
+}
+
+// loadInstructionsJSON reads a JSON array of instructions.
+func loadInstructionsJSON(path string) ([]solana.Instruction, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read instructions json: %w", err)
+	}
+	var entries []instructionJSON
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("parse instructions json: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("instructions json %s is empty", path)
+	}
+	ixs := make([]solana.Instruction, 0, len(entries))
+	for i, e := range entries {
+		program, err := parsePubkey(fmt.Sprintf("instruction %d program_id", i), e.ProgramID)
+		if err != nil {
+			return nil, err
+		}
+		metas := make(solana.AccountMetaSlice, 0, len(e.Accounts))
+		for j, a := range e.Accounts {
+			pk, err := parsePubkey(fmt.Sprintf("instruction %d account %d", i, j), a.Pubkey)
+			if err != nil {
+				return nil, err
+			}
+			metas = append(metas, solana.NewAccountMeta(pk, a.IsWritable, a.IsSigner))
+		}
+		data, err := base64.StdEncoding.DecodeString(e.Data)
+		if err != nil {
+			return nil, fmt.Errorf("instruction %d data: %w", i, err)
+		}
+		ixs = append(ixs, solana.NewInstruction(program, metas, data))
+	}
+	return ixs, nil
+}
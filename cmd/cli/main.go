@@ -26,16 +26,20 @@ func main() {
 }
 
 type globalOpts struct {
-	rpcURL         string
-	commitment     string
-	feePayerPath   string
-	signerEndpoint string
-	skipPreflight  bool
-	retryAttempts  int
-	retryBackoffMs int
-	rateLimitRPS   float64
-	logLevel       string
-	timeoutSec     int
+	configPath      string
+	rpcURL          string
+	commitment      string
+	feePayerPath    string
+	signerEndpoint  string
+	signerPubkey    string
+	signerAuth      string
+	skipPreflight   bool
+	retryAttempts   int
+	retryBackoffMs  int
+	rateLimitRPS    float64
+	logLevel        string
+	timeoutSec      int
+	strictOverrides bool
 }
 
 func newRootCmd() *cobra.Command {
@@ -46,34 +50,43 @@ func newRootCmd() *cobra.Command {
 		Short: "Pump platform SDK CLI (pump + pump_amm)",
 	}
 
+	root.PersistentFlags().StringVar(&opts.configPath, "config", "", "YAML/JSON config file (default $PUMP_CONFIG)")
 	root.PersistentFlags().StringVar(&opts.rpcURL, "rpc-url", "", "RPC endpoint (default mainnet if empty)")
 	root.PersistentFlags().StringVar(&opts.commitment, "commitment", "finalized", "RPC commitment level")
 	root.PersistentFlags().StringVar(&opts.feePayerPath, "fee-payer", "", "path to solana-keygen json for fee payer")
-	root.PersistentFlags().StringVar(&opts.signerEndpoint, "signer-endpoint", "", "remote signer endpoint (placeholder)")
+	root.PersistentFlags().StringVar(&opts.signerEndpoint, "signer-endpoint", "", "remote signer HTTP endpoint (requires --signer-pubkey)")
+	root.PersistentFlags().StringVar(&opts.signerPubkey, "signer-pubkey", "", "public key of the remote signer")
+	root.PersistentFlags().StringVar(&opts.signerAuth, "signer-auth", "", "Authorization header value for the remote signer (default $PUMP_SIGNER_AUTH)")
 	root.PersistentFlags().BoolVar(&opts.skipPreflight, "skip-preflight", false, "skip preflight checks")
 	root.PersistentFlags().IntVar(&opts.retryAttempts, "retry-attempts", 3, "RPC retry attempts")
 	root.PersistentFlags().IntVar(&opts.retryBackoffMs, "retry-backoff-ms", 150, "initial backoff in ms")
 	root.PersistentFlags().Float64Var(&opts.rateLimitRPS, "rate-limit-rps", 8, "rate limit RPS (0 to disable)")
 	root.PersistentFlags().StringVar(&opts.logLevel, "log-level", "info", "log level (debug|info|warn|error)")
 	root.PersistentFlags().IntVar(&opts.timeoutSec, "timeout-sec", 20, "RPC timeout seconds")
+	root.PersistentFlags().BoolVar(&opts.strictOverrides, "strict-overrides", false, "fail on --override-json keys that match no account (default: warn)")
 
 	root.AddCommand(
-		newConfigCmd(),
+		newConfigCmd(opts),
 		newAccountCmd(opts),
 		newPumpCmd(opts),
 		newPumpAMMCmd(opts),
+		newJitoCmd(opts),
 	)
 
 	return root
 }
 
-func newConfigCmd() *cobra.Command {
+func newConfigCmd(opts *globalOpts) *cobra.Command {
 	return &cobra.Command{
 		Use:   "config",
-		Short: "Show default config",
+		Short: "Show resolved config (flags > PUMP_* env > config file > defaults)",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg := sdkconfig.DefaultRPCConfig()
+			cfg, err := loadRPCConfig(cmd, opts)
+			if err != nil {
+				return err
+			}
 			fmt.Fprintf(cmd.OutOrStdout(), "network=%s\nrpc=%s\ncommitment=%s\n", cfg.Network, cfg.ResolveRPCURL(), cfg.Commitment)
+			fmt.Fprintf(cmd.OutOrStdout(), "timeout=%s\nsimulate_timeout=%s\nconfirm_timeout=%s\n", cfg.Timeout, cfg.SimulateTimeout, cfg.ConfirmTimeout)
 			return nil
 		},
 	}
@@ -86,22 +99,9 @@ type runtimeDeps struct {
 }
 
 func newBuilder(cmd *cobra.Command, opts *globalOpts) (*runtimeDeps, error) {
-	cfg := sdkconfig.DefaultRPCConfig()
-	if opts != nil {
-		if opts.rpcURL != "" {
-			cfg.RPCURL = opts.rpcURL
-		}
-		if opts.commitment != "" {
-			cfg.Commitment = opts.commitment
-		}
-		cfg.RateLimit.RPS = opts.rateLimitRPS
-		cfg.Retry.MaxAttempts = opts.retryAttempts
-		if opts.retryBackoffMs > 0 {
-			cfg.Retry.InitialBackoff = time.Duration(opts.retryBackoffMs) * time.Millisecond
-		}
-		if opts.timeoutSec > 0 {
-			cfg.Timeout = time.Duration(opts.timeoutSec) * time.Second
-		}
+	cfg, err := loadRPCConfig(cmd, opts)
+	if err != nil {
+		return nil, err
 	}
 	level := parseLogLevel(opts.logLevel)
 	cfg.Logger = zerolog.New(cmd.ErrOrStderr()).Level(level)
@@ -110,29 +110,93 @@ func newBuilder(cmd *cobra.Command, opts *globalOpts) (*runtimeDeps, error) {
 	commit := rpc.CommitmentType(cfg.Commitment)
 	builder := txbuilder.NewBuilder(client, commit).WithSkipPreflight(opts != nil && opts.skipPreflight)
 
-	var signer wallet.Signer
+	signer, err := loadSigner(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := client.GetLatestBlockhash(ctx); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: rpc ping failed: %v\n", err)
+	}
+
+	return &runtimeDeps{builder: builder, signer: signer, rpc: client}, nil
+}
+
+// loadSigner returns the fee payer from --fee-payer or --signer-endpoint.
+func loadSigner(opts *globalOpts) (wallet.Signer, error) {
 	switch {
 	case opts != nil && opts.feePayerPath != "":
 		local, err := wallet.NewLocalFromKeygen(opts.feePayerPath)
 		if err != nil {
 			return nil, err
 		}
-		signer = local
+		return local, nil
 	case opts != nil && opts.signerEndpoint != "":
-		signer = wallet.NewRemoteSigner(solana.PublicKey{}, func(ctx context.Context, message []byte) ([]byte, error) {
-			return nil, fmt.Errorf("remote signer placeholder: %s", opts.signerEndpoint)
-		})
+		if opts.signerPubkey == "" {
+			return nil, fmt.Errorf("--signer-pubkey is required with --signer-endpoint")
+		}
+		pub, err := solana.PublicKeyFromBase58(opts.signerPubkey)
+		if err != nil {
+			return nil, fmt.Errorf("parse signer pubkey: %w", err)
+		}
+		auth := opts.signerAuth
+		if auth == "" {
+			auth = os.Getenv("PUMP_SIGNER_AUTH")
+		}
+		var signerOpts []wallet.HTTPSignerOption
+		if auth != "" {
+			signerOpts = append(signerOpts, wallet.WithAuthHeader("Authorization", auth))
+		}
+		return wallet.NewHTTPRemoteSigner(opts.signerEndpoint, pub, signerOpts...), nil
 	default:
-		return nil, fmt.Errorf("fee payer is required (use --fee-payer)")
+		return nil, fmt.Errorf("fee payer is required (use --fee-payer or --signer-endpoint)")
 	}
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	if _, err := client.GetLatestBlockhash(ctx); err != nil {
-		fmt.Fprintf(cmd.ErrOrStderr(), "warning: rpc ping failed: %v\n", err)
+// loadRPCConfig resolves the RPC config in order of precedence:
+// explicitly set flags, PUMP_* environment variables, the config file, defaults.
+func loadRPCConfig(cmd *cobra.Command, opts *globalOpts) (sdkconfig.RPCConfig, error) {
+	cfg := sdkconfig.DefaultRPCConfig()
+	if opts == nil {
+		return cfg, nil
 	}
 
-	return &runtimeDeps{builder: builder, signer: signer, rpc: client}, nil
+	path := opts.configPath
+	if path == "" {
+		path = os.Getenv("PUMP_CONFIG")
+	}
+	var err error
+	if path != "" {
+		if cfg, err = sdkconfig.ApplyFile(cfg, path); err != nil {
+			return cfg, err
+		}
+	}
+	if cfg, err = sdkconfig.ApplyEnv(cfg); err != nil {
+		return cfg, err
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("rpc-url") {
+		cfg.RPCURL = opts.rpcURL
+	}
+	if flags.Changed("commitment") {
+		cfg.Commitment = opts.commitment
+	}
+	if flags.Changed("rate-limit-rps") {
+		cfg.RateLimit.RPS = opts.rateLimitRPS
+	}
+	if flags.Changed("retry-attempts") {
+		cfg.Retry.MaxAttempts = opts.retryAttempts
+	}
+	if flags.Changed("retry-backoff-ms") && opts.retryBackoffMs > 0 {
+		cfg.Retry.InitialBackoff = time.Duration(opts.retryBackoffMs) * time.Millisecond
+	}
+	if flags.Changed("timeout-sec") && opts.timeoutSec > 0 {
+		cfg.Timeout = time.Duration(opts.timeoutSec) * time.Second
+	}
+	return cfg, nil
 }
 
 func parseLogLevel(lvl string) zerolog.Level {
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/spf13/cobra"
+
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+	"github.com/ninja0404/pump-go-sdk/pkg/txbuilder"
+	"github.com/ninja0404/pump-go-sdk/pkg/wallet"
+)
+
+// advanceNonceTag is the system program's AdvanceNonceAccount instruction tag.
+var advanceNonceTag = []byte{4, 0, 0, 0}
+
+func newAccountSignOfflineCmd(opts *globalOpts) *cobra.Command {
+	var inPath, outPath string
+	cmd := &cobra.Command{
+		Use:   "sign-offline",
+		Short: "Sign a serialized transaction with the fee payer key, without network access",
+		Long: "Reads a base64 transaction from --in, fills the fee payer's signature slot\n" +
+			"(keeping any other signatures) and writes it to --out for a separate broadcaster.\n" +
+			"Use a durable nonce as the blockhash: a recent blockhash expires within about a minute.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			signer, err := loadSigner(opts)
+			if err != nil {
+				return err
+			}
+			tx, err := wallet.SignToFile(cmd.Context(), inPath, outPath, signer)
+			if err != nil {
+				return err
+			}
+			if !usesDurableNonce(tx) {
+				fmt.Fprintln(cmd.ErrOrStderr(), "warning: transaction does not advance a durable nonce; its blockhash will expire soon")
+			}
+			missing := 0
+			for _, sig := range tx.Signatures {
+				if sig.IsZero() {
+					missing++
+				}
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "signed by %s -> %s (%d signature(s) still missing)\n", signer.PublicKey(), outPath, missing)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&inPath, "in", "", "unsigned (or partially signed) base64 transaction file")
+	cmd.Flags().StringVar(&outPath, "out", "", "output file for the signed transaction")
+	_ = cmd.MarkFlagRequired("in")
+	_ = cmd.MarkFlagRequired("out")
+	return cmd
+}
+
+func newAccountBroadcastCmd(opts *globalOpts) *cobra.Command {
+	var inPath string
+	cmd := &cobra.Command{
+		Use:   "broadcast",
+		Short: "Send a transaction signed offline (see sign-offline)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := sdkconfigFromOpts(opts, cmd)
+			if err != nil {
+				return err
+			}
+			builder := txbuilder.NewBuilder(sdkrpc.NewClient(cfg), solanarpc.CommitmentType(cfg.Commitment)).
+				WithSkipPreflight(opts.skipPreflight)
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			sig, err := builder.BroadcastSignedFile(ctx, inPath)
+			if err != nil {
+				return err
+			}
+			if err := builder.WaitForConfirmation(ctx, sig, confirmationConfirmed); err != nil {
+				return fmt.Errorf("confirm %s: %w", sig, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "confirmed: %s\n", sig)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&inPath, "in", "", "fully signed base64 transaction file")
+	_ = cmd.MarkFlagRequired("in")
+	return cmd
+}
+
+// usesDurableNonce reports whether the first instruction of tx advances a
+// nonce account, which keeps the transaction valid until the nonce is used.
+func usesDurableNonce(tx *solana.Transaction) bool {
+	if len(tx.Message.Instructions) == 0 {
+		return false
+	}
+	ix := tx.Message.Instructions[0]
+	program, err := tx.Message.Program(ix.ProgramIDIndex)
+	if err != nil || !program.Equals(solana.SystemProgramID) {
+		return false
+	}
+	return bytes.HasPrefix(ix.Data, advanceNonceTag)
+}
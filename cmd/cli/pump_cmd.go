@@ -38,6 +38,8 @@ func newPumpCmd(opts *globalOpts) *cobra.Command {
 		newPumpInfoCmd(opts),
 		newPumpSimBuyCmd(opts),
 		newPumpSimSellCmd(opts),
+		newPumpWatchCmd(opts),
+		newPumpQuoteCmd(opts),
 	)
 	return cmd
 }
@@ -74,6 +76,9 @@ func newPumpBuyCmd(opts *globalOpts) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				if err := checkOverrideKeys(cmd, opts, accounts, mp); err != nil {
+					return err
+				}
 				if err := applyPubkeyOverrides(&accounts, mp); err != nil {
 					return err
 				}
@@ -209,6 +214,9 @@ func newPumpSellCmd(opts *globalOpts) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				if err := checkOverrideKeys(cmd, opts, accounts, mp); err != nil {
+					return err
+				}
 				if err := applyPubkeyOverrides(&accounts, mp); err != nil {
 					return err
 				}
@@ -614,6 +622,9 @@ func newPumpSimBuyCmd(opts *globalOpts) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				if err := checkOverrideKeys(cmd, opts, accounts, mp); err != nil {
+					return err
+				}
 				if err := applyPubkeyOverrides(&accounts, mp); err != nil {
 					return err
 				}
@@ -677,6 +688,9 @@ func newPumpSimSellCmd(opts *globalOpts) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				if err := checkOverrideKeys(cmd, opts, accounts, mp); err != nil {
+					return err
+				}
 				if err := applyPubkeyOverrides(&accounts, mp); err != nil {
 					return err
 				}
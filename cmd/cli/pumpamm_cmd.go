@@ -28,6 +28,7 @@ func newPumpAMMCmd(opts *globalOpts) *cobra.Command {
 		newPumpAMMWithdrawCmd(opts),
 		newPumpAMMPoolInfoCmd(opts),
 		newPumpAMMSimBuyCmd(opts),
+		newPumpAMMQuoteCmd(opts),
 		newPumpAMMAdminUpdateFeeCmd(opts),
 	)
 	return cmd
@@ -64,6 +65,9 @@ func newPumpAMMBuyCmd(opts *globalOpts) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				if err := checkOverrideKeys(cmd, opts, accounts, mp); err != nil {
+					return err
+				}
 				if err := applyPubkeyOverrides(&accounts, mp); err != nil {
 					return err
 				}
@@ -144,6 +148,9 @@ func newPumpAMMBuySolCmd(opts *globalOpts) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				if err := checkOverrideKeys(cmd, opts, pumpamm.BuyExactQuoteInAccounts{}, mp); err != nil {
+					return err
+				}
 				overrides := make(map[string]solana.PublicKey, len(mp))
 				for k, v := range mp {
 					pk, err := parsePubkey(k, v)
@@ -153,21 +160,17 @@ func newPumpAMMBuySolCmd(opts *globalOpts) *cobra.Command {
 					overrides[k] = pk
 				}
 				options = append(options, autofill.WithOverrides(overrides))
+				if opts.strictOverrides {
+					options = append(options, autofill.WithStrictOverrides())
+				}
 			}
 
-			accounts, argsObj, instrs, simBase, err := autofill.PumpAmmBuyWithSol(ctx, deps.rpc, deps.signer.PublicKey(), pool, amountSol, slippageBps, options...)
-			if err != nil {
-				return err
-			}
 			if preview {
-				payload := struct {
-					Accounts      pumpamm.BuyExactQuoteInAccounts `json:"accounts"`
-					Args          pumpamm.BuyExactQuoteInArgs     `json:"args"`
-					SimulatedBase uint64                          `json:"simulated_base_out"`
-				}{accounts, argsObj, simBase}
-				bz, _ := json.MarshalIndent(payload, "", "  ")
-				fmt.Fprintln(cmd.OutOrStdout(), string(bz))
-				return nil
+				options = append(options, autofill.WithPreview(cmd.OutOrStdout()))
+			}
+			_, argsObj, instrs, simBase, err := autofill.PumpAmmBuyWithSol(ctx, deps.rpc, deps.signer.PublicKey(), pool, amountSol, slippageBps, options...)
+			if err != nil || preview {
+				return err
 			}
 
 			sig, err := deps.builder.BuildSignSend(ctx, deps.signer, nil, instrs...)
@@ -184,7 +187,7 @@ func newPumpAMMBuySolCmd(opts *globalOpts) *cobra.Command {
 	cmd.Flags().BoolVar(&trackVolume, "track-volume", true, "track volume flag")
 	cmd.Flags().StringVar(&poolStr, "pool", "", "pool pubkey")
 	cmd.Flags().StringVar(&overridePath, "override-json", "", "optional partial accounts override json")
-	cmd.Flags().BoolVar(&preview, "preview", false, "only print derived accounts/args")
+	cmd.Flags().BoolVar(&preview, "preview", false, "only print the preview JSON (accounts, args, min out, price impact, instructions)")
 	_ = cmd.MarkFlagRequired("pool")
 	_ = cmd.MarkFlagRequired("amount-sol")
 	_ = cmd.MarkFlagRequired("slippage-bps")
@@ -222,6 +225,9 @@ func newPumpAMMBuyExactQuoteCmd(opts *globalOpts) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				if err := checkOverrideKeys(cmd, opts, pumpamm.BuyExactQuoteInAccounts{}, mp); err != nil {
+					return err
+				}
 				overrides := make(map[string]solana.PublicKey, len(mp))
 				for k, v := range mp {
 					pk, err := parsePubkey(k, v)
@@ -231,20 +237,17 @@ func newPumpAMMBuyExactQuoteCmd(opts *globalOpts) *cobra.Command {
 					overrides[k] = pk
 				}
 				options = append(options, autofill.WithOverrides(overrides))
+				if opts.strictOverrides {
+					options = append(options, autofill.WithStrictOverrides())
+				}
 			}
 
-			accounts, argsObj, instrs, err := autofill.PumpAmmBuyExactQuoteIn(ctx, deps.rpc, deps.signer.PublicKey(), pool, amountQuote, minBaseOut, options...)
-			if err != nil {
-				return err
-			}
 			if preview {
-				payload := struct {
-					Accounts pumpamm.BuyExactQuoteInAccounts `json:"accounts"`
-					Args     pumpamm.BuyExactQuoteInArgs     `json:"args"`
-				}{accounts, argsObj}
-				bz, _ := json.MarshalIndent(payload, "", "  ")
-				fmt.Fprintln(cmd.OutOrStdout(), string(bz))
-				return nil
+				options = append(options, autofill.WithPreview(cmd.OutOrStdout()))
+			}
+			_, argsObj, instrs, err := autofill.PumpAmmBuyExactQuoteIn(ctx, deps.rpc, deps.signer.PublicKey(), pool, amountQuote, minBaseOut, options...)
+			if err != nil || preview {
+				return err
 			}
 
 			sig, err := deps.builder.BuildSignSend(ctx, deps.signer, nil, instrs...)
@@ -261,7 +264,7 @@ func newPumpAMMBuyExactQuoteCmd(opts *globalOpts) *cobra.Command {
 	cmd.Flags().BoolVar(&trackVolume, "track-volume", true, "track volume flag")
 	cmd.Flags().StringVar(&poolStr, "pool", "", "pool pubkey")
 	cmd.Flags().StringVar(&overridePath, "override-json", "", "optional partial accounts override json")
-	cmd.Flags().BoolVar(&preview, "preview", false, "only print derived accounts/args")
+	cmd.Flags().BoolVar(&preview, "preview", false, "only print the preview JSON (accounts, args, min out, price impact, instructions)")
 	_ = cmd.MarkFlagRequired("pool")
 	_ = cmd.MarkFlagRequired("amount-quote")
 	_ = cmd.MarkFlagRequired("min-base-out")
@@ -298,6 +301,9 @@ func newPumpAMMSellCmd(opts *globalOpts) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				if err := checkOverrideKeys(cmd, opts, accounts, mp); err != nil {
+					return err
+				}
 				if err := applyPubkeyOverrides(&accounts, mp); err != nil {
 					return err
 				}
@@ -512,6 +518,9 @@ func newPumpAMMSimBuyCmd(opts *globalOpts) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				if err := checkOverrideKeys(cmd, opts, accounts, mp); err != nil {
+					return err
+				}
 				if err := applyPubkeyOverrides(&accounts, mp); err != nil {
 					return err
 				}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/quote"
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+)
+
+// quoteFunc fetches a read-only buy quote for target.
+type quoteFunc func(ctx context.Context, rpc *sdkrpc.Client, target solana.PublicKey, lamports, slippageBps uint64) (*quote.QuoteResult, error)
+
+func newPumpQuoteCmd(opts *globalOpts) *cobra.Command {
+	return newQuoteCmd(opts, "mint", "Quote a bonding-curve buy without signing (read-only)", quote.PumpBuyQuoteReadOnly)
+}
+
+func newPumpAMMQuoteCmd(opts *globalOpts) *cobra.Command {
+	return newQuoteCmd(opts, "pool", "Quote a pool buy without signing (read-only)", quote.AmmBuyQuoteReadOnly)
+}
+
+// newQuoteCmd builds a "quote" subcommand keyed by --mint or --pool. It only
+// reads chain state, so no keypair or fee payer is needed.
+func newQuoteCmd(opts *globalOpts, targetFlag, short string, fn quoteFunc) *cobra.Command {
+	var (
+		targetStr   string
+		amountSol   uint64
+		slippageBps uint64
+		asJSON      bool
+	)
+	cmd := &cobra.Command{
+		Use:   "quote",
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := parsePubkey(targetFlag, targetStr)
+			if err != nil {
+				return err
+			}
+			cfg, err := sdkconfigFromOpts(opts, cmd)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			defer cancel()
+
+			q, err := fn(ctx, sdkrpc.NewClient(cfg), target, amountSol, slippageBps)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if asJSON {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(struct {
+					Target         solana.PublicKey `json:"target"`
+					InLamports     uint64           `json:"in_lamports"`
+					SlippageBps    uint64           `json:"slippage_bps"`
+					ExpectedOut    uint64           `json:"expected_out"`
+					MinOut         uint64           `json:"min_out"`
+					SpotPrice      uint64           `json:"spot_price"`
+					ExecutionPrice uint64           `json:"execution_price"`
+					PriceImpactBps uint64           `json:"price_impact_bps"`
+				}{target, amountSol, slippageBps, q.ExpectedOut, q.MinOut, q.SpotPrice, q.ExecutionPrice, q.PriceImpactBps})
+			}
+			fmt.Fprintf(out, "%s: %s\n", targetFlag, target)
+			fmt.Fprintf(out, "in (lamports):     %d\n", amountSol)
+			fmt.Fprintf(out, "expected out:      %d\n", q.ExpectedOut)
+			fmt.Fprintf(out, "min out (%d bps): %d\n", slippageBps, q.MinOut)
+			fmt.Fprintf(out, "spot price:        %d (quote per base, x1e9)\n", q.SpotPrice)
+			fmt.Fprintf(out, "execution price:   %d (quote per base, x1e9)\n", q.ExecutionPrice)
+			fmt.Fprintf(out, "price impact:      %d bps\n", q.PriceImpactBps)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&targetStr, targetFlag, "", targetFlag+" address")
+	cmd.Flags().Uint64Var(&amountSol, "sol", 0, "SOL to spend in lamports, fees included")
+	cmd.Flags().Uint64Var(&slippageBps, "slippage-bps", 100, "slippage bps for min out (max 10000)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print the quote as JSON")
+	_ = cmd.MarkFlagRequired(targetFlag)
+	_ = cmd.MarkFlagRequired("sol")
+	return cmd
+}
@@ -9,6 +9,9 @@ import (
 	"strings"
 
 	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/autofill"
 )
 
 // parsePubkey converts base58 string to PublicKey.
@@ -94,6 +97,24 @@ func applyPubkeyOverrides(target interface{}, m map[string]string) error {
 	return nil
 }
 
+// checkOverrideKeys reports override keys that match no field of
+// accountsType: a warning on stderr, or an error with --strict-overrides.
+func checkOverrideKeys(cmd *cobra.Command, opts *globalOpts, accountsType any, m map[string]string) error {
+	keys := make(map[string]solana.PublicKey, len(m))
+	for k := range m {
+		keys[k] = solana.PublicKey{}
+	}
+	unknown := autofill.ValidateOverrides(accountsType, keys)
+	if len(unknown) == 0 {
+		return nil
+	}
+	if opts.strictOverrides {
+		return fmt.Errorf("unknown --override-json keys: %s", strings.Join(unknown, ", "))
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "warning: ignoring unknown --override-json keys: %s\n", strings.Join(unknown, ", "))
+	return nil
+}
+
 // loadAccountsJSON fills a struct T from a JSON object of base58 pubkeys keyed by field name variants.
 func loadAccountsJSON[T any](path string) (T, error) {
 	var zero T
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+)
+
+func newPumpWatchCmd(opts *globalOpts) *cobra.Command {
+	var (
+		wsURL         string
+		filterCreator string
+		asJSON        bool
+	)
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Stream new pump launches (create events) until Ctrl-C",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := sdkconfigFromOpts(opts, cmd)
+			if err != nil {
+				return err
+			}
+			if wsURL != "" {
+				cfg.WSURL = wsURL
+			}
+			var creator solana.PublicKey
+			if filterCreator != "" {
+				if creator, err = parsePubkey("filter-creator", filterCreator); err != nil {
+					return err
+				}
+			}
+			client := sdkrpc.NewClient(cfg)
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			out := cmd.OutOrStdout()
+			enc := json.NewEncoder(out)
+			fmt.Fprintf(cmd.ErrOrStderr(), "watching pump launches via %s (Ctrl-C to stop)\n", cfg.ResolveWSURL())
+			err = client.SubscribeProgramLogs(ctx, pump.ProgramKey, func(ev sdkrpc.LogEvent) {
+				if ev.Err != nil {
+					return
+				}
+				created, err := pump.ParseCreateEvent(ev.Logs)
+				if err != nil {
+					return
+				}
+				if !creator.IsZero() && !created.Creator.Equals(creator) && !created.User.Equals(creator) {
+					return
+				}
+				if asJSON {
+					_ = enc.Encode(struct {
+						Signature    solana.Signature `json:"signature"`
+						Slot         uint64           `json:"slot"`
+						Mint         solana.PublicKey `json:"mint"`
+						Creator      solana.PublicKey `json:"creator"`
+						BondingCurve solana.PublicKey `json:"bonding_curve"`
+						Name         string           `json:"name"`
+						Symbol       string           `json:"symbol"`
+						URI          string           `json:"uri"`
+						Timestamp    int64            `json:"timestamp"`
+					}{ev.Signature, ev.Slot, created.Mint, created.Creator, created.BondingCurve, created.Name, created.Symbol, created.Uri, created.Timestamp})
+					return
+				}
+				fmt.Fprintf(out, "%s mint=%s creator=%s name=%q symbol=%q sig=%s\n",
+					time.Unix(created.Timestamp, 0).UTC().Format(time.RFC3339), created.Mint, created.Creator, created.Name, created.Symbol, ev.Signature)
+			})
+			if errors.Is(err, context.Canceled) {
+				return nil // Ctrl-C
+			}
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&wsURL, "ws-url", "", "websocket endpoint (default derived from the RPC URL, or $PUMP_WS_URL)")
+	cmd.Flags().StringVar(&filterCreator, "filter-creator", "", "only show launches by this creator pubkey")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print one JSON object per launch")
+	return cmd
+}
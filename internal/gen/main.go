@@ -8,8 +8,11 @@ import (
 	"go/format"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
+
+	"github.com/gagliardetto/solana-go"
 )
 
 type idl struct {
@@ -41,6 +44,10 @@ type idlInstrAccount struct {
 	PDA       *idlPDA  `json:"pda"`
 	Address   string   `json:"address"`
 	Relations []string `json:"relations"`
+	// Optional marks an account the instruction accepts as absent. Validate
+	// does not require it, and a zero key is passed as the program ID, the
+	// Anchor encoding of None.
+	Optional bool `json:"optional"`
 }
 
 type idlPDA struct {
@@ -128,6 +135,7 @@ func main() {
 	writeFile(*outDir, "accounts.go", generateAccounts(*pkgName, doc))
 	writeFile(*outDir, "instructions.go", generateInstructions(*pkgName, doc))
 	writeFile(*outDir, "errors.go", generateErrors(*pkgName, doc))
+	writeFile(*outDir, "roundtrip_gen_test.go", generateRoundTripTests(*pkgName, doc))
 }
 
 func writeFile(outDir, name, content string) {
@@ -142,6 +150,60 @@ func writeFile(outDir, name, content string) {
 	fmt.Printf("generated %s\n", target)
 }
 
+// pumpProgramKeys names the variables holding the Pump program IDs. Fixed
+// accounts and PDA programs at these addresses reference the variable rather
+// than a parsed literal, so overriding the IDs (config.ProgramIDs.Apply)
+// reaches every account an instruction passes.
+var pumpProgramKeys = map[string]string{
+	"6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P": "PumpProgramKey",
+	"pAMMBay6oceH9fJKBRHGP5D4bD4sWpmSwMn52FMfXEA": "PumpAmmProgramKey",
+	"pfeeUxB6jkeY1Hxd7CsFCAjcbHA9rWtchMGdZ6VojVZ": "PumpFeesProgramKey",
+}
+
+// programKeyVar returns the variable to use for address in doc's bindings:
+// ProgramKey for the program itself, a pumpProgramKeys variable for another
+// Pump program, or "" for any other address.
+func programKeyVar(doc idl, address string) string {
+	if address == doc.Address {
+		return "ProgramKey"
+	}
+	return pumpProgramKeys[address]
+}
+
+// pdaProgramAddress returns the base58 address of a PDA's constant program,
+// or "" when it is not given as a constant.
+func pdaProgramAddress(pda *idlPDA) string {
+	if pda == nil || pda.Program == nil || len(pda.Program.Value) == 0 {
+		return ""
+	}
+	key := make([]byte, len(pda.Program.Value))
+	for i, v := range pda.Program.Value {
+		key[i] = byte(v)
+	}
+	return solana.PublicKeyFromBytes(key).String()
+}
+
+// otherPumpPrograms returns the addresses of the other Pump programs doc's
+// instructions reference, sorted by variable name.
+func otherPumpPrograms(doc idl) []string {
+	seen := map[string]bool{}
+	for _, ins := range doc.Instructions {
+		for _, acc := range ins.Accounts {
+			for _, addr := range []string{acc.Address, pdaProgramAddress(acc.PDA)} {
+				if v := programKeyVar(doc, addr); v != "" && v != "ProgramKey" {
+					seen[addr] = true
+				}
+			}
+		}
+	}
+	addrs := make([]string, 0, len(seen))
+	for addr := range seen {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return pumpProgramKeys[addrs[i]] < pumpProgramKeys[addrs[j]] })
+	return addrs
+}
+
 func generateProgram(pkg string, doc idl) string {
 	var b strings.Builder
 	header(&b, pkg)
@@ -150,6 +212,16 @@ func generateProgram(pkg string, doc idl) string {
 	b.WriteString("const ProgramName string = \"" + doc.Metadata.Name + "\"\n")
 	b.WriteString("const ProgramVersion string = \"" + doc.Metadata.Version + "\"\n")
 	b.WriteString("var ProgramKey = solana.MustPublicKeyFromBase58(ProgramID)\n")
+	if others := otherPumpPrograms(doc); len(others) > 0 {
+		b.WriteString("\n// Other Pump programs this program's instructions pass as accounts or derive\n")
+		b.WriteString("// PDAs under. Like ProgramKey, they may be changed to target another\n")
+		b.WriteString("// deployment before instructions are built.\n")
+		b.WriteString("var (\n")
+		for _, addr := range others {
+			b.WriteString("\t" + pumpProgramKeys[addr] + " = solana.MustPublicKeyFromBase58(\"" + addr + "\")\n")
+		}
+		b.WriteString(")\n")
+	}
 	return b.String()
 }
 
@@ -200,7 +272,7 @@ func generateTypes(pkg string, doc idl) string {
 func generateAccounts(pkg string, doc idl) string {
 	var b strings.Builder
 	header(&b, pkg)
-	b.WriteString("import (\n\t\"bytes\"\n\t\"fmt\"\n\n\tbin \"github.com/gagliardetto/binary\"\n\t\"github.com/gagliardetto/solana-go\"\n)\n\n")
+	b.WriteString("import (\n\t\"bytes\"\n\t\"errors\"\n\t\"fmt\"\n\n\tbin \"github.com/gagliardetto/binary\"\n\t\"github.com/gagliardetto/solana-go\"\n)\n\n")
 
 	// map for quick lookup of defined struct presence
 	typeNames := map[string]bool{}
@@ -226,6 +298,23 @@ func generateAccounts(pkg string, doc idl) string {
 
 		b.WriteString("func (a *" + toExport(acc.Name) + ") Address(pubkey solana.PublicKey) solana.PublicKey {\n\treturn pubkey\n}\n\n")
 	}
+
+	b.WriteString("// ErrUnknownAccount is returned by DecodeAccount for data whose discriminator\n// matches no account of this program.\n")
+	b.WriteString("var ErrUnknownAccount = errors.New(\"unknown account discriminator\")\n\n")
+	b.WriteString("// DecodeAccount identifies account data by its discriminator and decodes it,\n// returning the account type name and a pointer to the decoded struct.\n")
+	b.WriteString("func DecodeAccount(data []byte) (string, interface{}, error) {\n")
+	b.WriteString("\tif len(data) < 8 {\n\t\treturn \"\", nil, fmt.Errorf(\"account data too short\")\n\t}\n")
+	b.WriteString("\tswitch {\n")
+	for _, acc := range doc.Accounts {
+		name := toExport(acc.Name)
+		b.WriteString("\tcase bytes.Equal(data[:8], " + name + "Discriminator):\n")
+		b.WriteString("\t\tvar a " + name + "\n")
+		b.WriteString("\t\tif err := a.Unmarshal(data); err != nil {\n\t\t\treturn \"" + name + "\", nil, err\n\t\t}\n")
+		b.WriteString("\t\treturn \"" + name + "\", &a, nil\n")
+	}
+	b.WriteString("\t}\n")
+	b.WriteString("\treturn \"\", nil, ErrUnknownAccount\n")
+	b.WriteString("}\n")
 	return b.String()
 }
 
@@ -254,10 +343,23 @@ func generateInstructions(pkg string, doc idl) string {
 		b.WriteString("\t\"encoding/binary\"\n")
 	}
 	b.WriteString("\t\"fmt\"\n")
+	b.WriteString("\t\"strings\"\n")
 	b.WriteString("\n\tbin \"github.com/gagliardetto/binary\"\n")
 	b.WriteString("\t\"github.com/gagliardetto/solana-go\"\n")
 	b.WriteString(")\n\n")
 
+	b.WriteString("// appendAccountMeta appends a meta for key to dst, overwriting the meta left\n")
+	b.WriteString("// in the spare capacity of dst by an earlier build if there is one.\n")
+	b.WriteString("func appendAccountMeta(dst []*solana.AccountMeta, key solana.PublicKey, writable, signer bool) []*solana.AccountMeta {\n")
+	b.WriteString("\tif n := len(dst); n < cap(dst) {\n")
+	b.WriteString("\t\tif m := dst[:n+1][n]; m != nil {\n")
+	b.WriteString("\t\t\tm.PublicKey, m.IsWritable, m.IsSigner = key, writable, signer\n")
+	b.WriteString("\t\t\treturn dst[:n+1]\n")
+	b.WriteString("\t\t}\n")
+	b.WriteString("\t}\n")
+	b.WriteString("\treturn append(dst, solana.NewAccountMeta(key, writable, signer))\n")
+	b.WriteString("}\n\n")
+
 	for _, ins := range doc.Instructions {
 		disc := bytesLiteral(ins.Discriminator)
 		b.WriteString("var " + toExport(ins.Name) + "Discriminator = " + disc + "\n\n")
@@ -285,16 +387,40 @@ func generateInstructions(pkg string, doc idl) string {
 		}
 		b.WriteString("}\n\n")
 
-		// AccountMeta builder
+		// Fixed addresses are parsed once at init, not on every build.
+		for _, acc := range ins.Accounts {
+			if acc.Address != "" && programKeyVar(doc, acc.Address) == "" {
+				b.WriteString("var default" + toExport(ins.Name) + toExport(acc.Name) + " = solana.MustPublicKeyFromBase58(\"" + acc.Address + "\")\n\n")
+			}
+		}
+
+		// AccountMeta builders
 		b.WriteString("func (a " + toExport(ins.Name) + "Accounts) ToAccountMetas() []*solana.AccountMeta {\n")
-		b.WriteString("\tmetas := make([]*solana.AccountMeta, 0, " + fmt.Sprint(len(ins.Accounts)) + ")\n")
+		b.WriteString("\treturn a.AppendAccountMetas(make([]*solana.AccountMeta, 0, " + fmt.Sprint(len(ins.Accounts)) + "))\n")
+		b.WriteString("}\n\n")
+
+		b.WriteString("// AppendAccountMetas appends the account metas to dst and returns the\n")
+		b.WriteString("// extended slice. Metas already in the spare capacity of dst are\n")
+		b.WriteString("// overwritten in place rather than allocated, so building repeatedly into\n")
+		b.WriteString("// metas[:0] does not allocate. The caller owns dst and its metas: an\n")
+		b.WriteString("// instruction built from an earlier result, or any copy of its meta\n")
+		b.WriteString("// pointers, changes when dst is reused, so finish with it (sign and\n")
+		b.WriteString("// serialize) first. ToAccountMetas always returns new metas.\n")
+		b.WriteString("func (a " + toExport(ins.Name) + "Accounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {\n")
 		for _, acc := range ins.Accounts {
 			pkExpr := "a." + toExport(acc.Name)
-			if acc.Address != "" {
-				pkExpr = "default" + toExport(ins.Name) + toExport(acc.Name) + "()"
-				b.WriteString("var default" + toExport(ins.Name) + toExport(acc.Name) + " = func() solana.PublicKey {\n")
-				b.WriteString("\treturn solana.MustPublicKeyFromBase58(\"" + acc.Address + "\")\n")
-				b.WriteString("}\n\n")
+			if v := programKeyVar(doc, acc.Address); v != "" {
+				pkExpr = v
+			} else if acc.Address != "" {
+				pkExpr = "default" + toExport(ins.Name) + toExport(acc.Name)
+			}
+			if acc.Optional && acc.Address == "" {
+				b.WriteString("\tif " + pkExpr + ".IsZero() {\n")
+				b.WriteString("\t\tdst = appendAccountMeta(dst, ProgramKey, false, false)\n")
+				b.WriteString("\t} else {\n")
+				b.WriteString("\t\tdst = appendAccountMeta(dst, " + pkExpr + ", " + boolStr(acc.Writable) + ", " + boolStr(acc.Signer && acc.PDA == nil) + ")\n")
+				b.WriteString("\t}\n")
+				continue
 			}
 			signer := acc.Signer
 			// PDAs 或常量地址不应为 signer
@@ -304,14 +430,17 @@ func generateInstructions(pkg string, doc idl) string {
 			if acc.Address != "" {
 				signer = false
 			}
-			// solana.NewAccountMeta(pubkey, isWritable, isSigner)
-			b.WriteString("\tmetas = append(metas, solana.NewAccountMeta(" + pkExpr + ", " + boolStr(acc.Writable) + ", " + boolStr(signer) + "))\n")
+			// appendAccountMeta(dst, pubkey, isWritable, isSigner)
+			b.WriteString("\tdst = appendAccountMeta(dst, " + pkExpr + ", " + boolStr(acc.Writable) + ", " + boolStr(signer) + ")\n")
 		}
-		b.WriteString("\treturn metas\n")
+		b.WriteString("\treturn dst\n")
 		b.WriteString("}\n\n")
 
+		writeValidate(&b, ins)
+
 		// Instruction builder
 		b.WriteString("func Build" + toExport(ins.Name) + "(accounts " + toExport(ins.Name) + "Accounts, args " + toExport(ins.Name) + "Args) (solana.Instruction, error) {\n")
+		b.WriteString("\tif err := accounts.Validate(); err != nil {\n\t\treturn nil, err\n\t}\n")
 		b.WriteString("\tbuf := bytes.NewBuffer(make([]byte, 0, 128))\n")
 		b.WriteString("\tbuf.Write(" + toExport(ins.Name) + "Discriminator)\n")
 		if len(ins.Args) > 0 {
@@ -343,7 +472,9 @@ func generateInstructions(pkg string, doc idl) string {
 			}
 			prog := "ProgramKey"
 			if acc.PDA.Program != nil {
-				if len(acc.PDA.Program.Value) > 0 {
+				if v := programKeyVar(doc, pdaProgramAddress(acc.PDA)); v != "" {
+					prog = v
+				} else if len(acc.PDA.Program.Value) > 0 {
 					prog = "solana.PublicKeyFromBytes(" + bytesLiteral(acc.PDA.Program.Value) + ")"
 				} else if acc.PDA.Program.Kind == "account" && acc.PDA.Program.Path != "" {
 					prog = "accounts." + toExport(pathHead(acc.PDA.Program.Path))
@@ -356,6 +487,100 @@ func generateInstructions(pkg string, doc idl) string {
 	return b.String()
 }
 
+// writeValidate emits the Validate method of an instruction's accounts
+// struct. Accounts with a fixed address are filled in by ToAccountMetas and
+// optional accounts may be left zero, so neither is checked.
+func writeValidate(b *strings.Builder, ins idlInstruction) {
+	name := toExport(ins.Name)
+	b.WriteString("// Validate returns an error naming every required account that is the zero key.\n")
+	b.WriteString("func (a " + name + "Accounts) Validate() error {\n")
+	b.WriteString("\tvar missing []string\n")
+	for _, acc := range ins.Accounts {
+		if acc.Address != "" || acc.Optional {
+			continue
+		}
+		b.WriteString("\tif a." + toExport(acc.Name) + ".IsZero() {\n\t\tmissing = append(missing, \"" + acc.Name + "\")\n\t}\n")
+	}
+	b.WriteString("\tif len(missing) > 0 {\n")
+	b.WriteString("\t\treturn fmt.Errorf(\"" + ins.Name + ": missing accounts: %s\", strings.Join(missing, \", \"))\n")
+	b.WriteString("\t}\n")
+	b.WriteString("\treturn nil\n")
+	b.WriteString("}\n\n")
+}
+
+// roundTripTag is the build tag of the generated round-trip tests. They need
+// no RPC, but run them explicitly after regenerating:
+//
+//	go test -tags roundtrip ./pkg/program/...
+const roundTripTag = "roundtrip"
+
+// generateRoundTripTests emits a test that Borsh-encodes the zero value of
+// every account and type struct, decodes it back and compares the two. A
+// field generated as interface{} for an unsupported IDL type fails here
+// instead of at runtime.
+func generateRoundTripTests(pkg string, doc idl) string {
+	var b strings.Builder
+	b.WriteString("//go:build " + roundTripTag + "\n\n")
+	header(&b, pkg)
+	b.WriteString("import (\n\t\"bytes\"\n\t\"fmt\"\n\t\"reflect\"\n\t\"testing\"\n\n\tbin \"github.com/gagliardetto/binary\"\n)\n\n")
+
+	// A nil interface{} encodes to nothing and decodes back unchanged, so the
+	// fallback type is rejected by inspection before the round trip.
+	b.WriteString("// unsupportedField returns the path of the first interface{} field in t,\n// descending only into the structs of package pkg.\n")
+	b.WriteString("func unsupportedField(t reflect.Type, pkg, path string) string {\n")
+	b.WriteString("\tswitch t.Kind() {\n")
+	b.WriteString("\tcase reflect.Interface:\n\t\treturn path\n")
+	b.WriteString("\tcase reflect.Ptr, reflect.Slice, reflect.Array:\n\t\treturn unsupportedField(t.Elem(), pkg, path)\n")
+	b.WriteString("\tcase reflect.Struct:\n")
+	b.WriteString("\t\tif t.PkgPath() != pkg {\n\t\t\treturn \"\"\n\t\t}\n")
+	b.WriteString("\t\tfor i := 0; i < t.NumField(); i++ {\n")
+	b.WriteString("\t\t\tif p := unsupportedField(t.Field(i).Type, pkg, path+\".\"+t.Field(i).Name); p != \"\" {\n\t\t\t\treturn p\n\t\t\t}\n")
+	b.WriteString("\t\t}\n")
+	b.WriteString("\t}\n")
+	b.WriteString("\treturn \"\"\n")
+	b.WriteString("}\n\n")
+
+	b.WriteString("func roundTrip(v, out interface{}) (err error) {\n")
+	b.WriteString("\tt := reflect.TypeOf(v).Elem()\n")
+	b.WriteString("\tif p := unsupportedField(t, t.PkgPath(), \"\"); p != \"\" {\n\t\treturn fmt.Errorf(\"field %s has unsupported IDL type interface{}\", p[1:])\n\t}\n")
+	b.WriteString("\tdefer func() {\n\t\tif r := recover(); r != nil {\n\t\t\terr = fmt.Errorf(\"panic: %v\", r)\n\t\t}\n\t}()\n")
+	b.WriteString("\tvar buf bytes.Buffer\n")
+	b.WriteString("\tif err := bin.NewBorshEncoder(&buf).Encode(v); err != nil {\n\t\treturn fmt.Errorf(\"encode: %w\", err)\n\t}\n")
+	b.WriteString("\tif err := bin.NewBorshDecoder(buf.Bytes()).Decode(out); err != nil {\n\t\treturn fmt.Errorf(\"decode: %w\", err)\n\t}\n")
+	b.WriteString("\tif !reflect.DeepEqual(v, out) {\n\t\treturn fmt.Errorf(\"round trip mismatch: %#v != %#v\", v, out)\n\t}\n")
+	b.WriteString("\treturn nil\n")
+	b.WriteString("}\n\n")
+
+	var names []string
+	seen := map[string]bool{}
+	for _, acc := range doc.Accounts {
+		if !seen[acc.Name] {
+			seen[acc.Name] = true
+			names = append(names, toExport(acc.Name))
+		}
+	}
+	for _, t := range doc.Types {
+		desc, _ := parseTypeDesc(t.Type)
+		if desc == nil || desc.Kind != "struct" || seen[t.Name] {
+			continue
+		}
+		seen[t.Name] = true
+		names = append(names, toExport(t.Name))
+	}
+
+	b.WriteString("func TestBorshRoundTrip(t *testing.T) {\n")
+	b.WriteString("\tcases := []struct {\n\t\tname    string\n\t\tv, out interface{}\n\t}{\n")
+	for _, name := range names {
+		b.WriteString("\t\t{\"" + name + "\", &" + name + "{}, &" + name + "{}},\n")
+	}
+	b.WriteString("\t}\n")
+	b.WriteString("\tfor _, tc := range cases {\n")
+	b.WriteString("\t\tif err := roundTrip(tc.v, tc.out); err != nil {\n\t\t\tt.Errorf(\"%s: %v\", tc.name, err)\n\t\t}\n")
+	b.WriteString("\t}\n")
+	b.WriteString("}\n")
+	return b.String()
+}
+
 func generateErrors(pkg string, doc idl) string {
 	var b strings.Builder
 	header(&b, pkg)
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// generatedAt matches the timestamp line of header, which changes per run.
+var generatedAt = regexp.MustCompile(`(?m)^// Generated at .*$`)
+
+func TestGenerateInstructionsGolden(t *testing.T) {
+	raw, err := os.ReadFile(filepath.Join("testdata", "sample.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc idl
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := format.Source([]byte(generateInstructions("sample", doc)))
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	got = generatedAt.ReplaceAll(got, []byte("// Generated at <time>"))
+
+	golden := filepath.Join("testdata", "instructions.golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("generated instructions differ from %s; rerun with -update and review the diff\n%s", golden, got)
+	}
+}
+
+func TestGenerateProgramDeclaresOtherPumpPrograms(t *testing.T) {
+	raw, err := os.ReadFile(filepath.Join("testdata", "sample.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc idl
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatal(err)
+	}
+	got := generateProgram("sample", doc)
+	if !strings.Contains(got, `PumpFeesProgramKey = solana.MustPublicKeyFromBase58("pfeeUxB6jkeY1Hxd7CsFCAjcbHA9rWtchMGdZ6VojVZ")`) {
+		t.Errorf("fee program variable missing:\n%s", got)
+	}
+	// The program itself is ProgramKey, not a second variable.
+	if strings.Contains(got, "PumpProgramKey") {
+		t.Errorf("own program declared as another Pump program:\n%s", got)
+	}
+}
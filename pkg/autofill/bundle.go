@@ -0,0 +1,158 @@
+package autofill
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/jito"
+	"github.com/ninja0404/pump-go-sdk/pkg/txbuilder"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+	"github.com/ninja0404/pump-go-sdk/pkg/wallet"
+)
+
+// PumpSellBundle builds a pump sell as a two-transaction Jito bundle:
+// tx1 carries the trade (without an inline tip) and tx2 transfers tipLamports
+// from the fee payer to a Jito tip account (tipLamports of 0 falls back to
+// the WithJitoBundleTip, then the WithJitoTip amount). Both transactions are signed by
+// signer and can be passed directly to Builder.SendBundleViaJito, so the tip
+// only lands if the trade does.
+//
+// The tip account is taken from WithJitoTipAccount if set; otherwise a live
+// account is fetched from the builder's Jito client, falling back to the
+// predefined list if none is configured or the request fails.
+//
+// With WithDryRun both transactions use DryRunBlockhash and the tip account
+// comes from the predefined list; they are signed but not sendable.
+//
+// Example:
+//
+//	trade, tip, err := autofill.PumpSellBundle(ctx, rpc, builder, signer, mint, amount, 100, 1_000_000)
+//	if err != nil { ... }
+//	bundleID, err := builder.SendBundleViaJito(ctx, []*solana.Transaction{trade, tip})
+func PumpSellBundle(ctx context.Context, rpc RPC, builder *txbuilder.Builder, signer wallet.Signer, mint solana.PublicKey, amount, slippageBps, tipLamports uint64, opts ...Option) (trade, tip *solana.Transaction, err error) {
+	if builder == nil || signer == nil {
+		return nil, nil, fmt.Errorf("builder and signer are required")
+	}
+
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if tipLamports == 0 {
+		tipLamports = options.JitoBundleTip
+	}
+	if tipLamports == 0 {
+		tipLamports = options.JitoTipLamports
+	}
+	if tipLamports == 0 {
+		return nil, nil, types.NewValidationError("tipLamports", "must be greater than 0")
+	}
+	user := signer.PublicKey()
+
+	// The tip travels in its own transaction, so strip any inline tip.
+	tradeOpts := append(append([]Option{}, opts...), func(o *Options) { o.JitoTipLamports, o.JitoBundleTip = 0, 0 })
+	_, _, instrs, err := PumpSellWithSlippage(ctx, rpc, user, mint, amount, slippageBps, tradeOpts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tipIx := system.NewTransferInstruction(tipLamports, user, resolveTipAccount(ctx, builder, options)).Build()
+
+	build := builder.BuildTransaction
+	if options.DryRun {
+		build = func(_ context.Context, payer solana.PublicKey, instrs ...solana.Instruction) (*solana.Transaction, error) {
+			return DryRunTransaction(payer, instrs...)
+		}
+	}
+	trade, err = build(ctx, user, instrs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("build trade tx: %w", err)
+	}
+	if err := txbuilder.SignTransaction(ctx, trade, signer); err != nil {
+		return nil, nil, fmt.Errorf("sign trade tx: %w", err)
+	}
+	tip, err = build(ctx, user, tipIx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("build tip tx: %w", err)
+	}
+	if err := txbuilder.SignTransaction(ctx, tip, signer); err != nil {
+		return nil, nil, fmt.Errorf("sign tip tx: %w", err)
+	}
+	return trade, tip, nil
+}
+
+// BuildAndSendInstructions builds, signs and sends instrs as one transaction
+// paid by signer. With WithJitoBundleTip the trade and a separate tip
+// transfer are sent as a Jito bundle via SendBundleViaJito instead, so the
+// tip only lands with the trade; the returned signature is the trade's.
+func BuildAndSendInstructions(ctx context.Context, builder *txbuilder.Builder, signer wallet.Signer, instrs []solana.Instruction, opts ...Option) (solana.Signature, error) {
+	if builder == nil || signer == nil {
+		return solana.Signature{}, fmt.Errorf("builder and signer are required")
+	}
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.JitoBundleTip == 0 && options.DeadlineSlots == 0 {
+		return builder.BuildSignSend(ctx, signer, nil, instrs...)
+	}
+	if options.JitoBundleTip > 0 && !builder.HasJito() {
+		return solana.Signature{}, fmt.Errorf("jito bundle tip requires a builder with a jito client")
+	}
+
+	user := signer.PublicKey()
+	trade, err := builder.BuildTransaction(ctx, user, instrs...)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("build trade tx: %w", err)
+	}
+	if err := txbuilder.SignTransaction(ctx, trade, signer); err != nil {
+		return solana.Signature{}, fmt.Errorf("sign trade tx: %w", err)
+	}
+	if options.JitoBundleTip == 0 {
+		if err := checkDeadline(ctx, builder, trade, options); err != nil {
+			return solana.Signature{}, err
+		}
+		return builder.Send(ctx, trade)
+	}
+	tipIx := system.NewTransferInstruction(options.JitoBundleTip, user, resolveTipAccount(ctx, builder, options)).Build()
+	tip, err := builder.BuildTransaction(ctx, user, tipIx)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("build tip tx: %w", err)
+	}
+	if err := txbuilder.SignTransaction(ctx, tip, signer); err != nil {
+		return solana.Signature{}, fmt.Errorf("sign tip tx: %w", err)
+	}
+	if err := checkDeadline(ctx, builder, trade, options); err != nil {
+		return solana.Signature{}, err
+	}
+	if _, err := builder.SendBundleViaJito(ctx, []*solana.Transaction{trade, tip}); err != nil {
+		return solana.Signature{}, err
+	}
+	return trade.Signatures[0], nil
+}
+
+// checkDeadline enforces WithExecutionDeadlineSlots on tx, if set.
+func checkDeadline(ctx context.Context, builder *txbuilder.Builder, tx *solana.Transaction, options *Options) error {
+	if options.DeadlineSlots == 0 {
+		return nil
+	}
+	return builder.CheckBlockhashAge(ctx, tx, options.DeadlineSlots)
+}
+
+// resolveTipAccount picks the explicit tip account, a live one from the
+// builder's Jito client (except in dry runs), or a predefined one, in that
+// order.
+func resolveTipAccount(ctx context.Context, builder *txbuilder.Builder, options *Options) solana.PublicKey {
+	if !options.JitoTipAccount.IsZero() {
+		return options.JitoTipAccount
+	}
+	if jc := builder.JitoSender(); jc != nil && !options.DryRun {
+		if acc, err := jc.GetRandomTipAccount(ctx); err == nil && !acc.IsZero() {
+			return acc
+		}
+	}
+	return jito.GetRandomTipAccountLocal()
+}
@@ -0,0 +1,239 @@
+package autofill
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/jito"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+	"github.com/ninja0404/pump-go-sdk/pkg/txbuilder"
+	"github.com/ninja0404/pump-go-sdk/pkg/wallet"
+)
+
+func TestBuildAndSendInstructionsBundleTip(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handlers["getLatestBlockhash"] = func(json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{
+			"context": map[string]interface{}{"slot": 1},
+			"value":   map[string]interface{}{"blockhash": solana.Hash{1}.String(), "lastValidBlockHeight": 100},
+		}, nil
+	}
+	var bundle []string
+	fake.handlers["sendBundle"] = func(params json.RawMessage) (interface{}, error) {
+		var p []json.RawMessage
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(p[0], &bundle); err != nil {
+			return nil, err
+		}
+		return "bundle-1", nil
+	}
+
+	key, _ := solana.NewRandomPrivateKey()
+	signer := wallet.NewLocalFromPrivateKey(key)
+	user := signer.PublicKey()
+	tipAccount := solana.NewWallet().PublicKey()
+	builder := txbuilder.NewBuilder(client, solanarpc.CommitmentConfirmed).
+		WithJito(jito.NewClient(fake.url, "").WithRetries(1, 0))
+
+	// The trade helpers leave the bundled tip out of the instruction set.
+	opts := []Option{WithJitoBundleTip(5_000), WithJitoTipAccount(tipAccount)}
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	ix := system.NewTransferInstruction(1, user, user).Build()
+	if instrs, err := finalizeInstructions([]solana.Instruction{ix}, user, 0, options); err != nil || len(instrs) != 1 {
+		t.Fatalf("finalized %d instructions (%v), want the trade only", len(instrs), err)
+	}
+	if instrs, err := finalizeInstructionsPump([]solana.Instruction{ix}, user, 0, options); err != nil || len(instrs) != 1 {
+		t.Fatalf("finalized %d pump instructions (%v), want the trade only", len(instrs), err)
+	}
+
+	sig, err := BuildAndSendInstructions(context.Background(), builder, signer, []solana.Instruction{ix}, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bundle) != 2 {
+		t.Fatalf("bundle has %d transactions, want trade and tip", len(bundle))
+	}
+	tip, err := solana.TransactionFromBase64(bundle[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tip.Message.AccountKeys.Contains(tipAccount) {
+		t.Fatal("second bundle transaction does not pay the tip account")
+	}
+	if sig.IsZero() || sig == tip.Signatures[0] {
+		t.Fatalf("returned signature %s, want the trade's", sig)
+	}
+	if fake.callCount("sendTransaction") != 0 {
+		t.Fatal("bundled trade must not also be sent over RPC")
+	}
+
+	// Without a Jito client there is nowhere to send the bundle.
+	plain := txbuilder.NewBuilder(client, solanarpc.CommitmentConfirmed)
+	if _, err := BuildAndSendInstructions(context.Background(), plain, signer, []solana.Instruction{ix}, opts...); err == nil {
+		t.Fatal("expected an error without a jito client")
+	}
+}
+
+func TestBuildAndSendInstructionsDeadline(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handlers["getLatestBlockhash"] = func(json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{
+			"context": map[string]interface{}{"slot": 10},
+			"value":   map[string]interface{}{"blockhash": solana.Hash{1}.String(), "lastValidBlockHeight": 100},
+		}, nil
+	}
+	slot := 12
+	fake.handlers["getSlot"] = func(json.RawMessage) (interface{}, error) { return slot, nil }
+	fake.handlers["sendTransaction"] = func(json.RawMessage) (interface{}, error) {
+		return solana.Signature{1}.String(), nil
+	}
+
+	key, _ := solana.NewRandomPrivateKey()
+	signer := wallet.NewLocalFromPrivateKey(key)
+	user := signer.PublicKey()
+	builder := txbuilder.NewBuilder(client, solanarpc.CommitmentConfirmed)
+	ix := system.NewTransferInstruction(1, user, user).Build()
+
+	if _, err := BuildAndSendInstructions(context.Background(), builder, signer, []solana.Instruction{ix}, WithExecutionDeadlineSlots(2)); err != nil {
+		t.Fatal(err)
+	}
+	slot = 13
+	if _, err := BuildAndSendInstructions(context.Background(), builder, signer, []solana.Instruction{ix}, WithExecutionDeadlineSlots(2)); !errors.Is(err, txbuilder.ErrBlockhashTooOld) {
+		t.Fatalf("expected ErrBlockhashTooOld, got %v", err)
+	}
+	if n := fake.callCount("sendTransaction"); n != 1 {
+		t.Fatalf("sent %d transactions, want only the one within the deadline", n)
+	}
+}
+
+// systemTransfers returns the lamports tx transfers per recipient.
+func systemTransfers(t *testing.T, tx *solana.Transaction) map[solana.PublicKey]uint64 {
+	t.Helper()
+	out := make(map[solana.PublicKey]uint64)
+	for _, cix := range tx.Message.Instructions {
+		program, err := tx.Message.Program(cix.ProgramIDIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !program.Equals(solana.SystemProgramID) {
+			continue
+		}
+		metas, err := cix.ResolveInstructionAccounts(&tx.Message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inst, err := system.DecodeInstruction(metas, cix.Data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tr, ok := inst.Impl.(*system.Transfer); ok {
+			out[tr.GetRecipientAccount().PublicKey] += *tr.Lamports
+		}
+	}
+	return out
+}
+
+func TestPumpSellBundle(t *testing.T) {
+	ctx := context.Background()
+	curve := mock.MustLoadFixture(mock.FixtureBondingCurve)
+	rpc := mock.New()
+	rpc.Load(curve)
+	mint := curve.Address("mint")
+
+	key, _ := solana.NewRandomPrivateKey()
+	signer := wallet.NewLocalFromPrivateKey(key)
+	user := signer.PublicKey()
+	rpc.SetBalance(user, 1_000_000_000)
+	rpc.Simulate = func(context.Context, *solana.Transaction, *solanarpc.SimulateTransactionOpts) (*solanarpc.SimulateTransactionResponse, error) {
+		return &solanarpc.SimulateTransactionResponse{Value: &solanarpc.SimulateTransactionResult{
+			Accounts: []*solanarpc.Account{{Owner: solana.SystemProgramID, Lamports: 1_030_000_000}},
+		}}, nil
+	}
+
+	fake, client := newFakeRPC(t)
+	fake.handlers["getLatestBlockhash"] = func(json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{
+			"context": map[string]interface{}{"slot": 1},
+			"value":   map[string]interface{}{"blockhash": solana.Hash{1}.String(), "lastValidBlockHeight": 100},
+		}, nil
+	}
+	liveTip := solana.NewWallet().PublicKey()
+	jitoUp := true
+	fake.handlers["getTipAccounts"] = func(json.RawMessage) (interface{}, error) {
+		if !jitoUp {
+			return nil, errors.New("jito unavailable")
+		}
+		return []string{liveTip.String()}, nil
+	}
+	builder := txbuilder.NewBuilder(client, solanarpc.CommitmentConfirmed).
+		WithJito(jito.NewClient(fake.url, "").WithRetries(1, 0))
+
+	isPredefined := func(acc solana.PublicKey) bool {
+		return solana.PublicKeySlice(jito.MainnetTipAccounts).Contains(acc)
+	}
+	cases := []struct {
+		name        string
+		tipLamports uint64
+		jitoUp      bool
+		opts        []Option
+		wantTip     uint64
+		// wantAccount is the tip account, or zero for any predefined one.
+		wantAccount solana.PublicKey
+	}{
+		{"live tip account", 7_000, true, nil, 7_000, liveTip},
+		{"jito down falls back to the predefined list", 7_000, false, nil, 7_000, solana.PublicKey{}},
+		{"zero tip uses WithJitoBundleTip", 0, true, []Option{WithJitoBundleTip(3_000)}, 3_000, liveTip},
+		// The inline tip moves to the tip tx, paying the account it picked.
+		{"zero tip uses WithJitoTip", 0, true, []Option{WithJitoTip(1_000)}, 1_000, solana.PublicKey{}},
+		{"explicit tip wins over WithJitoTip", 7_000, true, []Option{WithJitoTip(1_000)}, 7_000, solana.PublicKey{}},
+		{"dry run skips jito", 5_000, true, []Option{WithDryRun()}, 5_000, solana.PublicKey{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			jitoUp = tc.jitoUp
+			trade, tip, err := PumpSellBundle(ctx, rpc, builder, signer, mint, 1_000_000, 100, tc.tipLamports, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if transfers := systemTransfers(t, trade); len(transfers) != 0 {
+				t.Fatalf("trade tx carries transfers %v, want no inline tip", transfers)
+			}
+			transfers := systemTransfers(t, tip)
+			if len(transfers) != 1 {
+				t.Fatalf("tip tx transfers %v, want one tip", transfers)
+			}
+			for account, lamports := range transfers {
+				if lamports != tc.wantTip {
+					t.Fatalf("tip of %d lamports, want %d", lamports, tc.wantTip)
+				}
+				if tc.wantAccount.IsZero() && !isPredefined(account) || !tc.wantAccount.IsZero() && !account.Equals(tc.wantAccount) {
+					t.Fatalf("tip paid to %s", account)
+				}
+			}
+			if !trade.Message.AccountKeys[0].Equals(user) || !tip.Message.AccountKeys[0].Equals(user) || len(trade.Signatures) != 1 || len(tip.Signatures) != 1 {
+				t.Fatal("both transactions must be paid and signed by the signer")
+			}
+		})
+	}
+
+	if _, _, err := PumpSellBundle(ctx, rpc, builder, signer, mint, 1_000_000, 100, 0); err == nil {
+		t.Fatal("expected an error without any tip amount")
+	}
+	if _, _, err := PumpSellBundle(ctx, rpc, nil, signer, mint, 1_000_000, 100, 5_000); err == nil {
+		t.Fatal("expected an error without a builder")
+	}
+	if _, _, err := PumpSellBundle(ctx, rpc, builder, nil, mint, 1_000_000, 100, 5_000); err == nil {
+		t.Fatal("expected an error without a signer")
+	}
+}
@@ -0,0 +1,127 @@
+package autofill
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/curve"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// PumpBuyWithRetry builds a buy of amountSol lamports on the bonding curve
+// whose minimum output is quoted from the curve's current reserves less
+// slippageBps, and simulates it. If the simulation fails on slippage (per
+// types.ClassifyError) the curve is read again and the buy re-quoted, up to
+// maxAttempts in total; any other failure is returned at once. A bonding
+// curve injected with WithBondingCurve is only used for the first attempt.
+//
+// Returns accounts, args and instructions of the buy that simulated
+// successfully and the number of attempts used. After maxAttempts slippage
+// failures the last one is returned, wrapped.
+//
+// Example:
+//
+//	// Snipe 0.1 SOL with 5% slippage, re-quoting up to 3 times
+//	_, _, instrs, attempts, err := autofill.PumpBuyWithRetry(ctx, rpc, user, mint, 100_000_000, 500, 3)
+func PumpBuyWithRetry(ctx context.Context, rpc RPC, user, mint solana.PublicKey, amountSol, slippageBps uint64, maxAttempts int, opts ...Option) (pump.BuyExactSolInAccounts, pump.BuyExactSolInArgs, []solana.Instruction, int, error) {
+	// Input validation
+	if isNilRPC(rpc) {
+		return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, 0, types.ErrNilRPC
+	}
+	if err := types.ValidatePublicKey("user", user); err != nil {
+		return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, 0, err
+	}
+	if err := types.ValidatePublicKey("mint", mint); err != nil {
+		return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, 0, err
+	}
+	if amountSol == 0 {
+		return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, 0, types.NewValidationError("amountSol", "must be greater than 0")
+	}
+	if err := types.ValidateSlippage(slippageBps); err != nil {
+		return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, 0, err
+	}
+	if maxAttempts < 1 {
+		return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, 0, types.NewValidationError("maxAttempts", "must be at least 1")
+	}
+
+	global, bondingCurve := pumpCurveAddresses(mint)
+
+	var lastErr error
+	attemptOpts := opts
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt == 2 {
+			// Re-quote against fresh reserves, not the injected curve.
+			attemptOpts = append(append([]Option{}, opts...), func(o *Options) { o.BondingCurve = nil })
+		}
+		options := &Options{}
+		for _, opt := range attemptOpts {
+			opt(options)
+		}
+
+		globalState, bc, _, err := fetchPumpTradeState(ctx, rpc, global, mint, bondingCurve, options)
+		if err != nil {
+			return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, attempt, err
+		}
+		if bc.Complete {
+			return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, attempt, fmt.Errorf("bonding curve for %s is complete", mint)
+		}
+		expected := curve.BuyTokensOut(pumpReserves(bc), globalState.TradeFeeBps(bc), amountSol)
+		if expected == 0 {
+			return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, attempt, fmt.Errorf("bonding curve for %s quotes no tokens for %d lamports", mint, amountSol)
+		}
+
+		minTokensOut, err := slippageMin(expected, slippageBps, options)
+		if err != nil {
+			return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, attempt, err
+		}
+		accts, args, instrs, err := PumpBuyExactSolIn(ctx, rpc, user, mint, amountSol, minTokensOut, attemptOpts...)
+		if err != nil {
+			return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, attempt, err
+		}
+		if options.result != nil {
+			options.result.ExpectedOut = ptr(expected)
+		}
+		if options.DryRun {
+			return accts, args, instrs, attempt, nil
+		}
+		sim, err := SimulateTrade(ctx, rpc, user, instrs, opts...)
+		if err != nil {
+			return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, attempt, err
+		}
+		if sim.Err == nil {
+			return accts, args, instrs, attempt, nil
+		}
+		if types.ClassifyError(sim.Err) != types.ErrorClassSlippage {
+			return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, attempt, sim.Err
+		}
+		lastErr = sim.Err
+		log := rpcLogger(rpc)
+		log.Debug().Int("attempt", attempt).Err(sim.Err).Msg("pump buy exceeded slippage, re-quoting")
+	}
+	return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, maxAttempts, fmt.Errorf("pump buy failed after %d attempts: %w", maxAttempts, lastErr)
+}
+
+// pumpReserves returns the reserves of bc that price its trades.
+func pumpReserves(bc pump.BondingCurve) curve.Reserves {
+	return curve.Reserves{
+		VirtualTokenReserves: bc.VirtualTokenReserves,
+		VirtualSolReserves:   bc.VirtualSolReserves,
+		RealTokenReserves:    bc.RealTokenReserves,
+		RealSolReserves:      bc.RealSolReserves,
+	}
+}
+
+// pumpCurveAddresses derives the Global and bonding curve addresses of mint.
+func pumpCurveAddresses(mint solana.PublicKey) (global, bondingCurve solana.PublicKey) {
+	addrs := pump.BuyAccounts{Mint: mint}
+	if pk, _, err := pump.DeriveBuyGlobalPDA(addrs, pump.BuyArgs{}); err == nil {
+		global = pk
+	}
+	if pk, _, err := pump.DeriveBuyBondingCurvePDA(addrs, pump.BuyArgs{}); err == nil {
+		bondingCurve = pk
+	}
+	return global, bondingCurve
+}
@@ -0,0 +1,111 @@
+package autofill
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/curve"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// curveData encodes bc as bonding curve account data.
+func curveData(t *testing.T, bc pump.BondingCurve) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.Write(pump.BondingCurveDiscriminator)
+	if err := bin.NewBorshEncoder(&buf).Encode(bc); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// simulationResults answers each SimulateTransaction on rpc with the next
+// of errs (nil for success), given as decoded from JSON.
+func simulationResults(rpc *mock.Client, errs ...interface{}) *int {
+	calls := new(int)
+	rpc.Simulate = func(_ context.Context, _ *solana.Transaction, opts *solanarpc.SimulateTransactionOpts) (*solanarpc.SimulateTransactionResponse, error) {
+		result := &solanarpc.SimulateTransactionResult{
+			Err:      errs[min(*calls, len(errs)-1)],
+			Logs:     []string{},
+			Accounts: make([]*solanarpc.Account, len(opts.Accounts.Addresses)),
+		}
+		*calls++
+		return &solanarpc.SimulateTransactionResponse{Value: result}, nil
+	}
+	return calls
+}
+
+func TestPumpBuyWithRetry(t *testing.T) {
+	slippage := map[string]interface{}{"InstructionError": []interface{}{float64(2), map[string]interface{}{"Custom": float64(6003)}}}
+	other := map[string]interface{}{"InstructionError": []interface{}{float64(2), map[string]interface{}{"Custom": float64(6005)}}}
+
+	user := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	curveAddr, _, err := pump.DeriveBuyBondingCurvePDA(pump.BuyAccounts{Mint: mint}, pump.BuyArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bc := pump.BondingCurve{
+		VirtualTokenReserves: 1_000_000_000_000_000,
+		VirtualSolReserves:   30_000_000_000,
+		RealTokenReserves:    800_000_000_000_000,
+		Creator:              solana.NewWallet().PublicKey(),
+	}
+	global := WithPumpGlobal(pump.Global{FeeRecipient: solana.NewWallet().PublicKey(), FeeBasisPoints: 95, CreatorFeeBasisPoints: 30})
+
+	t.Run("re-quotes after slippage", func(t *testing.T) {
+		rpc := mock.New()
+		rpc.SetAccount(mint, constants.TokenProgramID, 1, make([]byte, 82))
+		moved := bc
+		moved.VirtualSolReserves *= 2
+		rpc.SetAccount(curveAddr, pump.ProgramKey, 1, curveData(t, moved))
+		calls := simulationResults(rpc, slippage, nil)
+
+		_, args, instrs, attempts, err := PumpBuyWithRetry(context.Background(), rpc, user, mint, 100_000_000, 500, 3, global, WithBondingCurve(bc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if attempts != 2 || *calls != 2 || len(instrs) == 0 {
+			t.Fatalf("attempts = %d, simulations = %d", attempts, *calls)
+		}
+		// The retry must quote the moved curve, not the injected one.
+		want := applySlippage(curve.BuyTokensOut(pumpReserves(moved), 125, 100_000_000), 500)
+		if args.MinTokensOut != want {
+			t.Fatalf("min tokens out = %d, want %d from fresh reserves", args.MinTokensOut, want)
+		}
+	})
+
+	t.Run("gives up after maxAttempts", func(t *testing.T) {
+		rpc := mock.New()
+		rpc.SetAccount(mint, constants.TokenProgramID, 1, make([]byte, 82))
+		rpc.SetAccount(curveAddr, pump.ProgramKey, 1, curveData(t, bc))
+		calls := simulationResults(rpc, slippage)
+
+		_, _, _, attempts, err := PumpBuyWithRetry(context.Background(), rpc, user, mint, 100_000_000, 500, 3, global)
+		if types.ClassifyError(err) != types.ErrorClassSlippage || attempts != 3 || *calls != 3 {
+			t.Fatalf("err = %v, attempts = %d, simulations = %d", err, attempts, *calls)
+		}
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		rpc := mock.New()
+		rpc.SetAccount(mint, constants.TokenProgramID, 1, make([]byte, 82))
+		rpc.SetAccount(curveAddr, pump.ProgramKey, 1, curveData(t, bc))
+		calls := simulationResults(rpc, other, nil)
+
+		_, _, _, attempts, err := PumpBuyWithRetry(context.Background(), rpc, user, mint, 100_000_000, 500, 3, global)
+		var progErr *types.ProgramError
+		if !errors.As(err, &progErr) || attempts != 1 || *calls != 1 {
+			t.Fatalf("err = %v, attempts = %d, simulations = %d", err, attempts, *calls)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package autofill
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// tokenAccountCloseAuthorityOffset is the offset of the close_authority
+// COption<Pubkey> (u32 tag + key) in the base token account layout.
+const tokenAccountCloseAuthorityOffset = 129
+
+// ATACleanup is the outcome of CleanupEmptyATAs.
+type ATACleanup struct {
+	// Transactions holds the close instructions batched into sets that each
+	// fit in one transaction signed by the owner. Compute budget options
+	// apply to each set; a Jito tip is appended to the last one.
+	Transactions [][]solana.Instruction
+	// Closed lists the token accounts the transactions close.
+	Closed []solana.PublicKey
+	// ReclaimedLamports is the rent returned to the owner once every
+	// transaction lands.
+	ReclaimedLamports uint64
+	// Skipped lists empty accounts the owner cannot close as-is.
+	Skipped []SkippedTokenAccount
+}
+
+// SkippedTokenAccount is an empty token account CleanupEmptyATAs leaves open.
+type SkippedTokenAccount struct {
+	Address solana.PublicKey
+	Reason  string
+}
+
+// CleanupEmptyATAs finds owner's token accounts with a zero balance, under
+// both the SPL Token and Token-2022 programs, and returns instructions that
+// close them, sending their rent to owner. Every token account owned by
+// owner is considered, not only associated ones.
+//
+// Accounts are skipped when closing would fail: a close authority other
+// than owner is set, or (Token-2022) transfer fees are still withheld in the
+// account and must be harvested to the mint first.
+//
+// Example:
+//
+//	cleanup, err := autofill.CleanupEmptyATAs(ctx, rpc, owner)
+//	for _, instrs := range cleanup.Transactions {
+//	    // build, sign and send each
+//	}
+//	fmt.Printf("reclaimed %d lamports from %d accounts\n", cleanup.ReclaimedLamports, len(cleanup.Closed))
+func CleanupEmptyATAs(ctx context.Context, rpc RPC, owner solana.PublicKey, opts ...Option) (*ATACleanup, error) {
+	if isNilRPC(rpc) {
+		return nil, types.ErrNilRPC
+	}
+	if err := types.ValidatePublicKey("owner", owner); err != nil {
+		return nil, err
+	}
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	accounts, err := listTokenAccounts(ctx, rpc, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	cleanup := &ATACleanup{}
+	var closes []solana.Instruction
+	for _, ta := range accounts {
+		_, amount, ok := userTokenBalance(&ta.Account, owner)
+		if !ok || amount != 0 {
+			continue
+		}
+		data := ta.Account.Data.GetBinary()
+		if authority, set := closeAuthority(data); set && !authority.Equals(owner) {
+			cleanup.Skipped = append(cleanup.Skipped, SkippedTokenAccount{Address: ta.Pubkey, Reason: fmt.Sprintf("close authority is %s", authority)})
+			continue
+		}
+		if withheld := withheldTransferFee(data); withheld > 0 {
+			cleanup.Skipped = append(cleanup.Skipped, SkippedTokenAccount{Address: ta.Pubkey, Reason: fmt.Sprintf("%d withheld transfer fee must be harvested", withheld)})
+			continue
+		}
+		closes = append(closes, buildCloseAccount(ta.Pubkey, owner, owner, ta.Account.Owner))
+		cleanup.Closed = append(cleanup.Closed, ta.Pubkey)
+		cleanup.ReclaimedLamports += ta.Account.Lamports
+	}
+
+	// Size every batch with the tip so that any of them can be the last.
+	var batch []solana.Instruction
+	for _, ix := range closes {
+		instrs, err := finalizeInstructionsPump(append(batch[:len(batch):len(batch)], ix), owner, 0, options)
+		if err != nil {
+			return nil, err
+		}
+		size, err := transactionSize(owner, instrs, 1)
+		if err != nil {
+			return nil, err
+		}
+		if size > maxTransactionSize && len(batch) > 0 {
+			cleanup.Transactions = append(cleanup.Transactions, batch)
+			batch = nil
+		}
+		batch = append(batch, ix)
+	}
+	if len(batch) > 0 {
+		cleanup.Transactions = append(cleanup.Transactions, batch)
+	}
+
+	// The tip and caller instructions go in the last transaction only.
+	noTip := *options
+	noTip.JitoTipLamports = 0
+	noTip.PrependInstructions, noTip.AppendInstructions = nil, nil
+	for i, instrs := range cleanup.Transactions {
+		txOpts := options
+		if i < len(cleanup.Transactions)-1 {
+			txOpts = &noTip
+		}
+		if cleanup.Transactions[i], err = finalizeInstructionsPump(instrs, owner, 0, txOpts); err != nil {
+			return nil, err
+		}
+	}
+	return cleanup, nil
+}
+
+// closeAuthority returns the close authority of a token account and whether
+// one is set.
+func closeAuthority(data []byte) (solana.PublicKey, bool) {
+	if len(data) < tokenAccountSize || binary.LittleEndian.Uint32(data[tokenAccountCloseAuthorityOffset:]) != 1 {
+		return solana.PublicKey{}, false
+	}
+	return solana.PublicKeyFromBytes(data[tokenAccountCloseAuthorityOffset+4 : tokenAccountSize]), true
+}
@@ -0,0 +1,110 @@
+package autofill
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+)
+
+// token2022AccountData encodes a Token-2022 token account with a
+// TransferFeeAmount extension holding withheld.
+func token2022AccountData(mint, owner solana.PublicKey, amount, withheld uint64) []byte {
+	data := append(tokenAccountData(mint, owner, amount), token2022AccountTypeAccount)
+	ext := make([]byte, 4+transferFeeAmountLen)
+	binary.LittleEndian.PutUint16(ext[0:], extensionTransferFeeAmount)
+	binary.LittleEndian.PutUint16(ext[2:], transferFeeAmountLen)
+	binary.LittleEndian.PutUint64(ext[4:], withheld)
+	return append(data, ext...)
+}
+
+func TestCleanupEmptyATAs(t *testing.T) {
+	rpc := mock.New()
+	owner := solana.NewWallet().PublicKey()
+	key := func() solana.PublicKey { return solana.NewWallet().PublicKey() }
+
+	splEmpty, t22Empty, held, foreignClose, withheld := key(), key(), key(), key(), key()
+	foreignData := tokenAccountData(key(), owner, 0)
+	binary.LittleEndian.PutUint32(foreignData[tokenAccountCloseAuthorityOffset:], 1)
+	copy(foreignData[tokenAccountCloseAuthorityOffset+4:], key().Bytes())
+	rpc.SetAccount(splEmpty, constants.TokenProgramID, 2_039_280, tokenAccountData(key(), owner, 0))
+	rpc.SetAccount(t22Empty, constants.Token2022ProgramID, 2_074_080, token2022AccountData(key(), owner, 0, 0))
+	rpc.SetAccount(held, constants.TokenProgramID, 2_039_280, tokenAccountData(key(), owner, 5))
+	rpc.SetAccount(foreignClose, constants.TokenProgramID, 2_039_280, foreignData)
+	rpc.SetAccount(withheld, constants.Token2022ProgramID, 2_074_080, token2022AccountData(key(), owner, 0, 42))
+
+	cleanup, err := CleanupEmptyATAs(context.Background(), rpc, owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cleanup.Closed) != 2 || cleanup.ReclaimedLamports != 2_039_280+2_074_080 {
+		t.Fatalf("closed %v reclaiming %d, want the SPL and Token-2022 empty accounts", cleanup.Closed, cleanup.ReclaimedLamports)
+	}
+	if len(cleanup.Skipped) != 2 {
+		t.Fatalf("skipped %+v, want the foreign close authority and withheld fee accounts", cleanup.Skipped)
+	}
+	for _, s := range cleanup.Skipped {
+		if !s.Address.Equals(foreignClose) && !s.Address.Equals(withheld) {
+			t.Fatalf("unexpected skipped account %s", s.Address)
+		}
+	}
+	if len(cleanup.Transactions) != 1 || len(cleanup.Transactions[0]) != 2 {
+		t.Fatalf("expected one transaction with two closes, got %v", cleanup.Transactions)
+	}
+	for _, ix := range cleanup.Transactions[0] {
+		metas := ix.Accounts()
+		want := constants.TokenProgramID
+		if metas[0].PublicKey.Equals(t22Empty) {
+			want = constants.Token2022ProgramID
+		}
+		if !ix.ProgramID().Equals(want) {
+			t.Fatalf("close of %s runs under %s, want %s", metas[0].PublicKey, ix.ProgramID(), want)
+		}
+		if !metas[1].PublicKey.Equals(owner) || !metas[2].PublicKey.Equals(owner) {
+			t.Fatalf("close of %s must send rent to and be signed by the owner", metas[0].PublicKey)
+		}
+	}
+}
+
+func TestCleanupEmptyATAsBatches(t *testing.T) {
+	rpc := mock.New()
+	owner := solana.NewWallet().PublicKey()
+	for range 60 {
+		rpc.SetAccount(solana.NewWallet().PublicKey(), constants.TokenProgramID, 1, tokenAccountData(solana.NewWallet().PublicKey(), owner, 0))
+	}
+
+	cleanup, err := CleanupEmptyATAs(context.Background(), rpc, owner, WithPriorityFee(10_000), WithJitoTip(1_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cleanup.Transactions) < 2 {
+		t.Fatalf("expected 60 closes to need several transactions, got %d", len(cleanup.Transactions))
+	}
+	closes := 0
+	for i, instrs := range cleanup.Transactions {
+		size, err := transactionSize(owner, instrs, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size > maxTransactionSize {
+			t.Fatalf("transaction %d is %d bytes, over the %d limit", i, size, maxTransactionSize)
+		}
+		last := instrs[len(instrs)-1]
+		isTip := last.ProgramID().Equals(constants.SystemProgramID)
+		if isTip != (i == len(cleanup.Transactions)-1) {
+			t.Fatalf("transaction %d: tip present = %v, want it on the last transaction only", i, isTip)
+		}
+		for _, ix := range instrs {
+			if ix.ProgramID().Equals(constants.TokenProgramID) {
+				closes++
+			}
+		}
+	}
+	if closes != 60 {
+		t.Fatalf("got %d closes, want 60", closes)
+	}
+}
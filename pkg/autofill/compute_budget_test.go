@@ -0,0 +1,107 @@
+package autofill
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+)
+
+// checkComputeBudgetFirst asserts that instrs start with SetComputeUnitLimit
+// of limit and SetComputeUnitPrice of price, followed by an ATA creation.
+func checkComputeBudgetFirst(t *testing.T, name string, instrs []solana.Instruction, limit uint32, price uint64) {
+	t.Helper()
+	if len(instrs) < 3 {
+		t.Fatalf("%s: %d instructions, want compute budget, ATA creation and trade", name, len(instrs))
+	}
+	for i, ix := range instrs[:2] {
+		if !ix.ProgramID().Equals(constants.ComputeBudgetProgramID) {
+			t.Fatalf("%s: instruction %d is %s, want the compute budget program", name, i, ix.ProgramID())
+		}
+	}
+	data, _ := instrs[0].Data()
+	if len(data) != 5 || data[0] != 2 || binary.LittleEndian.Uint32(data[1:]) != limit {
+		t.Errorf("%s: SetComputeUnitLimit data %v, want limit %d", name, data, limit)
+	}
+	data, _ = instrs[1].Data()
+	if len(data) != 9 || data[0] != 3 || binary.LittleEndian.Uint64(data[1:]) != price {
+		t.Errorf("%s: SetComputeUnitPrice data %v, want %d microLamports", name, data, price)
+	}
+	if !instrs[2].ProgramID().Equals(constants.AssociatedTokenProgramID) {
+		t.Errorf("%s: instruction 2 is %s, want ATA creation after the compute budget", name, instrs[2].ProgramID())
+	}
+	for i, ix := range instrs[2:] {
+		if ix.ProgramID().Equals(constants.ComputeBudgetProgramID) {
+			t.Errorf("%s: compute budget instruction at %d, after other instructions", name, i+2)
+		}
+	}
+}
+
+func TestComputeBudgetPrecedesATACreation(t *testing.T) {
+	ctx := context.Background()
+	user := solana.NewWallet().PublicKey()
+	curve := mock.MustLoadFixture(mock.FixtureBondingCurve)
+	amm := mock.MustLoadFixture(mock.FixtureAmmPool)
+	client := mock.New()
+	client.Load(curve)
+	client.Load(amm)
+
+	perCU := []Option{WithDryRun(), WithComputeUnitLimit(150_000), WithPriorityFeePerCU(5_000)}
+	// 15_000 lamports over 150_000 CU is 100_000 microLamports per CU.
+	total := []Option{WithDryRun(), WithComputeUnitLimit(150_000), WithPriorityFee(15_000)}
+
+	for _, tc := range []struct {
+		name  string
+		opts  []Option
+		price uint64
+	}{
+		{"per CU", perCU, 5_000},
+		{"total", total, 100_000},
+	} {
+		_, _, instrs, err := PumpBuy(ctx, client, user, curve.Address("mint"), 1_000_000, 100_000_000, tc.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkComputeBudgetFirst(t, "PumpBuy "+tc.name, instrs, 150_000, tc.price)
+
+		_, _, instrs, err = PumpBuyExactSolIn(ctx, client, user, curve.Address("mint"), 100_000_000, 1, tc.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkComputeBudgetFirst(t, "PumpBuyExactSolIn "+tc.name, instrs, 150_000, tc.price)
+
+		_, _, instrs, err = PumpSellWithSlippage(ctx, client, user, curve.Address("mint"), 1_000_000, 100, tc.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkComputeBudgetFirst(t, "PumpSellWithSlippage "+tc.name, instrs, 150_000, tc.price)
+
+		_, _, instrs, err = PumpAmmBuy(ctx, client, user, amm.Address("pool"), 1_000_000, 50_000_000, tc.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkComputeBudgetFirst(t, "PumpAmmBuy "+tc.name, instrs, 150_000, tc.price)
+
+		_, _, instrs, _, err = PumpAmmBuyWithSol(ctx, client, user, amm.Address("pool"), 10_000_000, 100, tc.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkComputeBudgetFirst(t, "PumpAmmBuyWithSol "+tc.name, instrs, 150_000, tc.price)
+
+		_, _, instrs, err = PumpAmmBuyExactQuoteIn(ctx, client, user, amm.Address("pool"), 10_000_000, 1, tc.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkComputeBudgetFirst(t, "PumpAmmBuyExactQuoteIn "+tc.name, instrs, 150_000, tc.price)
+
+		_, _, instrs, err = PumpAmmSellWithSlippage(ctx, client, user, amm.Address("pool"), 1_000_000, 100, tc.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkComputeBudgetFirst(t, "PumpAmmSellWithSlippage "+tc.name, instrs, 150_000, tc.price)
+	}
+}
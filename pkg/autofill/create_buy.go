@@ -0,0 +1,223 @@
+package autofill
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/curve"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/txbuilder"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+	"github.com/ninja0404/pump-go-sdk/pkg/wallet"
+)
+
+// maxTransactionSize is the Solana packet limit for a serialized transaction.
+const maxTransactionSize = txbuilder.MaxTransactionSize
+
+// CreateAndBuyResult is the outcome of PumpCreateAndBuy.
+type CreateAndBuyResult struct {
+	// MintKey is the new mint keypair. It must co-sign the create transaction.
+	MintKey        solana.PrivateKey
+	CreateAccounts pump.CreateAccounts
+	CreateArgs     pump.CreateArgs
+	BuyAccounts    pump.BuyExactSolInAccounts
+	BuyArgs        pump.BuyExactSolInArgs
+	// ExpectedTokens is the quoted token output before slippage.
+	ExpectedTokens uint64
+	// Transactions holds the instruction sets to submit in order: one when
+	// create and buy fit in a single transaction, otherwise two (create, then
+	// buy) to send together as a Jito bundle. Compute budget options apply
+	// to each set; a Jito tip is appended to the last one.
+	Transactions [][]solana.Instruction
+}
+
+// MintSigner returns a signer for the new mint, to pass alongside the user
+// when signing the first transaction.
+func (r *CreateAndBuyResult) MintSigner() wallet.Signer {
+	return wallet.NewLocalFromPrivateKey(r.MintKey)
+}
+
+// PumpCreateAndBuy creates a new SPL token on the bonding curve and buys it
+// with buyAmountSol lamports in the same submission ("create then snipe").
+//
+// The bonding curve and all buy accounts are derived from the new mint
+// without an RPC round trip (they do not exist yet); only the Global account
+// is fetched for the fee recipient, fee rates and initial reserves used to
+// quote the buy. minTokensOut is the quote reduced by slippageBps.
+//
+// Example:
+//
+//	res, err := autofill.PumpCreateAndBuy(ctx, rpc, user.PublicKey(), "My Token", "MTK", uri, 100_000_000, 500)
+//	if len(res.Transactions) == 1 {
+//	    sig, err := builder.BuildSignSendAndConfirm(ctx, user, []wallet.Signer{res.MintSigner()}, txbuilder.ConfirmationConfirmed, res.Transactions[0]...)
+//	}
+func PumpCreateAndBuy(ctx context.Context, rpc RPC, user solana.PublicKey, name, symbol, uri string, buyAmountSol, slippageBps uint64, opts ...Option) (*CreateAndBuyResult, error) {
+	if buyAmountSol == 0 {
+		return nil, types.NewValidationError("buyAmountSol", "must be greater than 0")
+	}
+	if err := types.ValidateSlippage(slippageBps); err != nil {
+		return nil, err
+	}
+
+	options := &Options{TrackVolume: true}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	createAccts, createArgs, createIx, mintKey, err := PumpCreate(ctx, rpc, user, name, symbol, uri, opts...)
+	if err != nil {
+		return nil, err
+	}
+	mint := mintKey.PublicKey()
+
+	global, err := fetchPumpGlobal(ctx, rpc, createAccts.Global)
+	if err != nil {
+		return nil, err
+	}
+	buyAccts, err := newCurveBuyAccounts(user, mint, createAccts.TokenProgram, global, options)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := newCurveTokensOut(global, buyAmountSol)
+	minTokensOut, err := slippageMin(expected, slippageBps, options)
+	if err != nil {
+		return nil, err
+	}
+	buyArgs := pump.BuyExactSolInArgs{
+		SpendableSolIn: buyAmountSol,
+		MinTokensOut:   minTokensOut,
+		TrackVolume:    pump.OptionBool{Field0: options.TrackVolume},
+	}
+	buyIx, err := pump.BuildBuyExactSolIn(buyAccts, buyArgs)
+	if err != nil {
+		return nil, err
+	}
+	// The user's ATA cannot exist before the mint does.
+	userATA := buildCreateATAIdempotent(user, buyAccts.AssociatedUser, user, mint, buyAccts.TokenProgram, constants.AssociatedTokenProgramID)
+
+	res := &CreateAndBuyResult{
+		MintKey:        mintKey,
+		CreateAccounts: createAccts,
+		CreateArgs:     createArgs,
+		BuyAccounts:    buyAccts,
+		BuyArgs:        buyArgs,
+		ExpectedTokens: expected,
+	}
+	single, err := finalizeInstructionsPump([]solana.Instruction{createIx, userATA, buyIx}, user, buyAmountSol, options)
+	if err != nil {
+		return nil, err
+	}
+	size, err := transactionSize(user, single, 2)
+	if err != nil {
+		return nil, err
+	}
+	if size <= maxTransactionSize {
+		res.Transactions = [][]solana.Instruction{single}
+		return res, nil
+	}
+
+	// The tip and caller instructions go with the buy.
+	noTip := *options
+	noTip.JitoTipLamports = 0
+	noTip.PrependInstructions, noTip.AppendInstructions = nil, nil
+	create, err := finalizeInstructionsPump([]solana.Instruction{createIx}, user, 0, &noTip)
+	if err != nil {
+		return nil, err
+	}
+	buy, err := finalizeInstructionsPump([]solana.Instruction{userATA, buyIx}, user, buyAmountSol, options)
+	if err != nil {
+		return nil, err
+	}
+	res.Transactions = [][]solana.Instruction{create, buy}
+	return res, nil
+}
+
+// newCurveBuyAccounts derives buy accounts for a bonding curve created in the
+// same transaction, with the user as creator. tokenProgram is the program
+// the create instruction mints under; both ATAs are derived with it. The fee
+// recipient is chosen from global as options select.
+func newCurveBuyAccounts(user, mint, tokenProgram solana.PublicKey, global pump.Global, options *Options) (pump.BuyExactSolInAccounts, error) {
+	accts := pump.BuyExactSolInAccounts{
+		Mint:          mint,
+		User:          user,
+		SystemProgram: constants.SystemProgramID,
+		TokenProgram:  tokenProgram,
+		Program:       pump.ProgramKey,
+		FeeProgram:    constants.PumpFeeProgramID,
+	}
+	var err error
+	if accts.FeeRecipient, err = selectFeeRecipient(global.AcceptedFeeRecipients(false), options); err != nil {
+		return accts, err
+	}
+	derive := func(dst *solana.PublicKey, fn func(pump.BuyExactSolInAccounts, pump.BuyExactSolInArgs) (solana.PublicKey, uint8, error)) {
+		if err != nil {
+			return
+		}
+		*dst, _, err = fn(accts, pump.BuyExactSolInArgs{})
+	}
+	derive(&accts.Global, pump.DeriveBuyExactSolInGlobalPDA)
+	derive(&accts.BondingCurve, pump.DeriveBuyExactSolInBondingCurvePDA)
+	derive(&accts.EventAuthority, pump.DeriveBuyExactSolInEventAuthorityPDA)
+	derive(&accts.GlobalVolumeAccumulator, pump.DeriveBuyExactSolInGlobalVolumeAccumulatorPDA)
+	derive(&accts.UserVolumeAccumulator, pump.DeriveBuyExactSolInUserVolumeAccumulatorPDA)
+	derive(&accts.FeeConfig, pump.DeriveBuyExactSolInFeeConfigPDA)
+	if err != nil {
+		return accts, fmt.Errorf("derive buy accounts: %w", err)
+	}
+
+	if accts.AssociatedBondingCurve, _, err = findATAWithProgram(accts.BondingCurve, mint, tokenProgram, constants.AssociatedTokenProgramID); err != nil {
+		return accts, fmt.Errorf("derive bonding curve ATA: %w", err)
+	}
+	if accts.AssociatedUser, _, err = findATAWithProgram(user, mint, tokenProgram, constants.AssociatedTokenProgramID); err != nil {
+		return accts, fmt.Errorf("derive user ATA: %w", err)
+	}
+	if accts.CreatorVault, _, err = solana.FindProgramAddress([][]byte{[]byte(constants.SeedCreatorVault), user[:]}, pump.ProgramKey); err != nil {
+		return accts, fmt.Errorf("derive creator vault: %w", err)
+	}
+	return accts, nil
+}
+
+// newCurveTokensOut quotes the tokens bought with solIn lamports (fees
+// included) on a fresh curve seeded with Global's initial reserves.
+func newCurveTokensOut(global pump.Global, solIn uint64) uint64 {
+	return curve.BuyTokensOut(curve.Reserves{
+		VirtualTokenReserves: global.InitialVirtualTokenReserves,
+		VirtualSolReserves:   global.InitialVirtualSolReserves,
+		RealTokenReserves:    global.InitialRealTokenReserves,
+	}, global.ProtocolFeeBps()+global.CreatorFeeBps(), solIn)
+}
+
+// fetchPumpGlobal loads and decodes the pump Global account.
+func fetchPumpGlobal(ctx context.Context, rpc RPC, addr solana.PublicKey) (pump.Global, error) {
+	var global pump.Global
+	amap, err := fetchAccountsBatch(ctx, rpc, addr)
+	if err != nil {
+		return global, err
+	}
+	acc := amap[addr.String()]
+	if acc == nil || acc.Data == nil {
+		return global, types.ErrGlobalConfigNotFound
+	}
+	if err := global.Unmarshal(acc.Data.GetBinary()); err != nil {
+		return global, fmt.Errorf("decode global %s: %w", addr, err)
+	}
+	return global, nil
+}
+
+// transactionSize returns the serialized size of a transaction carrying
+// instrs and numSigners signatures.
+func transactionSize(payer solana.PublicKey, instrs []solana.Instruction, numSigners int) (int, error) {
+	tx, err := solana.NewTransaction(instrs, solana.Hash{}, solana.TransactionPayer(payer))
+	if err != nil {
+		return 0, fmt.Errorf("build transaction: %w", err)
+	}
+	msg, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return 0, fmt.Errorf("encode message: %w", err)
+	}
+	return len(msg) + 1 + numSigners*solana.SignatureLength, nil
+}
@@ -0,0 +1,58 @@
+package autofill
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+)
+
+func TestNewCurveTokensOut(t *testing.T) {
+	global := pump.Global{
+		InitialVirtualSolReserves:   30_000_000_000,
+		InitialVirtualTokenReserves: 1_073_000_000_000_000,
+		InitialRealTokenReserves:    793_100_000_000_000,
+		FeeBasisPoints:              95,
+		CreatorFeeBasisPoints:       5,
+	}
+	// 1 SOL in, 1% fee: net = 990_099_009; out = vTok*net/(vSol+net).
+	if got, want := newCurveTokensOut(global, 1_000_000_000), uint64(34_281_150_129_545); got != want {
+		t.Fatalf("tokens out: got %d, want %d", got, want)
+	}
+	if got := newCurveTokensOut(global, 1_000_000_000_000); got != global.InitialRealTokenReserves {
+		t.Fatalf("expected output capped at real reserves, got %d", got)
+	}
+	if got := newCurveTokensOut(pump.Global{}, 1_000_000_000); got != 0 {
+		t.Fatalf("expected 0 for empty reserves, got %d", got)
+	}
+}
+
+func TestNewCurveBuyAccounts(t *testing.T) {
+	user := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	recipient := solana.NewWallet().PublicKey()
+
+	if _, err := newCurveBuyAccounts(user, mint, constants.TokenProgramID, pump.Global{}, &Options{}); err == nil {
+		t.Fatal("expected error without a fee recipient")
+	}
+
+	accts, err := newCurveBuyAccounts(user, mint, constants.TokenProgramID, pump.Global{FeeRecipient: recipient}, &Options{})
+	if err != nil {
+		t.Fatalf("newCurveBuyAccounts: %v", err)
+	}
+	if !accts.FeeRecipient.Equals(recipient) {
+		t.Fatalf("fee recipient: got %s, want %s", accts.FeeRecipient, recipient)
+	}
+	curve, _, err := solana.FindProgramAddress([][]byte{[]byte("bonding-curve"), mint[:]}, pump.ProgramKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !accts.BondingCurve.Equals(curve) {
+		t.Fatalf("bonding curve: got %s, want %s", accts.BondingCurve, curve)
+	}
+	if _, err := pump.BuildBuyExactSolIn(accts, pump.BuyExactSolInArgs{SpendableSolIn: 1}); err != nil {
+		t.Fatalf("derived accounts should build an instruction: %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package autofill
+
+import (
+	"context"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/wallet"
+)
+
+// PumpCreateSigners is PumpCreate returning the extra signers the create
+// transaction needs, the new mint wrapped as a wallet.Signer, instead of its
+// private key. Pass them to BuildSignSendAndConfirm alongside the user.
+//
+// Example:
+//
+//	_, _, ix, signers, err := autofill.PumpCreateSigners(ctx, rpc, user.PublicKey(), "My Token", "MTK", uri)
+//	sig, err := builder.BuildSignSendAndConfirm(ctx, user, signers, txbuilder.ConfirmationConfirmed, ix)
+func PumpCreateSigners(ctx context.Context, rpc RPC, user solana.PublicKey, name, symbol, uri string, opts ...Option) (pump.CreateAccounts, pump.CreateArgs, solana.Instruction, []wallet.Signer, error) {
+	accts, args, ix, mintKey, err := PumpCreate(ctx, rpc, user, name, symbol, uri, opts...)
+	if err != nil {
+		return accts, args, nil, nil, err
+	}
+	return accts, args, ix, []wallet.Signer{wallet.NewLocalFromPrivateKey(mintKey)}, nil
+}
+
+// PumpCreateV2Signers is PumpCreateV2 returning the new mint as a signer;
+// see PumpCreateSigners.
+func PumpCreateV2Signers(ctx context.Context, rpc RPC, user solana.PublicKey, name, symbol, uri string, isMayhemMode bool, opts ...Option) (pump.CreateV2Accounts, pump.CreateV2Args, solana.Instruction, []wallet.Signer, error) {
+	accts, args, ix, mintKey, err := PumpCreateV2(ctx, rpc, user, name, symbol, uri, isMayhemMode, opts...)
+	if err != nil {
+		return accts, args, nil, nil, err
+	}
+	return accts, args, ix, []wallet.Signer{wallet.NewLocalFromPrivateKey(mintKey)}, nil
+}
@@ -0,0 +1,43 @@
+package autofill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+	"github.com/ninja0404/pump-go-sdk/pkg/txbuilder"
+	"github.com/ninja0404/pump-go-sdk/pkg/wallet"
+)
+
+func TestPumpCreateSigners(t *testing.T) {
+	ctx := context.Background()
+	rpc := mock.New()
+	key, _ := solana.NewRandomPrivateKey()
+	user := wallet.NewLocalFromPrivateKey(key)
+
+	accts, _, ix, signers, err := PumpCreateSigners(ctx, rpc, user.PublicKey(), "My Token", "MTK", "https://example.com/m.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signers) != 1 || !signers[0].PublicKey().Equals(accts.Mint) {
+		t.Fatalf("signers %v, want the mint %s", signers, accts.Mint)
+	}
+
+	// The user and the returned signers sign every required signature.
+	tx, err := solana.NewTransaction([]solana.Instruction{ix}, solana.Hash{1}, solana.TransactionPayer(user.PublicKey()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := txbuilder.SignTransaction(ctx, tx, append([]wallet.Signer{user}, signers...)...); err != nil {
+		t.Fatalf("sign with user and returned signers: %v", err)
+	}
+	if err := tx.VerifySignatures(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, _, signers, err := PumpCreateSigners(ctx, rpc, user.PublicKey(), "", "MTK", "uri"); err == nil || signers != nil {
+		t.Fatalf("empty name: signers %v, err %v", signers, err)
+	}
+}
@@ -0,0 +1,23 @@
+package autofill
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// DryRunBlockhash is the fixed blockhash of dry-run transactions (see
+// WithDryRun). No cluster accepts it, so such transactions cannot land.
+var DryRunBlockhash = solana.Hash{}
+
+// DryRunTransaction builds an unsigned transaction of instrs paid by payer
+// with DryRunBlockhash, for inspecting the layout of a dry run's
+// instructions (message size, account keys) without fetching a blockhash.
+// The transaction is not sendable.
+func DryRunTransaction(payer solana.PublicKey, instrs ...solana.Instruction) (*solana.Transaction, error) {
+	tx, err := solana.NewTransaction(instrs, DryRunBlockhash, solana.TransactionPayer(payer))
+	if err != nil {
+		return nil, fmt.Errorf("build dry-run tx: %w", err)
+	}
+	return tx, nil
+}
@@ -0,0 +1,77 @@
+package autofill
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+)
+
+func TestDryRunSkipsSimulation(t *testing.T) {
+	ctx := context.Background()
+	user := solana.NewWallet().PublicKey()
+
+	curve := mock.MustLoadFixture(mock.FixtureBondingCurve)
+	amm := mock.MustLoadFixture(mock.FixtureAmmPool)
+	client := mock.New()
+	client.Load(curve)
+	client.Load(amm)
+
+	_, sellArgs, sellInstrs, err := PumpSellWithSlippage(ctx, client, user, curve.Address("mint"), 1_000_000, 100, WithDryRun())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sellArgs.MinSolOutput != 0 || len(sellInstrs) == 0 {
+		t.Fatalf("pump sell: args %+v, %d instructions", sellArgs, len(sellInstrs))
+	}
+
+	const maxQuoteIn = 50_000_000
+	buyAccts, _, buyInstrs, err := PumpAmmBuy(ctx, client, user, amm.Address("pool"), 1_000_000, maxQuoteIn, WithDryRun())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wrapped uint64
+	for _, ix := range buyInstrs {
+		// A system transfer: u32 index 2, u64 lamports.
+		if ix.ProgramID().Equals(constants.SystemProgramID) {
+			data, _ := ix.Data()
+			if len(data) == 12 && ix.Accounts()[1].PublicKey.Equals(buyAccts.UserQuoteTokenAccount) {
+				wrapped = binary.LittleEndian.Uint64(data[4:])
+			}
+		}
+	}
+	if wrapped != maxQuoteIn {
+		t.Fatalf("wrapped %d lamports, want maxQuoteIn %d", wrapped, maxQuoteIn)
+	}
+
+	_, withSolArgs, _, baseOut, err := PumpAmmBuyWithSol(ctx, client, user, amm.Address("pool"), 10_000_000, 100, WithDryRun())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if baseOut != 0 || withSolArgs.MinBaseAmountOut != 0 {
+		t.Fatalf("dry-run buy with SOL: baseOut %d, args %+v", baseOut, withSolArgs)
+	}
+	if _, _, _, err := PumpAmmSellWithSlippage(ctx, client, user, amm.Address("pool"), 1_000_000, 100, WithDryRun()); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := client.Calls("SimulateTransaction"); n != 0 {
+		t.Fatalf("SimulateTransaction called %d times in dry run", n)
+	}
+
+	tx, err := DryRunTransaction(user, buyInstrs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Message.RecentBlockhash != DryRunBlockhash || !tx.Message.AccountKeys[0].Equals(user) {
+		t.Fatalf("unexpected dry-run tx message header: %+v", tx.Message.Header)
+	}
+	if !tx.Message.AccountKeys.Contains(pumpamm.ProgramKey) {
+		t.Fatal("dry-run tx does not reference the pump_amm program")
+	}
+}
@@ -0,0 +1,96 @@
+package autofill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+)
+
+// createdATAs returns the ATAs that instrs create, keyed by address.
+func createdATAs(t *testing.T, instrs []solana.Instruction) map[solana.PublicKey]bool {
+	t.Helper()
+	out := make(map[solana.PublicKey]bool)
+	for _, ix := range instrs {
+		if !ix.ProgramID().Equals(constants.AssociatedTokenProgramID) {
+			continue
+		}
+		data, err := ix.Data()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) == 1 && data[0] == ataCreateIdempotentDiscriminator {
+			out[ix.Accounts()[1].PublicKey] = true
+		}
+	}
+	return out
+}
+
+// TestPumpAmmFeeATAsOnFreshPool checks that buys and sells on a pool whose
+// protocol fee recipient and coin creator vault have no quote ATA yet create
+// both, and stop creating them once they exist.
+func TestPumpAmmFeeATAsOnFreshPool(t *testing.T) {
+	fx := mock.MustLoadFixture(mock.FixtureAmmPool)
+	client := mock.New()
+	client.Load(fx)
+	user := solana.NewWallet().PublicKey()
+	pool := fx.Address("pool")
+	const maxQuoteIn = 50_000_000
+
+	userQuoteATA, _, err := solana.FindAssociatedTokenAddress(user, constants.WSOLMint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Simulate = func(context.Context, *solana.Transaction, *solanarpc.SimulateTransactionOpts) (*solanarpc.SimulateTransactionResponse, error) {
+		return &solanarpc.SimulateTransactionResponse{Value: &solanarpc.SimulateTransactionResult{
+			Accounts: []*solanarpc.Account{{
+				Owner: constants.TokenProgramID,
+				Data:  solanarpc.DataBytesOrJSONFromBytes(tokenAccountData(constants.WSOLMint, user, maxQuoteIn/2)),
+			}},
+		}}, nil
+	}
+
+	buyAccts, _, buyInstrs, err := PumpAmmBuy(context.Background(), client, user, pool, 1_000_000, maxQuoteIn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sellAccts, _, sellInstrs, err := PumpAmmSellWithSlippage(context.Background(), client, user, pool, 1_000_000, 100, WithExpectedQuoteOut(400_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, created := range map[string]map[solana.PublicKey]bool{
+		"buy":  createdATAs(t, buyInstrs),
+		"sell": createdATAs(t, sellInstrs),
+	} {
+		for _, ata := range []solana.PublicKey{buyAccts.ProtocolFeeRecipientTokenAccount, buyAccts.CoinCreatorVaultAta} {
+			if !created[ata] {
+				t.Errorf("%s: no create instruction for fee ATA %s", name, ata)
+			}
+		}
+	}
+	if !sellAccts.CoinCreatorVaultAta.Equals(buyAccts.CoinCreatorVaultAta) || !sellAccts.ProtocolFeeRecipientTokenAccount.Equals(buyAccts.ProtocolFeeRecipientTokenAccount) {
+		t.Fatalf("buy and sell disagree on fee ATAs: %+v, %+v", buyAccts, sellAccts)
+	}
+
+	for _, ata := range []struct{ addr, owner solana.PublicKey }{
+		{buyAccts.ProtocolFeeRecipientTokenAccount, buyAccts.ProtocolFeeRecipient},
+		{buyAccts.CoinCreatorVaultAta, buyAccts.CoinCreatorVaultAuthority},
+	} {
+		client.SetAccount(ata.addr, constants.TokenProgramID, 2_039_280, tokenAccountData(constants.WSOLMint, ata.owner, 0))
+	}
+	_, _, sellInstrs, err = PumpAmmSellWithSlippage(context.Background(), client, user, pool, 1_000_000, 100, WithExpectedQuoteOut(400_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := createdATAs(t, sellInstrs)
+	if created[buyAccts.ProtocolFeeRecipientTokenAccount] || created[buyAccts.CoinCreatorVaultAta] {
+		t.Fatal("existing fee ATAs created again")
+	}
+	if !created[userQuoteATA] {
+		t.Fatal("expected the user's quote ATA to still be created")
+	}
+}
@@ -0,0 +1,54 @@
+package autofill
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// Fee recipient selection
+//
+// Neither program rotates fee recipients by slot: buy and sell accept any
+// recipient configured on the global account, and the list only exists so
+// traders can spread the write lock on the fee account across several keys.
+// Curves and pools in mayhem mode accept the reserved recipients instead.
+// See pump.Global.AcceptedFeeRecipients and
+// pumpamm.GlobalConfig.AcceptedProtocolFeeRecipients.
+//
+// Autofill uses the first accepted recipient unless WithFeeRecipientIndex
+// picks another one. A recipient removed from the global account after the
+// transaction was built makes the program reject it.
+
+// WithFeeRecipientIndex selects the i-th accepted fee recipient, in the
+// order of AcceptedFeeRecipients or AcceptedProtocolFeeRecipients, instead
+// of the first, e.g. to spread a bot's trades over the recipients and avoid
+// write-lock contention with other traders. An index past the configured
+// recipients is an error.
+//
+// Example:
+//
+//	// Round-robin over the recipients
+//	autofill.PumpBuy(ctx, rpc, user, mint, amount, maxSol,
+//	    autofill.WithFeeRecipientIndex(int(n%uint64(len(recipients)))),
+//	)
+func WithFeeRecipientIndex(i int) Option {
+	return func(o *Options) { o.FeeRecipientIndex = i }
+}
+
+// selectFeeRecipient returns the candidate chosen by options'
+// FeeRecipientIndex.
+func selectFeeRecipient(candidates []solana.PublicKey, options *Options) (solana.PublicKey, error) {
+	i := options.FeeRecipientIndex
+	if i < 0 {
+		return solana.PublicKey{}, types.NewValidationError("feeRecipientIndex", "must not be negative")
+	}
+	if len(candidates) == 0 {
+		return solana.PublicKey{}, fmt.Errorf("no fee recipient configured")
+	}
+	if i >= len(candidates) {
+		return solana.PublicKey{}, types.NewValidationError("feeRecipientIndex", fmt.Sprintf("%d out of range, %d recipients configured", i, len(candidates)))
+	}
+	return candidates[i], nil
+}
@@ -0,0 +1,71 @@
+package autofill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+)
+
+func TestSelectFeeRecipient(t *testing.T) {
+	a, b := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	candidates := []solana.PublicKey{a, b}
+
+	if got, err := selectFeeRecipient(candidates, &Options{}); err != nil || !got.Equals(a) {
+		t.Fatalf("default: got %s, %v; want %s", got, err, a)
+	}
+	if got, err := selectFeeRecipient(candidates, &Options{FeeRecipientIndex: 1}); err != nil || !got.Equals(b) {
+		t.Fatalf("index 1: got %s, %v; want %s", got, err, b)
+	}
+	for _, i := range []int{-1, 2} {
+		if _, err := selectFeeRecipient(candidates, &Options{FeeRecipientIndex: i}); err == nil {
+			t.Fatalf("index %d: expected error", i)
+		}
+	}
+	if _, err := selectFeeRecipient(nil, &Options{}); err == nil {
+		t.Fatal("expected error without recipients")
+	}
+}
+
+func TestPumpBuyFeeRecipientIndex(t *testing.T) {
+	rpc := mock.New()
+	user := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	rpc.SetAccount(mint, constants.TokenProgramID, 1, make([]byte, 82))
+
+	var list, reservedList [7]solana.PublicKey
+	for i := range list {
+		list[i] = solana.NewWallet().PublicKey()
+		reservedList[i] = solana.NewWallet().PublicKey()
+	}
+	global := pump.Global{FeeRecipient: solana.NewWallet().PublicKey(), FeeRecipients: list, ReservedFeeRecipients: reservedList}
+	creator := solana.NewWallet().PublicKey()
+	ctx := context.Background()
+
+	accts, _, _, err := PumpBuy(ctx, rpc, user, mint, 1_000, 1_000_000,
+		WithPumpGlobal(global), WithBondingCurve(pump.BondingCurve{Creator: creator}), WithFeeRecipientIndex(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !accts.FeeRecipient.Equals(list[3]) {
+		t.Fatalf("fee recipient = %s, want %s", accts.FeeRecipient, list[3])
+	}
+
+	accts, _, _, err = PumpBuy(ctx, rpc, user, mint, 1_000, 1_000_000,
+		WithPumpGlobal(global), WithBondingCurve(pump.BondingCurve{Creator: creator, IsMayhemMode: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !accts.FeeRecipient.Equals(reservedList[0]) {
+		t.Fatalf("mayhem fee recipient = %s, want reserved %s", accts.FeeRecipient, reservedList[0])
+	}
+
+	if _, _, _, err := PumpBuy(ctx, rpc, user, mint, 1_000, 1_000_000,
+		WithPumpGlobal(global), WithBondingCurve(pump.BondingCurve{Creator: creator}), WithFeeRecipientIndex(8)); err == nil {
+		t.Fatal("expected error for an index past the 8 configured recipients")
+	}
+}
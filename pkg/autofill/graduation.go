@@ -0,0 +1,123 @@
+package autofill
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// LogSubscriber streams program logs. *sdkrpc.Client implements it.
+type LogSubscriber interface {
+	SubscribeProgramLogs(ctx context.Context, program solana.PublicKey, handler func(sdkrpc.LogEvent)) error
+}
+
+var _ LogSubscriber = (*sdkrpc.Client)(nil)
+
+// GraduationEvent reports a bonding curve leaving the curve: first its
+// completion, then the migration of its liquidity to pump AMM.
+type GraduationEvent struct {
+	Mint         solana.PublicKey
+	BondingCurve solana.PublicKey
+	// Pool is the pump AMM pool the token trades in after migration: taken
+	// from the migration event, or derived (the canonical WSOL pool) for a
+	// completion, when the pool may not exist yet.
+	Pool solana.PublicKey
+	// Migrated is false for the completion, after which the curve rejects
+	// trades, and true once the pool holds the liquidity.
+	Migrated  bool
+	Signature solana.Signature
+	Slot      uint64
+	Timestamp int64
+}
+
+// WatchForGraduation subscribes to pump program logs and calls handler when
+// mint's bonding curve completes and when it is migrated to pump AMM, so a
+// bot can move its trading to the pool. It returns nil after delivering the
+// migration, or ctx.Err() if ctx ends first; a subscription failure is
+// retried inside the subscriber, and a completion that happened while
+// disconnected is not replayed.
+//
+// Example:
+//
+//	err := autofill.WatchForGraduation(ctx, client, mint, func(ev autofill.GraduationEvent) {
+//	    if ev.Migrated {
+//	        log.Printf("%s trades in pool %s", ev.Mint, ev.Pool)
+//	    }
+//	})
+func WatchForGraduation(ctx context.Context, rpc LogSubscriber, mint solana.PublicKey, handler func(GraduationEvent)) error {
+	if rpc == nil {
+		return types.ErrNilRPC
+	}
+	if c, ok := rpc.(*sdkrpc.Client); ok && c == nil {
+		return types.ErrNilRPC
+	}
+	if err := types.ValidatePublicKey("mint", mint); err != nil {
+		return err
+	}
+	if handler == nil {
+		return types.NewValidationError("handler", "must not be nil")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	migrated := false
+	completed := false
+	err := rpc.SubscribeProgramLogs(ctx, pump.ProgramKey, func(ev sdkrpc.LogEvent) {
+		if ev.Err != nil || migrated {
+			return
+		}
+		if !completed {
+			if c, err := pump.ParseCompleteEvent(ev.Logs); err == nil && c.Mint.Equals(mint) {
+				completed = true
+				handler(GraduationEvent{
+					Mint:         mint,
+					BondingCurve: c.BondingCurve,
+					Pool:         canonicalPool(mint),
+					Signature:    ev.Signature,
+					Slot:         ev.Slot,
+					Timestamp:    c.Timestamp,
+				})
+			}
+		}
+		if m, err := pump.ParseCompletePumpAmmMigrationEvent(ev.Logs); err == nil && m.Mint.Equals(mint) {
+			migrated = true
+			handler(GraduationEvent{
+				Mint:         mint,
+				BondingCurve: m.BondingCurve,
+				Pool:         m.Pool,
+				Migrated:     true,
+				Signature:    ev.Signature,
+				Slot:         ev.Slot,
+				Timestamp:    m.Timestamp,
+			})
+			cancel()
+		}
+	})
+	if migrated && errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// canonicalPool derives the pump AMM pool that pump's migrate instruction
+// creates for mint: index 0, quoted in WSOL, owned by the mint's pool
+// authority. It returns the zero key if derivation fails.
+func canonicalPool(mint solana.PublicKey) solana.PublicKey {
+	accts := pump.MigrateAccounts{Mint: mint, WsolMint: constants.WSOLMint, PumpAmm: constants.PumpAmmProgramID}
+	authority, _, err := pump.DeriveMigratePoolAuthorityPDA(accts, pump.MigrateArgs{})
+	if err != nil {
+		return solana.PublicKey{}
+	}
+	accts.PoolAuthority = authority
+	pool, _, err := pump.DeriveMigratePoolPDA(accts, pump.MigrateArgs{})
+	if err != nil {
+		return solana.PublicKey{}
+	}
+	return pool
+}
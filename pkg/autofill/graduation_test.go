@@ -0,0 +1,62 @@
+package autofill
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+)
+
+// replaySubscriber delivers fixed log events, then blocks until ctx is done.
+type replaySubscriber []sdkrpc.LogEvent
+
+func (r replaySubscriber) SubscribeProgramLogs(ctx context.Context, _ solana.PublicKey, handler func(sdkrpc.LogEvent)) error {
+	for _, ev := range r {
+		handler(ev)
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func eventLog(t *testing.T, disc []byte, v interface{}) string {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.Write(disc)
+	if err := bin.NewBorshEncoder(&buf).Encode(v); err != nil {
+		t.Fatal(err)
+	}
+	return "Program data: " + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestWatchForGraduation(t *testing.T) {
+	mint := solana.NewWallet().PublicKey()
+	curve := solana.NewWallet().PublicKey()
+	pool := solana.NewWallet().PublicKey()
+	other := pump.CompleteEvent{Mint: solana.NewWallet().PublicKey()}
+	sub := replaySubscriber{
+		{Signature: solana.Signature{1}, Logs: []string{eventLog(t, pump.CompleteEventDiscriminator, other)}},
+		{Signature: solana.Signature{2}, Err: "failed", Logs: []string{eventLog(t, pump.CompleteEventDiscriminator, pump.CompleteEvent{Mint: mint})}},
+		{Signature: solana.Signature{3}, Slot: 10, Logs: []string{eventLog(t, pump.CompleteEventDiscriminator, pump.CompleteEvent{Mint: mint, BondingCurve: curve})}},
+		{Signature: solana.Signature{4}, Slot: 12, Logs: []string{eventLog(t, pump.CompletePumpAmmMigrationEventDiscriminator, pump.CompletePumpAmmMigrationEvent{Mint: mint, BondingCurve: curve, Pool: pool})}},
+	}
+
+	var got []GraduationEvent
+	if err := WatchForGraduation(context.Background(), sub, mint, func(ev GraduationEvent) { got = append(got, ev) }); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d events, want completion and migration: %+v", len(got), got)
+	}
+	if got[0].Migrated || got[0].Signature != (solana.Signature{3}) || got[0].BondingCurve != curve || got[0].Pool != canonicalPool(mint) || got[0].Pool.IsZero() {
+		t.Fatalf("completion = %+v", got[0])
+	}
+	if !got[1].Migrated || got[1].Pool != pool || got[1].Slot != 12 {
+		t.Fatalf("migration = %+v", got[1])
+	}
+}
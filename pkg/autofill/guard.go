@@ -0,0 +1,38 @@
+package autofill
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// checkPoolGuards enforces WithMinPoolLiquidity and WithMaxPoolPriceAge for
+// a trade on pool, whose quote reserves sit in poolQuote.
+func checkPoolGuards(ctx context.Context, rpc RPC, pool, poolQuote solana.PublicKey, options *Options) error {
+	if options.MinPoolLiquidity > 0 {
+		amounts, err := fetchTokenAmountBatch(ctx, rpc, []solana.PublicKey{poolQuote})
+		if err != nil {
+			return fmt.Errorf("read pool %s reserves: %w", pool, err)
+		}
+		if reserves := amounts[poolQuote.String()]; reserves < options.MinPoolLiquidity {
+			return fmt.Errorf("%w: pool %s holds %d quote, below the %d minimum", types.ErrInsufficientLiquidity, pool, reserves, options.MinPoolLiquidity)
+		}
+	}
+	if options.MaxPoolPriceAge > 0 && !options.DryRun {
+		n, ok := rpc.(lagReporter)
+		if !ok {
+			return fmt.Errorf("pool %s state age: rpc client cannot report its slot lag", pool)
+		}
+		behind, err := n.IsBehind(ctx, options.MaxPoolPriceAge)
+		if behind {
+			return fmt.Errorf("pool %s state is stale: %w", pool, err)
+		}
+		if err != nil {
+			return fmt.Errorf("pool %s state age: %w", pool, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package autofill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// laggingRPC is a mock client whose node is lag slots behind the cluster.
+type laggingRPC struct {
+	*mock.Client
+	lag uint64
+}
+
+func (l laggingRPC) IsBehind(_ context.Context, maxSlotLag uint64) (bool, error) {
+	if l.lag > maxSlotLag {
+		return true, fmt.Errorf("%w: %d slots behind", types.ErrNodeBehind, l.lag)
+	}
+	return false, nil
+}
+
+// loadAmmPool returns a mock client serving the FixtureAmmPool accounts.
+func loadAmmPool(t *testing.T) (*mock.Client, *mock.Fixture) {
+	t.Helper()
+	rpc := mock.New()
+	amm := mock.MustLoadFixture(mock.FixtureAmmPool)
+	rpc.Load(amm)
+	return rpc, amm
+}
+
+func TestMinPoolLiquidity(t *testing.T) {
+	ctx := context.Background()
+	rpc, amm := loadAmmPool(t)
+	pool, poolQuote := amm.Address("pool"), amm.Address("pool_quote_token_account")
+	user := solana.NewWallet().PublicKey()
+	amounts, err := fetchTokenAmountBatch(ctx, rpc, []solana.PublicKey{poolQuote})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reserves := amounts[poolQuote.String()]
+	if reserves == 0 {
+		t.Fatal("fixture pool has no quote reserves")
+	}
+
+	if _, _, _, err := PumpAmmBuyExactQuoteIn(ctx, rpc, user, pool, 1_000_000, 1, WithMinPoolLiquidity(reserves)); err != nil {
+		t.Fatalf("pool at the floor: %v", err)
+	}
+	_, _, _, err = PumpAmmBuyExactQuoteIn(ctx, rpc, user, pool, 1_000_000, 1, WithMinPoolLiquidity(reserves+1))
+	if !errors.Is(err, types.ErrInsufficientLiquidity) {
+		t.Fatalf("pool below the floor: expected ErrInsufficientLiquidity, got %v", err)
+	}
+	_, _, _, err = PumpAmmSellWithSlippage(ctx, rpc, user, pool, 1_000_000, 100, WithDryRun(), WithMinPoolLiquidity(reserves+1))
+	if !errors.Is(err, types.ErrInsufficientLiquidity) {
+		t.Fatalf("sell below the floor: expected ErrInsufficientLiquidity, got %v", err)
+	}
+}
+
+func TestMaxPoolPriceAge(t *testing.T) {
+	ctx := context.Background()
+	rpc, amm := loadAmmPool(t)
+	pool := amm.Address("pool")
+	user := solana.NewWallet().PublicKey()
+
+	if _, _, _, err := PumpAmmBuyExactQuoteIn(ctx, laggingRPC{rpc, 3}, user, pool, 1_000_000, 1, WithMaxPoolPriceAge(5)); err != nil {
+		t.Fatalf("node 3 slots behind: %v", err)
+	}
+	_, _, _, err := PumpAmmBuyExactQuoteIn(ctx, laggingRPC{rpc, 8}, user, pool, 1_000_000, 1, WithMaxPoolPriceAge(5))
+	if !errors.Is(err, types.ErrNodeBehind) {
+		t.Fatalf("node 8 slots behind: expected ErrNodeBehind, got %v", err)
+	}
+	// A client that cannot measure its lag fails closed.
+	if _, _, _, err := PumpAmmBuyExactQuoteIn(ctx, rpc, user, pool, 1_000_000, 1, WithMaxPoolPriceAge(5)); err == nil {
+		t.Fatal("expected an error from a client without lag reporting")
+	}
+}
+
+func TestSolHelpersRejectNonWSOLQuote(t *testing.T) {
+	ctx := context.Background()
+	rpc, amm := loadAmmPool(t)
+	poolAddr := amm.Address("pool")
+	var pool pumpamm.Pool
+	if err := pool.Unmarshal(amm.Account("pool").Data); err != nil {
+		t.Fatal(err)
+	}
+	// Re-quote the pool in an SPL token.
+	pool.QuoteMint = solana.NewWallet().PublicKey()
+	rpc.SetAccount(poolAddr, pumpamm.ProgramKey, 1, poolData(t, pool))
+	rpc.SetAccount(pool.QuoteMint, constants.TokenProgramID, 1, make([]byte, 82))
+	user := solana.NewWallet().PublicKey()
+
+	calls := map[string]func() error{
+		"PumpAmmBuyWithSol": func() error {
+			_, _, _, _, err := PumpAmmBuyWithSol(ctx, rpc, user, poolAddr, 10_000_000, 100, WithDryRun())
+			return err
+		},
+		"PumpAmmBuyWithSolWithResult": func() error {
+			_, err := PumpAmmBuyWithSolWithResult(ctx, rpc, user, poolAddr, 10_000_000, 100, WithDryRun())
+			return err
+		},
+		"PumpAmmSellAllForSol": func() error {
+			_, _, _, _, err := PumpAmmSellAllForSol(ctx, rpc, user, poolAddr, 100, WithDryRun())
+			return err
+		},
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, types.ErrQuoteNotWSOL) {
+			t.Errorf("%s: expected ErrQuoteNotWSOL, got %v", name, err)
+		}
+	}
+
+	// The token-denominated entry points still take the pool.
+	if _, _, _, err := PumpAmmSellWithSlippage(ctx, rpc, user, poolAddr, 1_000_000, 100, WithDryRun()); err != nil {
+		t.Fatalf("PumpAmmSellWithSlippage: %v", err)
+	}
+}
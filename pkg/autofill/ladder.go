@@ -0,0 +1,145 @@
+package autofill
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/curve"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// LadderedBuy is the outcome of PumpBuyLaddered.
+type LadderedBuy struct {
+	// Transactions holds one instruction set per chunk, to submit in order.
+	// Compute budget options and the referral apply to each set. Prepended
+	// instructions (WithPrependInstructions) go in the first set only;
+	// appended ones, memos included, and the Jito tip in the last only.
+	Transactions [][]solana.Instruction
+	// SolIn holds the lamports each chunk spends.
+	SolIn []uint64
+	// ExpectedTokens holds each chunk's quoted output before slippage. Only
+	// the first is quoted from the curve as read; later ones are quoted from
+	// the reserves projected after the chunks before them, so a trade by
+	// someone else in between makes them, and their minimums, stale.
+	ExpectedTokens []uint64
+	// Stopped reports that chunks were dropped because they would take the
+	// price impact past maxImpactBps, so less than totalSol is spent.
+	Stopped bool
+}
+
+// PumpBuyLaddered splits a buy of totalSol lamports on the bonding curve into
+// chunks equal buys, one transaction each, to limit the price impact of a
+// large entry. The curve is read once; each chunk is quoted against the
+// reserves left by the chunks before it, as if they landed in order.
+//
+// Laddering stops before the first chunk that would take the price impact,
+// measured from the curve's price before the first chunk, past
+// maxImpactBps; an error is returned if that is the first chunk. Each
+// chunk's minimum output is its quote less maxImpactBps, so a chunk whose
+// price moved by more than the threshold fails instead of filling.
+//
+// Example:
+//
+//	// Spend 10 SOL in 5 transactions, moving price by at most 3% overall
+//	ladder, err := autofill.PumpBuyLaddered(ctx, rpc, user, mint, 10_000_000_000, 5, 300)
+//	for _, instrs := range ladder.Transactions {
+//	    // build, sign and send each in order
+//	}
+func PumpBuyLaddered(ctx context.Context, rpc RPC, user, mint solana.PublicKey, totalSol uint64, chunks int, maxImpactBps uint64, opts ...Option) (*LadderedBuy, error) {
+	// Input validation
+	if isNilRPC(rpc) {
+		return nil, types.ErrNilRPC
+	}
+	if err := types.ValidatePublicKey("user", user); err != nil {
+		return nil, err
+	}
+	if err := types.ValidatePublicKey("mint", mint); err != nil {
+		return nil, err
+	}
+	if chunks < 1 {
+		return nil, types.NewValidationError("chunks", "must be at least 1")
+	}
+	if totalSol < uint64(chunks) {
+		return nil, types.NewValidationError("totalSol", "must be at least one lamport per chunk")
+	}
+	if maxImpactBps == 0 || maxImpactBps > 10_000 {
+		return nil, types.NewValidationError("maxImpactBps", "must be between 1 and 10000")
+	}
+
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	globalAddr, curveAddr := pumpCurveAddresses(mint)
+	global, bc, _, err := fetchPumpTradeState(ctx, rpc, globalAddr, mint, curveAddr, options)
+	if err != nil {
+		return nil, err
+	}
+	if bc.Complete {
+		return nil, fmt.Errorf("bonding curve for %s is complete", mint)
+	}
+
+	// Plan every chunk first, so the tip goes on the last one kept.
+	feeBps := global.TradeFeeBps(bc)
+	ladder := &LadderedBuy{}
+	curves := make([]pump.BondingCurve, 0, chunks)
+	startSol := bc.VirtualSolReserves
+	var spent uint64
+	for i := range chunks {
+		solIn := totalSol / uint64(chunks)
+		if i == chunks-1 {
+			solIn = totalSol - solIn*uint64(chunks-1)
+		}
+		net := curve.NetOfFee(solIn, feeBps)
+		if impact := impactExactIn(startSol, spent+net); impact == nil || *impact > maxImpactBps {
+			if i == 0 {
+				return nil, fmt.Errorf("first chunk of %d lamports exceeds max price impact of %d bps", solIn, maxImpactBps)
+			}
+			ladder.Stopped = true
+			break
+		}
+		out := curve.BuyTokensOut(pumpReserves(bc), feeBps, solIn)
+		if out == 0 {
+			if i == 0 {
+				return nil, fmt.Errorf("bonding curve for %s quotes no tokens for %d lamports", mint, solIn)
+			}
+			ladder.Stopped = true
+			break
+		}
+		curves = append(curves, bc)
+		ladder.SolIn = append(ladder.SolIn, solIn)
+		ladder.ExpectedTokens = append(ladder.ExpectedTokens, out)
+
+		spent += net
+		bc.VirtualSolReserves += net
+		bc.VirtualTokenReserves -= out
+		bc.RealSolReserves += net
+		bc.RealTokenReserves -= out
+	}
+
+	for i, solIn := range ladder.SolIn {
+		chunkOpts := append(append([]Option{}, opts...), WithPumpGlobal(global), WithBondingCurve(curves[i]))
+		if i > 0 {
+			chunkOpts = append(chunkOpts, func(o *Options) { o.PrependInstructions = nil })
+		}
+		if i < len(ladder.SolIn)-1 {
+			chunkOpts = append(chunkOpts, func(o *Options) {
+				o.JitoTipLamports = 0
+				o.AppendInstructions = nil
+			})
+		}
+		minTokensOut, err := slippageMin(ladder.ExpectedTokens[i], maxImpactBps, options)
+		if err != nil {
+			return nil, fmt.Errorf("chunk %d: %w", i, err)
+		}
+		_, _, instrs, err := PumpBuyExactSolIn(ctx, rpc, user, mint, solIn, minTokensOut, chunkOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("chunk %d: %w", i, err)
+		}
+		ladder.Transactions = append(ladder.Transactions, instrs)
+	}
+	return ladder, nil
+}
@@ -0,0 +1,65 @@
+package autofill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+)
+
+func TestPumpBuyLaddered(t *testing.T) {
+	rpc := mock.New()
+	user := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	rpc.SetAccount(mint, constants.TokenProgramID, 1, make([]byte, 82))
+	bc := pump.BondingCurve{
+		VirtualTokenReserves: 1_000_000_000_000_000,
+		VirtualSolReserves:   30_000_000_000,
+		RealTokenReserves:    800_000_000_000_000,
+		Creator:              solana.NewWallet().PublicKey(),
+	}
+	global := pump.Global{FeeRecipient: solana.NewWallet().PublicKey(), FeeBasisPoints: 95, CreatorFeeBasisPoints: 30}
+
+	// 4 x 2.5 SOL into 30 SOL of virtual reserves: the cumulative impact is
+	// about 760, 1410, 1980 and 2480 bps.
+	pre := system.NewTransferInstruction(1, user, solana.NewWallet().PublicKey()).Build()
+	ladder, err := PumpBuyLaddered(context.Background(), rpc, user, mint, 10_000_000_000, 4, 1_500,
+		WithPumpGlobal(global), WithBondingCurve(bc), WithJitoTip(1_000), WithPrependInstructions(pre), WithMemo("ladder"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ladder.Stopped || len(ladder.Transactions) != 2 || len(ladder.SolIn) != 2 {
+		t.Fatalf("got %d chunks (stopped %v), want 2 before the impact limit", len(ladder.Transactions), ladder.Stopped)
+	}
+	if first, second := ladder.ExpectedTokens[0], ladder.ExpectedTokens[1]; second >= first {
+		t.Errorf("second chunk quotes %d tokens, want fewer than the first's %d on the moved curve", second, first)
+	}
+	for i, instrs := range ladder.Transactions {
+		var tipped, prepended, memo bool
+		for _, ix := range instrs {
+			switch {
+			case ix == pre:
+				prepended = true
+			case ix.ProgramID().Equals(constants.MemoProgramID):
+				memo = true
+			case ix.ProgramID().Equals(solana.SystemProgramID) && len(ix.Accounts()) == 2 && isJitoTipAccount(ix.Accounts()[1].PublicKey):
+				tipped = true
+			}
+		}
+		first, last := i == 0, i == len(ladder.Transactions)-1
+		if tipped != last || memo != last || prepended != first {
+			t.Errorf("chunk %d: tipped %v, memo %v, prepended %v; want the prepend on the first chunk only, tip and memo on the last",
+				i, tipped, memo, prepended)
+		}
+	}
+
+	if _, err := PumpBuyLaddered(context.Background(), rpc, user, mint, 10_000_000_000, 4, 500,
+		WithPumpGlobal(global), WithBondingCurve(bc)); err == nil {
+		t.Fatal("expected an error when the first chunk exceeds the impact limit")
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"time"
 
 	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
 
 	"github.com/ninja0404/pump-go-sdk/pkg/jito"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
 )
 
 // Options configures autofill helpers.
@@ -21,12 +24,42 @@ type Options struct {
 	KnownATAs           []solana.PublicKey // Skip ATA existence check for these addresses
 	ExpectedQuoteOut    uint64             // Skip simulation and use this as expected quote output (for sell)
 	CloseBaseATA        bool               // Close base token ATA after sell (default: false)
+	IncludeRentRefund   bool               // Count the closed base ATA's rent in the expected sell output
 	CloseQuoteATA       bool               // Close quote token ATA after sell for WSOL unwrap (default: false)
 	JitoTipLamports     uint64             // Jito tip amount in lamports (0 = no tip)
 	JitoTipAccount      solana.PublicKey   // Jito tip account (if zero, uses random from predefined list)
+	JitoBundleTip       uint64             // Jito tip in lamports sent as a separate bundled transaction (see WithJitoBundleTip)
+	DeadlineSlots       uint64             // Max blockhash age in slots when sending (0 = unchecked; see WithExecutionDeadlineSlots)
+	AccountWait         time.Duration      // How long to re-poll for a missing pool, curve or mint (0 = fail at once; see WithAccountWait)
+	AccountWaitInterval time.Duration      // Delay between those polls
+	MinPoolLiquidity    uint64             // Min pool quote reserves for Pump AMM trades (0 = unchecked; see WithMinPoolLiquidity)
+	MaxPoolPriceAge     uint64             // Max lag in slots of the node reading pool reserves (0 = unchecked; see WithMaxPoolPriceAge)
 	PriorityFeeLamports uint64             // Priority fee total in lamports (simple mode)
 	PriorityFeePerCU    uint64             // Priority fee in microLamports per Compute Unit (advanced mode)
 	ComputeLimit        uint32             // Compute unit limit (0 = use default 200000)
+	TransferFeeAware    bool               // Reduce sell expectations by the base mint's Token-2022 transfer fee
+	StrictOverrides     bool               // Fail on override keys that match no account field
+	FeeRecipientIndex   int                // Index into the accepted fee recipients (see WithFeeRecipientIndex)
+	DryRun              bool               // Skip simulations and network calls other than account reads (see WithDryRun)
+	SlippageRounding    SlippageRounding   // Rounding of slippage minimums (see WithSlippageRounding)
+	// SimulationCommitment is the commitment of the state that trade
+	// simulations run against (empty = default; see WithSimulationCommitment).
+	SimulationCommitment solanarpc.CommitmentType
+	// Caller instructions spliced around the generated ones (see
+	// WithPrependInstructions and WithAppendInstructions).
+	PrependInstructions []solana.Instruction
+	AppendInstructions  []solana.Instruction
+	// SOL transfer to a referrer sized from the trade (see WithReferral).
+	Referrer    solana.PublicKey
+	ReferralBps uint64
+	// Pre-fetched account state used instead of RPC reads (see WithPoolState).
+	PoolState    *pumpamm.Pool
+	GlobalConfig *pumpamm.GlobalConfig
+	BondingCurve *pump.BondingCurve
+	PumpGlobal   *pump.Global
+
+	// result receives the call's Preview for the *WithResult variants.
+	result *Preview
 }
 
 // Option functional option.
@@ -36,6 +69,14 @@ func WithOverrides(m map[string]solana.PublicKey) Option {
 	return func(o *Options) { o.Overrides = m }
 }
 
+// WithStrictOverrides makes override keys that match no account field an
+// error instead of being ignored, so typos in override JSON are caught.
+func WithStrictOverrides() Option {
+	return func(o *Options) { o.StrictOverrides = true }
+}
+
+// WithPreview writes a JSON Preview of the built trade to w: accounts, args,
+// expected and minimum output, price impact and an instruction summary.
 func WithPreview(w io.Writer) Option {
 	return func(o *Options) { o.Preview = w }
 }
@@ -62,8 +103,10 @@ func WithVanityTimeout(d time.Duration) Option {
 }
 
 // WithKnownATAs skips ATA existence check for the specified addresses.
-// Use this when you know the ATA exists (e.g., from a previous buy transaction)
-// to avoid RPC state propagation delays.
+// Use this when you know the ATA exists (e.g., from a previous buy transaction
+// or EnsureTradeAccounts) to avoid RPC state propagation delays. Bonding-curve
+// buys and sells and Pump AMM sells honor it; Pump AMM buys still read the
+// user's ATAs for their balances.
 //
 // Example:
 //
@@ -100,6 +143,36 @@ func WithCloseBaseATA() Option {
 	return func(o *Options) { o.CloseBaseATA = true }
 }
 
+// WithDryRun builds the full instruction set, deriving and reading accounts
+// as usual, but never simulates or calls anything other than account reads.
+// Amounts that would come from a simulation are zero, so slippage minimums
+// are zero too, and PumpAmmBuy wraps the whole maxQuoteIn. Transactions that
+// helpers build (e.g. PumpSellBundle) use DryRunBlockhash.
+//
+// The result is for inspecting the instruction layout offline, e.g. in CI
+// against a mock RPC; it is not sendable.
+func WithDryRun() Option {
+	return func(o *Options) { o.DryRun = true }
+}
+
+// WithSimulationCommitment sets the commitment of the state the simulations
+// estimating trade outputs run against, and of the balances they compare
+// with. Processed saves latency when sniping, finalized trades speed for
+// stability. By default balances are read at confirmed and the simulation
+// runs on processed state.
+func WithSimulationCommitment(commitment solanarpc.CommitmentType) Option {
+	return func(o *Options) { o.SimulationCommitment = commitment }
+}
+
+// WithIncludeRentRefund adds the rent reclaimed by WithCloseBaseATA (about
+// 0.002 SOL) to the expected output of sells, as reported in the preview.
+// The slippage minimum still covers the trade proceeds only, since the
+// refund is paid by the close instruction, not the sell. For pool sells the
+// refund is only added when the quote is WSOL.
+func WithIncludeRentRefund(v bool) Option {
+	return func(o *Options) { o.IncludeRentRefund = v }
+}
+
 // WithCloseQuoteATA closes the quote token ATA after sell (for WSOL unwrap).
 // Use this when quote is WSOL and you want to unwrap it to native SOL.
 func WithCloseQuoteATA() Option {
@@ -110,6 +183,7 @@ func WithCloseQuoteATA() Option {
 // This is used to incentivize Jito validators to include your transaction.
 // tipLamports: amount to tip in lamports (e.g., 1_000_000 = 0.001 SOL)
 // Uses a random tip account from the predefined list.
+// See WithJitoBundleTip to send the tip as a separate bundled transaction.
 //
 // Example:
 //
@@ -125,6 +199,171 @@ func WithJitoTip(tipLamports uint64) Option {
 	}
 }
 
+// WithJitoBundleTip tips tipLamports in a separate transaction instead of
+// inline. Trade helpers then leave the tip out of the instruction set, and
+// BuildAndSend, BuildAndSendAmm and BuildAndSendInstructions submit the
+// trade and a tip transfer (to WithJitoTipAccount, or a Jito tip account)
+// as a two-transaction bundle via SendBundleViaJito; the builder needs a
+// Jito client.
+//
+// Unlike WithJitoTip, which appends the transfer to the trade transaction,
+// this keeps the trade transaction minimal (no tip account or transfer to
+// fit in its size budget) while the bundle keeps the tip atomic with the
+// trade. It takes precedence over WithJitoTip.
+//
+// Example:
+//
+//	opts := []autofill.Option{autofill.WithJitoBundleTip(1_000_000)}
+//	_, _, instrs, err := autofill.PumpAmmSellWithSlippage(ctx, rpc, user, pool, baseIn, 100, opts...)
+//	sig, err := autofill.BuildAndSendInstructions(ctx, builder, signer, instrs, opts...)
+func WithJitoBundleTip(tipLamports uint64) Option {
+	return func(o *Options) { o.JitoBundleTip = tipLamports }
+}
+
+// WithExecutionDeadlineSlots makes BuildAndSend, BuildAndSendAmm and
+// BuildAndSendInstructions refuse to send once more than n slots have
+// passed since the transaction's blockhash was fetched, returning
+// txbuilder.ErrBlockhashTooOld; the check runs after signing, which a
+// remote signer can make slow.
+//
+// What Solana can enforce is limited. A blockhash transaction stays valid
+// for about 150 blocks and that window cannot be shortened; a durable nonce
+// transaction never expires. Neither pump program takes a deadline argument,
+// and the runtime has no instruction that fails past a slot, so after the
+// send nothing stops the trade from landing later within the blockhash
+// window. The slippage minimum remains the on-chain protection.
+//
+// Example:
+//
+//	opts := []autofill.Option{autofill.WithExecutionDeadlineSlots(4)}
+//	sig, err := autofill.BuildAndSendInstructions(ctx, builder, signer, instrs, opts...)
+func WithExecutionDeadlineSlots(n uint64) Option {
+	return func(o *Options) { o.DeadlineSlots = n }
+}
+
+// WithAccountWait re-polls for a missing bonding curve, mint or pool every
+// interval for up to maxWait before failing with "not found". A buy sent
+// right after the create transaction confirms often reads from a node that
+// has not yet seen the new accounts; waiting briefly rides out that delay.
+// A non-positive interval defaults to 200ms.
+//
+// Example:
+//
+//	_, _, instrs, err := autofill.PumpBuy(ctx, rpc, user, mint, tokens, maxSol,
+//	    autofill.WithAccountWait(3*time.Second, 250*time.Millisecond))
+func WithAccountWait(maxWait, interval time.Duration) Option {
+	return func(o *Options) {
+		o.AccountWait = maxWait
+		o.AccountWaitInterval = interval
+	}
+}
+
+// WithMinPoolLiquidity makes Pump AMM trades fail with
+// types.ErrInsufficientLiquidity when the pool's quote token account holds
+// less than lamports (quote base units for pools not quoted in WSOL). Thin
+// pools move far on small trades and are cheap to sandwich.
+//
+// Example:
+//
+//	_, _, instrs, _, err := autofill.PumpAmmBuyWithSol(ctx, rpc, user, pool, amountSol, 100,
+//	    autofill.WithMinPoolLiquidity(50_000_000_000)) // 50 SOL
+func WithMinPoolLiquidity(lamports uint64) Option {
+	return func(o *Options) { o.MinPoolLiquidity = lamports }
+}
+
+// WithMaxPoolPriceAge makes Pump AMM trades fail with an error wrapping
+// types.ErrNodeBehind when the RPC node the pool reserves are read from is
+// more than slots behind the cluster, so the trade is not priced off stale
+// state. The lag is measured with the client's IsBehind (set
+// RPCConfig.ReferenceRPCURL for lags below the node's own health
+// threshold); clients that cannot measure it fail the trade. Under
+// WithDryRun the check is skipped.
+func WithMaxPoolPriceAge(slots uint64) Option {
+	return func(o *Options) { o.MaxPoolPriceAge = slots }
+}
+
+// WithPrependInstructions inserts ixs into the trade transaction before
+// every generated instruction except the compute budget. Trade helpers lay
+// out their instructions as:
+//
+//  1. SetComputeUnitLimit and SetComputeUnitPrice
+//  2. instructions from WithPrependInstructions
+//  3. ATA creation, and for WSOL buys the wrap (transfer and SyncNative)
+//  4. the pump or pump_amm trade instruction
+//  5. ATA closes, including the WSOL unwrap after AMM sells
+//  6. instructions from WithAppendInstructions and WithMemo
+//  7. the referral transfer (WithReferral)
+//  8. the inline Jito tip (WithJitoTip)
+//
+// Repeated calls accumulate in order. The spliced instructions are not part
+// of the simulations trade helpers run, and any extra signers they need must
+// be supplied when signing. Helpers that split work across transactions
+// (PumpCreateAndBuy, PumpBuyLaddered, CleanupEmptyATAs) place them in the
+// last one only.
+//
+// Example:
+//
+//	memo := solana.NewInstruction(memoProgram, nil, []byte("order 42"))
+//	_, _, instrs, err := autofill.PumpBuy(ctx, rpc, user, mint, amount, maxSol,
+//	    autofill.WithPrependInstructions(memo),
+//	)
+func WithPrependInstructions(ixs ...solana.Instruction) Option {
+	return func(o *Options) { o.PrependInstructions = append(o.PrependInstructions, ixs...) }
+}
+
+// WithAppendInstructions inserts ixs into the trade transaction after every
+// generated instruction except the referral transfer and the inline Jito
+// tip, which stay last; see
+// WithPrependInstructions for the full layout. Repeated calls accumulate in
+// order.
+//
+// Example:
+//
+//	referral := system.NewTransferInstruction(fee, user, referrer).Build()
+//	_, _, instrs, err := autofill.PumpAmmSellWithSlippage(ctx, rpc, user, pool, baseIn, 100,
+//	    autofill.WithAppendInstructions(referral),
+//	)
+func WithAppendInstructions(ixs ...solana.Instruction) Option {
+	return func(o *Options) { o.AppendInstructions = append(o.AppendInstructions, ixs...) }
+}
+
+// WithMemo appends an SPL Memo instruction carrying memo to the trade
+// transaction, in order with WithAppendInstructions. The memo shows up in
+// the transaction's logs, e.g. to tag trades by bot or order.
+//
+// Example:
+//
+//	autofill.PumpBuy(ctx, rpc, user, mint, amount, maxSol, autofill.WithMemo("order-42"))
+func WithMemo(memo string) Option {
+	return WithAppendInstructions(buildMemo(memo))
+}
+
+// MaxReferralBps is the largest referral WithReferral accepts: 10% of the
+// trade's SOL amount.
+const MaxReferralBps = 1_000
+
+// WithReferral transfers bps basis points of the trade's SOL amount from the
+// user to referrer, after the appended instructions and before the inline
+// Jito tip (see WithPrependInstructions). The SOL amount is what the user
+// commits on buys (maxSol, spendable SOL in or maxQuoteIn) and the
+// slippage-protected minimum on sells (minSol or minQuoteOut), so the fee
+// is known when the transaction is built. Pools quoted in a token other
+// than WSOL, dry-run sells (no minimum) and amounts that round to zero pay
+// no referral. Builders fail with a validation error if bps exceeds
+// MaxReferralBps.
+//
+// Example:
+//
+//	autofill.PumpSellWithSlippage(ctx, rpc, user, mint, amount, 100,
+//	    autofill.WithReferral(referrer, 50), // 0.5% of the minimum SOL out
+//	)
+func WithReferral(referrer solana.PublicKey, bps uint64) Option {
+	return func(o *Options) {
+		o.Referrer = referrer
+		o.ReferralBps = bps
+	}
+}
+
 // WithJitoTipAccount specifies a custom Jito tip account.
 // Use this with WithJitoTip to use a specific tip account instead of a random one.
 //
@@ -160,27 +399,114 @@ func WithPriorityFee(lamports uint64) Option {
 // Example:
 //
 //	autofill.PumpAmmBuyWithSol(ctx, rpc, user, pool, amountSol, slippageBps,
-//	    autofill.WithPriorityFeePerCU(10000), // 10000 microLamports per CU
-//	    autofill.WithComputeLimit(150000),    // Optional: set CU limit
+//	    autofill.WithPriorityFeePerCU(10000),  // 10000 microLamports per CU
+//	    autofill.WithComputeUnitLimit(150000), // Optional: set CU limit
 //	)
 func WithPriorityFeePerCU(microLamports uint64) Option {
 	return func(o *Options) { o.PriorityFeePerCU = microLamports }
 }
 
-// WithComputeLimit sets the compute unit limit for the transaction.
+// WithComputeUnitLimit sets the compute unit limit for the transaction.
 // Default is 200000 CU if not set.
 // Setting a lower limit can reduce transaction cost when priority fee is used.
 //
+// The SetComputeUnitLimit instruction, and SetComputeUnitPrice for
+// WithPriorityFee / WithPriorityFeePerCU, are the first instructions of every
+// instruction set autofill returns, ahead of any ATA creation.
+//
 // Example:
 //
 //	autofill.PumpAmmBuyWithSol(ctx, rpc, user, pool, amountSol, slippageBps,
 //	    autofill.WithPriorityFee(10000),
-//	    autofill.WithComputeLimit(150000), // 150k CU limit
+//	    autofill.WithComputeUnitLimit(150000), // 150k CU limit
 //	)
-func WithComputeLimit(units uint32) Option {
+func WithComputeUnitLimit(units uint32) Option {
 	return func(o *Options) { o.ComputeLimit = units }
 }
 
+// WithComputeLimit sets the compute unit limit for the transaction.
+//
+// Deprecated: use WithComputeUnitLimit.
+func WithComputeLimit(units uint32) Option {
+	return WithComputeUnitLimit(units)
+}
+
+// WithTransferFeeAware makes sell helpers read the base mint's Token-2022
+// TransferFeeConfig extension and scale the expected output by the share of
+// tokens that survives the transfer fee, so MinSolOutput / MinQuoteAmountOut
+// isn't set above what the pool can pay. The higher of the older/newer fee
+// schedule is used, so the adjustment is conservative around fee changes.
+// Mints without the extension are unaffected.
+//
+// Example:
+//
+//	autofill.PumpAmmSellWithSlippage(ctx, rpc, user, pool, baseIn, slippageBps,
+//	    autofill.WithTransferFeeAware(),
+//	)
+func WithTransferFeeAware() Option {
+	return func(o *Options) { o.TransferFeeAware = true }
+}
+
+// WithPoolState uses an already decoded pool, e.g. from an account
+// subscription, instead of reading it over RPC. The pool must derive to the
+// address being traded or autofill fails. Together with WithGlobalConfig it
+// leaves only the mint owner lookup on the critical path.
+//
+// Example:
+//
+//	autofill.PumpAmmBuyWithSol(ctx, rpc, user, pool, amountSol, slippageBps,
+//	    autofill.WithPoolState(decodedPool),
+//	    autofill.WithGlobalConfig(cachedGlobalConfig),
+//	)
+func WithPoolState(pool pumpamm.Pool) Option {
+	return func(o *Options) { o.PoolState = &pool }
+}
+
+// WithGlobalConfig uses an already decoded AMM global_config instead of
+// reading it over RPC.
+func WithGlobalConfig(config pumpamm.GlobalConfig) Option {
+	return func(o *Options) { o.GlobalConfig = &config }
+}
+
+// WithBondingCurve uses an already decoded bonding curve instead of reading
+// it over RPC. The curve carries no mint, so it cannot be checked against
+// the traded mint; pass the curve of that mint.
+func WithBondingCurve(bc pump.BondingCurve) Option {
+	return func(o *Options) { o.BondingCurve = &bc }
+}
+
+// WithPumpGlobal uses an already decoded pump Global account instead of
+// reading it over RPC.
+func WithPumpGlobal(global pump.Global) Option {
+	return func(o *Options) { o.PumpGlobal = &global }
+}
+
+// SlippageRounding selects how a slippage minimum, expected*(10000-bps)/10000,
+// is rounded to whole base units.
+type SlippageRounding int
+
+const (
+	// SlippageRoundDown floors the minimum, the most permissive choice. It
+	// is the default.
+	SlippageRoundDown SlippageRounding = iota
+	// SlippageRoundUp rounds the minimum up, so any nonzero expected output
+	// keeps a nonzero minimum.
+	SlippageRoundUp
+)
+
+// WithSlippageRounding sets how slippage minimums are rounded. Flooring can
+// bring the minimum of a tiny trade to zero, which would accept any fill;
+// trades where that happens fail with a validation error, and
+// SlippageRoundUp avoids it.
+//
+// Example:
+//
+//	autofill.PumpSellWithSlippage(ctx, rpc, user, mint, amount, 100,
+//	    autofill.WithSlippageRounding(autofill.SlippageRoundUp))
+func WithSlippageRounding(mode SlippageRounding) Option {
+	return func(o *Options) { o.SlippageRounding = mode }
+}
+
 // MergeOverridesFromJSON merges base58 pubkeys from JSON blob into map.
 func MergeOverridesFromJSON(dst map[string]solana.PublicKey, jsonBytes []byte) (map[string]solana.PublicKey, error) {
 	if dst == nil {
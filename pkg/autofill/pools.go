@@ -0,0 +1,57 @@
+package autofill
+
+import (
+	"context"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// poolBaseMintOffset is the offset of Pool.BaseMint in the account data:
+// discriminator (8), pool_bump (1), index (2), creator (32).
+const poolBaseMintOffset = 8 + 1 + 2 + 32
+
+// PoolAccount is a pump AMM pool found on chain.
+type PoolAccount struct {
+	Address solana.PublicKey
+	Pool    pumpamm.Pool
+}
+
+// FindPoolsByBaseMint returns every pump AMM pool whose base mint is
+// baseMint, e.g. the canonical pool of a graduated token alongside pools
+// created by users. It scans the AMM program with getProgramAccounts, which
+// some RPC providers rate limit heavily or disable.
+//
+// Example:
+//
+//	pools, err := autofill.FindPoolsByBaseMint(ctx, rpc, mint)
+//	for _, p := range pools {
+//	    fmt.Println(p.Address, p.Pool.QuoteMint)
+//	}
+func FindPoolsByBaseMint(ctx context.Context, rpc RPC, baseMint solana.PublicKey) ([]PoolAccount, error) {
+	if isNilRPC(rpc) {
+		return nil, types.ErrNilRPC
+	}
+	if err := types.ValidatePublicKey("baseMint", baseMint); err != nil {
+		return nil, err
+	}
+
+	accts, err := rpc.GetProgramAccountsFiltered(ctx, pumpamm.ProgramKey, []solanarpc.RPCFilter{
+		sdkrpc.MemcmpFilter(0, pumpamm.PoolDiscriminator),
+		sdkrpc.MemcmpFilter(poolBaseMintOffset, baseMint.Bytes()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	pools := make([]PoolAccount, 0, len(accts))
+	for _, a := range accts {
+		if pool, ok := a.Decoded.(*pumpamm.Pool); ok {
+			pools = append(pools, PoolAccount{Address: a.Pubkey, Pool: *pool})
+		}
+	}
+	return pools, nil
+}
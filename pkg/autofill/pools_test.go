@@ -0,0 +1,46 @@
+package autofill
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+)
+
+// poolData encodes pool as AMM pool account data.
+func poolData(t *testing.T, pool pumpamm.Pool) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.Write(pumpamm.PoolDiscriminator)
+	if err := bin.NewBorshEncoder(&buf).Encode(pool); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestFindPoolsByBaseMint(t *testing.T) {
+	rpc := mock.New()
+	pool, addr := testPool(t)
+	rpc.SetAccount(addr, pumpamm.ProgramKey, 1, poolData(t, pool))
+
+	other, otherAddr := testPool(t)
+	rpc.SetAccount(otherAddr, pumpamm.ProgramKey, 1, poolData(t, other))
+	// Same bytes under another owner must not match.
+	rpc.SetAccount(solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), 1, poolData(t, pool))
+
+	pools, err := FindPoolsByBaseMint(context.Background(), rpc, pool.BaseMint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools) != 1 {
+		t.Fatalf("found %d pools, want 1", len(pools))
+	}
+	if !pools[0].Address.Equals(addr) || pools[0].Pool != pool {
+		t.Fatalf("found %s %+v, want %s %+v", pools[0].Address, pools[0].Pool, addr, pool)
+	}
+}
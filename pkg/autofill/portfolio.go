@@ -0,0 +1,91 @@
+package autofill
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// TokenHolding is a non-empty token account owned by a user.
+type TokenHolding struct {
+	Mint         solana.PublicKey `json:"mint"`
+	ATA          solana.PublicKey `json:"ata"` // token account address (usually, but not necessarily, the ATA)
+	Amount       uint64           `json:"amount"`
+	TokenProgram solana.PublicKey `json:"token_program"`
+}
+
+// ListUserTokenBalances returns every token account owned by user with a
+// non-zero balance, across both the SPL Token and Token-2022 programs.
+// Results are deduplicated by account address and sorted by mint, then account.
+//
+// Example:
+//
+//	holdings, err := autofill.ListUserTokenBalances(ctx, rpc, user)
+//	for _, h := range holdings {
+//	    fmt.Printf("%s: %d\n", h.Mint, h.Amount)
+//	}
+func ListUserTokenBalances(ctx context.Context, rpc RPC, user solana.PublicKey) ([]TokenHolding, error) {
+	if isNilRPC(rpc) {
+		return nil, types.ErrNilRPC
+	}
+	if err := types.ValidatePublicKey("user", user); err != nil {
+		return nil, err
+	}
+
+	accounts, err := listTokenAccounts(ctx, rpc, user)
+	if err != nil {
+		return nil, err
+	}
+	var out []TokenHolding
+	for _, ta := range accounts {
+		mint, amount, ok := userTokenBalance(&ta.Account, user)
+		if !ok || amount == 0 {
+			continue
+		}
+		out = append(out, TokenHolding{
+			Mint:         mint,
+			ATA:          ta.Pubkey,
+			Amount:       amount,
+			TokenProgram: ta.Account.Owner,
+		})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if c := bytes.Compare(out[i].Mint[:], out[j].Mint[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(out[i].ATA[:], out[j].ATA[:]) < 0
+	})
+	return out, nil
+}
+
+// listTokenAccounts returns every token account owned by owner under the SPL
+// Token and Token-2022 programs, deduplicated by address.
+func listTokenAccounts(ctx context.Context, rpc RPC, owner solana.PublicKey) ([]*solanarpc.TokenAccount, error) {
+	seen := make(map[solana.PublicKey]struct{})
+	var out []*solanarpc.TokenAccount
+	for _, program := range []solana.PublicKey{constants.TokenProgramID, constants.Token2022ProgramID} {
+		accounts, err := rpc.GetTokenAccountsByOwner(ctx, owner, program)
+		if err != nil {
+			return nil, fmt.Errorf("get token accounts (%s): %w", program, err)
+		}
+		for _, ta := range accounts {
+			if ta == nil {
+				continue
+			}
+			if _, dup := seen[ta.Pubkey]; dup {
+				continue
+			}
+			seen[ta.Pubkey] = struct{}{}
+			out = append(out, ta)
+		}
+	}
+	return out, nil
+}
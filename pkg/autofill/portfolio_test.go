@@ -0,0 +1,78 @@
+package autofill
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+)
+
+func TestListUserTokenBalances(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	user := solana.NewWallet().PublicKey()
+	splMint := solana.NewWallet().PublicKey()
+	t22Mint := solana.NewWallet().PublicKey()
+	emptyMint := solana.NewWallet().PublicKey()
+	splATA := solana.NewWallet().PublicKey()
+	t22ATA := solana.NewWallet().PublicKey()
+	emptyATA := solana.NewWallet().PublicKey()
+
+	entry := func(addr, mint, program solana.PublicKey, amount uint64) map[string]interface{} {
+		return map[string]interface{}{
+			"pubkey": addr.String(),
+			"account": map[string]interface{}{
+				"data":       []string{base64.StdEncoding.EncodeToString(tokenAccountData(mint, user, amount)), "base64"},
+				"executable": false,
+				"lamports":   2039280,
+				"owner":      program.String(),
+				"rentEpoch":  0,
+			},
+		}
+	}
+	fake.handlers["getTokenAccountsByOwner"] = func(params json.RawMessage) (interface{}, error) {
+		var p []json.RawMessage
+		_ = json.Unmarshal(params, &p)
+		var filter struct {
+			ProgramID string `json:"programId"`
+		}
+		_ = json.Unmarshal(p[1], &filter)
+
+		var value []interface{}
+		switch filter.ProgramID {
+		case constants.TokenProgramID.String():
+			value = []interface{}{
+				entry(splATA, splMint, constants.TokenProgramID, 100),
+				entry(emptyATA, emptyMint, constants.TokenProgramID, 0),
+				entry(splATA, splMint, constants.TokenProgramID, 100), // duplicate
+			}
+		case constants.Token2022ProgramID.String():
+			value = []interface{}{entry(t22ATA, t22Mint, constants.Token2022ProgramID, 7)}
+		}
+		return map[string]interface{}{"context": map[string]interface{}{"slot": 1}, "value": value}, nil
+	}
+
+	holdings, err := ListUserTokenBalances(context.Background(), client, user)
+	if err != nil {
+		t.Fatalf("ListUserTokenBalances: %v", err)
+	}
+	if len(holdings) != 2 {
+		t.Fatalf("expected 2 non-zero holdings, got %d: %+v", len(holdings), holdings)
+	}
+	byMint := map[solana.PublicKey]TokenHolding{}
+	for _, h := range holdings {
+		byMint[h.Mint] = h
+	}
+	if h := byMint[splMint]; h.Amount != 100 || !h.ATA.Equals(splATA) || !h.TokenProgram.Equals(constants.TokenProgramID) {
+		t.Fatalf("unexpected SPL holding %+v", h)
+	}
+	if h := byMint[t22Mint]; h.Amount != 7 || !h.TokenProgram.Equals(constants.Token2022ProgramID) {
+		t.Fatalf("unexpected Token-2022 holding %+v", h)
+	}
+	if n := fake.callCount("getTokenAccountsByOwner"); n != 2 {
+		t.Fatalf("expected one call per token program, got %d", n)
+	}
+}
@@ -0,0 +1,168 @@
+package autofill
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/curve"
+	"github.com/ninja0404/pump-go-sdk/pkg/jito"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// Preview instruction kinds reported in PreviewInstruction.Kind.
+const (
+	PreviewKindComputeBudget = string(types.InstructionKindComputeBudget)
+	PreviewKindCreateATA     = string(types.InstructionKindCreateATA)
+	PreviewKindTransfer      = string(types.InstructionKindTransfer) // system transfer, e.g. SOL into a WSOL account
+	PreviewKindSyncNative    = string(types.InstructionKindSyncNative)
+	PreviewKindCloseAccount  = string(types.InstructionKindCloseAccount)
+	PreviewKindJitoTip       = "jito_tip"
+	PreviewKindPump          = string(types.InstructionKindPump)
+	PreviewKindPumpAmm       = string(types.InstructionKindPumpAmm)
+	PreviewKindOther         = string(types.InstructionKindOther)
+)
+
+// Preview is the JSON object written to Options.Preview, one per call. The
+// schema is stable: fields are only ever added.
+//
+//	accounts            instruction accounts
+//	args                instruction arguments
+//	mint                the new mint (create only, omitted otherwise)
+//	simulated_base_out  simulated base out (PumpAmmBuyWithSol only, omitted otherwise)
+//	expected_out        expected output before slippage; null when not estimated.
+//	                    Includes rent_refund when set
+//	rent_refund         lamports reclaimed by closing the base ATA after a sell
+//	                    (WithIncludeRentRefund only, omitted otherwise)
+//	min_out             minimum output the trade accepts (tokens for buys,
+//	                    lamports for sells); 0 for non-trades
+//	price_impact_bps    move of the pool price caused by the trade, excluding
+//	                    fees; null when unknown or not a trade
+//	instructions        every instruction returned, in order
+type Preview struct {
+	Accounts       any                  `json:"accounts"`
+	Args           any                  `json:"args"`
+	Mint           string               `json:"mint,omitempty"`
+	SimulatedBase  *uint64              `json:"simulated_base_out,omitempty"`
+	ExpectedOut    *uint64              `json:"expected_out"`
+	RentRefund     *uint64              `json:"rent_refund,omitempty"`
+	MinOut         uint64               `json:"min_out"`
+	PriceImpactBps *uint64              `json:"price_impact_bps"`
+	Instructions   []PreviewInstruction `json:"instructions"`
+}
+
+// PreviewInstruction summarizes one instruction of a Preview.
+type PreviewInstruction struct {
+	Kind    string           `json:"kind"`
+	Program solana.PublicKey `json:"program"`
+}
+
+// wantsPreview reports whether a Preview is to be built: for
+// options.Preview or for a *WithResult variant.
+func wantsPreview(options *Options) bool {
+	return options.Preview != nil || options.result != nil
+}
+
+// writePreview fills p.Instructions from instrs and encodes p to
+// options.Preview, if set. A *WithResult variant's result receives p too.
+func writePreview(options *Options, p Preview, instrs ...solana.Instruction) {
+	if !wantsPreview(options) {
+		return
+	}
+	if options.result != nil {
+		*options.result = p
+	}
+	if options.Preview != nil {
+		p.Instructions = summarizeInstructions(instrs)
+		_ = json.NewEncoder(options.Preview).Encode(p)
+	}
+}
+
+func summarizeInstructions(instrs []solana.Instruction) []PreviewInstruction {
+	out := make([]PreviewInstruction, 0, len(instrs))
+	for _, ix := range instrs {
+		out = append(out, PreviewInstruction{Kind: instructionKind(ix), Program: ix.ProgramID()})
+	}
+	return out
+}
+
+// instructionKind is types.ClassifyInstruction, with transfers to a Jito tip
+// account reported as tips.
+func instructionKind(ix solana.Instruction) string {
+	kind := types.ClassifyInstruction(ix)
+	if kind == types.InstructionKindTransfer {
+		if accts := ix.Accounts(); len(accts) >= 2 && isJitoTipAccount(accts[1].PublicKey) {
+			return PreviewKindJitoTip
+		}
+	}
+	return string(kind)
+}
+
+func isJitoTipAccount(pk solana.PublicKey) bool {
+	return slices.ContainsFunc(jito.MainnetTipAccounts, pk.Equals)
+}
+
+// impactExactIn returns curve.ExactInImpactBps, or nil for empty reserves.
+func impactExactIn(reserveIn, amountIn uint64) *uint64 {
+	if reserveIn == 0 {
+		return nil
+	}
+	bps := curve.ExactInImpactBps(reserveIn, amountIn)
+	return &bps
+}
+
+// impactExactOut returns curve.ExactOutImpactBps, or nil for empty reserves.
+func impactExactOut(reserveOut, amountOut uint64) *uint64 {
+	if reserveOut == 0 {
+		return nil
+	}
+	bps := curve.ExactOutImpactBps(reserveOut, amountOut)
+	return &bps
+}
+
+// previewCurveReserves returns a bonding curve's virtual token and SOL
+// reserves, or ok=false if it cannot be read. Only used for previews.
+func previewCurveReserves(ctx context.Context, rpc RPC, bondingCurve solana.PublicKey) (tokens, sol uint64, ok bool) {
+	info, err := fetchAccount(ctx, rpc, bondingCurve)
+	if err != nil || info == nil || info.Data == nil {
+		return 0, 0, false
+	}
+	var bc pump.BondingCurve
+	if err := bc.Unmarshal(info.Data.GetBinary()); err != nil {
+		return 0, 0, false
+	}
+	return bc.VirtualTokenReserves, bc.VirtualSolReserves, true
+}
+
+// previewPoolReserves returns a pool's base and quote reserves, or ok=false
+// if they cannot be read. Only used for previews.
+func previewPoolReserves(ctx context.Context, rpc RPC, poolBase, poolQuote solana.PublicKey) (base, quote uint64, ok bool) {
+	amounts, err := fetchTokenAmountBatch(ctx, rpc, []solana.PublicKey{poolBase, poolQuote})
+	if err != nil {
+		return 0, 0, false
+	}
+	return amounts[poolBase.String()], amounts[poolQuote.String()], true
+}
+
+// rentRefund returns the lamports held by ata, which closing it after a sell
+// returns to the user, when options ask for the refund to be counted. The
+// refund only shows in the preview, so without one nothing is fetched. A
+// missing account refunds nothing.
+func rentRefund(ctx context.Context, rpc RPC, options *Options, ata solana.PublicKey) (uint64, error) {
+	if !options.CloseBaseATA || !options.IncludeRentRefund || !wantsPreview(options) {
+		return 0, nil
+	}
+	amap, err := fetchAccountsBatch(ctx, rpc, ata)
+	if err != nil {
+		return 0, err
+	}
+	if acc := amap[ata.String()]; acc != nil {
+		return acc.Lamports, nil
+	}
+	return 0, nil
+}
+
+func ptr(v uint64) *uint64 { return &v }
@@ -0,0 +1,132 @@
+package autofill
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/jito"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+)
+
+func TestWritePreview(t *testing.T) {
+	user := solana.NewWallet().PublicKey()
+	wsolATA := solana.NewWallet().PublicKey()
+
+	instrs := buildComputeBudgetInstructions(Options{ComputeLimit: 100_000, PriorityFeePerCU: 1000})
+	instrs = append(instrs, buildWrapWSOL(user, wsolATA, 1_000)...)
+	instrs = append(instrs,
+		buildCloseAccount(wsolATA, user, user, constants.TokenProgramID),
+		system.NewTransferInstruction(1_000, user, jito.MainnetTipAccounts[0]).Build(),
+	)
+
+	var buf bytes.Buffer
+	options := &Options{Preview: &buf}
+	writePreview(options, Preview{Accounts: struct{}{}, Args: struct{}{}, ExpectedOut: ptr(100), MinOut: 99, PriceImpactBps: impactExactIn(9_900, 100)}, instrs...)
+
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("decode preview: %v", err)
+	}
+	for _, key := range []string{"accounts", "args", "expected_out", "min_out", "price_impact_bps", "instructions"} {
+		if _, ok := got[key]; !ok {
+			t.Errorf("preview is missing %q", key)
+		}
+	}
+	if string(got["price_impact_bps"]) != "100" {
+		t.Errorf("price_impact_bps = %s, want 100", got["price_impact_bps"])
+	}
+
+	var summary []PreviewInstruction
+	if err := json.Unmarshal(got["instructions"], &summary); err != nil {
+		t.Fatal(err)
+	}
+	var kinds []string
+	for _, ix := range summary {
+		kinds = append(kinds, ix.Kind)
+	}
+	want := []string{PreviewKindComputeBudget, PreviewKindComputeBudget, PreviewKindTransfer, PreviewKindSyncNative, PreviewKindCloseAccount, PreviewKindJitoTip}
+	if len(kinds) != len(want) {
+		t.Fatalf("kinds = %v, want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Fatalf("kinds = %v, want %v", kinds, want)
+		}
+	}
+}
+
+func TestPriceImpact(t *testing.T) {
+	// Putting 100 into 9_900 moves the price by 100/10_000 = 1%, the same as
+	// taking the matching 1% of the other side.
+	if got := *impactExactIn(9_900, 100); got != 100 {
+		t.Fatalf("exact in = %d, want 100", got)
+	}
+	if got := *impactExactOut(10_000, 100); got != 100 {
+		t.Fatalf("exact out = %d, want 100", got)
+	}
+	if got := *impactExactOut(100, 500); got != 10_000 {
+		t.Fatalf("oversized exact out = %d, want capped 10000", got)
+	}
+	if impactExactIn(0, 100) != nil {
+		t.Fatal("expected nil impact for an empty pool")
+	}
+}
+
+func TestRentRefund(t *testing.T) {
+	rpc := mock.New()
+	ata := solana.NewWallet().PublicKey()
+	rpc.SetAccount(ata, constants.TokenProgramID, 2_039_280, make([]byte, 165))
+
+	cases := []struct {
+		name string
+		opts Options
+		want uint64
+	}{
+		{"not requested", Options{CloseBaseATA: true, Preview: io.Discard}, 0},
+		{"ata kept", Options{IncludeRentRefund: true, Preview: io.Discard}, 0},
+		{"no preview", Options{CloseBaseATA: true, IncludeRentRefund: true}, 0},
+		{"close and include", Options{CloseBaseATA: true, IncludeRentRefund: true, Preview: io.Discard}, 2_039_280},
+	}
+	for _, tc := range cases {
+		got, err := rentRefund(context.Background(), rpc, &tc.opts, ata)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: refund = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+
+	missing, err := rentRefund(context.Background(), rpc, &Options{CloseBaseATA: true, IncludeRentRefund: true, Preview: io.Discard}, solana.NewWallet().PublicKey())
+	if err != nil || missing != 0 {
+		t.Fatalf("missing ata: refund = %d, err = %v", missing, err)
+	}
+}
+
+func TestRentRefundFetchedForPreviewOnly(t *testing.T) {
+	rpc, amm := loadAmmPool(t)
+	pool, user := amm.Address("pool"), solana.NewWallet().PublicKey()
+	opts := []Option{WithDryRun(), WithCloseBaseATA(), WithIncludeRentRefund(true)}
+
+	accts, _, _, err := PumpAmmSellWithSlippage(context.Background(), rpc, user, pool, 1_000_000, 100, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := rpc.Reads(accts.UserBaseTokenAccount); n != 1 {
+		t.Fatalf("base ATA fetched %d times without a preview, want 1", n)
+	}
+
+	if _, _, _, err := PumpAmmSellWithSlippage(context.Background(), rpc, user, pool, 1_000_000, 100, append(opts, WithPreview(io.Discard))...); err != nil {
+		t.Fatal(err)
+	}
+	if n := rpc.Reads(accts.UserBaseTokenAccount) - 1; n != 2 {
+		t.Fatalf("base ATA fetched %d times with a preview, want 2", n)
+	}
+}
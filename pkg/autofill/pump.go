@@ -2,7 +2,6 @@ package autofill
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -12,7 +11,6 @@ import (
 	"github.com/ninja0404/pump-go-sdk/pkg/constants"
 	"github.com/ninja0404/pump-go-sdk/pkg/jito"
 	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
-	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
 	"github.com/ninja0404/pump-go-sdk/pkg/txbuilder"
 	"github.com/ninja0404/pump-go-sdk/pkg/types"
 	"github.com/ninja0404/pump-go-sdk/pkg/vanity"
@@ -39,9 +37,9 @@ import (
 // Example:
 //
 //	accts, args, instrs, err := autofill.PumpBuy(ctx, rpc, user, mint, 1_000_000, 100_000_000)
-func PumpBuy(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicKey, amount, maxSol uint64, opts ...Option) (pump.BuyAccounts, pump.BuyArgs, []solana.Instruction, error) {
+func PumpBuy(ctx context.Context, rpc RPC, user, mint solana.PublicKey, amount, maxSol uint64, opts ...Option) (pump.BuyAccounts, pump.BuyArgs, []solana.Instruction, error) {
 	// Input validation
-	if rpc == nil {
+	if isNilRPC(rpc) {
 		return pump.BuyAccounts{}, pump.BuyArgs{}, nil, types.ErrNilRPC
 	}
 	if err := types.ValidatePublicKey("user", user); err != nil {
@@ -59,11 +57,13 @@ func PumpBuy(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicKe
 		opt(options)
 	}
 
-	accts, err := pumpAutofillBuy(ctx, rpc, user, mint)
+	accts, err := pumpAutofillBuy(ctx, rpc, user, mint, options)
 	if err != nil {
 		return pump.BuyAccounts{}, pump.BuyArgs{}, nil, err
 	}
-	applyOverrides(&accts, options.Overrides)
+	if err := applyOverrides(&accts, options); err != nil {
+		return pump.BuyAccounts{}, pump.BuyArgs{}, nil, err
+	}
 
 	args := pump.BuyArgs{
 		Amount:      amount,
@@ -76,6 +76,10 @@ func PumpBuy(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicKe
 		{Payer: accts.User, Wallet: accts.User, Mint: accts.Mint, TokenProgram: accts.TokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
 		{Payer: accts.User, Wallet: accts.BondingCurve, Mint: accts.Mint, TokenProgram: accts.TokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
 	}
+	ataReqs, err = dropKnownATAs(ataReqs, options)
+	if err != nil {
+		return pump.BuyAccounts{}, pump.BuyArgs{}, nil, err
+	}
 	instrs, err := ensureATABatch(ctx, rpc, ataReqs)
 	if err != nil {
 		return pump.BuyAccounts{}, pump.BuyArgs{}, nil, err
@@ -87,12 +91,16 @@ func PumpBuy(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicKe
 	}
 	instrs = append(instrs, ix)
 	// Finalize: prepend Compute Budget, append Jito tip
-	instrs = finalizeInstructionsPump(instrs, user, options)
-	if options.Preview != nil {
-		_ = json.NewEncoder(options.Preview).Encode(struct {
-			Accounts pump.BuyAccounts `json:"accounts"`
-			Args     pump.BuyArgs     `json:"args"`
-		}{accts, args})
+	instrs, err = finalizeInstructionsPump(instrs, user, maxSol, options)
+	if err != nil {
+		return pump.BuyAccounts{}, pump.BuyArgs{}, nil, err
+	}
+	if wantsPreview(options) {
+		p := Preview{Accounts: accts, Args: args, ExpectedOut: ptr(amount), MinOut: amount}
+		if tokens, _, ok := previewCurveReserves(ctx, rpc, accts.BondingCurve); ok {
+			p.PriceImpactBps = impactExactOut(tokens, amount)
+		}
+		writePreview(options, p, instrs...)
 	}
 	return accts, args, instrs, nil
 }
@@ -112,9 +120,9 @@ func PumpBuy(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicKe
 //   - opts: optional configurations
 //
 // Returns accounts, args, instructions, and any error.
-func PumpBuyExactSolIn(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicKey, spendableSolIn, minTokensOut uint64, opts ...Option) (pump.BuyExactSolInAccounts, pump.BuyExactSolInArgs, []solana.Instruction, error) {
+func PumpBuyExactSolIn(ctx context.Context, rpc RPC, user, mint solana.PublicKey, spendableSolIn, minTokensOut uint64, opts ...Option) (pump.BuyExactSolInAccounts, pump.BuyExactSolInArgs, []solana.Instruction, error) {
 	// Input validation
-	if rpc == nil {
+	if isNilRPC(rpc) {
 		return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, types.ErrNilRPC
 	}
 	if err := types.ValidatePublicKey("user", user); err != nil {
@@ -132,11 +140,13 @@ func PumpBuyExactSolIn(ctx context.Context, rpc *sdkrpc.Client, user, mint solan
 		opt(options)
 	}
 
-	baseAccts, err := pumpAutofillBuy(ctx, rpc, user, mint)
+	baseAccts, err := pumpAutofillBuy(ctx, rpc, user, mint, options)
 	if err != nil {
 		return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, err
 	}
-	applyOverrides(&baseAccts, options.Overrides)
+	if err := applyOverrides(&baseAccts, options); err != nil {
+		return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, err
+	}
 
 	accts := pump.BuyExactSolInAccounts{
 		Global:                  baseAccts.Global,
@@ -170,6 +180,10 @@ func PumpBuyExactSolIn(ctx context.Context, rpc *sdkrpc.Client, user, mint solan
 		{Payer: accts.User, Wallet: accts.User, Mint: accts.Mint, TokenProgram: accts.TokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
 		{Payer: accts.User, Wallet: accts.BondingCurve, Mint: accts.Mint, TokenProgram: accts.TokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
 	}
+	ataReqs, err = dropKnownATAs(ataReqs, options)
+	if err != nil {
+		return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, err
+	}
 	instrs, err := ensureATABatch(ctx, rpc, ataReqs)
 	if err != nil {
 		return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, err
@@ -181,12 +195,16 @@ func PumpBuyExactSolIn(ctx context.Context, rpc *sdkrpc.Client, user, mint solan
 	}
 	instrs = append(instrs, ix)
 	// Finalize: prepend Compute Budget, append Jito tip
-	instrs = finalizeInstructionsPump(instrs, user, options)
-	if options.Preview != nil {
-		_ = json.NewEncoder(options.Preview).Encode(struct {
-			Accounts pump.BuyExactSolInAccounts `json:"accounts"`
-			Args     pump.BuyExactSolInArgs     `json:"args"`
-		}{accts, args})
+	instrs, err = finalizeInstructionsPump(instrs, user, spendableSolIn, options)
+	if err != nil {
+		return pump.BuyExactSolInAccounts{}, pump.BuyExactSolInArgs{}, nil, err
+	}
+	if wantsPreview(options) {
+		p := Preview{Accounts: accts, Args: args, MinOut: args.MinTokensOut}
+		if _, sol, ok := previewCurveReserves(ctx, rpc, accts.BondingCurve); ok {
+			p.PriceImpactBps = impactExactIn(sol, spendableSolIn)
+		}
+		writePreview(options, p, instrs...)
 	}
 	return accts, args, instrs, nil
 }
@@ -204,9 +222,9 @@ func PumpBuyExactSolIn(ctx context.Context, rpc *sdkrpc.Client, user, mint solan
 //
 // Returns accounts, args, single instruction, and any error.
 // Note: For automatic slippage calculation, use PumpSellWithSlippage instead.
-func PumpSell(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicKey, amount, minSol uint64, opts ...Option) (pump.SellAccounts, pump.SellArgs, solana.Instruction, error) {
+func PumpSell(ctx context.Context, rpc RPC, user, mint solana.PublicKey, amount, minSol uint64, opts ...Option) (pump.SellAccounts, pump.SellArgs, solana.Instruction, error) {
 	// Input validation
-	if rpc == nil {
+	if isNilRPC(rpc) {
 		return pump.SellAccounts{}, pump.SellArgs{}, nil, types.ErrNilRPC
 	}
 	if err := types.ValidatePublicKey("user", user); err != nil {
@@ -224,11 +242,13 @@ func PumpSell(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicK
 		opt(options)
 	}
 
-	accts, err := pumpAutofillSell(ctx, rpc, user, mint)
+	accts, err := pumpAutofillSell(ctx, rpc, user, mint, options)
 	if err != nil {
 		return pump.SellAccounts{}, pump.SellArgs{}, nil, err
 	}
-	applyOverrides(&accts, options.Overrides)
+	if err := applyOverrides(&accts, options); err != nil {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, err
+	}
 
 	args := pump.SellArgs{
 		Amount:       amount,
@@ -239,11 +259,12 @@ func PumpSell(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicK
 	if err != nil {
 		return pump.SellAccounts{}, pump.SellArgs{}, nil, err
 	}
-	if options.Preview != nil {
-		_ = json.NewEncoder(options.Preview).Encode(struct {
-			Accounts pump.SellAccounts `json:"accounts"`
-			Args     pump.SellArgs     `json:"args"`
-		}{accts, args})
+	if wantsPreview(options) {
+		p := Preview{Accounts: accts, Args: args, MinOut: minSol}
+		if tokens, _, ok := previewCurveReserves(ctx, rpc, accts.BondingCurve); ok {
+			p.PriceImpactBps = impactExactIn(tokens, amount)
+		}
+		writePreview(options, p, ix)
 	}
 	return accts, args, ix, nil
 }
@@ -251,8 +272,9 @@ func PumpSell(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicK
 // PumpSellWithSlippage sells tokens with automatic slippage calculation.
 //
 // This function simulates the sell to estimate SOL output, then applies slippage
-// to calculate minimum acceptable SOL. If selling all tokens, it also closes
-// the ATA to reclaim rent.
+// to calculate minimum acceptable SOL. With WithCloseBaseATA it also closes
+// the ATA to reclaim rent; WithIncludeRentRefund counts that rent in the
+// expected output.
 //
 // Parameters:
 //   - ctx: context for RPC calls
@@ -269,9 +291,9 @@ func PumpSell(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicK
 //
 //	// Sell 1M tokens with 1% slippage
 //	accts, args, instrs, err := autofill.PumpSellWithSlippage(ctx, rpc, user, mint, 1_000_000, 100)
-func PumpSellWithSlippage(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicKey, amount uint64, slippageBps uint64, opts ...Option) (pump.SellAccounts, pump.SellArgs, []solana.Instruction, error) {
+func PumpSellWithSlippage(ctx context.Context, rpc RPC, user, mint solana.PublicKey, amount uint64, slippageBps uint64, opts ...Option) (pump.SellAccounts, pump.SellArgs, []solana.Instruction, error) {
 	// Input validation
-	if rpc == nil {
+	if isNilRPC(rpc) {
 		return pump.SellAccounts{}, pump.SellArgs{}, nil, types.ErrNilRPC
 	}
 	if err := types.ValidatePublicKey("user", user); err != nil {
@@ -301,17 +323,34 @@ func PumpSellWithSlippage(ctx context.Context, rpc *sdkrpc.Client, user, mint so
 	ataReqs := []ataRequest{
 		{Payer: user, Wallet: accts.User, Mint: mint, TokenProgram: accts.TokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
 	}
+	ataReqs, err = dropKnownATAs(ataReqs, options)
+	if err != nil {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, err
+	}
 	ataResult, err := ensureATABatchWithBalances(ctx, rpc, ataReqs)
 	if err != nil {
 		return pump.SellAccounts{}, pump.SellArgs{}, nil, err
 	}
 	instrs := ataResult.Instructions
 
-	quoteOut, err := simulateSolOut(ctx, rpc, user, accts, amount, instrs, ixBase)
+	var quoteOut uint64
+	if !options.DryRun {
+		if quoteOut, err = simulateSolOut(ctx, rpc, options.SimulationCommitment, user, accts, amount, instrs, ixBase); err != nil {
+			return pump.SellAccounts{}, pump.SellArgs{}, nil, err
+		}
+	}
+	quoteOut, err = adjustForTransferFee(ctx, rpc, options, mint, accts.TokenProgram, amount, quoteOut)
+	if err != nil {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, err
+	}
+	minSol, err := slippageMin(quoteOut, slippageBps, options)
+	if err != nil {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, err
+	}
+	refund, err := rentRefund(ctx, rpc, options, accts.AssociatedUser)
 	if err != nil {
 		return pump.SellAccounts{}, pump.SellArgs{}, nil, err
 	}
-	minSol := applySlippage(quoteOut, slippageBps)
 
 	args := pump.SellArgs{
 		Amount:       amount,
@@ -328,28 +367,33 @@ func PumpSellWithSlippage(ctx context.Context, rpc *sdkrpc.Client, user, mint so
 		instrs = append(instrs, buildCloseAccount(accts.AssociatedUser, user, user, accts.TokenProgram))
 	}
 	// Finalize: prepend Compute Budget, append Jito tip
-	instrs = finalizeInstructionsPump(instrs, user, options)
+	instrs, err = finalizeInstructionsPump(instrs, user, minSol, options)
+	if err != nil {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, err
+	}
 
-	if options.Preview != nil {
-		_ = json.NewEncoder(options.Preview).Encode(struct {
-			Accounts pump.SellAccounts `json:"accounts"`
-			Args     pump.SellArgs     `json:"args"`
-		}{accts, args})
+	if wantsPreview(options) {
+		p := Preview{Accounts: accts, Args: args, ExpectedOut: ptr(quoteOut + refund), MinOut: minSol}
+		if options.IncludeRentRefund && options.CloseBaseATA {
+			p.RentRefund = ptr(refund)
+		}
+		if tokens, _, ok := previewCurveReserves(ctx, rpc, accts.BondingCurve); ok {
+			p.PriceImpactBps = impactExactIn(tokens, amount)
+		}
+		writePreview(options, p, instrs...)
 	}
 	return accts, args, instrs, nil
 }
 
 // BuildAndSend executes the instruction with provided signer/txbuilder.
-func BuildAndSend(ctx context.Context, builder *txbuilder.Builder, signer wallet.Signer, ix solana.Instruction) (solana.Signature, error) {
-	if builder == nil || signer == nil {
-		return solana.Signature{}, fmt.Errorf("builder and signer are required")
-	}
-	return builder.BuildSignSend(ctx, signer, nil, ix)
+// With WithJitoBundleTip it is sent as a Jito bundle with a tip transaction.
+func BuildAndSend(ctx context.Context, builder *txbuilder.Builder, signer wallet.Signer, ix solana.Instruction, opts ...Option) (solana.Signature, error) {
+	return BuildAndSendInstructions(ctx, builder, signer, []solana.Instruction{ix}, opts...)
 }
 
 // BuildAndSimulate simulates the instruction without signature.
-func BuildAndSimulate(ctx context.Context, rpc *sdkrpc.Client, builder *txbuilder.Builder, user solana.PublicKey, ix solana.Instruction) (*solanarpc.SimulateTransactionResponse, error) {
-	if rpc == nil || builder == nil {
+func BuildAndSimulate(ctx context.Context, rpc RPC, builder *txbuilder.Builder, user solana.PublicKey, ix solana.Instruction) (*solanarpc.SimulateTransactionResponse, error) {
+	if isNilRPC(rpc) || builder == nil {
 		return nil, fmt.Errorf("rpc and builder required")
 	}
 	tx, err := builder.BuildTransaction(ctx, user, ix)
@@ -364,12 +408,12 @@ func BuildAndSimulate(ctx context.Context, rpc *sdkrpc.Client, builder *txbuilde
 }
 
 // simulateSolOut returns lamports delta of user main account after simulating sell ix (MinSolOutput=0).
-func simulateSolOut(ctx context.Context, rpc *sdkrpc.Client, user solana.PublicKey, accounts pump.SellAccounts, amount uint64, prefix []solana.Instruction, baseIx solana.Instruction) (uint64, error) {
-	preRes, err := rpc.Raw().GetBalance(ctx, user, solanarpc.CommitmentConfirmed)
+func simulateSolOut(ctx context.Context, rpc RPC, commitment solanarpc.CommitmentType, user solana.PublicKey, accounts pump.SellAccounts, amount uint64, prefix []solana.Instruction, baseIx solana.Instruction) (uint64, error) {
+	readCommitment, bankCommitment := simulationCommitments(rpc, commitment)
+	pre, err := rpc.GetBalance(ctx, user, readCommitment)
 	if err != nil {
 		return 0, err
 	}
-	pre := preRes.Value
 	instrs := append([]solana.Instruction{}, prefix...)
 	if baseIx == nil {
 		ix, err := pump.BuildSell(accounts, pump.SellArgs{
@@ -383,15 +427,14 @@ func simulateSolOut(ctx context.Context, rpc *sdkrpc.Client, user solana.PublicK
 	} else {
 		instrs = append(instrs, baseIx)
 	}
-	builder := txbuilder.NewBuilder(rpc, solanarpc.CommitmentConfirmed)
-	tx, err := builder.BuildTransaction(ctx, user, instrs...)
+	tx, err := simulationTx(user, instrs...)
 	if err != nil {
 		return 0, err
 	}
 	res, err := rpc.SimulateTransaction(ctx, tx, &solanarpc.SimulateTransactionOpts{
 		SigVerify:              false,
 		ReplaceRecentBlockhash: true,
-		Commitment:             solanarpc.CommitmentProcessed,
+		Commitment:             bankCommitment,
 		Accounts: &solanarpc.SimulateTransactionAccountsOpts{
 			Encoding:  solana.EncodingBase64,
 			Addresses: []solana.PublicKey{user},
@@ -401,7 +444,7 @@ func simulateSolOut(ctx context.Context, rpc *sdkrpc.Client, user solana.PublicK
 		return 0, err
 	}
 	if res != nil && res.Value != nil && res.Value.Err != nil {
-		return 0, types.ParseSimulationError(res.Value.Err, res.Value.Logs)
+		return 0, types.ParseSimulationErrorWithInstructions(res.Value.Err, res.Value.Logs, instrs)
 	}
 	if res == nil || res.Value == nil || len(res.Value.Accounts) == 0 || res.Value.Accounts[0] == nil {
 		return 0, fmt.Errorf("simulate result empty")
@@ -415,7 +458,7 @@ func simulateSolOut(ctx context.Context, rpc *sdkrpc.Client, user solana.PublicK
 
 // --- internal helpers ---
 
-func pumpAutofillBuy(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicKey) (pump.BuyAccounts, error) {
+func pumpAutofillBuy(ctx context.Context, rpc RPC, user, mint solana.PublicKey, options *Options) (pump.BuyAccounts, error) {
 	var accts pump.BuyAccounts
 
 	accts = pump.BuyAccounts{
@@ -445,33 +488,17 @@ func pumpAutofillBuy(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.
 		accts.FeeConfig = pk
 	}
 
-	// batch fetch required accounts (global, mint, bonding_curve)
-	addrs := []solana.PublicKey{accts.Global, accts.Mint, accts.BondingCurve}
-	amap, err := fetchAccountsBatch(ctx, rpc, addrs...)
+	globalState, bc, mintAcc, err := fetchPumpTradeState(ctx, rpc, accts.Global, mint, accts.BondingCurve, options)
 	if err != nil {
 		return accts, err
 	}
-
-	// parse global for fee recipient
-	globalAcc := amap[accts.Global.String()]
-	if globalAcc != nil && globalAcc.Data != nil {
-		var globalState pump.Global
-		if err := globalState.Unmarshal(globalAcc.Data.GetBinary()); err == nil {
-			feeRecipient := firstNonZeroPK(append(globalState.FeeRecipients[:], globalState.FeeRecipient))
-			if !isZeroPK(feeRecipient) {
-				accts.FeeRecipient = feeRecipient
-			}
-		}
-	}
-	if isZeroPK(accts.FeeRecipient) {
-		return accts, fmt.Errorf("fee recipient not found in global config for mint %s", mint)
+	feeRecipient, err := selectFeeRecipient(globalState.AcceptedFeeRecipients(bc.IsMayhemMode), options)
+	if err != nil {
+		return accts, fmt.Errorf("fee recipient for mint %s: %w", mint, err)
 	}
+	accts.FeeRecipient = feeRecipient
 
 	// identify token program from mint owner
-	mintAcc := amap[accts.Mint.String()]
-	if mintAcc == nil {
-		return accts, fmt.Errorf("mint account %s not found (may be invalid mint or RPC issue)", mint)
-	}
 	accts.TokenProgram = mintAcc.Owner
 
 	// derive user ATA
@@ -488,15 +515,7 @@ func pumpAutofillBuy(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.
 	}
 	accts.AssociatedBondingCurve = assocBC
 
-	// parse bonding_curve for creator vault
-	bcAcc := amap[accts.BondingCurve.String()]
-	if bcAcc == nil || bcAcc.Data == nil {
-		return accts, fmt.Errorf("bonding_curve account %s not found for mint %s", accts.BondingCurve, mint)
-	}
-	var bc pump.BondingCurve
-	if err := bc.Unmarshal(bcAcc.Data.GetBinary()); err != nil {
-		return accts, fmt.Errorf("decode bonding_curve %s: %w", accts.BondingCurve, err)
-	}
+	// creator vault from the bonding curve's creator
 	if pk, _, err := solana.FindProgramAddress([][]byte{[]byte(constants.SeedCreatorVault), bc.Creator[:]}, pump.ProgramKey); err == nil {
 		accts.CreatorVault = pk
 	}
@@ -504,7 +523,7 @@ func pumpAutofillBuy(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.
 	return accts, nil
 }
 
-func pumpAutofillSell(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicKey) (pump.SellAccounts, error) {
+func pumpAutofillSell(ctx context.Context, rpc RPC, user, mint solana.PublicKey, options *Options) (pump.SellAccounts, error) {
 	var accts pump.SellAccounts
 
 	accts = pump.SellAccounts{
@@ -528,33 +547,17 @@ func pumpAutofillSell(ctx context.Context, rpc *sdkrpc.Client, user, mint solana
 		accts.FeeConfig = pk
 	}
 
-	// batch fetch required accounts (global, mint, bonding_curve)
-	addrs := []solana.PublicKey{accts.Global, accts.Mint, accts.BondingCurve}
-	amap, err := fetchAccountsBatch(ctx, rpc, addrs...)
+	globalState, bc, mintAcc, err := fetchPumpTradeState(ctx, rpc, accts.Global, mint, accts.BondingCurve, options)
 	if err != nil {
 		return accts, err
 	}
-
-	// parse global for fee recipient
-	globalAcc := amap[accts.Global.String()]
-	if globalAcc == nil || globalAcc.Data == nil {
-		return accts, fmt.Errorf("global account %s not found for mint %s", accts.Global, mint)
-	}
-	var globalState pump.Global
-	if err := globalState.Unmarshal(globalAcc.Data.GetBinary()); err != nil {
-		return accts, fmt.Errorf("decode global %s: %w", accts.Global, err)
-	}
-	feeRecipient := firstNonZeroPK(append(globalState.FeeRecipients[:], globalState.FeeRecipient))
-	if isZeroPK(feeRecipient) {
-		return accts, fmt.Errorf("fee recipient not found in global config for mint %s", mint)
+	feeRecipient, err := selectFeeRecipient(globalState.AcceptedFeeRecipients(bc.IsMayhemMode), options)
+	if err != nil {
+		return accts, fmt.Errorf("fee recipient for mint %s: %w", mint, err)
 	}
 	accts.FeeRecipient = feeRecipient
 
 	// identify token program from mint owner
-	mintAcc := amap[accts.Mint.String()]
-	if mintAcc == nil {
-		return accts, fmt.Errorf("mint account %s not found (may be invalid mint or RPC issue)", mint)
-	}
 	accts.TokenProgram = mintAcc.Owner
 
 	// derive user ATA
@@ -571,15 +574,7 @@ func pumpAutofillSell(ctx context.Context, rpc *sdkrpc.Client, user, mint solana
 	}
 	accts.AssociatedBondingCurve = assocBC
 
-	// parse bonding_curve for creator vault
-	bcAcc := amap[accts.BondingCurve.String()]
-	if bcAcc == nil || bcAcc.Data == nil {
-		return accts, fmt.Errorf("bonding_curve account %s not found for mint %s", accts.BondingCurve, mint)
-	}
-	var bc pump.BondingCurve
-	if err := bc.Unmarshal(bcAcc.Data.GetBinary()); err != nil {
-		return accts, fmt.Errorf("decode bonding_curve %s: %w", accts.BondingCurve, err)
-	}
+	// creator vault from the bonding curve's creator
 	if pk, _, err := solana.FindProgramAddress([][]byte{[]byte(constants.SeedCreatorVault), bc.Creator[:]}, pump.ProgramKey); err == nil {
 		accts.CreatorVault = pk
 	}
@@ -587,11 +582,70 @@ func pumpAutofillSell(ctx context.Context, rpc *sdkrpc.Client, user, mint solana
 	return accts, nil
 }
 
-func applyOverrides(target interface{}, m map[string]solana.PublicKey) {
-	if len(m) == 0 {
-		return
+// fetchPumpTradeState returns the Global and bonding curve a trade needs and
+// the mint account, reading in one batch whatever options do not inject.
+func fetchPumpTradeState(ctx context.Context, rpc RPC, global, mint, bondingCurve solana.PublicKey, options *Options) (pump.Global, pump.BondingCurve, *solanarpc.Account, error) {
+	var globalState pump.Global
+	var bc pump.BondingCurve
+
+	addrs := []solana.PublicKey{mint}
+	if options.PumpGlobal == nil {
+		addrs = append(addrs, global)
+	}
+	required := []solana.PublicKey{mint}
+	if options.BondingCurve == nil {
+		addrs = append(addrs, bondingCurve)
+		required = append(required, bondingCurve)
+	}
+	amap, err := fetchAccountsAwaiting(ctx, rpc, options, required, addrs...)
+	if err != nil {
+		return globalState, bc, nil, err
+	}
+
+	if options.PumpGlobal != nil {
+		globalState = *options.PumpGlobal
+	} else {
+		globalAcc := amap[global.String()]
+		if globalAcc == nil || globalAcc.Data == nil {
+			return globalState, bc, nil, fmt.Errorf("global account %s not found for mint %s", global, mint)
+		}
+		if err := globalState.Unmarshal(globalAcc.Data.GetBinary()); err != nil {
+			return globalState, bc, nil, fmt.Errorf("decode global %s: %w", global, err)
+		}
 	}
-	applyPubkeyOverrides(target, m) // ignore error: field mismatch will panic; ensure map keys are valid
+
+	if options.BondingCurve != nil {
+		bc = *options.BondingCurve
+	} else {
+		bcAcc := amap[bondingCurve.String()]
+		if bcAcc == nil || bcAcc.Data == nil {
+			if err := nodeLag(ctx, rpc); err != nil {
+				return globalState, bc, nil, fmt.Errorf("bonding_curve account %s not found for mint %s: %w", bondingCurve, mint, err)
+			}
+			return globalState, bc, nil, fmt.Errorf("bonding_curve account %s not found for mint %s", bondingCurve, mint)
+		}
+		if err := bc.Unmarshal(bcAcc.Data.GetBinary()); err != nil {
+			return globalState, bc, nil, fmt.Errorf("decode bonding_curve %s: %w", bondingCurve, err)
+		}
+	}
+
+	mintAcc := amap[mint.String()]
+	if mintAcc == nil {
+		return globalState, bc, nil, fmt.Errorf("mint account %s not found (may be invalid mint or RPC issue)", mint)
+	}
+	return globalState, bc, mintAcc, nil
+}
+
+// applyOverrides applies options.Overrides to an accounts struct, failing on
+// unknown keys when WithStrictOverrides is set.
+func applyOverrides(target interface{}, options *Options) error {
+	if len(options.Overrides) == 0 {
+		return nil
+	}
+	if err := applyPubkeyOverrides(target, options.Overrides, options.StrictOverrides); err != nil {
+		return fmt.Errorf("apply overrides: %w", err)
+	}
+	return nil
 }
 
 // PumpCreate creates a new SPL Token on Pump.fun bonding curve.
@@ -619,9 +673,9 @@ func applyOverrides(target interface{}, m map[string]solana.PublicKey) {
 //
 //	accts, args, ix, mintKey, err := autofill.PumpCreate(ctx, rpc, user, "My Token", "MTK", "https://...")
 //	// Sign with both user and mintKey
-func PumpCreate(ctx context.Context, rpc *sdkrpc.Client, user solana.PublicKey, name, symbol, uri string, opts ...Option) (pump.CreateAccounts, pump.CreateArgs, solana.Instruction, solana.PrivateKey, error) {
+func PumpCreate(ctx context.Context, rpc RPC, user solana.PublicKey, name, symbol, uri string, opts ...Option) (pump.CreateAccounts, pump.CreateArgs, solana.Instruction, solana.PrivateKey, error) {
 	// Input validation
-	if rpc == nil {
+	if isNilRPC(rpc) {
 		return pump.CreateAccounts{}, pump.CreateArgs{}, nil, nil, types.ErrNilRPC
 	}
 	if err := types.ValidatePublicKey("user", user); err != nil {
@@ -654,7 +708,9 @@ func PumpCreate(ctx context.Context, rpc *sdkrpc.Client, user solana.PublicKey,
 	if err != nil {
 		return pump.CreateAccounts{}, pump.CreateArgs{}, nil, nil, err
 	}
-	applyOverrides(&accts, options.Overrides)
+	if err := applyOverrides(&accts, options); err != nil {
+		return pump.CreateAccounts{}, pump.CreateArgs{}, nil, nil, err
+	}
 
 	// Build args (creator defaults to user)
 	args := pump.CreateArgs{
@@ -669,13 +725,7 @@ func PumpCreate(ctx context.Context, rpc *sdkrpc.Client, user solana.PublicKey,
 		return pump.CreateAccounts{}, pump.CreateArgs{}, nil, nil, err
 	}
 
-	if options.Preview != nil {
-		_ = json.NewEncoder(options.Preview).Encode(struct {
-			Accounts pump.CreateAccounts `json:"accounts"`
-			Args     pump.CreateArgs     `json:"args"`
-			Mint     string              `json:"mint"`
-		}{accts, args, mint.String()})
-	}
+	writePreview(options, Preview{Accounts: accts, Args: args, Mint: mint.String()}, ix)
 
 	return accts, args, ix, mintKey, nil
 }
@@ -708,8 +758,8 @@ func generateMintKey(ctx context.Context, options *Options) (solana.PrivateKey,
 //
 // Use this when you want to control the mint address (e.g., for vanity addresses).
 // For Token-2022 tokens, use PumpCreateV2WithMint instead.
-func PumpCreateWithMint(ctx context.Context, rpc *sdkrpc.Client, user solana.PublicKey, mintKey solana.PrivateKey, name, symbol, uri string, opts ...Option) (pump.CreateAccounts, pump.CreateArgs, solana.Instruction, error) {
-	if rpc == nil {
+func PumpCreateWithMint(ctx context.Context, rpc RPC, user solana.PublicKey, mintKey solana.PrivateKey, name, symbol, uri string, opts ...Option) (pump.CreateAccounts, pump.CreateArgs, solana.Instruction, error) {
+	if isNilRPC(rpc) {
 		return pump.CreateAccounts{}, pump.CreateArgs{}, nil, types.ErrNilRPC
 	}
 	if err := types.ValidatePublicKey("user", user); err != nil {
@@ -738,7 +788,9 @@ func PumpCreateWithMint(ctx context.Context, rpc *sdkrpc.Client, user solana.Pub
 	if err != nil {
 		return pump.CreateAccounts{}, pump.CreateArgs{}, nil, err
 	}
-	applyOverrides(&accts, options.Overrides)
+	if err := applyOverrides(&accts, options); err != nil {
+		return pump.CreateAccounts{}, pump.CreateArgs{}, nil, err
+	}
 
 	args := pump.CreateArgs{
 		Name:    name,
@@ -756,7 +808,7 @@ func PumpCreateWithMint(ctx context.Context, rpc *sdkrpc.Client, user solana.Pub
 }
 
 // pumpAutofillCreate auto-fills accounts for create instruction (SPL Token).
-func pumpAutofillCreate(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicKey) (pump.CreateAccounts, error) {
+func pumpAutofillCreate(ctx context.Context, rpc RPC, user, mint solana.PublicKey) (pump.CreateAccounts, error) {
 	accts := pump.CreateAccounts{
 		Mint:                   mint,
 		User:                   user,
@@ -836,8 +888,8 @@ var MayhemProgramID = solana.MustPublicKeyFromBase58("MAyhSmzXzV1pTf7LsNkrNwkWKT
 //   - solana.Instruction: the create_v2 instruction
 //   - solana.PrivateKey: the generated mint keypair (must be added as signer)
 //   - error: validation or RPC errors
-func PumpCreateV2(ctx context.Context, rpc *sdkrpc.Client, user solana.PublicKey, name, symbol, uri string, isMayhemMode bool, opts ...Option) (pump.CreateV2Accounts, pump.CreateV2Args, solana.Instruction, solana.PrivateKey, error) {
-	if rpc == nil {
+func PumpCreateV2(ctx context.Context, rpc RPC, user solana.PublicKey, name, symbol, uri string, isMayhemMode bool, opts ...Option) (pump.CreateV2Accounts, pump.CreateV2Args, solana.Instruction, solana.PrivateKey, error) {
+	if isNilRPC(rpc) {
 		return pump.CreateV2Accounts{}, pump.CreateV2Args{}, nil, nil, types.ErrNilRPC
 	}
 	if err := types.ValidatePublicKey("user", user); err != nil {
@@ -870,7 +922,9 @@ func PumpCreateV2(ctx context.Context, rpc *sdkrpc.Client, user solana.PublicKey
 	if err != nil {
 		return pump.CreateV2Accounts{}, pump.CreateV2Args{}, nil, nil, err
 	}
-	applyOverrides(&accts, options.Overrides)
+	if err := applyOverrides(&accts, options); err != nil {
+		return pump.CreateV2Accounts{}, pump.CreateV2Args{}, nil, nil, err
+	}
 
 	args := pump.CreateV2Args{
 		Name:         name,
@@ -885,20 +939,14 @@ func PumpCreateV2(ctx context.Context, rpc *sdkrpc.Client, user solana.PublicKey
 		return pump.CreateV2Accounts{}, pump.CreateV2Args{}, nil, nil, err
 	}
 
-	if options.Preview != nil {
-		_ = json.NewEncoder(options.Preview).Encode(struct {
-			Accounts pump.CreateV2Accounts `json:"accounts"`
-			Args     pump.CreateV2Args     `json:"args"`
-			Mint     string                `json:"mint"`
-		}{accts, args, mint.String()})
-	}
+	writePreview(options, Preview{Accounts: accts, Args: args, Mint: mint.String()}, ix)
 
 	return accts, args, ix, mintKey, nil
 }
 
 // PumpCreateV2WithMint creates a Token-2022 token with a pre-generated mint keypair.
-func PumpCreateV2WithMint(ctx context.Context, rpc *sdkrpc.Client, user solana.PublicKey, mintKey solana.PrivateKey, name, symbol, uri string, isMayhemMode bool, opts ...Option) (pump.CreateV2Accounts, pump.CreateV2Args, solana.Instruction, error) {
-	if rpc == nil {
+func PumpCreateV2WithMint(ctx context.Context, rpc RPC, user solana.PublicKey, mintKey solana.PrivateKey, name, symbol, uri string, isMayhemMode bool, opts ...Option) (pump.CreateV2Accounts, pump.CreateV2Args, solana.Instruction, error) {
+	if isNilRPC(rpc) {
 		return pump.CreateV2Accounts{}, pump.CreateV2Args{}, nil, types.ErrNilRPC
 	}
 	if err := types.ValidatePublicKey("user", user); err != nil {
@@ -927,7 +975,9 @@ func PumpCreateV2WithMint(ctx context.Context, rpc *sdkrpc.Client, user solana.P
 	if err != nil {
 		return pump.CreateV2Accounts{}, pump.CreateV2Args{}, nil, err
 	}
-	applyOverrides(&accts, options.Overrides)
+	if err := applyOverrides(&accts, options); err != nil {
+		return pump.CreateV2Accounts{}, pump.CreateV2Args{}, nil, err
+	}
 
 	args := pump.CreateV2Args{
 		Name:         name,
@@ -946,7 +996,7 @@ func PumpCreateV2WithMint(ctx context.Context, rpc *sdkrpc.Client, user solana.P
 }
 
 // pumpAutofillCreateV2 auto-fills accounts for create_v2 instruction (Token-2022).
-func pumpAutofillCreateV2(ctx context.Context, rpc *sdkrpc.Client, user, mint solana.PublicKey) (pump.CreateV2Accounts, error) {
+func pumpAutofillCreateV2(ctx context.Context, rpc RPC, user, mint solana.PublicKey) (pump.CreateV2Accounts, error) {
 	accts := pump.CreateV2Accounts{
 		Mint:                   mint,
 		User:                   user,
@@ -1007,16 +1057,26 @@ func pumpAutofillCreateV2(ctx context.Context, rpc *sdkrpc.Client, user, mint so
 	return accts, nil
 }
 
-// finalizeInstructionsPump adds Compute Budget (prepend) and Jito tip (append) instructions.
-func finalizeInstructionsPump(instrs []solana.Instruction, from solana.PublicKey, options *Options) []solana.Instruction {
+// finalizeInstructionsPump adds Compute Budget (prepend) and Jito tip (append) instructions,
+// splicing in caller instructions and the referral transfer sized from solAmount.
+func finalizeInstructionsPump(instrs []solana.Instruction, from solana.PublicKey, solAmount uint64, options *Options) ([]solana.Instruction, error) {
 	if options == nil {
-		return instrs
+		return instrs, nil
+	}
+	// Splice caller instructions around the trade, inside the compute budget and tip
+	instrs = spliceInstructions(instrs, *options)
+	referral, err := buildReferral(from, solAmount, *options)
+	if err != nil {
+		return nil, err
+	}
+	if referral != nil {
+		instrs = append(instrs, referral)
 	}
 	// Prepend Compute Budget instructions (priority fee, compute limit)
 	instrs = prependComputeBudget(instrs, *options)
 
-	// Append Jito tip if configured
-	if options.JitoTipLamports > 0 {
+	// Append Jito tip if configured, unless it travels in its own transaction
+	if options.JitoTipLamports > 0 && options.JitoBundleTip == 0 {
 		tipAccount := options.JitoTipAccount
 		if tipAccount.IsZero() {
 			tipAccount = jito.GetRandomTipAccountLocal()
@@ -1024,5 +1084,5 @@ func finalizeInstructionsPump(instrs []solana.Instruction, from solana.PublicKey
 		tipIx := system.NewTransferInstruction(options.JitoTipLamports, from, tipAccount).Build()
 		instrs = append(instrs, tipIx)
 	}
-	return instrs
+	return instrs, nil
 }
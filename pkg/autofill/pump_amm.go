@@ -2,9 +2,8 @@ package autofill
 
 import (
 	"context"
-	"encoding/json"
+	"encoding/binary"
 	"fmt"
-	"strings"
 
 	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
@@ -13,8 +12,8 @@ import (
 	solanarpc "github.com/gagliardetto/solana-go/rpc"
 
 	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/curve"
 	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
-	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
 	"github.com/ninja0404/pump-go-sdk/pkg/txbuilder"
 	"github.com/ninja0404/pump-go-sdk/pkg/types"
 	"github.com/ninja0404/pump-go-sdk/pkg/wallet"
@@ -42,7 +41,8 @@ import (
 //   - BuyExactQuoteInArgs: instruction args with calculated minBaseOut
 //   - []Instruction: complete instruction set
 //   - uint64: simulated base token output (before slippage)
-//   - error: validation or execution errors
+//   - error: validation or execution errors; types.ErrQuoteNotWSOL if the
+//     pool is not quoted in WSOL
 //
 // Example:
 //
@@ -50,14 +50,14 @@ import (
 //	accts, args, instrs, simOut, err := autofill.PumpAmmBuyWithSol(ctx, rpc, user, pool, 10_000_000, 100)
 func PumpAmmBuyWithSol(
 	ctx context.Context,
-	rpc *sdkrpc.Client,
+	rpc RPC,
 	user, pool solana.PublicKey,
 	quoteLamports uint64,
 	slippageBps uint64,
 	opts ...Option,
 ) (pumpamm.BuyExactQuoteInAccounts, pumpamm.BuyExactQuoteInArgs, []solana.Instruction, uint64, error) {
 	// Input validation
-	if rpc == nil {
+	if isNilRPC(rpc) {
 		return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, 0, types.ErrNilRPC
 	}
 	if err := types.ValidatePublicKey("user", user); err != nil {
@@ -78,20 +78,24 @@ func PumpAmmBuyWithSol(
 		opt(options)
 	}
 
-	buyAccts, err := pumpAmmAutofillBuy(ctx, rpc, user, pool)
+	buyAccts, err := pumpAmmAutofillBuy(ctx, rpc, user, pool, options)
 	if err != nil {
 		return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, 0, err
 	}
 	exactAccts := toBuyExactAccounts(buyAccts)
-	applyOverrides(&exactAccts, options.Overrides)
+	if err := applyOverrides(&exactAccts, options); err != nil {
+		return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, 0, err
+	}
+	if !isWSOL(exactAccts.QuoteMint, exactAccts.QuoteTokenProgram) {
+		return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, 0, fmt.Errorf("%w: pool %s is quoted in %s; use PumpAmmBuyExactQuoteIn or PumpAmmBuy with that token", types.ErrQuoteNotWSOL, pool, exactAccts.QuoteMint)
+	}
 
 	// 批量检查 ATA 是否存在（同时获取余额）
 	ataReqs := []ataRequest{
 		{Payer: exactAccts.User, Wallet: exactAccts.User, Mint: exactAccts.BaseMint, TokenProgram: exactAccts.BaseTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
 		{Payer: exactAccts.User, Wallet: exactAccts.User, Mint: exactAccts.QuoteMint, TokenProgram: exactAccts.QuoteTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
-		{Payer: exactAccts.User, Wallet: exactAccts.ProtocolFeeRecipient, Mint: exactAccts.QuoteMint, TokenProgram: exactAccts.QuoteTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
-		{Payer: exactAccts.User, Wallet: exactAccts.CoinCreatorVaultAuthority, Mint: exactAccts.QuoteMint, TokenProgram: exactAccts.QuoteTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
 	}
+	ataReqs = append(ataReqs, ammFeeATARequests(exactAccts.User, exactAccts.ProtocolFeeRecipient, exactAccts.CoinCreatorVaultAuthority, exactAccts.QuoteMint, exactAccts.QuoteTokenProgram)...)
 	ataResult, err := ensureATABatchWithBalances(ctx, rpc, ataReqs)
 	if err != nil {
 		return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, 0, err
@@ -101,9 +105,7 @@ func PumpAmmBuyWithSol(
 	initialBase := ataResult.Balances[exactAccts.UserBaseTokenAccount.String()]
 
 	// 自动 wrap SOL -> WSOL，仅补足差额
-	if isWSOL(exactAccts.QuoteMint, exactAccts.QuoteTokenProgram) && quoteLamports > existingQuote {
-		instrs = append(instrs, buildWrapWSOL(exactAccts.User, exactAccts.UserQuoteTokenAccount, quoteLamports-existingQuote)...)
-	}
+	instrs = append(instrs, wrapWSOLShortfall(exactAccts.User, exactAccts.UserQuoteTokenAccount, existingQuote, quoteLamports)...)
 
 	// 先模拟：用 min_base=1 估算 base_out
 	simArgs := pumpamm.BuyExactQuoteInArgs{
@@ -115,12 +117,17 @@ func PumpAmmBuyWithSol(
 	if err != nil {
 		return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, 0, err
 	}
-	baseOutSim, err := simulateBaseOut(ctx, rpc, user, exactAccts.UserBaseTokenAccount, initialBase, append(instrs, simIx)...)
+	var baseOutSim uint64
+	if !options.DryRun {
+		if baseOutSim, err = simulateBaseOut(ctx, rpc, options.SimulationCommitment, user, exactAccts.UserBaseTokenAccount, initialBase, append(instrs, simIx)...); err != nil {
+			return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, 0, err
+		}
+	}
+
+	minBaseOut, err := slippageMin(baseOutSim, slippageBps, options)
 	if err != nil {
 		return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, 0, err
 	}
-
-	minBaseOut := applySlippage(baseOutSim, slippageBps)
 	finalArgs := pumpamm.BuyExactQuoteInArgs{
 		SpendableQuoteIn: quoteLamports,
 		MinBaseAmountOut: minBaseOut,
@@ -131,18 +138,17 @@ func PumpAmmBuyWithSol(
 		return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, 0, err
 	}
 	instrs = append(instrs, finalIx)
-	// Auto unwrap if user receives WSOL
-	if exactAccts.BaseMint == constants.WSOLMint {
-		instrs = append(instrs, buildCloseAccount(exactAccts.UserBaseTokenAccount, exactAccts.User, exactAccts.User, exactAccts.BaseTokenProgram))
-	}
 	// Finalize: prepend Compute Budget, append Jito tip
-	instrs = finalizeInstructions(instrs, user, options)
-	if options.Preview != nil {
-		_ = json.NewEncoder(options.Preview).Encode(struct {
-			Accounts      pumpamm.BuyExactQuoteInAccounts `json:"accounts"`
-			Args          pumpamm.BuyExactQuoteInArgs     `json:"args"`
-			SimulatedBase uint64                          `json:"simulated_base_out"`
-		}{exactAccts, finalArgs, baseOutSim})
+	instrs, err = finalizeInstructions(instrs, user, quoteLamports, options)
+	if err != nil {
+		return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, 0, err
+	}
+	if wantsPreview(options) {
+		p := Preview{Accounts: exactAccts, Args: finalArgs, SimulatedBase: ptr(baseOutSim), ExpectedOut: ptr(baseOutSim), MinOut: minBaseOut}
+		if _, quote, ok := previewPoolReserves(ctx, rpc, exactAccts.PoolBaseTokenAccount, exactAccts.PoolQuoteTokenAccount); ok {
+			p.PriceImpactBps = impactExactIn(quote, quoteLamports)
+		}
+		writePreview(options, p, instrs...)
 	}
 	return exactAccts, finalArgs, instrs, baseOutSim, nil
 }
@@ -164,14 +170,14 @@ func PumpAmmBuyWithSol(
 // Returns accounts, args, instructions, and any error.
 func PumpAmmBuyExactQuoteIn(
 	ctx context.Context,
-	rpc *sdkrpc.Client,
+	rpc RPC,
 	user, pool solana.PublicKey,
 	quoteLamports uint64,
 	minBaseOut uint64,
 	opts ...Option,
 ) (pumpamm.BuyExactQuoteInAccounts, pumpamm.BuyExactQuoteInArgs, []solana.Instruction, error) {
 	// Input validation
-	if rpc == nil {
+	if isNilRPC(rpc) {
 		return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, types.ErrNilRPC
 	}
 	if err := types.ValidatePublicKey("user", user); err != nil {
@@ -189,20 +195,21 @@ func PumpAmmBuyExactQuoteIn(
 		opt(options)
 	}
 
-	buyAccts, err := pumpAmmAutofillBuy(ctx, rpc, user, pool)
+	buyAccts, err := pumpAmmAutofillBuy(ctx, rpc, user, pool, options)
 	if err != nil {
 		return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, err
 	}
 	exactAccts := toBuyExactAccounts(buyAccts)
-	applyOverrides(&exactAccts, options.Overrides)
+	if err := applyOverrides(&exactAccts, options); err != nil {
+		return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, err
+	}
 
 	// 批量检查 ATA 是否存在（同时获取余额）
 	ataReqs := []ataRequest{
 		{Payer: exactAccts.User, Wallet: exactAccts.User, Mint: exactAccts.BaseMint, TokenProgram: exactAccts.BaseTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
 		{Payer: exactAccts.User, Wallet: exactAccts.User, Mint: exactAccts.QuoteMint, TokenProgram: exactAccts.QuoteTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
-		{Payer: exactAccts.User, Wallet: exactAccts.ProtocolFeeRecipient, Mint: exactAccts.QuoteMint, TokenProgram: exactAccts.QuoteTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
-		{Payer: exactAccts.User, Wallet: exactAccts.CoinCreatorVaultAuthority, Mint: exactAccts.QuoteMint, TokenProgram: exactAccts.QuoteTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
 	}
+	ataReqs = append(ataReqs, ammFeeATARequests(exactAccts.User, exactAccts.ProtocolFeeRecipient, exactAccts.CoinCreatorVaultAuthority, exactAccts.QuoteMint, exactAccts.QuoteTokenProgram)...)
 	ataResult, err := ensureATABatchWithBalances(ctx, rpc, ataReqs)
 	if err != nil {
 		return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, err
@@ -210,12 +217,9 @@ func PumpAmmBuyExactQuoteIn(
 	instrs := ataResult.Instructions
 
 	// 自动 wrap SOL -> WSOL，仅补足差额（使用批量查询的余额）
-	if isWSOL(exactAccts.QuoteMint, exactAccts.QuoteTokenProgram) && quoteLamports > 0 {
+	if isWSOL(exactAccts.QuoteMint, exactAccts.QuoteTokenProgram) {
 		existing := ataResult.Balances[exactAccts.UserQuoteTokenAccount.String()]
-		if quoteLamports > existing {
-			wrapLamports := quoteLamports - existing
-			instrs = append(instrs, buildWrapWSOL(exactAccts.User, exactAccts.UserQuoteTokenAccount, wrapLamports)...)
-		}
+		instrs = append(instrs, wrapWSOLShortfall(exactAccts.User, exactAccts.UserQuoteTokenAccount, existing, quoteLamports)...)
 	}
 
 	args := pumpamm.BuyExactQuoteInArgs{
@@ -233,13 +237,17 @@ func PumpAmmBuyExactQuoteIn(
 		instrs = append(instrs, buildCloseAccount(exactAccts.UserBaseTokenAccount, exactAccts.User, exactAccts.User, exactAccts.BaseTokenProgram))
 	}
 	// Finalize: prepend Compute Budget, append Jito tip
-	instrs = finalizeInstructions(instrs, user, options)
+	instrs, err = finalizeInstructions(instrs, user, solSide(exactAccts.QuoteMint, quoteLamports), options)
+	if err != nil {
+		return pumpamm.BuyExactQuoteInAccounts{}, pumpamm.BuyExactQuoteInArgs{}, nil, err
+	}
 
-	if options.Preview != nil {
-		_ = json.NewEncoder(options.Preview).Encode(struct {
-			Accounts pumpamm.BuyExactQuoteInAccounts `json:"accounts"`
-			Args     pumpamm.BuyExactQuoteInArgs     `json:"args"`
-		}{exactAccts, args})
+	if wantsPreview(options) {
+		p := Preview{Accounts: exactAccts, Args: args, MinOut: args.MinBaseAmountOut}
+		if _, quote, ok := previewPoolReserves(ctx, rpc, exactAccts.PoolBaseTokenAccount, exactAccts.PoolQuoteTokenAccount); ok {
+			p.PriceImpactBps = impactExactIn(quote, quoteLamports)
+		}
+		writePreview(options, p, instrs...)
 	}
 	return exactAccts, args, instrs, nil
 }
@@ -259,9 +267,9 @@ func PumpAmmBuyExactQuoteIn(
 //   - opts: optional configurations
 //
 // Returns accounts, args, instructions, and any error.
-func PumpAmmBuy(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.PublicKey, baseOut, maxQuoteIn uint64, opts ...Option) (pumpamm.BuyAccounts, pumpamm.BuyArgs, []solana.Instruction, error) {
+func PumpAmmBuy(ctx context.Context, rpc RPC, user, pool solana.PublicKey, baseOut, maxQuoteIn uint64, opts ...Option) (pumpamm.BuyAccounts, pumpamm.BuyArgs, []solana.Instruction, error) {
 	// Input validation
-	if rpc == nil {
+	if isNilRPC(rpc) {
 		return pumpamm.BuyAccounts{}, pumpamm.BuyArgs{}, nil, types.ErrNilRPC
 	}
 	if err := types.ValidatePublicKey("user", user); err != nil {
@@ -278,11 +286,13 @@ func PumpAmmBuy(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.Publi
 	for _, opt := range opts {
 		opt(options)
 	}
-	accts, err := pumpAmmAutofillBuy(ctx, rpc, user, pool)
+	accts, err := pumpAmmAutofillBuy(ctx, rpc, user, pool, options)
 	if err != nil {
 		return pumpamm.BuyAccounts{}, pumpamm.BuyArgs{}, nil, err
 	}
-	applyOverrides(&accts, options.Overrides)
+	if err := applyOverrides(&accts, options); err != nil {
+		return pumpamm.BuyAccounts{}, pumpamm.BuyArgs{}, nil, err
+	}
 
 	args := pumpamm.BuyArgs{
 		BaseAmountOut:    baseOut,
@@ -294,9 +304,8 @@ func PumpAmmBuy(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.Publi
 	ataReqs := []ataRequest{
 		{Payer: user, Wallet: user, Mint: accts.BaseMint, TokenProgram: accts.BaseTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
 		{Payer: user, Wallet: user, Mint: accts.QuoteMint, TokenProgram: accts.QuoteTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
-		{Payer: user, Wallet: accts.ProtocolFeeRecipient, Mint: accts.QuoteMint, TokenProgram: accts.QuoteTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
-		{Payer: user, Wallet: accts.CoinCreatorVaultAuthority, Mint: accts.QuoteMint, TokenProgram: accts.QuoteTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
 	}
+	ataReqs = append(ataReqs, ammFeeATARequests(user, accts.ProtocolFeeRecipient, accts.CoinCreatorVaultAuthority, accts.QuoteMint, accts.QuoteTokenProgram)...)
 	ataResult, err := ensureATABatchWithBalances(ctx, rpc, ataReqs)
 	if err != nil {
 		return pumpamm.BuyAccounts{}, pumpamm.BuyArgs{}, nil, err
@@ -306,19 +315,19 @@ func PumpAmmBuy(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.Publi
 
 	// 模拟交易以获取实际需要的 quote 数量（无需签名）
 	var actualQuoteNeeded uint64
-	if accts.QuoteMint == constants.WSOLMint && accts.QuoteTokenProgram == constants.TokenProgramID {
+	if options.DryRun {
+		actualQuoteNeeded = maxQuoteIn
+	} else if isWSOL(accts.QuoteMint, accts.QuoteTokenProgram) {
 		simInstrs := append([]solana.Instruction{}, ensureInstrs...)
-		if maxQuoteIn > existingQuote {
-			simInstrs = append(simInstrs, buildWrapWSOL(user, accts.UserQuoteTokenAccount, maxQuoteIn-existingQuote)...)
-		}
+		simInstrs = append(simInstrs, wrapWSOLShortfall(user, accts.UserQuoteTokenAccount, existingQuote, maxQuoteIn)...)
 		simIx, err := pumpamm.BuildBuy(accts, args)
 		if err != nil {
 			return pumpamm.BuyAccounts{}, pumpamm.BuyArgs{}, nil, err
 		}
 		simInstrs = append(simInstrs, simIx)
 
-		preBalance := existingQuote + (maxQuoteIn - existingQuote)
-		quoteConsumed, err := simulateQuoteConsumedNoSign(ctx, rpc, user, accts.UserQuoteTokenAccount, preBalance, simInstrs...)
+		preBalance := max(existingQuote, maxQuoteIn)
+		quoteConsumed, err := simulateQuoteConsumedNoSign(ctx, rpc, options.SimulationCommitment, user, accts.UserQuoteTokenAccount, preBalance, simInstrs...)
 		if err != nil {
 			return pumpamm.BuyAccounts{}, pumpamm.BuyArgs{}, nil, fmt.Errorf("simulate quote consumed: %w", err)
 		}
@@ -328,11 +337,8 @@ func PumpAmmBuy(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.Publi
 	// 构建最终指令：只 wrap 实际需要的金额
 	var instrs []solana.Instruction
 	instrs = append(instrs, ensureInstrs...)
-	if accts.QuoteMint == constants.WSOLMint && accts.QuoteTokenProgram == constants.TokenProgramID && actualQuoteNeeded > 0 {
-		if actualQuoteNeeded > existingQuote {
-			wrapLamports := actualQuoteNeeded - existingQuote
-			instrs = append(instrs, buildWrapWSOL(user, accts.UserQuoteTokenAccount, wrapLamports)...)
-		}
+	if isWSOL(accts.QuoteMint, accts.QuoteTokenProgram) {
+		instrs = append(instrs, wrapWSOLShortfall(user, accts.UserQuoteTokenAccount, existingQuote, actualQuoteNeeded)...)
 	}
 
 	ix, err := pumpamm.BuildBuy(accts, args)
@@ -350,13 +356,17 @@ func PumpAmmBuy(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.Publi
 		instrs = append(instrs, buildCloseAccount(accts.UserQuoteTokenAccount, user, user, accts.QuoteTokenProgram))
 	}
 	// Finalize: prepend Compute Budget, append Jito tip
-	instrs = finalizeInstructions(instrs, user, options)
+	instrs, err = finalizeInstructions(instrs, user, solSide(accts.QuoteMint, maxQuoteIn), options)
+	if err != nil {
+		return pumpamm.BuyAccounts{}, pumpamm.BuyArgs{}, nil, err
+	}
 
-	if options.Preview != nil {
-		_ = json.NewEncoder(options.Preview).Encode(struct {
-			Accounts pumpamm.BuyAccounts `json:"accounts"`
-			Args     pumpamm.BuyArgs     `json:"args"`
-		}{accts, args})
+	if wantsPreview(options) {
+		p := Preview{Accounts: accts, Args: args, ExpectedOut: ptr(baseOut), MinOut: baseOut}
+		if base, _, ok := previewPoolReserves(ctx, rpc, accts.PoolBaseTokenAccount, accts.PoolQuoteTokenAccount); ok {
+			p.PriceImpactBps = impactExactOut(base, baseOut)
+		}
+		writePreview(options, p, instrs...)
 	}
 	return accts, args, instrs, nil
 }
@@ -376,9 +386,9 @@ func PumpAmmBuy(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.Publi
 //   - opts: optional configurations
 //
 // Returns accounts, args, single instruction, and any error.
-func PumpAmmSell(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.PublicKey, baseIn, minQuoteOut uint64, opts ...Option) (pumpamm.SellAccounts, pumpamm.SellArgs, solana.Instruction, error) {
+func PumpAmmSell(ctx context.Context, rpc RPC, user, pool solana.PublicKey, baseIn, minQuoteOut uint64, opts ...Option) (pumpamm.SellAccounts, pumpamm.SellArgs, solana.Instruction, error) {
 	// Input validation
-	if rpc == nil {
+	if isNilRPC(rpc) {
 		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, types.ErrNilRPC
 	}
 	if err := types.ValidatePublicKey("user", user); err != nil {
@@ -392,11 +402,13 @@ func PumpAmmSell(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.Publ
 	for _, opt := range opts {
 		opt(options)
 	}
-	accts, err := pumpAmmAutofillSell(ctx, rpc, user, pool)
+	accts, err := pumpAmmAutofillSell(ctx, rpc, user, pool, options)
 	if err != nil {
 		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, err
 	}
-	applyOverrides(&accts, options.Overrides)
+	if err := applyOverrides(&accts, options); err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, err
+	}
 
 	args := pumpamm.SellArgs{
 		BaseAmountIn:      baseIn,
@@ -407,11 +419,12 @@ func PumpAmmSell(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.Publ
 	if err != nil {
 		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, err
 	}
-	if options.Preview != nil {
-		_ = json.NewEncoder(options.Preview).Encode(struct {
-			Accounts pumpamm.SellAccounts `json:"accounts"`
-			Args     pumpamm.SellArgs     `json:"args"`
-		}{accts, args})
+	if wantsPreview(options) {
+		p := Preview{Accounts: accts, Args: args, MinOut: minQuoteOut}
+		if base, _, ok := previewPoolReserves(ctx, rpc, accts.PoolBaseTokenAccount, accts.PoolQuoteTokenAccount); ok {
+			p.PriceImpactBps = impactExactIn(base, baseIn)
+		}
+		writePreview(options, p, ix)
 	}
 	return accts, args, ix, nil
 }
@@ -421,6 +434,7 @@ func PumpAmmSell(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.Publ
 // This is the recommended high-level function for AMM sells. It:
 //   - Simulates the swap to estimate quote (SOL) output
 //   - Applies slippage to calculate minimum acceptable quote
+//   - Creates the protocol fee and coin creator vault quote ATAs if missing
 //   - Closes the base token ATA if selling all tokens (reclaims rent)
 //   - Unwraps WSOL to SOL if quote is WSOL
 //
@@ -439,9 +453,9 @@ func PumpAmmSell(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.Publ
 //
 //	// Sell 1M tokens with 1% slippage
 //	accts, args, instrs, err := autofill.PumpAmmSellWithSlippage(ctx, rpc, user, pool, 1_000_000, 100)
-func PumpAmmSellWithSlippage(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.PublicKey, baseIn uint64, slippageBps uint64, opts ...Option) (pumpamm.SellAccounts, pumpamm.SellArgs, []solana.Instruction, error) {
+func PumpAmmSellWithSlippage(ctx context.Context, rpc RPC, user, pool solana.PublicKey, baseIn uint64, slippageBps uint64, opts ...Option) (pumpamm.SellAccounts, pumpamm.SellArgs, []solana.Instruction, error) {
 	// Input validation
-	if rpc == nil {
+	if isNilRPC(rpc) {
 		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, types.ErrNilRPC
 	}
 	if err := types.ValidatePublicKey("user", user); err != nil {
@@ -467,44 +481,43 @@ func PumpAmmSellWithSlippage(ctx context.Context, rpc *sdkrpc.Client, user, pool
 		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, err
 	}
 
-	// Build known ATA set for fast lookup
-	knownATASet := make(map[string]bool, len(options.KnownATAs))
-	for _, ata := range options.KnownATAs {
-		knownATASet[ata.String()] = true
-	}
-
-	// Build ATA requests, skipping known ATAs
-	var ataReqs []ataRequest
-	if !knownATASet[accts.UserQuoteTokenAccount.String()] {
-		ataReqs = append(ataReqs, ataRequest{Payer: user, Wallet: accts.User, Mint: accts.QuoteMint, TokenProgram: accts.QuoteTokenProgram, ATAProgram: constants.AssociatedTokenProgramID})
-	}
-	if !knownATASet[accts.UserBaseTokenAccount.String()] {
-		ataReqs = append(ataReqs, ataRequest{Payer: user, Wallet: accts.User, Mint: accts.BaseMint, TokenProgram: accts.BaseTokenProgram, ATAProgram: constants.AssociatedTokenProgramID})
+	ataReqs, err := ammSellATARequests(user, accts, options)
+	if err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, err
 	}
-
-	var ensureInstrs []solana.Instruction
-	if len(ataReqs) > 0 {
-		ataResult, err := ensureATABatchWithBalances(ctx, rpc, ataReqs)
-		if err != nil {
-			return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, err
-		}
-		ensureInstrs = ataResult.Instructions
+	ataResult, err := ensureATABatchWithBalances(ctx, rpc, ataReqs)
+	if err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, err
 	}
+	ensureInstrs := ataResult.Instructions
 
 	// Get expected quote output (simulate or use provided value)
 	var quoteOut uint64
 	if options.ExpectedQuoteOut > 0 {
 		// Skip simulation, use provided expected output
 		quoteOut = options.ExpectedQuoteOut
-	} else {
+	} else if !options.DryRun {
 		// Simulate to get expected output
 		var err error
-		quoteOut, err = simulateAmmQuoteOut(ctx, rpc, user, accts, baseIn, append(ensureInstrs, errIx)...)
+		quoteOut, err = simulateAmmQuoteOut(ctx, rpc, options.SimulationCommitment, user, accts, baseIn, append(ensureInstrs, errIx)...)
 		if err != nil {
 			return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, err
 		}
 	}
-	minQuote := applySlippage(quoteOut, slippageBps)
+	quoteOut, err = adjustForTransferFee(ctx, rpc, options, accts.BaseMint, accts.BaseTokenProgram, baseIn, quoteOut)
+	if err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, err
+	}
+	minQuote, err := slippageMin(quoteOut, slippageBps, options)
+	if err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, err
+	}
+	var refund uint64
+	if accts.QuoteMint == constants.WSOLMint {
+		if refund, err = rentRefund(ctx, rpc, options, accts.UserBaseTokenAccount); err != nil {
+			return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, err
+		}
+	}
 
 	args := pumpamm.SellArgs{
 		BaseAmountIn:      baseIn,
@@ -525,28 +538,153 @@ func PumpAmmSellWithSlippage(ctx context.Context, rpc *sdkrpc.Client, user, pool
 		instrs = append(instrs, buildCloseAccount(accts.UserQuoteTokenAccount, user, user, accts.QuoteTokenProgram))
 	}
 	// Finalize: prepend Compute Budget, append Jito tip
-	instrs = finalizeInstructions(instrs, user, options)
+	instrs, err = finalizeInstructions(instrs, user, solSide(accts.QuoteMint, minQuote), options)
+	if err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, err
+	}
 
-	if options.Preview != nil {
-		_ = json.NewEncoder(options.Preview).Encode(struct {
-			Accounts pumpamm.SellAccounts `json:"accounts"`
-			Args     pumpamm.SellArgs     `json:"args"`
-		}{accts, args})
+	if wantsPreview(options) {
+		p := Preview{Accounts: accts, Args: args, ExpectedOut: ptr(quoteOut + refund), MinOut: minQuote}
+		if options.IncludeRentRefund && options.CloseBaseATA && accts.QuoteMint == constants.WSOLMint {
+			p.RentRefund = ptr(refund)
+		}
+		if base, _, ok := previewPoolReserves(ctx, rpc, accts.PoolBaseTokenAccount, accts.PoolQuoteTokenAccount); ok {
+			p.PriceImpactBps = impactExactIn(base, baseIn)
+		}
+		writePreview(options, p, instrs...)
 	}
 	return accts, args, instrs, nil
 }
 
-// BuildAndSendAmm executes the instruction with provided signer/txbuilder.
-func BuildAndSendAmm(ctx context.Context, builder *txbuilder.Builder, signer wallet.Signer, ix solana.Instruction) (solana.Signature, error) {
-	if builder == nil || signer == nil {
-		return solana.Signature{}, fmt.Errorf("builder and signer are required")
+// PumpAmmSellAllForSol sells the user's entire base balance in a WSOL-quoted
+// pool and returns the proceeds as native SOL.
+//
+// It reads the base token balance, sells all of it with slippage protection,
+// closes the base ATA (reclaiming rent) and unwraps the received WSOL. The
+// sale is simulated once, which both prices it and gives netSol: the
+// resulting change of the user's SOL balance (after network fees, rent
+// refunds and unwrap).
+//
+// Example:
+//
+//	// Dump everything to SOL with 1% slippage
+//	_, _, instrs, netSol, err := autofill.PumpAmmSellAllForSol(ctx, rpc, user, pool, 100)
+func PumpAmmSellAllForSol(ctx context.Context, rpc RPC, user, pool solana.PublicKey, slippageBps uint64, opts ...Option) (pumpamm.SellAccounts, pumpamm.SellArgs, []solana.Instruction, uint64, error) {
+	// Input validation
+	if isNilRPC(rpc) {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, types.ErrNilRPC
+	}
+	if err := types.ValidatePublicKey("user", user); err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, err
+	}
+	if err := types.ValidatePublicKey("pool", pool); err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, err
+	}
+	if err := types.ValidateSlippage(slippageBps); err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, err
+	}
+
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	accts, err := pumpAmmAutofillSell(ctx, rpc, user, pool, options)
+	if err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, err
+	}
+	if !isWSOL(accts.QuoteMint, accts.QuoteTokenProgram) {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, fmt.Errorf("%w: pool %s is quoted in %s; use PumpAmmSellWithSlippage", types.ErrQuoteNotWSOL, pool, accts.QuoteMint)
+	}
+	baseBalance, err := fetchTokenAmount(ctx, rpc, accts.UserBaseTokenAccount)
+	if err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, err
+	}
+	if baseBalance == 0 {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, fmt.Errorf("%w: no %s to sell", types.ErrInsufficientBalance, accts.BaseMint)
+	}
+
+	sellOpts := append(append([]Option{}, opts...), WithCloseBaseATA())
+	if options.DryRun {
+		accts, args, instrs, err := PumpAmmSellWithSlippage(ctx, rpc, user, pool, baseBalance, slippageBps, sellOpts...)
+		if err != nil {
+			return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, err
+		}
+		return accts, args, instrs, 0, nil
+	}
+
+	// One simulation prices the sale and the SOL it nets: the sell with no
+	// minimum, closing the base ATA but leaving the WSOL ATA open so the
+	// quote received shows in it.
+	ataReqs, err := ammSellATARequests(user, accts, options)
+	if err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, err
+	}
+	probe, err := ensureATABatch(ctx, rpc, ataReqs)
+	if err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, err
 	}
-	return builder.BuildSignSend(ctx, signer, nil, ix)
+	sellIx, err := pumpamm.BuildSell(accts, pumpamm.SellArgs{BaseAmountIn: baseBalance})
+	if err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, err
+	}
+	probe = append(probe, sellIx, buildCloseAccount(accts.UserBaseTokenAccount, user, user, accts.BaseTokenProgram))
+	probe, err = finalizeInstructions(probe, user, 0, options)
+	if err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, err
+	}
+	sim, post, err := simulateTrade(ctx, rpc, user, probe, options)
+	if err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, err
+	}
+	if sim.Err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, sim.Err
+	}
+	if sim.QuoteDelta <= 0 {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, fmt.Errorf("simulate tx: selling %d %s returns no quote", baseBalance, accts.BaseMint)
+	}
+
+	sellOpts = append(sellOpts, WithExpectedQuoteOut(uint64(sim.QuoteDelta)))
+	accts, args, instrs, err := PumpAmmSellWithSlippage(ctx, rpc, user, pool, baseBalance, slippageBps, sellOpts...)
+	if err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, err
+	}
+	// The unwrap returns the WSOL ATA's lamports; a referral is paid on the
+	// final minimum.
+	referral, err := referralLamports(args.MinQuoteAmountOut, *options)
+	if err != nil {
+		return pumpamm.SellAccounts{}, pumpamm.SellArgs{}, nil, 0, err
+	}
+	net := sim.SolDelta + int64(lamportsOf(post[accts.UserQuoteTokenAccount])) - int64(referral)
+	var netSol uint64
+	if net > 0 {
+		netSol = uint64(net)
+	}
+	return accts, args, instrs, netSol, nil
+}
+
+// ammSellATARequests returns the ATA requests of a pump_amm sell: the user's
+// quote and base ATAs, less those in options.KnownATAs, and the fee ATAs.
+func ammSellATARequests(user solana.PublicKey, accts pumpamm.SellAccounts, options *Options) ([]ataRequest, error) {
+	ataReqs := []ataRequest{
+		{Payer: user, Wallet: accts.User, Mint: accts.QuoteMint, TokenProgram: accts.QuoteTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
+		{Payer: user, Wallet: accts.User, Mint: accts.BaseMint, TokenProgram: accts.BaseTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
+	}
+	ataReqs, err := dropKnownATAs(ataReqs, options)
+	if err != nil {
+		return nil, err
+	}
+	return append(ataReqs, ammFeeATARequests(user, accts.ProtocolFeeRecipient, accts.CoinCreatorVaultAuthority, accts.QuoteMint, accts.QuoteTokenProgram)...), nil
+}
+
+// BuildAndSendAmm executes the instruction with provided signer/txbuilder.
+// With WithJitoBundleTip it is sent as a Jito bundle with a tip transaction.
+func BuildAndSendAmm(ctx context.Context, builder *txbuilder.Builder, signer wallet.Signer, ix solana.Instruction, opts ...Option) (solana.Signature, error) {
+	return BuildAndSendInstructions(ctx, builder, signer, []solana.Instruction{ix}, opts...)
 }
 
 // BuildAndSimulateAmm simulates the instruction without signature.
-func BuildAndSimulateAmm(ctx context.Context, rpc *sdkrpc.Client, builder *txbuilder.Builder, user solana.PublicKey, ix solana.Instruction) (*solanarpc.SimulateTransactionResponse, error) {
-	if rpc == nil || builder == nil {
+func BuildAndSimulateAmm(ctx context.Context, rpc RPC, builder *txbuilder.Builder, user solana.PublicKey, ix solana.Instruction) (*solanarpc.SimulateTransactionResponse, error) {
+	if isNilRPC(rpc) || builder == nil {
 		return nil, fmt.Errorf("rpc and builder required")
 	}
 	tx, err := builder.BuildTransaction(ctx, user, ix)
@@ -562,7 +700,7 @@ func BuildAndSimulateAmm(ctx context.Context, rpc *sdkrpc.Client, builder *txbui
 
 // --- internal helpers ---
 
-func pumpAmmAutofillBuy(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.PublicKey) (pumpamm.BuyAccounts, error) {
+func pumpAmmAutofillBuy(ctx context.Context, rpc RPC, user, pool solana.PublicKey, options *Options) (pumpamm.BuyAccounts, error) {
 	var accts pumpamm.BuyAccounts
 
 	globalConfig, err := deriveAmmGlobalConfigPDA()
@@ -570,13 +708,13 @@ func pumpAmmAutofillBuy(ctx context.Context, rpc *sdkrpc.Client, user, pool sola
 		return accts, fmt.Errorf("derive global_config PDA: %w", err)
 	}
 
-	core, err := fetchAmmCore(ctx, rpc, pool, globalConfig)
+	core, err := fetchAmmCore(ctx, rpc, pool, globalConfig, options)
 	if err != nil {
 		return accts, fmt.Errorf("fetch amm core for pool %s: %w", pool, err)
 	}
-	protocolRecipient := firstNonZeroPK(core.GlobalConfig.ProtocolFeeRecipients[:])
-	if isZeroPK(protocolRecipient) {
-		return accts, fmt.Errorf("protocol fee recipient not found in global_config for pool %s", pool)
+	protocolRecipient, err := selectFeeRecipient(core.GlobalConfig.AcceptedProtocolFeeRecipients(core.Pool.IsMayhemMode), options)
+	if err != nil {
+		return accts, fmt.Errorf("protocol fee recipient for pool %s: %w", pool, err)
 	}
 
 	userBaseATA, _, err := findATAWithProgram(user, core.Pool.BaseMint, core.BaseTokenProgram, constants.AssociatedTokenProgramID)
@@ -589,52 +727,57 @@ func pumpAmmAutofillBuy(ctx context.Context, rpc *sdkrpc.Client, user, pool sola
 	}
 
 	accts = pumpamm.BuyAccounts{
-		Pool:                      pool,
-		User:                      user,
-		GlobalConfig:              globalConfig,
-		BaseMint:                  core.Pool.BaseMint,
-		QuoteMint:                 core.Pool.QuoteMint,
-		UserBaseTokenAccount:      userBaseATA,
-		UserQuoteTokenAccount:     userQuoteATA,
-		PoolBaseTokenAccount:      core.Pool.PoolBaseTokenAccount,
-		PoolQuoteTokenAccount:     core.Pool.PoolQuoteTokenAccount,
-		ProtocolFeeRecipient:      protocolRecipient,
-		BaseTokenProgram:          core.BaseTokenProgram,
-		QuoteTokenProgram:         core.QuoteTokenProgram,
-		SystemProgram:             constants.SystemProgramID,
-		AssociatedTokenProgram:    constants.AssociatedTokenProgramID,
-		Program:                   pumpamm.ProgramKey,
-		FeeProgram:                constants.PumpAmmFeeProgramID,
-		CoinCreatorVaultAuthority: pumpamm.ProgramKey, // placeholder; may be overwritten below
-	}
-
-	if pk, _, err := pumpamm.DeriveBuyEventAuthorityPDA(accts, pumpamm.BuyArgs{}); err == nil {
-		accts.EventAuthority = pk
+		Pool:                   pool,
+		User:                   user,
+		GlobalConfig:           globalConfig,
+		BaseMint:               core.Pool.BaseMint,
+		QuoteMint:              core.Pool.QuoteMint,
+		UserBaseTokenAccount:   userBaseATA,
+		UserQuoteTokenAccount:  userQuoteATA,
+		PoolBaseTokenAccount:   core.Pool.PoolBaseTokenAccount,
+		PoolQuoteTokenAccount:  core.Pool.PoolQuoteTokenAccount,
+		ProtocolFeeRecipient:   protocolRecipient,
+		BaseTokenProgram:       core.BaseTokenProgram,
+		QuoteTokenProgram:      core.QuoteTokenProgram,
+		SystemProgram:          constants.SystemProgramID,
+		AssociatedTokenProgram: constants.AssociatedTokenProgramID,
+		Program:                pumpamm.ProgramKey,
+		FeeProgram:             constants.PumpAmmFeeProgramID,
 	}
-	if pk, _, err := pumpamm.DeriveBuyGlobalVolumeAccumulatorPDA(accts, pumpamm.BuyArgs{}); err == nil {
-		accts.GlobalVolumeAccumulator = pk
+
+	if accts.EventAuthority, _, err = pumpamm.DeriveBuyEventAuthorityPDA(accts, pumpamm.BuyArgs{}); err != nil {
+		return accts, fmt.Errorf("derive event_authority PDA: %w", err)
 	}
-	if pk, _, err := pumpamm.DeriveBuyUserVolumeAccumulatorPDA(accts, pumpamm.BuyArgs{}); err == nil {
-		accts.UserVolumeAccumulator = pk
+	if accts.GlobalVolumeAccumulator, _, err = pumpamm.DeriveBuyGlobalVolumeAccumulatorPDA(accts, pumpamm.BuyArgs{}); err != nil {
+		return accts, fmt.Errorf("derive global_volume_accumulator PDA: %w", err)
 	}
-	if pk, _, err := pumpamm.DeriveBuyFeeConfigPDA(accts, pumpamm.BuyArgs{}); err == nil {
-		accts.FeeConfig = pk
+	if accts.UserVolumeAccumulator, _, err = pumpamm.DeriveBuyUserVolumeAccumulatorPDA(accts, pumpamm.BuyArgs{}); err != nil {
+		return accts, fmt.Errorf("derive user_volume_accumulator PDA: %w", err)
+	}
+	if accts.FeeConfig, _, err = pumpamm.DeriveBuyFeeConfigPDA(accts, pumpamm.BuyArgs{}); err != nil {
+		return accts, fmt.Errorf("derive fee_config PDA: %w", err)
 	}
 	// coin_creator_vault_authority should be derived with core.Pool.CoinCreator
-	if pk, _, err := solana.FindProgramAddress([][]byte{[]byte(constants.SeedCreatorVaultAmm), core.Pool.CoinCreator[:]}, pumpamm.ProgramKey); err == nil {
-		accts.CoinCreatorVaultAuthority = pk
-		if pk2, _, err := pumpamm.DeriveBuyCoinCreatorVaultAtaPDA(accts, pumpamm.BuyArgs{}); err == nil {
-			accts.CoinCreatorVaultAta = pk2
-		}
+	if accts.CoinCreatorVaultAuthority, _, err = solana.FindProgramAddress([][]byte{[]byte(constants.SeedCreatorVaultAmm), core.Pool.CoinCreator[:]}, pumpamm.ProgramKey); err != nil {
+		return accts, fmt.Errorf("derive coin_creator_vault_authority PDA: %w", err)
 	}
-	if pk, _, err := pumpamm.DeriveBuyProtocolFeeRecipientTokenAccountPDA(accts, pumpamm.BuyArgs{}); err == nil {
-		accts.ProtocolFeeRecipientTokenAccount = pk
+	if accts.CoinCreatorVaultAta, _, err = pumpamm.DeriveBuyCoinCreatorVaultAtaPDA(accts, pumpamm.BuyArgs{}); err != nil {
+		return accts, fmt.Errorf("derive coin_creator_vault_ata PDA: %w", err)
+	}
+	if accts.ProtocolFeeRecipientTokenAccount, _, err = pumpamm.DeriveBuyProtocolFeeRecipientTokenAccountPDA(accts, pumpamm.BuyArgs{}); err != nil {
+		return accts, fmt.Errorf("derive protocol_fee_recipient_token_account PDA: %w", err)
 	}
 
+	if err := pumpamm.ValidateBuyAccounts(accts); err != nil {
+		return accts, fmt.Errorf("autofill pool %s: %w", pool, err)
+	}
+	if err := checkPoolGuards(ctx, rpc, pool, accts.PoolQuoteTokenAccount, options); err != nil {
+		return accts, err
+	}
 	return accts, nil
 }
 
-func pumpAmmAutofillSell(ctx context.Context, rpc *sdkrpc.Client, user, pool solana.PublicKey) (pumpamm.SellAccounts, error) {
+func pumpAmmAutofillSell(ctx context.Context, rpc RPC, user, pool solana.PublicKey, options *Options) (pumpamm.SellAccounts, error) {
 	var accts pumpamm.SellAccounts
 
 	globalConfig, err := deriveAmmGlobalConfigPDA()
@@ -642,13 +785,13 @@ func pumpAmmAutofillSell(ctx context.Context, rpc *sdkrpc.Client, user, pool sol
 		return accts, err
 	}
 
-	core, err := fetchAmmCore(ctx, rpc, pool, globalConfig)
+	core, err := fetchAmmCore(ctx, rpc, pool, globalConfig, options)
 	if err != nil {
 		return accts, fmt.Errorf("fetch amm core for pool %s: %w", pool, err)
 	}
-	protocolRecipient := firstNonZeroPK(core.GlobalConfig.ProtocolFeeRecipients[:])
-	if isZeroPK(protocolRecipient) {
-		return accts, fmt.Errorf("protocol fee recipient not found in global_config for pool %s", pool)
+	protocolRecipient, err := selectFeeRecipient(core.GlobalConfig.AcceptedProtocolFeeRecipients(core.Pool.IsMayhemMode), options)
+	if err != nil {
+		return accts, fmt.Errorf("protocol fee recipient for pool %s: %w", pool, err)
 	}
 
 	userBaseATA, _, err := findATAWithProgram(user, core.Pool.BaseMint, core.BaseTokenProgram, constants.AssociatedTokenProgramID)
@@ -695,6 +838,9 @@ func pumpAmmAutofillSell(ctx context.Context, rpc *sdkrpc.Client, user, pool sol
 			accts.CoinCreatorVaultAta = pk2
 		}
 	}
+	if err := checkPoolGuards(ctx, rpc, pool, accts.PoolQuoteTokenAccount, options); err != nil {
+		return accts, err
+	}
 	return accts, nil
 }
 
@@ -713,31 +859,57 @@ type ammCoreResult struct {
 
 // fetchAmmCore 批量获取 pool/global_config 并解码，减少 RPC。
 // 同时获取 baseMint 和 quoteMint 的 owner（token program）。
-func fetchAmmCore(ctx context.Context, rpc *sdkrpc.Client, pool, globalConfig solana.PublicKey) (ammCoreResult, error) {
+// options 注入的 pool/global_config 不再查询。
+func fetchAmmCore(ctx context.Context, rpc RPC, pool, globalConfig solana.PublicKey, options *Options) (ammCoreResult, error) {
 	var result ammCoreResult
 	result.BaseTokenProgram = constants.TokenProgramID
 	result.QuoteTokenProgram = constants.TokenProgramID
 
+	if options.PoolState != nil {
+		if err := checkPoolAddress(pool, *options.PoolState); err != nil {
+			return result, err
+		}
+		result.Pool = *options.PoolState
+	}
+	if options.GlobalConfig != nil {
+		result.GlobalConfig = *options.GlobalConfig
+	}
+
 	// 批量查询：pool, global_config
-	amap, err := fetchAccountsBatch(ctx, rpc, pool, globalConfig)
+	var addrs, required []solana.PublicKey
+	if options.PoolState == nil {
+		addrs = append(addrs, pool)
+		required = append(required, pool)
+	}
+	if options.GlobalConfig == nil {
+		addrs = append(addrs, globalConfig)
+	}
+	amap, err := fetchAccountsAwaiting(ctx, rpc, options, required, addrs...)
 	if err != nil {
 		return result, err
 	}
 
-	poolAcc := amap[pool.String()]
-	if poolAcc == nil || poolAcc.Data == nil {
-		return result, fmt.Errorf("pool account %s not found (may be invalid pool address or RPC issue)", pool)
-	}
-	if err := result.Pool.Unmarshal(poolAcc.Data.GetBinary()); err != nil {
-		return result, fmt.Errorf("decode pool %s: %w", pool, err)
+	if options.PoolState == nil {
+		poolAcc := amap[pool.String()]
+		if poolAcc == nil || poolAcc.Data == nil {
+			if err := nodeLag(ctx, rpc); err != nil {
+				return result, fmt.Errorf("pool account %s not found: %w", pool, err)
+			}
+			return result, fmt.Errorf("pool account %s not found (may be invalid pool address or RPC issue)", pool)
+		}
+		if err := result.Pool.Unmarshal(poolAcc.Data.GetBinary()); err != nil {
+			return result, fmt.Errorf("decode pool %s: %w", pool, err)
+		}
 	}
 
-	globalAcc := amap[globalConfig.String()]
-	if globalAcc == nil || globalAcc.Data == nil {
-		return result, fmt.Errorf("global_config account %s not found", globalConfig)
-	}
-	if err := result.GlobalConfig.Unmarshal(globalAcc.Data.GetBinary()); err != nil {
-		return result, fmt.Errorf("decode global_config %s: %w", globalConfig, err)
+	if options.GlobalConfig == nil {
+		globalAcc := amap[globalConfig.String()]
+		if globalAcc == nil || globalAcc.Data == nil {
+			return result, fmt.Errorf("global_config account %s not found", globalConfig)
+		}
+		if err := result.GlobalConfig.Unmarshal(globalAcc.Data.GetBinary()); err != nil {
+			return result, fmt.Errorf("decode global_config %s: %w", globalConfig, err)
+		}
 	}
 
 	// 第二次批量查询：baseMint 和 quoteMint 的 owner
@@ -756,8 +928,34 @@ func fetchAmmCore(ctx context.Context, rpc *sdkrpc.Client, pool, globalConfig so
 	return result, nil
 }
 
+// checkPoolAddress verifies that pool state p derives, with its stored bump,
+// to the pool address addr.
+func checkPoolAddress(addr solana.PublicKey, p pumpamm.Pool) error {
+	var index [2]byte
+	binary.LittleEndian.PutUint16(index[:], p.Index)
+	derived, err := solana.CreateProgramAddress([][]byte{
+		[]byte(constants.SeedPool), index[:], p.Creator[:], p.BaseMint[:], p.QuoteMint[:], {p.PoolBump},
+	}, pumpamm.ProgramKey)
+	if err != nil || !derived.Equals(addr) {
+		return fmt.Errorf("injected pool state does not match pool %s", addr)
+	}
+	return nil
+}
+
 // --- helpers ---
 
+// ammFeeATARequests returns the ATA requests for the fee accounts a pump_amm
+// buy or sell pays into: the protocol fee recipient's and the coin creator
+// vault's quote token accounts. The program transfers fees into them but does
+// not create them, so on a fresh pool (or a newly rotated fee recipient) the
+// trade fails unless the caller creates them first.
+func ammFeeATARequests(payer, protocolFeeRecipient, coinCreatorVaultAuthority, quoteMint, quoteTokenProgram solana.PublicKey) []ataRequest {
+	return []ataRequest{
+		{Payer: payer, Wallet: protocolFeeRecipient, Mint: quoteMint, TokenProgram: quoteTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
+		{Payer: payer, Wallet: coinCreatorVaultAuthority, Mint: quoteMint, TokenProgram: quoteTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
+	}
+}
+
 func toBuyExactAccounts(a pumpamm.BuyAccounts) pumpamm.BuyExactQuoteInAccounts {
 	return pumpamm.BuyExactQuoteInAccounts{
 		Pool:                             a.Pool,
@@ -790,27 +988,39 @@ func isWSOL(mint, tokenProgram solana.PublicKey) bool {
 	return mint == constants.WSOLMint && tokenProgram == constants.TokenProgramID
 }
 
+// applySlippage returns floor(amount*(10000-slippageBps)/10000).
 func applySlippage(amount uint64, slippageBps uint64) uint64 {
+	return curve.MinOut(amount, slippageBps)
+}
+
+// slippageMin returns the minimum output accepted for an expected output of
+// amount, rounded per options.SlippageRounding. A minimum that rounds to
+// zero although amount does not is rejected, unless slippageBps allows
+// losing everything.
+func slippageMin(amount, slippageBps uint64, options *Options) (uint64, error) {
 	if slippageBps >= 10_000 {
-		return 0
+		return 0, nil
+	}
+	minOut := applySlippage(amount, slippageBps)
+	if options.SlippageRounding == SlippageRoundUp {
+		minOut = curve.MinOutCeil(amount, slippageBps)
 	}
-	return amount * (10_000 - slippageBps) / 10_000
+	if minOut == 0 && amount > 0 {
+		return 0, types.NewValidationError("slippageBps", fmt.Sprintf("minimum output for expected %d rounds to zero at %d bps; use WithSlippageRounding(SlippageRoundUp)", amount, slippageBps))
+	}
+	return minOut, nil
 }
 
-func fetchTokenAmount(ctx context.Context, rpc *sdkrpc.Client, account solana.PublicKey) (uint64, error) {
-	info, err := rpc.Raw().GetAccountInfo(ctx, account)
+func fetchTokenAmount(ctx context.Context, rpc RPC, account solana.PublicKey) (uint64, error) {
+	info, err := fetchAccount(ctx, rpc, account)
 	if err != nil {
-		// RPC error might indicate account doesn't exist, return 0 instead of error
-		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "could not find") {
-			return 0, nil
-		}
 		return 0, fmt.Errorf("fetch token account %s: %w", account, err)
 	}
-	if info == nil || info.Value == nil || info.Value.Data == nil {
+	if info == nil || info.Data == nil {
 		// Account doesn't exist yet, return 0
 		return 0, nil
 	}
-	data := info.Value.Data.GetBinary()
+	data := info.Data.GetBinary()
 	if len(data) == 0 {
 		// Account exists but empty, return 0
 		return 0, nil
@@ -823,19 +1033,19 @@ func fetchTokenAmount(ctx context.Context, rpc *sdkrpc.Client, account solana.Pu
 	return acc.Amount, nil
 }
 
-func simulateBaseOut(ctx context.Context, rpc *sdkrpc.Client, user, baseATA solana.PublicKey, initialBase uint64, instrs ...solana.Instruction) (uint64, error) {
+func simulateBaseOut(ctx context.Context, rpc RPC, commitment solanarpc.CommitmentType, user, baseATA solana.PublicKey, initialBase uint64, instrs ...solana.Instruction) (uint64, error) {
+	_, bankCommitment := simulationCommitments(rpc, commitment)
 	if len(instrs) == 0 {
 		return 0, fmt.Errorf("no instructions to simulate")
 	}
-	builder := txbuilder.NewBuilder(rpc, solanarpc.CommitmentConfirmed)
-	tx, err := builder.BuildTransaction(ctx, user, instrs...)
+	tx, err := simulationTx(user, instrs...)
 	if err != nil {
-		return 0, fmt.Errorf("build tx for simulate: %w", err)
+		return 0, err
 	}
 	res, err := rpc.SimulateTransaction(ctx, tx, &solanarpc.SimulateTransactionOpts{
 		SigVerify:              false,
 		ReplaceRecentBlockhash: true, // Use a valid recent blockhash for more accurate simulation
-		Commitment:             solanarpc.CommitmentProcessed,
+		Commitment:             bankCommitment,
 		Accounts: &solanarpc.SimulateTransactionAccountsOpts{
 			Encoding:  solana.EncodingBase64,
 			Addresses: []solana.PublicKey{baseATA},
@@ -848,7 +1058,7 @@ func simulateBaseOut(ctx context.Context, rpc *sdkrpc.Client, user, baseATA sola
 		return 0, fmt.Errorf("simulate tx: empty result")
 	}
 	if res.Value.Err != nil {
-		return 0, types.ParseSimulationError(res.Value.Err, res.Value.Logs)
+		return 0, types.ParseSimulationErrorWithInstructions(res.Value.Err, res.Value.Logs, instrs)
 	}
 	if len(res.Value.Accounts) == 0 || res.Value.Accounts[0] == nil || res.Value.Accounts[0].Data == nil {
 		return 0, fmt.Errorf("simulate tx: missing account data for %s", baseATA)
@@ -875,7 +1085,8 @@ func simulateBaseOut(ctx context.Context, rpc *sdkrpc.Client, user, baseATA sola
 }
 
 // simulateAmmQuoteOut 返回用户 quote ATA 增量（卖出 base -> quote）。
-func simulateAmmQuoteOut(ctx context.Context, rpc *sdkrpc.Client, user solana.PublicKey, accounts pumpamm.SellAccounts, baseIn uint64, instrs ...solana.Instruction) (uint64, error) {
+func simulateAmmQuoteOut(ctx context.Context, rpc RPC, commitment solanarpc.CommitmentType, user solana.PublicKey, accounts pumpamm.SellAccounts, baseIn uint64, instrs ...solana.Instruction) (uint64, error) {
+	_, bankCommitment := simulationCommitments(rpc, commitment)
 	pre, err := fetchTokenAmount(ctx, rpc, accounts.UserQuoteTokenAccount)
 	if err != nil {
 		return 0, err
@@ -890,15 +1101,14 @@ func simulateAmmQuoteOut(ctx context.Context, rpc *sdkrpc.Client, user solana.Pu
 		}
 		instrs = append(instrs, ix)
 	}
-	builder := txbuilder.NewBuilder(rpc, solanarpc.CommitmentConfirmed)
-	tx, err := builder.BuildTransaction(ctx, user, instrs...)
+	tx, err := simulationTx(user, instrs...)
 	if err != nil {
 		return 0, err
 	}
 	res, err := rpc.SimulateTransaction(ctx, tx, &solanarpc.SimulateTransactionOpts{
 		SigVerify:              false,
 		ReplaceRecentBlockhash: true,
-		Commitment:             solanarpc.CommitmentProcessed,
+		Commitment:             bankCommitment,
 		Accounts: &solanarpc.SimulateTransactionAccountsOpts{
 			Encoding:  solana.EncodingBase64,
 			Addresses: []solana.PublicKey{accounts.UserQuoteTokenAccount},
@@ -911,7 +1121,7 @@ func simulateAmmQuoteOut(ctx context.Context, rpc *sdkrpc.Client, user solana.Pu
 		return 0, fmt.Errorf("simulate result empty response")
 	}
 	if res.Value.Err != nil {
-		return 0, types.ParseSimulationError(res.Value.Err, res.Value.Logs)
+		return 0, types.ParseSimulationErrorWithInstructions(res.Value.Err, res.Value.Logs, instrs)
 	}
 	if len(res.Value.Accounts) == 0 || res.Value.Accounts[0] == nil || res.Value.Accounts[0].Data == nil {
 		return 0, fmt.Errorf("simulate result empty (quote ATA missing?), logs: %v", res.Value.Logs)
@@ -932,9 +1142,9 @@ func simulateAmmQuoteOut(ctx context.Context, rpc *sdkrpc.Client, user solana.Pu
 }
 
 // simulateQuoteConsumedNoSign simulates a buy transaction without signature to get actual quote consumed.
-func simulateQuoteConsumedNoSign(ctx context.Context, rpc *sdkrpc.Client, user, quoteATA solana.PublicKey, preBalance uint64, instrs ...solana.Instruction) (uint64, error) {
-	builder := txbuilder.NewBuilder(rpc, solanarpc.CommitmentConfirmed)
-	tx, err := builder.BuildTransaction(ctx, user, instrs...)
+func simulateQuoteConsumedNoSign(ctx context.Context, rpc RPC, commitment solanarpc.CommitmentType, user, quoteATA solana.PublicKey, preBalance uint64, instrs ...solana.Instruction) (uint64, error) {
+	_, bankCommitment := simulationCommitments(rpc, commitment)
+	tx, err := simulationTx(user, instrs...)
 	if err != nil {
 		return 0, err
 	}
@@ -942,7 +1152,7 @@ func simulateQuoteConsumedNoSign(ctx context.Context, rpc *sdkrpc.Client, user,
 	res, err := rpc.SimulateTransaction(ctx, tx, &solanarpc.SimulateTransactionOpts{
 		SigVerify:              false, // Skip signature verification
 		ReplaceRecentBlockhash: true,
-		Commitment:             solanarpc.CommitmentProcessed,
+		Commitment:             bankCommitment,
 		Accounts: &solanarpc.SimulateTransactionAccountsOpts{
 			Encoding:  solana.EncodingBase64,
 			Addresses: []solana.PublicKey{quoteATA},
@@ -955,7 +1165,7 @@ func simulateQuoteConsumedNoSign(ctx context.Context, rpc *sdkrpc.Client, user,
 		return 0, fmt.Errorf("simulate result empty response")
 	}
 	if res.Value.Err != nil {
-		return 0, types.ParseSimulationError(res.Value.Err, res.Value.Logs)
+		return 0, types.ParseSimulationErrorWithInstructions(res.Value.Err, res.Value.Logs, instrs)
 	}
 	if len(res.Value.Accounts) == 0 || res.Value.Accounts[0] == nil || res.Value.Accounts[0].Data == nil {
 		return 0, fmt.Errorf("simulate result empty (quote ATA missing?), logs: %v", res.Value.Logs)
@@ -976,18 +1186,38 @@ func simulateQuoteConsumedNoSign(ctx context.Context, rpc *sdkrpc.Client, user,
 	return preBalance - acc.Amount, nil
 }
 
-// finalizeInstructions adds Compute Budget (prepend) and Jito tip (append) instructions.
-func finalizeInstructions(instrs []solana.Instruction, from solana.PublicKey, options *Options) []solana.Instruction {
+// solSide returns amount if quoteMint is WSOL and 0 otherwise, so that only
+// SOL amounts size the WithReferral transfer.
+func solSide(quoteMint solana.PublicKey, amount uint64) uint64 {
+	if !quoteMint.Equals(constants.WSOLMint) {
+		return 0
+	}
+	return amount
+}
+
+// finalizeInstructions adds Compute Budget (prepend) and Jito tip (append) instructions,
+// splicing in caller instructions and the referral transfer sized from solAmount
+// (pass 0 for pools not quoted in WSOL).
+func finalizeInstructions(instrs []solana.Instruction, from solana.PublicKey, solAmount uint64, options *Options) ([]solana.Instruction, error) {
 	if options == nil {
-		return instrs
+		return instrs, nil
+	}
+	// Splice caller instructions around the trade, inside the compute budget and tip
+	instrs = spliceInstructions(instrs, *options)
+	referral, err := buildReferral(from, solAmount, *options)
+	if err != nil {
+		return nil, err
+	}
+	if referral != nil {
+		instrs = append(instrs, referral)
 	}
 	// Prepend Compute Budget instructions (priority fee, compute limit)
 	instrs = prependComputeBudget(instrs, *options)
 
-	// Append Jito tip if configured
-	if options.JitoTipLamports > 0 && !options.JitoTipAccount.IsZero() {
+	// Append Jito tip if configured, unless it travels in its own transaction
+	if options.JitoTipLamports > 0 && !options.JitoTipAccount.IsZero() && options.JitoBundleTip == 0 {
 		tipIx := system.NewTransferInstruction(options.JitoTipLamports, from, options.JitoTipAccount).Build()
 		instrs = append(instrs, tipIx)
 	}
-	return instrs
+	return instrs, nil
 }
@@ -2,9 +2,7 @@ package autofill_test
 
 import (
 	"context"
-	"fmt"
 	"os"
-	"strconv"
 	"testing"
 	"time"
 
@@ -77,7 +75,7 @@ func TestPumpAmmBuyThenSell(t *testing.T) {
 	// Step 1: Buy tokens
 	t.Log("\n=== Step 1: Buy tokens ===")
 	buyAccts, buyArgs, buyInstrs, simOut, err := autofill.PumpAmmBuyWithSol(
-		ctx, rpcClient, signer, pool, buyAmountLamports, slippageBps,
+		ctx, rpcClient, signer.PublicKey(), pool, buyAmountLamports, slippageBps,
 	)
 	if err != nil {
 		t.Fatalf("build buy: %v", err)
@@ -101,7 +99,7 @@ func TestPumpAmmBuyThenSell(t *testing.T) {
 	// Step 2: Get actual tokens bought from transaction result
 	t.Log("\n=== Step 2: Parse buy transaction result ===")
 
-	tokensReceived, err := getTokensReceivedFromTx(ctx, rpcClient.Raw(), buySig, signer.PublicKey(), buyAccts.BaseMint)
+	tokensReceived, err := txbuilder.TokensReceived(ctx, rpcClient, buySig, signer.PublicKey(), buyAccts.BaseMint)
 	if err != nil {
 		t.Fatalf("parse tx result: %v", err)
 	}
@@ -120,7 +118,7 @@ func TestPumpAmmBuyThenSell(t *testing.T) {
 
 	// Use WithKnownATAs + WithExpectedQuoteOut to skip RPC queries entirely
 	sellAccts, sellArgs, sellInstrs, err := autofill.PumpAmmSellWithSlippage(
-		ctx, rpcClient, signer, pool, tokensReceived, slippageBps,
+		ctx, rpcClient, signer.PublicKey(), pool, tokensReceived, slippageBps,
 		autofill.WithKnownATAs(buyAccts.UserBaseTokenAccount, buyAccts.UserQuoteTokenAccount),
 		autofill.WithExpectedQuoteOut(estimatedQuoteOut),
 	)
@@ -148,11 +146,11 @@ func TestPumpAmmBuyThenSell(t *testing.T) {
 	// Step 4: Verify sell result
 	t.Log("\n=== Step 4: Verify sell result ===")
 
-	solReceived, err := getSOLReceivedFromTx(ctx, rpcClient.Raw(), sellSig, signer.PublicKey())
+	solReceived, fee, err := txbuilder.SolDelta(ctx, rpcClient, sellSig, signer.PublicKey())
 	if err != nil {
 		t.Logf("  Could not parse SOL received: %v", err)
 	} else {
-		t.Logf("  SOL received: %d lamports (%.6f SOL)", solReceived, float64(solReceived)/1e9)
+		t.Logf("  SOL received: %d lamports (%.6f SOL), fee %d", solReceived, float64(solReceived)/1e9, fee)
 	}
 
 	t.Log("\n=== Test completed successfully ===")
@@ -187,7 +185,7 @@ func TestPumpAmmBuyExactAndSell(t *testing.T) {
 
 	// Buy with exact quote input
 	buyAccts, buyArgs, buyInstrs, err := autofill.PumpAmmBuyExactQuoteIn(
-		ctx, rpcClient, signer, pool, buyAmountLamports, minBaseOut,
+		ctx, rpcClient, signer.PublicKey(), pool, buyAmountLamports, minBaseOut,
 	)
 	if err != nil {
 		t.Fatalf("build buy: %v", err)
@@ -204,7 +202,7 @@ func TestPumpAmmBuyExactAndSell(t *testing.T) {
 	t.Logf("  Buy tx: %s", buySig)
 
 	// Get tokens from tx result
-	tokensReceived, err := getTokensReceivedFromTx(ctx, rpcClient.Raw(), buySig, signer.PublicKey(), buyAccts.BaseMint)
+	tokensReceived, err := txbuilder.TokensReceived(ctx, rpcClient, buySig, signer.PublicKey(), buyAccts.BaseMint)
 	if err != nil {
 		t.Fatalf("parse tx result: %v", err)
 	}
@@ -219,7 +217,7 @@ func TestPumpAmmBuyExactAndSell(t *testing.T) {
 
 	// Sell all using WithKnownATAs + WithExpectedQuoteOut to skip RPC queries
 	_, sellArgs, sellInstrs, err := autofill.PumpAmmSellWithSlippage(
-		ctx, rpcClient, signer, pool, tokensReceived, slippageBps,
+		ctx, rpcClient, signer.PublicKey(), pool, tokensReceived, slippageBps,
 		autofill.WithKnownATAs(buyAccts.UserBaseTokenAccount, buyAccts.UserQuoteTokenAccount),
 		autofill.WithExpectedQuoteOut(estimatedQuoteOut),
 	)
@@ -239,98 +237,6 @@ func TestPumpAmmBuyExactAndSell(t *testing.T) {
 	t.Log("Test completed successfully")
 }
 
-// getTokensReceivedFromTx parses the transaction to get the actual tokens received.
-// It calculates: postBalance - preBalance for the specified mint.
-func getTokensReceivedFromTx(
-	ctx context.Context,
-	rpc *solanarpc.Client,
-	sig solana.Signature,
-	owner solana.PublicKey,
-	mint solana.PublicKey,
-) (uint64, error) {
-	version := uint64(0)
-	tx, err := rpc.GetTransaction(ctx, sig, &solanarpc.GetTransactionOpts{
-		Commitment:                     solanarpc.CommitmentConfirmed,
-		MaxSupportedTransactionVersion: &version,
-	})
-	if err != nil {
-		return 0, fmt.Errorf("get transaction: %w", err)
-	}
-	if tx == nil || tx.Meta == nil {
-		return 0, fmt.Errorf("transaction not found or no meta")
-	}
-
-	// Find pre and post balance for the specified mint owned by the user
-	var preBalance, postBalance uint64
-
-	for _, b := range tx.Meta.PreTokenBalances {
-		if b.Owner != nil && b.Owner.String() == owner.String() && b.Mint.String() == mint.String() {
-			preBalance, _ = strconv.ParseUint(b.UiTokenAmount.Amount, 10, 64)
-			break
-		}
-	}
-
-	for _, b := range tx.Meta.PostTokenBalances {
-		if b.Owner != nil && b.Owner.String() == owner.String() && b.Mint.String() == mint.String() {
-			postBalance, _ = strconv.ParseUint(b.UiTokenAmount.Amount, 10, 64)
-			break
-		}
-	}
-
-	if postBalance > preBalance {
-		return postBalance - preBalance, nil
-	}
-	return 0, nil
-}
-
-// getSOLReceivedFromTx parses the transaction to get the SOL received (for sells).
-// It calculates: postBalance - preBalance for the user's SOL account.
-func getSOLReceivedFromTx(
-	ctx context.Context,
-	rpc *solanarpc.Client,
-	sig solana.Signature,
-	owner solana.PublicKey,
-) (uint64, error) {
-	version := uint64(0)
-	tx, err := rpc.GetTransaction(ctx, sig, &solanarpc.GetTransactionOpts{
-		Commitment:                     solanarpc.CommitmentConfirmed,
-		MaxSupportedTransactionVersion: &version,
-	})
-	if err != nil {
-		return 0, fmt.Errorf("get transaction: %w", err)
-	}
-	if tx == nil || tx.Meta == nil {
-		return 0, fmt.Errorf("transaction not found or no meta")
-	}
-
-	// Find the owner's account index
-	decodedTx, err := tx.Transaction.GetTransaction()
-	if err != nil {
-		return 0, fmt.Errorf("decode transaction: %w", err)
-	}
-
-	ownerIdx := -1
-	for i, key := range decodedTx.Message.AccountKeys {
-		if key.String() == owner.String() {
-			ownerIdx = i
-			break
-		}
-	}
-
-	if ownerIdx < 0 || ownerIdx >= len(tx.Meta.PreBalances) || ownerIdx >= len(tx.Meta.PostBalances) {
-		return 0, fmt.Errorf("owner account not found in transaction")
-	}
-
-	preBalance := tx.Meta.PreBalances[ownerIdx]
-	postBalance := tx.Meta.PostBalances[ownerIdx]
-
-	// Account for fees
-	if postBalance > preBalance {
-		return postBalance - preBalance, nil
-	}
-	return 0, nil
-}
-
 // BenchmarkPumpAmmBuy benchmarks buy transaction construction.
 func BenchmarkPumpAmmBuy(b *testing.B) {
 	rpcURL := os.Getenv("PUMP_TEST_RPC_URL")
@@ -357,7 +263,7 @@ func BenchmarkPumpAmmBuy(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _, _, _, err := autofill.PumpAmmBuyWithSol(
-			ctx, rpcClient, signer, pool, 1_000_000, 500,
+			ctx, rpcClient, signer.PublicKey(), pool, 1_000_000, 500,
 		)
 		if err != nil {
 			b.Fatal(err)
@@ -0,0 +1,160 @@
+package autofill
+
+import (
+	"context"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+)
+
+// PumpBuyResult is the outcome of PumpBuyWithRetryWithResult.
+type PumpBuyResult struct {
+	Accounts     pump.BuyExactSolInAccounts
+	Args         pump.BuyExactSolInArgs
+	Instructions []solana.Instruction
+	// ExpectedTokensOut is the quoted token output before slippage.
+	ExpectedTokensOut uint64
+	MinTokensOut      uint64
+	// PriceImpactBps is nil when the curve reserves could not be read.
+	PriceImpactBps *uint64
+	Attempts       int
+}
+
+// PumpSellResult is the outcome of PumpSellWithSlippageWithResult.
+type PumpSellResult struct {
+	Accounts     pump.SellAccounts
+	Args         pump.SellArgs
+	Instructions []solana.Instruction
+	// ExpectedSolOut is the simulated SOL output before slippage, including
+	// the rent refund when WithIncludeRentRefund counts it.
+	ExpectedSolOut uint64
+	MinSolOut      uint64
+	// PriceImpactBps is nil when the curve reserves could not be read.
+	PriceImpactBps *uint64
+}
+
+// PumpAmmBuyResult is the outcome of PumpAmmBuyWithSolWithResult.
+type PumpAmmBuyResult struct {
+	Accounts     pumpamm.BuyExactQuoteInAccounts
+	Args         pumpamm.BuyExactQuoteInArgs
+	Instructions []solana.Instruction
+	// SimulatedBaseOut is the simulated base output before slippage.
+	SimulatedBaseOut uint64
+	MinBaseOut       uint64
+	// PriceImpactBps is nil when the pool reserves could not be read.
+	PriceImpactBps *uint64
+}
+
+// PumpAmmSellResult is the outcome of PumpAmmSellWithSlippageWithResult.
+type PumpAmmSellResult struct {
+	Accounts     pumpamm.SellAccounts
+	Args         pumpamm.SellArgs
+	Instructions []solana.Instruction
+	// ExpectedQuoteOut is the expected quote output before slippage,
+	// including the rent refund when WithIncludeRentRefund counts it.
+	ExpectedQuoteOut uint64
+	MinQuoteOut      uint64
+	// PriceImpactBps is nil when the pool reserves could not be read.
+	PriceImpactBps *uint64
+}
+
+// PumpBuyWithRetryWithResult is PumpBuyWithRetry returning a PumpBuyResult.
+// Reading the reserves for the price impact costs one extra account fetch.
+//
+// Example:
+//
+//	res, err := autofill.PumpBuyWithRetryWithResult(ctx, rpc, user, mint, 100_000_000, 500, 3)
+//	sig, err := builder.BuildSignSend(ctx, signer, nil, res.Instructions...)
+func PumpBuyWithRetryWithResult(ctx context.Context, rpc RPC, user, mint solana.PublicKey, amountSol, slippageBps uint64, maxAttempts int, opts ...Option) (*PumpBuyResult, error) {
+	var p Preview
+	accts, args, instrs, attempts, err := PumpBuyWithRetry(ctx, rpc, user, mint, amountSol, slippageBps, maxAttempts, withResult(opts, &p)...)
+	if err != nil {
+		return nil, err
+	}
+	return &PumpBuyResult{
+		Accounts:          accts,
+		Args:              args,
+		Instructions:      instrs,
+		ExpectedTokensOut: deref(p.ExpectedOut),
+		MinTokensOut:      args.MinTokensOut,
+		PriceImpactBps:    p.PriceImpactBps,
+		Attempts:          attempts,
+	}, nil
+}
+
+// PumpSellWithSlippageWithResult is PumpSellWithSlippage returning a
+// PumpSellResult. Reading the reserves for the price impact costs one extra
+// account fetch.
+func PumpSellWithSlippageWithResult(ctx context.Context, rpc RPC, user, mint solana.PublicKey, amount, slippageBps uint64, opts ...Option) (*PumpSellResult, error) {
+	var p Preview
+	accts, args, instrs, err := PumpSellWithSlippage(ctx, rpc, user, mint, amount, slippageBps, withResult(opts, &p)...)
+	if err != nil {
+		return nil, err
+	}
+	return &PumpSellResult{
+		Accounts:       accts,
+		Args:           args,
+		Instructions:   instrs,
+		ExpectedSolOut: deref(p.ExpectedOut),
+		MinSolOut:      args.MinSolOutput,
+		PriceImpactBps: p.PriceImpactBps,
+	}, nil
+}
+
+// PumpAmmBuyWithSolWithResult is PumpAmmBuyWithSol returning a
+// PumpAmmBuyResult. Reading the reserves for the price impact costs one
+// extra account fetch.
+//
+// Example:
+//
+//	res, err := autofill.PumpAmmBuyWithSolWithResult(ctx, rpc, user, pool, 10_000_000, 100)
+//	log.Printf("expect %d tokens (min %d)", res.SimulatedBaseOut, res.MinBaseOut)
+func PumpAmmBuyWithSolWithResult(ctx context.Context, rpc RPC, user, pool solana.PublicKey, quoteLamports, slippageBps uint64, opts ...Option) (*PumpAmmBuyResult, error) {
+	var p Preview
+	accts, args, instrs, simOut, err := PumpAmmBuyWithSol(ctx, rpc, user, pool, quoteLamports, slippageBps, withResult(opts, &p)...)
+	if err != nil {
+		return nil, err
+	}
+	return &PumpAmmBuyResult{
+		Accounts:         accts,
+		Args:             args,
+		Instructions:     instrs,
+		SimulatedBaseOut: simOut,
+		MinBaseOut:       args.MinBaseAmountOut,
+		PriceImpactBps:   p.PriceImpactBps,
+	}, nil
+}
+
+// PumpAmmSellWithSlippageWithResult is PumpAmmSellWithSlippage returning a
+// PumpAmmSellResult. Reading the reserves for the price impact costs one
+// extra account fetch.
+func PumpAmmSellWithSlippageWithResult(ctx context.Context, rpc RPC, user, pool solana.PublicKey, baseIn, slippageBps uint64, opts ...Option) (*PumpAmmSellResult, error) {
+	var p Preview
+	accts, args, instrs, err := PumpAmmSellWithSlippage(ctx, rpc, user, pool, baseIn, slippageBps, withResult(opts, &p)...)
+	if err != nil {
+		return nil, err
+	}
+	return &PumpAmmSellResult{
+		Accounts:         accts,
+		Args:             args,
+		Instructions:     instrs,
+		ExpectedQuoteOut: deref(p.ExpectedOut),
+		MinQuoteOut:      args.MinQuoteAmountOut,
+		PriceImpactBps:   p.PriceImpactBps,
+	}, nil
+}
+
+// withResult returns opts plus an option capturing the call's Preview in p.
+// The outermost builder writes its Preview last, so p ends up holding it.
+func withResult(opts []Option, p *Preview) []Option {
+	return append(append([]Option{}, opts...), func(o *Options) { o.result = p })
+}
+
+func deref(v *uint64) uint64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
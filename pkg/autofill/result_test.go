@@ -0,0 +1,49 @@
+package autofill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/curve"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+)
+
+func TestPumpBuyWithRetryWithResult(t *testing.T) {
+	user := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	curveAddr, _, err := pump.DeriveBuyBondingCurvePDA(pump.BuyAccounts{Mint: mint}, pump.BuyArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bc := pump.BondingCurve{
+		VirtualTokenReserves: 1_000_000_000_000_000,
+		VirtualSolReserves:   30_000_000_000,
+		RealTokenReserves:    800_000_000_000_000,
+		Creator:              solana.NewWallet().PublicKey(),
+	}
+	rpc := mock.New()
+	rpc.SetAccount(mint, constants.TokenProgramID, 1, make([]byte, 82))
+	rpc.SetAccount(curveAddr, pump.ProgramKey, 1, curveData(t, bc))
+	simulationResults(rpc, nil)
+	global := WithPumpGlobal(pump.Global{FeeRecipient: solana.NewWallet().PublicKey(), FeeBasisPoints: 95, CreatorFeeBasisPoints: 30})
+
+	res, err := PumpBuyWithRetryWithResult(context.Background(), rpc, user, mint, 100_000_000, 500, 3, global)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := curve.BuyTokensOut(pumpReserves(bc), 125, 100_000_000)
+	if res.ExpectedTokensOut != expected || res.MinTokensOut != applySlippage(expected, 500) || res.MinTokensOut != res.Args.MinTokensOut {
+		t.Fatalf("expected %d min %d, want %d min %d", res.ExpectedTokensOut, res.MinTokensOut, expected, applySlippage(expected, 500))
+	}
+	if res.Attempts != 1 || len(res.Instructions) == 0 || res.Accounts.BondingCurve != curveAddr {
+		t.Fatalf("result = %+v", res)
+	}
+	// 0.1 SOL into 30 SOL of virtual reserves moves the price 33 bps.
+	if res.PriceImpactBps == nil || *res.PriceImpactBps != 33 {
+		t.Fatalf("price impact = %v, want 33 bps", res.PriceImpactBps)
+	}
+}
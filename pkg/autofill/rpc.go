@@ -0,0 +1,127 @@
+package autofill
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/rs/zerolog"
+
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+)
+
+// AccountFetcher reads chain state. *sdkrpc.Client implements it.
+type AccountFetcher interface {
+	// GetMultipleAccounts returns the accounts at addrs, in order, with nil
+	// for missing ones.
+	GetMultipleAccounts(ctx context.Context, addrs []solana.PublicKey, commitment solanarpc.CommitmentType) ([]*solanarpc.Account, error)
+	GetBalance(ctx context.Context, addr solana.PublicKey, commitment solanarpc.CommitmentType) (uint64, error)
+	// GetTokenAccountsByOwner returns owner's token accounts under the token
+	// program programID, with base64 data.
+	GetTokenAccountsByOwner(ctx context.Context, owner, programID solana.PublicKey) ([]*solanarpc.TokenAccount, error)
+	GetProgramAccountsFiltered(ctx context.Context, programID solana.PublicKey, filters []solanarpc.RPCFilter) ([]sdkrpc.ProgramAccount, error)
+}
+
+// Simulator simulates transactions. *sdkrpc.Client implements it.
+type Simulator interface {
+	SimulateTransaction(ctx context.Context, tx *solana.Transaction, opts *solanarpc.SimulateTransactionOpts) (*solanarpc.SimulateTransactionResponse, error)
+}
+
+// RPC is the client autofill functions take: everything they read from or
+// simulate against the chain. Pass an *sdkrpc.Client, or a fake in tests.
+type RPC interface {
+	AccountFetcher
+	Simulator
+}
+
+var _ RPC = (*sdkrpc.Client)(nil)
+
+// isNilRPC reports whether rpc is nil, including a nil *sdkrpc.Client.
+func isNilRPC(rpc RPC) bool {
+	if rpc == nil {
+		return true
+	}
+	c, ok := rpc.(*sdkrpc.Client)
+	return ok && c == nil
+}
+
+// rpcLogger returns the logger of rpc, or a no-op logger if it has none.
+func rpcLogger(rpc RPC) zerolog.Logger {
+	if l, ok := rpc.(interface{ Logger() zerolog.Logger }); ok {
+		return l.Logger()
+	}
+	return zerolog.Nop()
+}
+
+// lagReporter is an RPC client that can tell how far behind the cluster its
+// node is. *sdkrpc.Client implements it.
+type lagReporter interface {
+	IsBehind(ctx context.Context, maxSlotLag uint64) (bool, error)
+}
+
+// staleNodeSlotLag is the lag past which a missing account is blamed on the
+// RPC node rather than the address.
+const staleNodeSlotLag = 20
+
+// nodeLag returns the error of an RPC client that can tell it is more than
+// staleNodeSlotLag slots behind (wrapping types.ErrNodeBehind), or nil. It
+// explains accounts missing because they have not reached the node yet,
+// such as a freshly created pool.
+func nodeLag(ctx context.Context, rpc RPC) error {
+	n, ok := rpc.(lagReporter)
+	if !ok {
+		return nil
+	}
+	if behind, err := n.IsBehind(ctx, staleNodeSlotLag); behind {
+		return err
+	}
+	return nil
+}
+
+// readCommitter is an RPC client configured with a commitment for reads.
+// *sdkrpc.Client implements it.
+type readCommitter interface {
+	ReadCommitment() solanarpc.CommitmentType
+}
+
+// clientReadCommitment returns the read commitment configured on rpc
+// (RPCConfig.ReadCommitment), or "" if it has none.
+func clientReadCommitment(rpc AccountFetcher) solanarpc.CommitmentType {
+	if c, ok := rpc.(readCommitter); ok {
+		return c.ReadCommitment()
+	}
+	return ""
+}
+
+// readCommitment returns the commitment account reads use: the client's
+// read commitment, confirmed by default.
+func readCommitment(rpc AccountFetcher) solanarpc.CommitmentType {
+	if c := clientReadCommitment(rpc); c != "" {
+		return c
+	}
+	return solanarpc.CommitmentConfirmed
+}
+
+// fetchAccount returns the account at addr, or nil if it does not exist.
+func fetchAccount(ctx context.Context, rpc AccountFetcher, addr solana.PublicKey) (*solanarpc.Account, error) {
+	accounts, err := rpc.GetMultipleAccounts(ctx, []solana.PublicKey{addr}, readCommitment(rpc))
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, nil
+	}
+	return accounts[0], nil
+}
+
+// simulationTx builds an unsigned transaction of instrs paid by payer, for
+// simulation with ReplaceRecentBlockhash: the node substitutes a recent
+// blockhash, so none is fetched.
+func simulationTx(payer solana.PublicKey, instrs ...solana.Instruction) (*solana.Transaction, error) {
+	tx, err := solana.NewTransaction(instrs, solana.Hash{}, solana.TransactionPayer(payer))
+	if err != nil {
+		return nil, fmt.Errorf("build tx for simulate: %w", err)
+	}
+	return tx, nil
+}
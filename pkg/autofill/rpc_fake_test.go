@@ -0,0 +1,173 @@
+package autofill
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/config"
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+)
+
+// fakeAccount is an account served by fakeRPC.
+type fakeAccount struct {
+	Owner    solana.PublicKey
+	Lamports uint64
+	Data     []byte
+}
+
+// fakeRPC is a minimal JSON-RPC server for tests that exercise the
+// *sdkrpc.Client HTTP path; everything else uses pkg/rpc/mock. It serves
+// getMultipleAccounts and getAccountInfo from an in-memory account map;
+// other methods can be stubbed through handlers.
+type fakeRPC struct {
+	url      string
+	mu       sync.Mutex
+	accounts map[solana.PublicKey]fakeAccount
+	handlers map[string]func(params json.RawMessage) (interface{}, error)
+	calls    map[string]int
+}
+
+func newFakeRPC(t *testing.T) (*fakeRPC, *sdkrpc.Client) {
+	t.Helper()
+	f := &fakeRPC{
+		accounts: make(map[solana.PublicKey]fakeAccount),
+		handlers: make(map[string]func(params json.RawMessage) (interface{}, error)),
+		calls:    make(map[string]int),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(srv.Close)
+	f.url = srv.URL
+
+	cfg := config.DefaultRPCConfig()
+	cfg.RPCURL = srv.URL
+	cfg.RateLimit.RPS = 0
+	cfg.Retry.Enabled = false
+	return f, sdkrpc.NewClient(cfg)
+}
+
+func (f *fakeRPC) setAccount(addr solana.PublicKey, acc fakeAccount) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.accounts[addr] = acc
+}
+
+func (f *fakeRPC) callCount(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[method]
+}
+
+func (f *fakeRPC) serve(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	f.calls[req.Method]++
+	handler := f.handlers[req.Method]
+	f.mu.Unlock()
+
+	var (
+		result interface{}
+		err    error
+	)
+	switch {
+	case handler != nil:
+		result, err = handler(req.Params)
+	case req.Method == "getMultipleAccounts":
+		result, err = f.getMultipleAccounts(req.Params)
+	case req.Method == "getAccountInfo":
+		result, err = f.getAccountInfo(req.Params)
+	default:
+		err = errMethodNotFound(req.Method)
+	}
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if err != nil {
+		resp["error"] = map[string]interface{}{"code": -32601, "message": err.Error()}
+	} else {
+		resp["result"] = result
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+type errMethodNotFound string
+
+func (e errMethodNotFound) Error() string { return "method not found: " + string(e) }
+
+func (f *fakeRPC) encode(addr string) interface{} {
+	pk, err := solana.PublicKeyFromBase58(addr)
+	if err != nil {
+		return nil
+	}
+	f.mu.Lock()
+	acc, ok := f.accounts[pk]
+	f.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return map[string]interface{}{
+		"data":       []string{base64.StdEncoding.EncodeToString(acc.Data), "base64"},
+		"executable": false,
+		"lamports":   acc.Lamports,
+		"owner":      acc.Owner.String(),
+		"rentEpoch":  0,
+		"space":      len(acc.Data),
+	}
+}
+
+func (f *fakeRPC) getMultipleAccounts(params json.RawMessage) (interface{}, error) {
+	var p []json.RawMessage
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	var addrs []string
+	if err := json.Unmarshal(p[0], &addrs); err != nil {
+		return nil, err
+	}
+	if len(addrs) > 100 {
+		return nil, fmt.Errorf("Too many inputs provided; max 100")
+	}
+	values := make([]interface{}, len(addrs))
+	for i, a := range addrs {
+		values[i] = f.encode(a)
+	}
+	return map[string]interface{}{"context": map[string]interface{}{"slot": 1}, "value": values}, nil
+}
+
+func (f *fakeRPC) getAccountInfo(params json.RawMessage) (interface{}, error) {
+	var p []json.RawMessage
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	var addr string
+	if err := json.Unmarshal(p[0], &addr); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"context": map[string]interface{}{"slot": 1}, "value": f.encode(addr)}, nil
+}
+
+// tokenAccountData encodes a minimal initialized SPL token account.
+func tokenAccountData(mint, owner solana.PublicKey, amount uint64) []byte {
+	data := make([]byte, 165)
+	copy(data[0:32], mint[:])
+	copy(data[32:64], owner[:])
+	binary.LittleEndian.PutUint64(data[64:72], amount)
+	data[108] = 1 // AccountState::Initialized
+	return data
+}
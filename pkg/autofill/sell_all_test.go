@@ -0,0 +1,89 @@
+package autofill
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+func TestPumpAmmSellAllForSol(t *testing.T) {
+	const (
+		rent      = 2_039_280
+		fee       = 5_000
+		balance   = 1_000_000_000
+		startSol  = 1_000_000_000
+		quoteOut  = 50_000_000
+		slippage  = 100
+		wantNet   = quoteOut - fee + rent
+		wantQuote = quoteOut * (10_000 - slippage) / 10_000
+	)
+	ctx := context.Background()
+	rpc, amm := loadAmmPool(t)
+	pool, baseMint := amm.Address("pool"), amm.Address("base_mint")
+	user := solana.NewWallet().PublicKey()
+	baseATA, _, _ := findATAWithProgram(user, baseMint, amm.Account("base_mint").Owner, constants.AssociatedTokenProgramID)
+	wsolATA, _, _ := findATAWithProgram(user, constants.WSOLMint, constants.TokenProgramID, constants.AssociatedTokenProgramID)
+
+	// No base ATA, then an empty one: nothing to sell.
+	if _, _, _, _, err := PumpAmmSellAllForSol(ctx, rpc, user, pool, slippage); !errors.Is(err, types.ErrInsufficientBalance) {
+		t.Fatalf("no base ATA: expected ErrInsufficientBalance, got %v", err)
+	}
+	rpc.SetAccount(baseATA, amm.Account("base_mint").Owner, 1, tokenAccountData(baseMint, user, 0))
+	if _, _, _, _, err := PumpAmmSellAllForSol(ctx, rpc, user, pool, slippage); !errors.Is(err, types.ErrInsufficientBalance) {
+		t.Fatalf("empty base ATA: expected ErrInsufficientBalance, got %v", err)
+	}
+
+	rpc.SetAccount(baseATA, amm.Account("base_mint").Owner, rent, tokenAccountData(baseMint, user, balance))
+	rpc.SetBalance(user, startSol)
+	simulations := 0
+	rpc.Simulate = func(_ context.Context, _ *solana.Transaction, opts *solanarpc.SimulateTransactionOpts) (*solanarpc.SimulateTransactionResponse, error) {
+		simulations++
+		// The sell credits the created WSOL ATA and closes the base ATA,
+		// whose rent pays for the WSOL ATA's.
+		var accounts []*solanarpc.Account
+		for _, addr := range opts.Accounts.Addresses {
+			var acc *solanarpc.Account
+			switch addr {
+			case user:
+				acc = &solanarpc.Account{Owner: constants.SystemProgramID, Lamports: startSol - fee}
+			case wsolATA:
+				acc = &solanarpc.Account{Owner: constants.TokenProgramID, Lamports: rent + quoteOut, Data: solanarpc.DataBytesOrJSONFromBytes(tokenAccountData(constants.WSOLMint, user, quoteOut))}
+			}
+			accounts = append(accounts, acc)
+		}
+		return &solanarpc.SimulateTransactionResponse{Value: &solanarpc.SimulateTransactionResult{Accounts: accounts}}, nil
+	}
+
+	accts, args, instrs, netSol, err := PumpAmmSellAllForSol(ctx, rpc, user, pool, slippage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if simulations != 1 {
+		t.Fatalf("simulated %d times, want once", simulations)
+	}
+	if args.BaseAmountIn != balance || args.MinQuoteAmountOut != wantQuote {
+		t.Fatalf("args = %+v, want the whole balance and min %d", args, wantQuote)
+	}
+	// Unwrapping returns the WSOL ATA's rent and the quote received.
+	if netSol != wantNet {
+		t.Fatalf("netSol = %d, want %d", netSol, wantNet)
+	}
+	var closedBase, unwrapped bool
+	for _, ix := range instrs {
+		data, _ := ix.Data()
+		if !ix.ProgramID().Equals(accts.BaseTokenProgram) && !ix.ProgramID().Equals(accts.QuoteTokenProgram) || len(data) != 1 || data[0] != 9 {
+			continue
+		}
+		closedBase = closedBase || ix.Accounts()[0].PublicKey.Equals(baseATA)
+		unwrapped = unwrapped || ix.Accounts()[0].PublicKey.Equals(wsolATA)
+	}
+	if !closedBase || !unwrapped {
+		t.Fatalf("closes base ATA %v, unwraps WSOL %v; want both", closedBase, unwrapped)
+	}
+}
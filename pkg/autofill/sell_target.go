@@ -0,0 +1,78 @@
+package autofill
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/curve"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// PumpSellForSolTarget sells just enough tokens on the bonding curve to
+// receive about targetSolOut lamports after fees, e.g. to recover a cost
+// basis. The token amount is solved from the curve's current reserves and
+// fees, clamped to the user's balance, and sold with PumpSellWithSlippage.
+// If the curve cannot pay targetSolOut, or the balance is too small, the
+// whole balance is sold.
+//
+// Returns accounts, args, instructions and the token amount sold.
+//
+// Example:
+//
+//	// Take 0.5 SOL off the table with 1% slippage
+//	_, _, instrs, tokens, err := autofill.PumpSellForSolTarget(ctx, rpc, user, mint, 500_000_000, 100)
+func PumpSellForSolTarget(ctx context.Context, rpc RPC, user, mint solana.PublicKey, targetSolOut, slippageBps uint64, opts ...Option) (pump.SellAccounts, pump.SellArgs, []solana.Instruction, uint64, error) {
+	// Input validation
+	if isNilRPC(rpc) {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, 0, types.ErrNilRPC
+	}
+	if err := types.ValidatePublicKey("user", user); err != nil {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, 0, err
+	}
+	if err := types.ValidatePublicKey("mint", mint); err != nil {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, 0, err
+	}
+	if targetSolOut == 0 {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, 0, types.NewValidationError("targetSolOut", "must be greater than 0")
+	}
+	if err := types.ValidateSlippage(slippageBps); err != nil {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, 0, err
+	}
+
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	accts, err := pumpAutofillSell(ctx, rpc, user, mint, options)
+	if err != nil {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, 0, err
+	}
+	global, bc, _, err := fetchPumpTradeState(ctx, rpc, accts.Global, mint, accts.BondingCurve, options)
+	if err != nil {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, 0, err
+	}
+	if bc.Complete {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, 0, fmt.Errorf("bonding curve for %s is complete", mint)
+	}
+
+	balances, err := fetchTokenAmountBatch(ctx, rpc, []solana.PublicKey{accts.AssociatedUser})
+	if err != nil {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, 0, err
+	}
+	balance := balances[accts.AssociatedUser.String()]
+	if balance == 0 {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, 0, fmt.Errorf("%w: no %s to sell", types.ErrInsufficientBalance, mint)
+	}
+
+	amount := min(curve.SellTokensForSolOut(pumpReserves(bc), global.TradeFeeBps(bc), targetSolOut), balance)
+
+	accts, args, instrs, err := PumpSellWithSlippage(ctx, rpc, user, mint, amount, slippageBps, opts...)
+	if err != nil {
+		return pump.SellAccounts{}, pump.SellArgs{}, nil, 0, err
+	}
+	return accts, args, instrs, amount, nil
+}
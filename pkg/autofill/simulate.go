@@ -0,0 +1,210 @@
+package autofill
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// tokenAccountSize is the length of an SPL token account (Token-2022 accounts
+// with extensions are longer but share the same prefix layout).
+const tokenAccountSize = 165
+
+// simulationCommitments returns the commitment for balance reads and the
+// one for the simulation itself. An empty commitment falls back to the
+// client's read commitment, and without one to the defaults: confirmed
+// reads, processed simulation.
+func simulationCommitments(rpc RPC, commitment solanarpc.CommitmentType) (read, bank solanarpc.CommitmentType) {
+	if commitment == "" {
+		commitment = clientReadCommitment(rpc)
+	}
+	if commitment == "" {
+		return solanarpc.CommitmentConfirmed, solanarpc.CommitmentProcessed
+	}
+	return commitment, commitment
+}
+
+// TradeSimulation is the decoded outcome of SimulateTrade.
+type TradeSimulation struct {
+	// BaseMint is the first non-WSOL mint whose balance changed for the user.
+	BaseMint solana.PublicKey
+	// BaseDelta is the change of the user's BaseMint balance (raw units).
+	BaseDelta int64
+	// QuoteDelta is the change of the user's WSOL token balance (lamports).
+	QuoteDelta int64
+	// SolDelta is the change of the user's native SOL balance (lamports),
+	// including fees, rent and any WSOL unwrap.
+	SolDelta int64
+	// TokenDeltas holds the change per mint across all the user's token
+	// accounts touched by the transaction.
+	TokenDeltas map[solana.PublicKey]int64
+	// UnitsConsumed is the compute units used by the simulation.
+	UnitsConsumed uint64
+	// Err is the decoded program error (via types.ParseSimulationError), or nil.
+	Err error
+	// Logs are the simulation logs.
+	Logs []string
+}
+
+// SimulateTrade simulates instrs with user as fee payer (no signature needed)
+// and returns the decoded balance changes for the user's wallet and token
+// accounts touched by the transaction. A failing program is reported in
+// TradeSimulation.Err; the returned error is only set when the simulation
+// itself could not run. WithSimulationCommitment selects the commitment the
+// simulation runs at; other options are ignored.
+//
+// Example:
+//
+//	_, _, instrs, _, err := autofill.PumpAmmBuyWithSol(ctx, client, user, pool, amount, 100)
+//	sim, err := autofill.SimulateTrade(ctx, client, user, instrs)
+//	if err != nil {
+//	    return err
+//	}
+//	if sim.Err != nil {
+//	    log.Printf("trade would fail: %v", sim.Err)
+//	}
+//	fmt.Printf("base +%d, sol %d, CU %d\n", sim.BaseDelta, sim.SolDelta, sim.UnitsConsumed)
+func SimulateTrade(ctx context.Context, rpc RPC, user solana.PublicKey, instrs []solana.Instruction, opts ...Option) (*TradeSimulation, error) {
+	if isNilRPC(rpc) {
+		return nil, types.ErrNilRPC
+	}
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	sim, _, err := simulateTrade(ctx, rpc, user, instrs, options)
+	return sim, err
+}
+
+// simulateTrade is SimulateTrade that also returns the simulated post-state
+// of the writable accounts, keyed by address (nil for a failed simulation).
+func simulateTrade(ctx context.Context, rpc RPC, user solana.PublicKey, instrs []solana.Instruction, options *Options) (*TradeSimulation, map[solana.PublicKey]*solanarpc.Account, error) {
+	if len(instrs) == 0 {
+		return nil, nil, fmt.Errorf("no instructions to simulate")
+	}
+
+	addrs := []solana.PublicKey{user}
+	seen := map[solana.PublicKey]struct{}{user: {}}
+	for _, ix := range instrs {
+		for _, meta := range ix.Accounts() {
+			if !meta.IsWritable {
+				continue
+			}
+			if _, ok := seen[meta.PublicKey]; ok {
+				continue
+			}
+			seen[meta.PublicKey] = struct{}{}
+			addrs = append(addrs, meta.PublicKey)
+		}
+	}
+
+	pre, err := fetchAccountsBatch(ctx, rpc, addrs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetch pre-simulation accounts: %w", err)
+	}
+
+	tx, err := simulationTx(user, instrs...)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, bank := simulationCommitments(rpc, options.SimulationCommitment)
+	res, err := rpc.SimulateTransaction(ctx, tx, &solanarpc.SimulateTransactionOpts{
+		SigVerify:              false,
+		ReplaceRecentBlockhash: true,
+		Commitment:             bank,
+		Accounts: &solanarpc.SimulateTransactionAccountsOpts{
+			Encoding:  solana.EncodingBase64,
+			Addresses: addrs,
+		},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("simulate tx: %w", err)
+	}
+	if res == nil || res.Value == nil {
+		return nil, nil, fmt.Errorf("simulate tx: empty result")
+	}
+
+	out := &TradeSimulation{
+		TokenDeltas: make(map[solana.PublicKey]int64),
+		Logs:        res.Value.Logs,
+	}
+	if res.Value.UnitsConsumed != nil {
+		out.UnitsConsumed = *res.Value.UnitsConsumed
+	}
+	if res.Value.Err != nil {
+		// Post-state is not returned for failed simulations.
+		out.Err = types.ParseSimulationErrorWithInstructions(res.Value.Err, res.Value.Logs, instrs)
+		return out, nil, nil
+	}
+	if len(res.Value.Accounts) != len(addrs) {
+		return nil, nil, fmt.Errorf("simulate tx: expected %d accounts, got %d", len(addrs), len(res.Value.Accounts))
+	}
+
+	post := make(map[solana.PublicKey]*solanarpc.Account, len(addrs))
+	for i, addr := range addrs {
+		post[addr] = res.Value.Accounts[i]
+		before := pre[addr.String()]
+		after := res.Value.Accounts[i]
+		if addr.Equals(user) {
+			out.SolDelta = int64(lamportsOf(after)) - int64(lamportsOf(before))
+			continue
+		}
+		mintBefore, amountBefore, okBefore := userTokenBalance(before, user)
+		mintAfter, amountAfter, okAfter := userTokenBalance(after, user)
+		switch {
+		case okBefore && okAfter:
+			out.TokenDeltas[mintAfter] += int64(amountAfter) - int64(amountBefore)
+		case okAfter:
+			out.TokenDeltas[mintAfter] += int64(amountAfter)
+		case okBefore:
+			// Closed during the transaction.
+			out.TokenDeltas[mintBefore] -= int64(amountBefore)
+		}
+	}
+
+	out.QuoteDelta = out.TokenDeltas[constants.WSOLMint]
+	// Pick the base mint deterministically in account order.
+	for i, addr := range addrs {
+		mint, _, ok := userTokenBalance(res.Value.Accounts[i], user)
+		if !ok {
+			mint, _, ok = userTokenBalance(pre[addr.String()], user)
+		}
+		if ok && !mint.Equals(constants.WSOLMint) && out.TokenDeltas[mint] != 0 {
+			out.BaseMint = mint
+			out.BaseDelta = out.TokenDeltas[mint]
+			break
+		}
+	}
+	return out, post, nil
+}
+
+// userTokenBalance decodes acc as a token account owned by user.
+func userTokenBalance(acc *solanarpc.Account, user solana.PublicKey) (solana.PublicKey, uint64, bool) {
+	if acc == nil || acc.Data == nil {
+		return solana.PublicKey{}, 0, false
+	}
+	if !acc.Owner.Equals(constants.TokenProgramID) && !acc.Owner.Equals(constants.Token2022ProgramID) {
+		return solana.PublicKey{}, 0, false
+	}
+	data := acc.Data.GetBinary()
+	if len(data) < tokenAccountSize {
+		return solana.PublicKey{}, 0, false
+	}
+	if !solana.PublicKeyFromBytes(data[32:64]).Equals(user) {
+		return solana.PublicKey{}, 0, false
+	}
+	return solana.PublicKeyFromBytes(data[0:32]), binary.LittleEndian.Uint64(data[64:72]), true
+}
+
+func lamportsOf(acc *solanarpc.Account) uint64 {
+	if acc == nil {
+		return 0
+	}
+	return acc.Lamports
+}
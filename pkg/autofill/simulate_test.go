@@ -0,0 +1,297 @@
+package autofill
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/config"
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// commitmentOf returns the commitment in the config object of a request's
+// params, or "" if there is none.
+func commitmentOf(t *testing.T, params json.RawMessage) solanarpc.CommitmentType {
+	t.Helper()
+	var p []json.RawMessage
+	if err := json.Unmarshal(params, &p); err != nil {
+		t.Fatal(err)
+	}
+	var cfg struct {
+		Commitment solanarpc.CommitmentType `json:"commitment"`
+	}
+	for _, raw := range p {
+		if json.Unmarshal(raw, &cfg) == nil && cfg.Commitment != "" {
+			return cfg.Commitment
+		}
+	}
+	return ""
+}
+
+// serveSimulatedTokenAccount answers simulateTransaction with a token
+// account of owner holding amount, recording the simulation's commitment
+// in got.
+func serveSimulatedTokenAccount(t *testing.T, fake *fakeRPC, owner solana.PublicKey, amount uint64, got *solanarpc.CommitmentType) {
+	t.Helper()
+	fake.handlers["simulateTransaction"] = func(params json.RawMessage) (interface{}, error) {
+		*got = commitmentOf(t, params)
+		data := tokenAccountData(solana.NewWallet().PublicKey(), owner, amount)
+		return map[string]interface{}{
+			"context": map[string]interface{}{"slot": 1},
+			"value": map[string]interface{}{
+				"err":  nil,
+				"logs": []string{},
+				"accounts": []interface{}{map[string]interface{}{
+					"data":       []string{base64.StdEncoding.EncodeToString(data), "base64"},
+					"executable": false,
+					"lamports":   2_039_280,
+					"owner":      constants.TokenProgramID.String(),
+					"rentEpoch":  0,
+				}},
+			},
+		}, nil
+	}
+}
+
+func TestSimulationCommitment(t *testing.T) {
+	user := solana.NewWallet().PublicKey()
+	baseATA := solana.NewWallet().PublicKey()
+	ix := system.NewTransferInstruction(1, user, baseATA).Build()
+
+	cases := []struct {
+		option, want solanarpc.CommitmentType
+	}{
+		{"", solanarpc.CommitmentProcessed},
+		{solanarpc.CommitmentProcessed, solanarpc.CommitmentProcessed},
+		{solanarpc.CommitmentFinalized, solanarpc.CommitmentFinalized},
+	}
+	for _, tc := range cases {
+		fake, rpc := newFakeRPC(t)
+		var got solanarpc.CommitmentType
+		serveSimulatedTokenAccount(t, fake, user, 500, &got)
+
+		out, err := simulateBaseOut(context.Background(), rpc, tc.option, user, baseATA, 100, ix)
+		if err != nil {
+			t.Fatalf("commitment %q: %v", tc.option, err)
+		}
+		if out != 400 {
+			t.Fatalf("commitment %q: base out = %d, want 400", tc.option, out)
+		}
+		if got != tc.want {
+			t.Errorf("commitment %q: simulated at %q, want %q", tc.option, got, tc.want)
+		}
+	}
+}
+
+func TestReadCommitment(t *testing.T) {
+	user := solana.NewWallet().PublicKey()
+	baseATA := solana.NewWallet().PublicKey()
+	ix := system.NewTransferInstruction(1, user, baseATA).Build()
+
+	cases := []struct {
+		client, option     solanarpc.CommitmentType
+		wantRead, wantBank solanarpc.CommitmentType
+	}{
+		{"", "", solanarpc.CommitmentConfirmed, solanarpc.CommitmentProcessed},
+		{solanarpc.CommitmentProcessed, "", solanarpc.CommitmentProcessed, solanarpc.CommitmentProcessed},
+		{solanarpc.CommitmentFinalized, "", solanarpc.CommitmentFinalized, solanarpc.CommitmentFinalized},
+		// WithSimulationCommitment overrides the client for simulations.
+		{solanarpc.CommitmentFinalized, solanarpc.CommitmentProcessed, solanarpc.CommitmentFinalized, solanarpc.CommitmentProcessed},
+	}
+	for _, tc := range cases {
+		fake, _ := newFakeRPC(t)
+		cfg := config.DefaultRPCConfig()
+		cfg.RPCURL = fake.url
+		cfg.RateLimit.RPS = 0
+		cfg.Retry.Enabled = false
+		cfg.ReadCommitment = string(tc.client)
+		rpc := sdkrpc.NewClient(cfg)
+
+		var read, bank solanarpc.CommitmentType
+		fake.handlers["getMultipleAccounts"] = func(params json.RawMessage) (interface{}, error) {
+			read = commitmentOf(t, params)
+			return fake.getMultipleAccounts(params)
+		}
+		serveSimulatedTokenAccount(t, fake, user, 500, &bank)
+
+		if _, err := fetchAccountsBatch(context.Background(), rpc, user); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := simulateBaseOut(context.Background(), rpc, tc.option, user, baseATA, 100, ix); err != nil {
+			t.Fatal(err)
+		}
+		if read != tc.wantRead || bank != tc.wantBank {
+			t.Errorf("client %q option %q: read at %q, simulated at %q; want %q, %q", tc.client, tc.option, read, bank, tc.wantRead, tc.wantBank)
+		}
+	}
+}
+
+func TestSimulateTrade(t *testing.T) {
+	user := solana.NewWallet().PublicKey()
+	other := solana.NewWallet().PublicKey()
+	base := solana.NewWallet().PublicKey()
+	wsolATA := solana.NewWallet().PublicKey()
+	baseATA := solana.NewWallet().PublicKey()
+	foreignATA := solana.NewWallet().PublicKey()
+	ix := solana.NewInstruction(constants.PumpAmmProgramID, solana.AccountMetaSlice{
+		solana.NewAccountMeta(user, true, true),
+		solana.NewAccountMeta(wsolATA, true, false),
+		solana.NewAccountMeta(baseATA, true, false),
+		solana.NewAccountMeta(foreignATA, true, false),
+		solana.NewAccountMeta(base, false, false),
+	}, nil)
+
+	wallet := func(lamports uint64) *solanarpc.Account {
+		return &solanarpc.Account{Owner: constants.SystemProgramID, Lamports: lamports}
+	}
+	tokenAcc := func(mint, owner solana.PublicKey, amount uint64) *solanarpc.Account {
+		return &solanarpc.Account{Owner: constants.TokenProgramID, Lamports: 2_039_280, Data: solanarpc.DataBytesOrJSONFromBytes(tokenAccountData(mint, owner, amount))}
+	}
+
+	cases := []struct {
+		name string
+		// pre is keyed by address; post follows the simulated addresses:
+		// user, wsolATA, baseATA, foreignATA.
+		pre    map[solana.PublicKey]*solanarpc.Account
+		post   []*solanarpc.Account
+		simErr interface{}
+
+		wantErr                      bool
+		wantBaseMint                 solana.PublicKey
+		wantBase, wantQuote, wantSol int64
+		wantCode                     int
+	}{
+		{
+			name: "buy creates base ATA",
+			pre: map[solana.PublicKey]*solanarpc.Account{
+				user:       wallet(10_000_000_000),
+				wsolATA:    tokenAcc(constants.WSOLMint, user, 0),
+				foreignATA: tokenAcc(base, other, 100),
+			},
+			post: []*solanarpc.Account{
+				wallet(8_990_000_000),
+				tokenAcc(constants.WSOLMint, user, 0),
+				tokenAcc(base, user, 5_000),
+				// Another wallet's balance does not count.
+				tokenAcc(base, other, 0),
+			},
+			wantBaseMint: base, wantBase: 5_000, wantSol: -1_010_000_000,
+		},
+		{
+			name: "sell closes base and WSOL ATAs",
+			pre: map[solana.PublicKey]*solanarpc.Account{
+				user:    wallet(1_000_000_000),
+				wsolATA: tokenAcc(constants.WSOLMint, user, 0),
+				baseATA: tokenAcc(base, user, 5_000),
+			},
+			post:         []*solanarpc.Account{wallet(1_504_073_560), nil, nil, nil},
+			wantBaseMint: base, wantBase: -5_000, wantSol: 504_073_560,
+		},
+		{
+			name: "quote only",
+			pre: map[solana.PublicKey]*solanarpc.Account{
+				user:    wallet(1_000_000_000),
+				wsolATA: tokenAcc(constants.WSOLMint, user, 0),
+				baseATA: tokenAcc(base, user, 5_000),
+			},
+			post: []*solanarpc.Account{
+				wallet(999_995_000),
+				tokenAcc(constants.WSOLMint, user, 700),
+				tokenAcc(base, user, 5_000),
+				nil,
+			},
+			wantQuote: 700, wantSol: -5_000,
+		},
+		{
+			name:     "program error",
+			pre:      map[solana.PublicKey]*solanarpc.Account{user: wallet(1_000_000_000)},
+			simErr:   map[string]interface{}{"InstructionError": []interface{}{float64(0), map[string]interface{}{"Custom": float64(6004)}}},
+			wantCode: 6004,
+		},
+		{
+			name:    "missing post accounts",
+			pre:     map[solana.PublicKey]*solanarpc.Account{user: wallet(1_000_000_000)},
+			post:    []*solanarpc.Account{wallet(1_000_000_000)},
+			wantErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rpc := mock.New()
+			for addr, acc := range tc.pre {
+				rpc.SetAccount(addr, acc.Owner, acc.Lamports, acc.Data.GetBinary())
+			}
+			units := uint64(42_000)
+			var simOpts *solanarpc.SimulateTransactionOpts
+			rpc.Simulate = func(_ context.Context, _ *solana.Transaction, opts *solanarpc.SimulateTransactionOpts) (*solanarpc.SimulateTransactionResponse, error) {
+				simOpts = opts
+				return &solanarpc.SimulateTransactionResponse{Value: &solanarpc.SimulateTransactionResult{
+					Err:           tc.simErr,
+					Logs:          []string{"Program log: test"},
+					Accounts:      tc.post,
+					UnitsConsumed: &units,
+				}}, nil
+			}
+
+			sim, err := SimulateTrade(context.Background(), rpc, user, []solana.Instruction{ix})
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if simOpts.Commitment != solanarpc.CommitmentProcessed {
+				t.Fatalf("simulated at %q, want processed by default", simOpts.Commitment)
+			}
+			if sim.UnitsConsumed != units || len(sim.Logs) != 1 {
+				t.Fatalf("units %d, logs %v", sim.UnitsConsumed, sim.Logs)
+			}
+			if tc.wantCode != 0 {
+				var pe *types.ProgramError
+				if !errors.As(sim.Err, &pe) || pe.Code != tc.wantCode || pe.InstructionIndex != 0 {
+					t.Fatalf("Err = %v, want program error %d", sim.Err, tc.wantCode)
+				}
+				return
+			}
+			if sim.Err != nil {
+				t.Fatalf("unexpected Err %v", sim.Err)
+			}
+			if !sim.BaseMint.Equals(tc.wantBaseMint) || sim.BaseDelta != tc.wantBase || sim.QuoteDelta != tc.wantQuote || sim.SolDelta != tc.wantSol {
+				t.Fatalf("got base %s %d, quote %d, sol %d; want base %s %d, quote %d, sol %d",
+					sim.BaseMint, sim.BaseDelta, sim.QuoteDelta, sim.SolDelta, tc.wantBaseMint, tc.wantBase, tc.wantQuote, tc.wantSol)
+			}
+			if sim.TokenDeltas[base] != tc.wantBase || sim.TokenDeltas[constants.WSOLMint] != tc.wantQuote {
+				t.Fatalf("TokenDeltas = %v", sim.TokenDeltas)
+			}
+		})
+	}
+
+	rpc := mock.New()
+	rpc.SetBalance(user, 1)
+	var simOpts *solanarpc.SimulateTransactionOpts
+	rpc.Simulate = func(_ context.Context, _ *solana.Transaction, opts *solanarpc.SimulateTransactionOpts) (*solanarpc.SimulateTransactionResponse, error) {
+		simOpts = opts
+		return &solanarpc.SimulateTransactionResponse{Value: &solanarpc.SimulateTransactionResult{Accounts: make([]*solanarpc.Account, 4)}}, nil
+	}
+	if _, err := SimulateTrade(context.Background(), rpc, user, []solana.Instruction{ix}, WithSimulationCommitment(solanarpc.CommitmentFinalized)); err != nil {
+		t.Fatal(err)
+	}
+	if simOpts.Commitment != solanarpc.CommitmentFinalized {
+		t.Fatalf("simulated at %q, want the WithSimulationCommitment value", simOpts.Commitment)
+	}
+	if _, err := SimulateTrade(context.Background(), rpc, user, nil); err == nil {
+		t.Fatal("expected an error without instructions")
+	}
+}
@@ -0,0 +1,174 @@
+package autofill
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+)
+
+// testPool returns a pool state and the address it derives to.
+func testPool(t *testing.T) (pumpamm.Pool, solana.PublicKey) {
+	t.Helper()
+	p := pumpamm.Pool{
+		Index:     3,
+		Creator:   solana.NewWallet().PublicKey(),
+		BaseMint:  solana.NewWallet().PublicKey(),
+		QuoteMint: constants.WSOLMint,
+	}
+	var index [2]byte
+	binary.LittleEndian.PutUint16(index[:], p.Index)
+	addr, bump, err := solana.FindProgramAddress([][]byte{
+		[]byte(constants.SeedPool), index[:], p.Creator[:], p.BaseMint[:], p.QuoteMint[:],
+	}, pumpamm.ProgramKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.PoolBump = bump
+	return p, addr
+}
+
+func TestFetchAmmCoreInjectedState(t *testing.T) {
+	pool, addr := testPool(t)
+	rpc := mock.New()
+	rpc.SetAccount(pool.BaseMint, constants.Token2022ProgramID, 1, nil)
+	rpc.SetAccount(pool.QuoteMint, constants.TokenProgramID, 1, nil)
+
+	options := &Options{}
+	WithPoolState(pool)(options)
+	WithGlobalConfig(pumpamm.GlobalConfig{LpFeeBasisPoints: 20})(options)
+
+	core, err := fetchAmmCore(context.Background(), rpc, addr, solana.NewWallet().PublicKey(), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if core.Pool.BaseMint != pool.BaseMint || core.GlobalConfig.LpFeeBasisPoints != 20 {
+		t.Fatalf("injected state not used: %+v", core)
+	}
+	if !core.BaseTokenProgram.Equals(constants.Token2022ProgramID) {
+		t.Fatalf("base token program = %s", core.BaseTokenProgram)
+	}
+	if n := rpc.Calls("GetMultipleAccounts"); n != 1 {
+		t.Fatalf("getMultipleAccounts calls = %d, want 1 (mints only)", n)
+	}
+
+	_, err = fetchAmmCore(context.Background(), rpc, solana.NewWallet().PublicKey(), solana.PublicKey{}, options)
+	if err == nil || !strings.Contains(err.Error(), "does not match pool") {
+		t.Fatalf("mismatched pool: err = %v", err)
+	}
+}
+
+func TestPumpBuyTokenProgramFromMint(t *testing.T) {
+	rpc := mock.New()
+	user := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	creator := solana.NewWallet().PublicKey()
+	bondingCurve, _, err := pump.DeriveBuyBondingCurvePDA(pump.BuyAccounts{Mint: mint}, pump.BuyArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rpc.SetAccount(mint, constants.Token2022ProgramID, 1, make([]byte, 82))
+	rpc.SetAccount(bondingCurve, pump.ProgramKey, 1, curveData(t, pump.BondingCurve{
+		VirtualTokenReserves: 1_073_000_000_000_000,
+		VirtualSolReserves:   30_000_000_000,
+		RealTokenReserves:    793_100_000_000_000,
+		Creator:              creator,
+	}))
+	global := pump.Global{FeeRecipient: solana.NewWallet().PublicKey(), FeeBasisPoints: 95, CreatorFeeBasisPoints: 30}
+
+	accts, args, instrs, err := PumpBuy(context.Background(), rpc, user, mint, 1_000_000, 100_000_000, WithPumpGlobal(global))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !accts.TokenProgram.Equals(constants.Token2022ProgramID) {
+		t.Fatalf("token program = %s, want Token-2022 from the mint owner", accts.TokenProgram)
+	}
+	if !accts.BondingCurve.Equals(bondingCurve) || !accts.FeeRecipient.Equals(global.FeeRecipient) {
+		t.Fatalf("unexpected accounts %+v", accts)
+	}
+	if args.Amount != 1_000_000 || args.MaxSolCost != 100_000_000 {
+		t.Fatalf("unexpected args %+v", args)
+	}
+	if len(instrs) == 0 {
+		t.Fatal("expected instructions")
+	}
+}
+
+func TestPumpBuyAccountWait(t *testing.T) {
+	rpc := mock.New()
+	user := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	bondingCurve, _, err := pump.DeriveBuyBondingCurvePDA(pump.BuyAccounts{Mint: mint}, pump.BuyArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rpc.SetAccount(mint, constants.TokenProgramID, 1, make([]byte, 82))
+	rpc.SetAccount(bondingCurve, pump.ProgramKey, 1, curveData(t, pump.BondingCurve{
+		VirtualTokenReserves: 1_073_000_000_000_000,
+		VirtualSolReserves:   30_000_000_000,
+		RealTokenReserves:    793_100_000_000_000,
+		Creator:              solana.NewWallet().PublicKey(),
+	}))
+	global := WithPumpGlobal(pump.Global{FeeRecipient: solana.NewWallet().PublicKey(), FeeBasisPoints: 95})
+
+	// Without a wait, a curve the node has not seen yet fails the buy.
+	rpc.Hide(bondingCurve, 2)
+	if _, _, _, err := PumpBuy(context.Background(), rpc, user, mint, 1_000_000, 100_000_000, global); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected bonding curve not found, got %v", err)
+	}
+
+	// With one, the buy polls until the curve appears.
+	rpc.Hide(bondingCurve, 2)
+	reads := rpc.Reads(bondingCurve)
+	if _, _, _, err := PumpBuy(context.Background(), rpc, user, mint, 1_000_000, 100_000_000, global,
+		WithAccountWait(time.Second, time.Millisecond)); err != nil {
+		t.Fatalf("buy after the curve appears: %v", err)
+	}
+	if n := rpc.Reads(bondingCurve) - reads; n < 3 {
+		t.Fatalf("curve read %d times, want it polled past 2 missing reads", n)
+	}
+
+	// A curve that never appears fails once the wait runs out.
+	rpc.Hide(bondingCurve, 1_000_000)
+	start := time.Now()
+	if _, _, _, err := PumpBuy(context.Background(), rpc, user, mint, 1_000_000, 100_000_000, global,
+		WithAccountWait(20*time.Millisecond, 5*time.Millisecond)); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected bonding curve not found, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond || elapsed > time.Second {
+		t.Fatalf("gave up after %v, want about the 20ms wait", elapsed)
+	}
+}
+
+func TestFetchAmmCoreAccountWait(t *testing.T) {
+	rpc, amm := loadAmmPool(t)
+	pool, globalConfig := amm.Address("pool"), amm.Address("global_config")
+
+	rpc.Hide(pool, 2)
+	if _, err := fetchAmmCore(context.Background(), rpc, pool, globalConfig, &Options{}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected pool not found, got %v", err)
+	}
+
+	rpc.Hide(pool, 2)
+	reads := rpc.Reads(pool)
+	core, err := fetchAmmCore(context.Background(), rpc, pool, globalConfig, &Options{
+		AccountWait: time.Second, AccountWaitInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("pool after it appears: %v", err)
+	}
+	if core.Pool.BaseMint.IsZero() {
+		t.Fatal("pool not decoded")
+	}
+	if n := rpc.Reads(pool) - reads; n != 3 {
+		t.Fatalf("pool read %d times, want 3", n)
+	}
+}
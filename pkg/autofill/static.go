@@ -0,0 +1,43 @@
+package autofill
+
+import (
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+)
+
+// StaticAccountAddresses returns the accounts autofill reads on every trade
+// that rarely change: the pump Global, the AMM global_config and both
+// programs' fee_config.
+func StaticAccountAddresses() []solana.PublicKey {
+	var addrs []solana.PublicKey
+	if pk, _, err := pump.DeriveBuyGlobalPDA(pump.BuyAccounts{}, pump.BuyArgs{}); err == nil {
+		addrs = append(addrs, pk)
+	}
+	if pk, _, err := pump.DeriveBuyFeeConfigPDA(pump.BuyAccounts{FeeProgram: constants.PumpFeeProgramID}, pump.BuyArgs{}); err == nil {
+		addrs = append(addrs, pk)
+	}
+	if pk, err := deriveAmmGlobalConfigPDA(); err == nil {
+		addrs = append(addrs, pk)
+	}
+	if pk, _, err := pumpamm.DeriveBuyFeeConfigPDA(pumpamm.BuyAccounts{FeeProgram: constants.PumpAmmFeeProgramID}, pumpamm.BuyArgs{}); err == nil {
+		addrs = append(addrs, pk)
+	}
+	return addrs
+}
+
+// StaticAccountTTLs maps each of StaticAccountAddresses to ttl, for
+// rpc.NewCachingClient. Fee changes take up to ttl to be seen, so keep it
+// short (a minute or so) when trading with tight slippage.
+func StaticAccountTTLs(ttl time.Duration) map[string]time.Duration {
+	addrs := StaticAccountAddresses()
+	ttls := make(map[string]time.Duration, len(addrs))
+	for _, addr := range addrs {
+		ttls[addr.String()] = ttl
+	}
+	return ttls
+}
@@ -0,0 +1,48 @@
+package autofill
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+)
+
+func TestCachingClientStaticAccounts(t *testing.T) {
+	fake, inner := newFakeRPC(t)
+	rpc := sdkrpc.NewCachingClient(inner, StaticAccountTTLs(time.Minute))
+	global := StaticAccountAddresses()[0]
+	curve := solana.NewWallet().PublicKey()
+	owner := solana.NewWallet().PublicKey()
+	fake.setAccount(global, fakeAccount{Owner: owner, Lamports: 1})
+	fake.setAccount(curve, fakeAccount{Owner: owner, Lamports: 1})
+
+	if _, err := fetchAccountsBatch(context.Background(), rpc, global, curve); err != nil {
+		t.Fatal(err)
+	}
+	fake.setAccount(global, fakeAccount{Owner: owner, Lamports: 2})
+	fake.setAccount(curve, fakeAccount{Owner: owner, Lamports: 2})
+
+	amap, err := fetchAccountsBatch(context.Background(), rpc, global, curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := amap[global.String()].Lamports; got != 1 {
+		t.Errorf("global lamports = %d, want the cached 1", got)
+	}
+	if got := amap[curve.String()].Lamports; got != 2 {
+		t.Errorf("curve lamports = %d, want the fresh 2", got)
+	}
+
+	if _, err := fetchAccountsBatch(context.Background(), rpc, global); err != nil {
+		t.Fatal(err)
+	}
+	if n := fake.callCount("getMultipleAccounts"); n != 2 {
+		t.Errorf("getMultipleAccounts calls = %d, want 2", n)
+	}
+	if stats := rpc.CacheStats(); stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("stats = %+v, want 2 hits and 1 miss", stats)
+	}
+}
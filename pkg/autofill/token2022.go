@@ -0,0 +1,154 @@
+package autofill
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+)
+
+// Token-2022 mint layout: the 82-byte base mint is padded to the token account
+// size, followed by the account type byte and TLV-encoded extensions.
+const (
+	token2022AccountTypeOffset  = tokenAccountSize
+	token2022ExtensionsOffset   = tokenAccountSize + 1
+	token2022AccountTypeMint    = 1
+	token2022AccountTypeAccount = 2
+
+	extensionTransferFeeConfig = 1
+	transferFeeConfigLen       = 108
+	extensionTransferFeeAmount = 2
+	transferFeeAmountLen       = 8
+)
+
+// transferFee is one Token-2022 fee schedule entry.
+type transferFee struct {
+	Epoch       uint64
+	MaximumFee  uint64
+	BasisPoints uint16
+}
+
+// calculate returns ceil(amount * bps / 10000), capped at MaximumFee.
+func (f transferFee) calculate(amount uint64) uint64 {
+	if f.BasisPoints == 0 || amount == 0 {
+		return 0
+	}
+	num := new(big.Int).Mul(new(big.Int).SetUint64(amount), big.NewInt(int64(f.BasisPoints)))
+	num.Add(num, big.NewInt(9_999))
+	num.Quo(num, big.NewInt(10_000))
+	if !num.IsUint64() || num.Uint64() > f.MaximumFee {
+		return f.MaximumFee
+	}
+	return num.Uint64()
+}
+
+// transferFeeConfig is the decoded TransferFeeConfig mint extension.
+type transferFeeConfig struct {
+	Older transferFee
+	Newer transferFee
+}
+
+// maxFee returns the larger fee of the two schedules for amount.
+func (c transferFeeConfig) maxFee(amount uint64) uint64 {
+	older, newer := c.Older.calculate(amount), c.Newer.calculate(amount)
+	if older > newer {
+		return older
+	}
+	return newer
+}
+
+// parseTransferFeeConfig extracts the TransferFeeConfig extension from
+// Token-2022 mint data. ok is false for SPL mints or mints without the extension.
+func parseTransferFeeConfig(data []byte) (cfg transferFeeConfig, ok bool) {
+	if len(data) <= token2022ExtensionsOffset || data[token2022AccountTypeOffset] != token2022AccountTypeMint {
+		return cfg, false
+	}
+	for off := token2022ExtensionsOffset; off+4 <= len(data); {
+		typ := binary.LittleEndian.Uint16(data[off:])
+		length := int(binary.LittleEndian.Uint16(data[off+2:]))
+		off += 4
+		if off+length > len(data) {
+			return cfg, false
+		}
+		if typ == extensionTransferFeeConfig && length >= transferFeeConfigLen {
+			v := data[off:]
+			// authorities (32 + 32) and withheld_amount (8) precede the schedules.
+			cfg.Older = decodeTransferFee(v[72:90])
+			cfg.Newer = decodeTransferFee(v[90:108])
+			return cfg, true
+		}
+		if typ == 0 {
+			// Uninitialized padding.
+			return cfg, false
+		}
+		off += length
+	}
+	return cfg, false
+}
+
+// withheldTransferFee returns the transfer fees withheld in a Token-2022
+// token account's TransferFeeAmount extension, or 0 for SPL accounts and
+// accounts without the extension.
+func withheldTransferFee(data []byte) uint64 {
+	if len(data) <= token2022ExtensionsOffset || data[token2022AccountTypeOffset] != token2022AccountTypeAccount {
+		return 0
+	}
+	for off := token2022ExtensionsOffset; off+4 <= len(data); {
+		typ := binary.LittleEndian.Uint16(data[off:])
+		length := int(binary.LittleEndian.Uint16(data[off+2:]))
+		off += 4
+		if off+length > len(data) || typ == 0 {
+			return 0
+		}
+		if typ == extensionTransferFeeAmount && length >= transferFeeAmountLen {
+			return binary.LittleEndian.Uint64(data[off:])
+		}
+		off += length
+	}
+	return 0
+}
+
+func decodeTransferFee(b []byte) transferFee {
+	return transferFee{
+		Epoch:       binary.LittleEndian.Uint64(b[0:8]),
+		MaximumFee:  binary.LittleEndian.Uint64(b[8:16]),
+		BasisPoints: binary.LittleEndian.Uint16(b[16:18]),
+	}
+}
+
+// fetchTransferFeeConfig loads mint and decodes its transfer-fee extension.
+func fetchTransferFeeConfig(ctx context.Context, rpc RPC, mint solana.PublicKey) (transferFeeConfig, bool, error) {
+	amap, err := fetchAccountsBatch(ctx, rpc, mint)
+	if err != nil {
+		return transferFeeConfig{}, false, fmt.Errorf("fetch mint %s: %w", mint, err)
+	}
+	acc := amap[mint.String()]
+	if acc == nil || acc.Data == nil || !acc.Owner.Equals(constants.Token2022ProgramID) {
+		return transferFeeConfig{}, false, nil
+	}
+	cfg, ok := parseTransferFeeConfig(acc.Data.GetBinary())
+	return cfg, ok, nil
+}
+
+// adjustForTransferFee scales expectedOut by (amount - fee) / amount when
+// options.TransferFeeAware is set and mint carries a transfer fee.
+func adjustForTransferFee(ctx context.Context, rpc RPC, options *Options, mint, tokenProgram solana.PublicKey, amount, expectedOut uint64) (uint64, error) {
+	if options == nil || !options.TransferFeeAware || !tokenProgram.Equals(constants.Token2022ProgramID) || amount == 0 {
+		return expectedOut, nil
+	}
+	cfg, ok, err := fetchTransferFeeConfig(ctx, rpc, mint)
+	if err != nil || !ok {
+		return expectedOut, err
+	}
+	fee := cfg.maxFee(amount)
+	if fee >= amount {
+		return 0, nil
+	}
+	out := new(big.Int).Mul(new(big.Int).SetUint64(expectedOut), new(big.Int).SetUint64(amount-fee))
+	out.Quo(out, new(big.Int).SetUint64(amount))
+	return out.Uint64(), nil
+}
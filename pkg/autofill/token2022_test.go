@@ -0,0 +1,119 @@
+package autofill
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+)
+
+// transferFeeMintData encodes a Token-2022 mint with a TransferFeeConfig extension.
+func transferFeeMintData(older, newer transferFee) []byte {
+	data := make([]byte, token2022ExtensionsOffset, token2022ExtensionsOffset+4+transferFeeConfigLen)
+	data[44] = 6 // decimals
+	data[45] = 1 // is_initialized
+	data[token2022AccountTypeOffset] = token2022AccountTypeMint
+
+	ext := make([]byte, 4+transferFeeConfigLen)
+	binary.LittleEndian.PutUint16(ext[0:], extensionTransferFeeConfig)
+	binary.LittleEndian.PutUint16(ext[2:], transferFeeConfigLen)
+	put := func(off int, f transferFee) {
+		binary.LittleEndian.PutUint64(ext[off:], f.Epoch)
+		binary.LittleEndian.PutUint64(ext[off+8:], f.MaximumFee)
+		binary.LittleEndian.PutUint16(ext[off+16:], f.BasisPoints)
+	}
+	put(4+72, older)
+	put(4+90, newer)
+	return append(data, ext...)
+}
+
+func TestParseTransferFeeConfig(t *testing.T) {
+	data := transferFeeMintData(
+		transferFee{Epoch: 1, MaximumFee: 1_000, BasisPoints: 50},
+		transferFee{Epoch: 10, MaximumFee: 1 << 62, BasisPoints: 100},
+	)
+	cfg, ok := parseTransferFeeConfig(data)
+	if !ok {
+		t.Fatal("expected transfer fee extension")
+	}
+	if cfg.Newer.BasisPoints != 100 || cfg.Older.MaximumFee != 1_000 {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+	// older: ceil(1_000_000*50/10000)=5000 capped at 1000; newer: 10000.
+	if fee := cfg.maxFee(1_000_000); fee != 10_000 {
+		t.Fatalf("maxFee: got %d, want 10000", fee)
+	}
+	if _, ok := parseTransferFeeConfig(make([]byte, 82)); ok {
+		t.Fatal("SPL mint must not report a transfer fee")
+	}
+}
+
+func TestAdjustForTransferFee(t *testing.T) {
+	client := mock.New()
+	mint := solana.NewWallet().PublicKey()
+	fee := transferFee{MaximumFee: 1 << 62, BasisPoints: 200} // 2%
+	client.SetAccount(mint, constants.Token2022ProgramID, 1, transferFeeMintData(fee, fee))
+	ctx := context.Background()
+
+	opts := &Options{TransferFeeAware: true}
+	got, err := adjustForTransferFee(ctx, client, opts, mint, constants.Token2022ProgramID, 1_000_000, 500_000)
+	if err != nil {
+		t.Fatalf("adjustForTransferFee: %v", err)
+	}
+	if got != 490_000 {
+		t.Fatalf("expected 2%% reduction to 490000, got %d", got)
+	}
+
+	got, err = adjustForTransferFee(ctx, client, &Options{}, mint, constants.Token2022ProgramID, 1_000_000, 500_000)
+	if err != nil || got != 500_000 {
+		t.Fatalf("option disabled must not adjust: got %d, err %v", got, err)
+	}
+	if n := client.Calls("GetMultipleAccounts"); n != 1 {
+		t.Fatalf("expected a single mint fetch, got %d", n)
+	}
+}
+
+func TestPumpBuyToken2022(t *testing.T) {
+	rpc := mock.New()
+	user := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	rpc.SetAccount(mint, constants.Token2022ProgramID, 1, make([]byte, 82))
+
+	accts, _, instrs, err := PumpBuy(context.Background(), rpc, user, mint, 1_000, 1_000_000,
+		WithPumpGlobal(pump.Global{FeeRecipient: solana.NewWallet().PublicKey()}),
+		WithBondingCurve(pump.BondingCurve{Creator: solana.NewWallet().PublicKey()}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !accts.TokenProgram.Equals(constants.Token2022ProgramID) {
+		t.Fatalf("token program = %s, want Token-2022", accts.TokenProgram)
+	}
+	curveATA, _, err := findATAWithProgram(accts.BondingCurve, mint, constants.Token2022ProgramID, constants.AssociatedTokenProgramID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !accts.AssociatedBondingCurve.Equals(curveATA) {
+		t.Fatalf("bonding curve ATA = %s, want %s (Token-2022)", accts.AssociatedBondingCurve, curveATA)
+	}
+
+	// Neither ATA exists: both creates must run under Token-2022.
+	if len(instrs) != 3 {
+		t.Fatalf("got %d instructions, want 2 ATA creates and the buy", len(instrs))
+	}
+	for i, want := range []solana.PublicKey{accts.AssociatedUser, accts.AssociatedBondingCurve} {
+		ix := instrs[i]
+		if !ix.ProgramID().Equals(constants.AssociatedTokenProgramID) {
+			t.Fatalf("instruction %d: program %s, want the ATA program", i, ix.ProgramID())
+		}
+		metas := ix.Accounts()
+		if !metas[1].PublicKey.Equals(want) || !metas[5].PublicKey.Equals(constants.Token2022ProgramID) {
+			t.Errorf("instruction %d creates %s under %s, want %s under Token-2022", i, metas[1].PublicKey, metas[5].PublicKey, want)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package autofill
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// Trade is a fluent builder for a buy or sell that picks the underlying
+// autofill function: the bonding curve while it trades, the mint's pump AMM
+// pool once it has graduated (or the pool set with Pool), and the exact-SOL
+// or exact-token variant depending on WithSol or WithTokens. Setters only
+// record values; Build validates them.
+//
+// Example:
+//
+//	instrs, err := autofill.NewTrade(rpc).Buy(mint).WithSol(100_000_000).Slippage(500).Jito(10_000).Build(ctx, user)
+type Trade struct {
+	rpc         RPC
+	mint        solana.PublicKey
+	pool        solana.PublicKey
+	sell        bool
+	sideSet     bool
+	sol         uint64
+	tokens      uint64
+	maxSol      uint64
+	slippageBps uint64
+	attempts    int
+	opts        []Option
+}
+
+// NewTrade starts a trade against rpc.
+func NewTrade(rpc RPC) *Trade {
+	return &Trade{rpc: rpc, attempts: 1}
+}
+
+// Buy makes the trade a buy of mint.
+func (t *Trade) Buy(mint solana.PublicKey) *Trade {
+	t.mint, t.sell, t.sideSet = mint, false, true
+	return t
+}
+
+// Sell makes the trade a sell of mint.
+func (t *Trade) Sell(mint solana.PublicKey) *Trade {
+	t.mint, t.sell, t.sideSet = mint, true, true
+	return t
+}
+
+// Pool trades in the given pump AMM pool instead of detecting the venue.
+func (t *Trade) Pool(pool solana.PublicKey) *Trade {
+	t.pool = pool
+	return t
+}
+
+// WithSol sets the SOL side in lamports: spent exactly by a buy, or the
+// proceeds a bonding curve sell aims for (see PumpSellForSolTarget).
+func (t *Trade) WithSol(lamports uint64) *Trade {
+	t.sol, t.tokens = lamports, 0
+	return t
+}
+
+// WithTokens sets the token amount, in base units, bought or sold exactly.
+// A buy by token amount also needs MaxSol.
+func (t *Trade) WithTokens(amount uint64) *Trade {
+	t.tokens, t.sol = amount, 0
+	return t
+}
+
+// MaxSol caps the lamports a WithTokens buy may spend.
+func (t *Trade) MaxSol(lamports uint64) *Trade {
+	t.maxSol = lamports
+	return t
+}
+
+// Slippage sets the slippage tolerance in basis points.
+func (t *Trade) Slippage(bps uint64) *Trade {
+	t.slippageBps = bps
+	return t
+}
+
+// Retries sets how many times an exact-SOL bonding curve buy is re-quoted
+// after failing on slippage (see PumpBuyWithRetry). The default is 1.
+func (t *Trade) Retries(maxAttempts int) *Trade {
+	t.attempts = maxAttempts
+	return t
+}
+
+// Jito appends a Jito tip of tipLamports (see WithJitoTip).
+func (t *Trade) Jito(tipLamports uint64) *Trade {
+	return t.Options(WithJitoTip(tipLamports))
+}
+
+// PriorityFee sets the priority fee in lamports (see WithPriorityFee).
+func (t *Trade) PriorityFee(lamports uint64) *Trade {
+	return t.Options(WithPriorityFee(lamports))
+}
+
+// Options adds autofill options passed to the underlying function.
+func (t *Trade) Options(opts ...Option) *Trade {
+	t.opts = append(t.opts, opts...)
+	return t
+}
+
+// Build validates the trade, selects the venue and returns the instructions
+// of the underlying autofill function for user.
+func (t *Trade) Build(ctx context.Context, user solana.PublicKey) ([]solana.Instruction, error) {
+	if isNilRPC(t.rpc) {
+		return nil, types.ErrNilRPC
+	}
+	if !t.sideSet {
+		return nil, types.NewValidationError("side", "call Buy or Sell")
+	}
+	if t.sol == 0 && t.tokens == 0 {
+		return nil, types.NewValidationError("amount", "call WithSol or WithTokens")
+	}
+	if !t.sell && t.tokens > 0 && t.maxSol == 0 {
+		return nil, types.NewValidationError("maxSol", "required for a buy by token amount")
+	}
+
+	pool, opts, err := t.venue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var instrs []solana.Instruction
+	switch {
+	case pool.IsZero() && !t.sell && t.sol > 0:
+		_, _, instrs, _, err = PumpBuyWithRetry(ctx, t.rpc, user, t.mint, t.sol, t.slippageBps, t.attempts, opts...)
+	case pool.IsZero() && !t.sell:
+		_, _, instrs, err = PumpBuy(ctx, t.rpc, user, t.mint, t.tokens, t.maxSol, opts...)
+	case pool.IsZero() && t.sol > 0:
+		_, _, instrs, _, err = PumpSellForSolTarget(ctx, t.rpc, user, t.mint, t.sol, t.slippageBps, opts...)
+	case pool.IsZero():
+		_, _, instrs, err = PumpSellWithSlippage(ctx, t.rpc, user, t.mint, t.tokens, t.slippageBps, opts...)
+	case !t.sell && t.sol > 0:
+		_, _, instrs, _, err = PumpAmmBuyWithSol(ctx, t.rpc, user, pool, t.sol, t.slippageBps, opts...)
+	case !t.sell:
+		_, _, instrs, err = PumpAmmBuy(ctx, t.rpc, user, pool, t.tokens, t.maxSol, opts...)
+	case t.sol > 0:
+		return nil, types.NewValidationError("amount", "a pump AMM sell takes WithTokens")
+	default:
+		_, _, instrs, err = PumpAmmSellWithSlippage(ctx, t.rpc, user, pool, t.tokens, t.slippageBps, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return instrs, nil
+}
+
+// venue returns the pump AMM pool to trade in, or the zero key for the
+// bonding curve, and the options to pass on. A curve it reads is passed on
+// with WithBondingCurve so the trade does not fetch it again.
+func (t *Trade) venue(ctx context.Context) (solana.PublicKey, []Option, error) {
+	opts := append([]Option{}, t.opts...)
+	if !t.pool.IsZero() {
+		return t.pool, opts, nil
+	}
+	if err := types.ValidatePublicKey("mint", t.mint); err != nil {
+		return solana.PublicKey{}, nil, err
+	}
+
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	bc := options.BondingCurve
+	if bc == nil {
+		_, curve := pumpCurveAddresses(t.mint)
+		acc, err := fetchAccount(ctx, t.rpc, curve)
+		if err != nil {
+			return solana.PublicKey{}, nil, fmt.Errorf("fetch bonding curve %s: %w", curve, err)
+		}
+		if acc != nil && acc.Data != nil {
+			bc = new(pump.BondingCurve)
+			if err := bc.Unmarshal(acc.Data.GetBinary()); err != nil {
+				return solana.PublicKey{}, nil, fmt.Errorf("decode bonding curve %s: %w", curve, err)
+			}
+			if !bc.Complete {
+				opts = append(opts, WithBondingCurve(*bc))
+			}
+		}
+	}
+	if bc != nil && !bc.Complete {
+		return solana.PublicKey{}, opts, nil
+	}
+	// No curve (a token launched elsewhere) or a graduated one.
+	pool := canonicalPool(t.mint)
+	if pool.IsZero() {
+		return solana.PublicKey{}, nil, fmt.Errorf("derive pump AMM pool for %s", t.mint)
+	}
+	return pool, opts, nil
+}
@@ -0,0 +1,125 @@
+package autofill
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// TradeAccounts is the outcome of EnsureTradeAccounts.
+type TradeAccounts struct {
+	// Instructions create the accounts that are missing, to be landed in a
+	// setup transaction signed by the user. Empty when all exist.
+	Instructions []solana.Instruction
+	// UserBaseTokenAccount is the user's ATA of the traded token.
+	UserBaseTokenAccount solana.PublicKey
+	// UserQuoteTokenAccount is the user's ATA of the pool's quote mint (WSOL
+	// for SOL pools), or the zero key when no pool was given.
+	UserQuoteTokenAccount solana.PublicKey
+}
+
+// KnownATAs returns the user's ATAs, for WithKnownATAs once the setup
+// transaction has landed.
+func (t *TradeAccounts) KnownATAs() []solana.PublicKey {
+	atas := []solana.PublicKey{t.UserBaseTokenAccount}
+	if !t.UserQuoteTokenAccount.IsZero() {
+		atas = append(atas, t.UserQuoteTokenAccount)
+	}
+	return atas
+}
+
+// EnsureTradeAccounts returns a setup instruction set that creates the token
+// accounts trading mint needs, so they can be landed once up front and kept
+// out of the trade transactions. For a bonding-curve trade pass the mint and
+// a zero pool: the user's ATA of the mint is ensured. For a Pump AMM trade
+// pass the pool (mint may then be zero, and must be the pool's base mint if
+// not): the user's base and quote ATAs are ensured, and the quote ATAs of the
+// pool's fee recipients, which the trade would otherwise create. Accounts are
+// created idempotently; compute budget and Jito tip options apply to the
+// setup instructions.
+//
+// Example:
+//
+//	setup, err := autofill.EnsureTradeAccounts(ctx, rpc, user, solana.PublicKey{}, pool)
+//	if len(setup.Instructions) > 0 {
+//	    // build, sign and send setup.Instructions, and wait for it to land
+//	}
+//	_, _, instrs, err := autofill.PumpAmmSellWithSlippage(ctx, rpc, user, pool, baseIn, 100,
+//	    autofill.WithKnownATAs(setup.KnownATAs()...),
+//	)
+func EnsureTradeAccounts(ctx context.Context, rpc RPC, user, mint, pool solana.PublicKey, opts ...Option) (*TradeAccounts, error) {
+	if isNilRPC(rpc) {
+		return nil, types.ErrNilRPC
+	}
+	if err := types.ValidatePublicKey("user", user); err != nil {
+		return nil, err
+	}
+	if mint.IsZero() && pool.IsZero() {
+		return nil, types.NewValidationError("mint", "mint or pool is required")
+	}
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	out := &TradeAccounts{}
+	var reqs []ataRequest
+	if pool.IsZero() {
+		ata, tokenProgram, err := DeriveATAAuto(ctx, rpc, user, mint)
+		if err != nil {
+			return nil, err
+		}
+		out.UserBaseTokenAccount = ata
+		reqs = append(reqs, ataRequest{Payer: user, Wallet: user, Mint: mint, TokenProgram: tokenProgram, ATAProgram: constants.AssociatedTokenProgramID})
+	} else {
+		accts, err := pumpAmmAutofillSell(ctx, rpc, user, pool, options)
+		if err != nil {
+			return nil, err
+		}
+		if !mint.IsZero() && !mint.Equals(accts.BaseMint) {
+			return nil, types.NewValidationError("mint", fmt.Sprintf("pool %s trades %s, not %s", pool, accts.BaseMint, mint))
+		}
+		out.UserBaseTokenAccount = accts.UserBaseTokenAccount
+		out.UserQuoteTokenAccount = accts.UserQuoteTokenAccount
+		reqs = append(reqs,
+			ataRequest{Payer: user, Wallet: user, Mint: accts.BaseMint, TokenProgram: accts.BaseTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
+			ataRequest{Payer: user, Wallet: user, Mint: accts.QuoteMint, TokenProgram: accts.QuoteTokenProgram, ATAProgram: constants.AssociatedTokenProgramID},
+		)
+		reqs = append(reqs, ammFeeATARequests(user, accts.ProtocolFeeRecipient, accts.CoinCreatorVaultAuthority, accts.QuoteMint, accts.QuoteTokenProgram)...)
+	}
+
+	instrs, err := ensureATABatch(ctx, rpc, reqs)
+	if err != nil {
+		return nil, err
+	}
+	if len(instrs) > 0 {
+		if out.Instructions, err = finalizeInstructionsPump(instrs, user, 0, options); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// dropKnownATAs removes the requests for ATAs listed in options.KnownATAs,
+// so their existence is not checked.
+func dropKnownATAs(reqs []ataRequest, options *Options) ([]ataRequest, error) {
+	if len(options.KnownATAs) == 0 {
+		return reqs, nil
+	}
+	var kept []ataRequest
+	for _, req := range reqs {
+		ata, _, err := findATAWithProgram(req.Wallet, req.Mint, req.TokenProgram, req.ATAProgram)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(options.KnownATAs, ata) {
+			kept = append(kept, req)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,99 @@
+package autofill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+)
+
+func TestEnsureTradeAccountsPool(t *testing.T) {
+	ctx := context.Background()
+	rpc, amm := loadAmmPool(t)
+	pool, baseMint := amm.Address("pool"), amm.Address("base_mint")
+	user := solana.NewWallet().PublicKey()
+
+	setup, err := EnsureTradeAccounts(ctx, rpc, user, solana.PublicKey{}, pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantBase, _, _ := findATAWithProgram(user, baseMint, amm.Account("base_mint").Owner, constants.AssociatedTokenProgramID)
+	wantQuote, _, _ := findATAWithProgram(user, constants.WSOLMint, constants.TokenProgramID, constants.AssociatedTokenProgramID)
+	if !setup.UserBaseTokenAccount.Equals(wantBase) || !setup.UserQuoteTokenAccount.Equals(wantQuote) {
+		t.Fatalf("ATAs = %s, %s; want %s, %s", setup.UserBaseTokenAccount, setup.UserQuoteTokenAccount, wantBase, wantQuote)
+	}
+	// User base and quote ATAs plus both fee recipients' quote ATAs.
+	if len(setup.Instructions) != 4 {
+		t.Fatalf("got %d setup instructions, want 4", len(setup.Instructions))
+	}
+
+	// Once the setup lands there is nothing left to create.
+	for _, ix := range setup.Instructions {
+		ata := ix.Accounts()[1].PublicKey
+		rpc.SetAccount(ata, ix.Accounts()[5].PublicKey, 1, tokenAccountData(ix.Accounts()[3].PublicKey, ix.Accounts()[2].PublicKey, 0))
+	}
+	again, err := EnsureTradeAccounts(ctx, rpc, user, baseMint, pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Instructions) != 0 {
+		t.Fatalf("got %d setup instructions after setup, want none", len(again.Instructions))
+	}
+
+	// A sell told about the ATAs does not look them up.
+	reads := make(map[solana.PublicKey]int)
+	for _, ata := range setup.KnownATAs() {
+		reads[ata] = rpc.Reads(ata)
+	}
+	if _, _, _, err := PumpAmmSellWithSlippage(ctx, rpc, user, pool, 1_000_000, 100, WithDryRun(), WithKnownATAs(setup.KnownATAs()...)); err != nil {
+		t.Fatal(err)
+	}
+	for ata, n := range reads {
+		if rpc.Reads(ata) != n {
+			t.Fatalf("sell fetched known ATA %s", ata)
+		}
+	}
+
+	if _, err := EnsureTradeAccounts(ctx, rpc, user, solana.NewWallet().PublicKey(), pool); err == nil {
+		t.Fatal("expected an error for a mint the pool does not trade")
+	}
+	if _, err := EnsureTradeAccounts(ctx, rpc, user, solana.PublicKey{}, solana.PublicKey{}); err == nil {
+		t.Fatal("expected an error without mint or pool")
+	}
+}
+
+func TestEnsureTradeAccountsMint(t *testing.T) {
+	ctx := context.Background()
+	rpc := mock.New()
+	user := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	rpc.SetAccount(mint, constants.Token2022ProgramID, 1, make([]byte, 82))
+
+	setup, err := EnsureTradeAccounts(ctx, rpc, user, mint, solana.PublicKey{}, WithComputeUnitLimit(50_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _, _ := findATAWithProgram(user, mint, constants.Token2022ProgramID, constants.AssociatedTokenProgramID)
+	if !setup.UserBaseTokenAccount.Equals(want) || !setup.UserQuoteTokenAccount.IsZero() {
+		t.Fatalf("ATAs = %s, %s; want %s and none", setup.UserBaseTokenAccount, setup.UserQuoteTokenAccount, want)
+	}
+	if len(setup.KnownATAs()) != 1 {
+		t.Fatalf("KnownATAs = %v, want the base ATA only", setup.KnownATAs())
+	}
+	// Compute budget, then the create.
+	if len(setup.Instructions) != 2 || !setup.Instructions[1].ProgramID().Equals(constants.AssociatedTokenProgramID) {
+		t.Fatalf("unexpected setup instructions %v", setup.Instructions)
+	}
+
+	rpc.SetAccount(want, constants.Token2022ProgramID, 1, tokenAccountData(mint, user, 0))
+	setup, err = EnsureTradeAccounts(ctx, rpc, user, mint, solana.PublicKey{}, WithComputeUnitLimit(50_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(setup.Instructions) != 0 {
+		t.Fatalf("got %d setup instructions for an existing ATA, want none", len(setup.Instructions))
+	}
+}
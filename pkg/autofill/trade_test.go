@@ -0,0 +1,84 @@
+package autofill
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+func TestTradeVenue(t *testing.T) {
+	mint := solana.NewWallet().PublicKey()
+	_, curve := pumpCurveAddresses(mint)
+	bc := pump.BondingCurve{VirtualTokenReserves: 1_000_000_000_000_000, VirtualSolReserves: 30_000_000_000, RealTokenReserves: 800_000_000_000_000}
+
+	rpc := mock.New()
+	if pool, _, err := NewTrade(rpc).Buy(mint).venue(context.Background()); err != nil || pool != canonicalPool(mint) {
+		t.Fatalf("no curve: pool %s, err %v; want the canonical pool", pool, err)
+	}
+	rpc.SetAccount(curve, pump.ProgramKey, 1, curveData(t, bc))
+	if pool, opts, err := NewTrade(rpc).Buy(mint).venue(context.Background()); err != nil || !pool.IsZero() || len(opts) != 1 {
+		t.Fatalf("active curve: pool %s, %d opts, err %v; want the curve passed on", pool, len(opts), err)
+	}
+	complete := bc
+	complete.Complete = true
+	rpc.SetAccount(curve, pump.ProgramKey, 1, curveData(t, complete))
+	if pool, _, err := NewTrade(rpc).Sell(mint).venue(context.Background()); err != nil || pool != canonicalPool(mint) {
+		t.Fatalf("graduated: pool %s, err %v; want the canonical pool", pool, err)
+	}
+	pool := solana.NewWallet().PublicKey()
+	if got, _, err := NewTrade(rpc).Sell(mint).Pool(pool).venue(context.Background()); err != nil || got != pool {
+		t.Fatalf("explicit pool: got %s, err %v", got, err)
+	}
+}
+
+func TestTradeBuild(t *testing.T) {
+	user := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	_, curve := pumpCurveAddresses(mint)
+	bc := pump.BondingCurve{
+		VirtualTokenReserves: 1_000_000_000_000_000,
+		VirtualSolReserves:   30_000_000_000,
+		RealTokenReserves:    800_000_000_000_000,
+		Creator:              solana.NewWallet().PublicKey(),
+	}
+	rpc := mock.New()
+	rpc.SetAccount(mint, constants.TokenProgramID, 1, make([]byte, 82))
+	rpc.SetAccount(curve, pump.ProgramKey, 1, curveData(t, bc))
+	calls := simulationResults(rpc, nil)
+	global := WithPumpGlobal(pump.Global{FeeRecipient: solana.NewWallet().PublicKey(), FeeBasisPoints: 95, CreatorFeeBasisPoints: 30})
+
+	instrs, err := NewTrade(rpc).Buy(mint).WithSol(100_000_000).Slippage(500).Jito(10_000).Options(global).Build(context.Background(), user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *calls != 1 {
+		t.Fatalf("simulations = %d, want the exact-SOL curve buy's 1", *calls)
+	}
+	var buy, tip bool
+	for _, ix := range instrs {
+		switch instructionKind(ix) {
+		case PreviewKindPump:
+			buy = true
+		case PreviewKindJitoTip:
+			tip = true
+		}
+	}
+	if !buy || !tip {
+		t.Fatalf("instructions %v lack the pump buy or the Jito tip", summarizeInstructions(instrs))
+	}
+
+	var verr types.ValidationError
+	if _, err := NewTrade(rpc).WithSol(1).Build(context.Background(), user); !errors.As(err, &verr) || verr.Field != "side" {
+		t.Fatalf("missing side: err = %v", err)
+	}
+	if _, err := NewTrade(rpc).Buy(mint).WithTokens(1).Build(context.Background(), user); !errors.As(err, &verr) || verr.Field != "maxSol" {
+		t.Fatalf("token buy without MaxSol: err = %v", err)
+	}
+}
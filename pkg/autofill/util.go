@@ -3,8 +3,13 @@ package autofill
 import (
 	"context"
 	"encoding/binary"
+	"fmt"
+	"math/big"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
@@ -13,23 +18,24 @@ import (
 	solanarpc "github.com/gagliardetto/solana-go/rpc"
 
 	"github.com/ninja0404/pump-go-sdk/pkg/constants"
-	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
 )
 
-// applyPubkeyOverrides sets exported fields from a map (key: field name or snake_case).
-func applyPubkeyOverrides(target interface{}, m map[string]solana.PublicKey) {
+// applyPubkeyOverrides sets exported fields from a map (key: field name,
+// lowerCamel or snake_case). It fails if target is not a pointer to a struct,
+// if a matched field is not a solana.PublicKey, or, when strict is set, if a
+// key matches no field.
+func applyPubkeyOverrides(target interface{}, m map[string]solana.PublicKey, strict bool) error {
 	if len(m) == 0 {
-		return
+		return nil
 	}
 	val := reflect.ValueOf(target)
-	if val.Kind() != reflect.Ptr {
-		panic("target must be pointer to struct")
-	}
-	val = reflect.Indirect(val)
-	if val.Kind() != reflect.Struct {
-		panic("target must be struct")
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("override target must be a pointer to struct, got %T", target)
 	}
+	val = val.Elem()
 	t := val.Type()
+	pkType := reflect.TypeOf(solana.PublicKey{})
 	for i := 0; i < val.NumField(); i++ {
 		field := t.Field(i)
 		if !field.IsExported() {
@@ -39,10 +45,64 @@ func applyPubkeyOverrides(target interface{}, m map[string]solana.PublicKey) {
 		if key == "" {
 			continue
 		}
-		if pk, ok := m[key]; ok {
-			val.Field(i).Set(reflect.ValueOf(pk))
+		if field.Type != pkType {
+			return fmt.Errorf("override %q: field %s is %s, not a public key", key, field.Name, field.Type)
+		}
+		val.Field(i).Set(reflect.ValueOf(m[key]))
+	}
+	if strict {
+		if unknown := unknownOverrideKeys(t, m); len(unknown) > 0 {
+			return fmt.Errorf("unknown override keys for %s: %s", t.Name(), strings.Join(unknown, ", "))
+		}
+	}
+	return nil
+}
+
+// ValidateOverrides returns the keys of m, sorted, that do not map to any
+// exported field of accountsType (a struct or pointer to struct such as
+// pump.BuyAccounts{}). Such keys are silently ignored unless
+// WithStrictOverrides is set; callers loading override JSON can surface
+// them as warnings.
+//
+// Example:
+//
+//	if unknown := autofill.ValidateOverrides(pump.BuyAccounts{}, overrides); len(unknown) > 0 {
+//	    log.Printf("ignoring unknown override keys: %v", unknown)
+//	}
+func ValidateOverrides(accountsType any, m map[string]solana.PublicKey) []string {
+	t := reflect.TypeOf(accountsType)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return unknownOverrideKeys(nil, m)
+	}
+	return unknownOverrideKeys(t, m)
+}
+
+// unknownOverrideKeys lists the keys of m, sorted, that match no exported
+// field of struct type t (all keys when t is nil).
+func unknownOverrideKeys(t reflect.Type, m map[string]solana.PublicKey) []string {
+	known := make(map[string]bool, len(m))
+	if t != nil {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			for _, k := range []string{field.Name, lowerCamel(field.Name), snake(field.Name)} {
+				known[k] = true
+			}
+		}
+	}
+	var unknown []string
+	for k := range m {
+		if !known[k] {
+			unknown = append(unknown, k)
 		}
 	}
+	sort.Strings(unknown)
+	return unknown
 }
 
 func pickKey(name string, m map[string]solana.PublicKey) string {
@@ -83,15 +143,6 @@ func isZeroPK(pk solana.PublicKey) bool {
 	return pk == (solana.PublicKey{})
 }
 
-func firstNonZeroPK(list []solana.PublicKey) solana.PublicKey {
-	for _, pk := range list {
-		if !isZeroPK(pk) {
-			return pk
-		}
-	}
-	return solana.PublicKey{}
-}
-
 // ataRequest holds parameters for a single ATA ensure check.
 type ataRequest struct {
 	Payer        solana.PublicKey
@@ -109,7 +160,7 @@ type ensureATABatchResult struct {
 }
 
 // ensureATABatch checks multiple ATAs in one batch RPC call and returns create instructions for missing ones.
-func ensureATABatch(ctx context.Context, rpc *sdkrpc.Client, requests []ataRequest) ([]solana.Instruction, error) {
+func ensureATABatch(ctx context.Context, rpc RPC, requests []ataRequest) ([]solana.Instruction, error) {
 	result, err := ensureATABatchWithBalances(ctx, rpc, requests)
 	if err != nil {
 		return nil, err
@@ -119,7 +170,7 @@ func ensureATABatch(ctx context.Context, rpc *sdkrpc.Client, requests []ataReque
 
 // ensureATABatchWithBalances checks multiple ATAs and also returns their balances (0 for non-existent accounts).
 // This avoids needing a separate fetchTokenAmount call after ensureATABatch.
-func ensureATABatchWithBalances(ctx context.Context, rpc *sdkrpc.Client, requests []ataRequest) (ensureATABatchResult, error) {
+func ensureATABatchWithBalances(ctx context.Context, rpc RPC, requests []ataRequest) (ensureATABatchResult, error) {
 	result := ensureATABatchResult{
 		Balances: make(map[string]uint64),
 	}
@@ -162,22 +213,86 @@ func ensureATABatchWithBalances(ctx context.Context, rpc *sdkrpc.Client, request
 			continue
 		}
 		// doesn't exist - create instruction, balance = 0
+		// Use CreateIdempotent so a concurrent create of the same ATA doesn't fail the tx.
 		result.Balances[req.ATAAddr.String()] = 0
-		metas := []*solana.AccountMeta{
-			solana.NewAccountMeta(req.Payer, true, true),
-			solana.NewAccountMeta(req.ATAAddr, true, false),
-			solana.NewAccountMeta(req.Wallet, false, false),
-			solana.NewAccountMeta(req.Mint, false, false),
-			solana.NewAccountMeta(constants.SystemProgramID, false, false),
-			solana.NewAccountMeta(req.TokenProgram, false, false),
-		}
-		result.Instructions = append(result.Instructions, solana.NewInstruction(req.ATAProgram, metas, nil))
+		result.Instructions = append(result.Instructions, buildCreateATAIdempotent(req.Payer, req.ATAAddr, req.Wallet, req.Mint, req.TokenProgram, req.ATAProgram))
 	}
 	return result, nil
 }
 
+// ataCreateIdempotentDiscriminator is the Associated Token Program CreateIdempotent instruction index.
+const ataCreateIdempotentDiscriminator = 1
+
+// CreateATAIdempotent builds an Associated Token Program CreateIdempotent
+// instruction for wallet's ATA of mint. Unlike Create, it succeeds as a no-op
+// when the ATA already exists, so racing transactions don't fail.
+// tokenProgram selects SPL Token or Token-2022.
+//
+// Example:
+//
+//	ix, err := autofill.CreateATAIdempotent(user, user, mint, constants.Token2022ProgramID)
+func CreateATAIdempotent(payer, wallet, mint, tokenProgram solana.PublicKey) (solana.Instruction, error) {
+	ata, _, err := findATAWithProgram(wallet, mint, tokenProgram, constants.AssociatedTokenProgramID)
+	if err != nil {
+		return nil, fmt.Errorf("derive ata: %w", err)
+	}
+	return buildCreateATAIdempotent(payer, ata, wallet, mint, tokenProgram, constants.AssociatedTokenProgramID), nil
+}
+
+func buildCreateATAIdempotent(payer, ata, wallet, mint, tokenProgram, ataProgram solana.PublicKey) solana.Instruction {
+	metas := []*solana.AccountMeta{
+		solana.NewAccountMeta(payer, true, true),
+		solana.NewAccountMeta(ata, true, false),
+		solana.NewAccountMeta(wallet, false, false),
+		solana.NewAccountMeta(mint, false, false),
+		solana.NewAccountMeta(constants.SystemProgramID, false, false),
+		solana.NewAccountMeta(tokenProgram, false, false),
+	}
+	return solana.NewInstruction(ataProgram, metas, []byte{ataCreateIdempotentDiscriminator})
+}
+
+// mintOwners caches mint address -> token program. A mint's owner never
+// changes, so entries are kept for the life of the process.
+var mintOwners sync.Map
+
+// DeriveATAAuto returns wallet's ATA of mint together with the token program
+// that owns mint, read from the chain so SPL Token and Token-2022 mints both
+// resolve to the right address. Mint owners are cached after the first read.
+//
+// Example:
+//
+//	ata, tokenProgram, err := autofill.DeriveATAAuto(ctx, rpc, user, mint)
+//	ix, err := autofill.CreateATAIdempotent(user, user, mint, tokenProgram)
+func DeriveATAAuto(ctx context.Context, rpc RPC, wallet, mint solana.PublicKey) (ata, tokenProgram solana.PublicKey, err error) {
+	if isNilRPC(rpc) {
+		return solana.PublicKey{}, solana.PublicKey{}, types.ErrNilRPC
+	}
+	if cached, ok := mintOwners.Load(mint); ok {
+		tokenProgram = cached.(solana.PublicKey)
+	} else {
+		acc, err := fetchAccount(ctx, rpc, mint)
+		if err != nil {
+			return solana.PublicKey{}, solana.PublicKey{}, fmt.Errorf("fetch mint: %w", err)
+		}
+		if acc == nil {
+			return solana.PublicKey{}, solana.PublicKey{}, fmt.Errorf("%w: %s", types.ErrMintNotFound, mint)
+		}
+		if !acc.Owner.Equals(constants.TokenProgramID) && !acc.Owner.Equals(constants.Token2022ProgramID) {
+			return solana.PublicKey{}, solana.PublicKey{}, fmt.Errorf("mint %s is owned by %s, not a token program", mint, acc.Owner)
+		}
+		tokenProgram = acc.Owner
+		mintOwners.Store(mint, tokenProgram)
+	}
+
+	ata, _, err = findATAWithProgram(wallet, mint, tokenProgram, constants.AssociatedTokenProgramID)
+	if err != nil {
+		return solana.PublicKey{}, solana.PublicKey{}, fmt.Errorf("derive ata: %w", err)
+	}
+	return ata, tokenProgram, nil
+}
+
 // fetchTokenAmountBatch fetches token amounts for multiple accounts in one batch RPC call.
-func fetchTokenAmountBatch(ctx context.Context, rpc *sdkrpc.Client, accounts []solana.PublicKey) (map[string]uint64, error) {
+func fetchTokenAmountBatch(ctx context.Context, rpc RPC, accounts []solana.PublicKey) (map[string]uint64, error) {
 	if len(accounts) == 0 {
 		return map[string]uint64{}, nil
 	}
@@ -217,29 +332,124 @@ func findATAWithProgram(wallet, mint, tokenProgram, ataProgram solana.PublicKey)
 	}, ataProgram)
 }
 
-// fetchAccountsBatch pulls multiple accounts in one RPC call.
-func fetchAccountsBatch(ctx context.Context, rpc *sdkrpc.Client, addrs ...solana.PublicKey) (map[string]*solanarpc.Account, error) {
+const (
+	// maxMultipleAccounts is the getMultipleAccounts per-request address limit.
+	maxMultipleAccounts = 100
+	// fetchAccountsWorkers bounds concurrent getMultipleAccounts requests.
+	fetchAccountsWorkers = 4
+)
+
+// fetchAccountsBatch pulls multiple accounts keyed by address. Requests are
+// split into chunks of at most 100 addresses, fetched concurrently by a
+// bounded worker pool; missing accounts are omitted from the result.
+func fetchAccountsBatch(ctx context.Context, rpc RPC, addrs ...solana.PublicKey) (map[string]*solanarpc.Account, error) {
+	out := make(map[string]*solanarpc.Account, len(addrs))
 	if len(addrs) == 0 {
-		return map[string]*solanarpc.Account{}, nil
+		return out, nil
+	}
+	if len(addrs) <= maxMultipleAccounts {
+		return out, fetchAccountsChunk(ctx, rpc, addrs, out, nil)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		sem      = make(chan struct{}, fetchAccountsWorkers)
+	)
+	for start := 0; start < len(addrs); start += maxMultipleAccounts {
+		chunk := addrs[start:min(start+maxMultipleAccounts, len(addrs))]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				return
+			}
+			if err := fetchAccountsChunk(ctx, rpc, chunk, out, &mu); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return out, nil
+}
+
+// defaultAccountWaitInterval is the poll interval of WithAccountWait when
+// none is given.
+const defaultAccountWaitInterval = 200 * time.Millisecond
+
+// fetchAccountsAwaiting is fetchAccountsBatch that, with WithAccountWait,
+// re-fetches addrs until every account in required exists or the wait runs
+// out. The last batch is returned either way; callers report what is missing.
+func fetchAccountsAwaiting(ctx context.Context, rpc RPC, options *Options, required []solana.PublicKey, addrs ...solana.PublicKey) (map[string]*solanarpc.Account, error) {
+	amap, err := fetchAccountsBatch(ctx, rpc, addrs...)
+	if err != nil || options.AccountWait <= 0 {
+		return amap, err
+	}
+	interval := options.AccountWaitInterval
+	if interval <= 0 {
+		interval = defaultAccountWaitInterval
 	}
-	keys := make([]string, 0, len(addrs))
-	for _, a := range addrs {
-		keys = append(keys, a.String())
+	deadline := time.Now().Add(options.AccountWait)
+	for !hasAccounts(amap, required) {
+		wait := min(interval, time.Until(deadline))
+		if wait <= 0 {
+			break
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		if amap, err = fetchAccountsBatch(ctx, rpc, addrs...); err != nil {
+			return nil, err
+		}
 	}
-	res, err := rpc.Raw().GetMultipleAccountsWithOpts(ctx, addrs, &solanarpc.GetMultipleAccountsOpts{
-		Commitment: solanarpc.CommitmentConfirmed,
-	})
+	return amap, nil
+}
+
+// hasAccounts reports whether amap holds data for every address in addrs.
+func hasAccounts(amap map[string]*solanarpc.Account, addrs []solana.PublicKey) bool {
+	for _, addr := range addrs {
+		if acc := amap[addr.String()]; acc == nil || acc.Data == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// fetchAccountsChunk fetches up to maxMultipleAccounts addresses into out,
+// holding mu (if non-nil) while writing.
+func fetchAccountsChunk(ctx context.Context, rpc RPC, addrs []solana.PublicKey, out map[string]*solanarpc.Account, mu *sync.Mutex) error {
+	accounts, err := rpc.GetMultipleAccounts(ctx, addrs, readCommitment(rpc))
 	if err != nil {
-		return nil, err
+		return err
 	}
-	out := make(map[string]*solanarpc.Account, len(addrs))
-	for i, v := range res.Value {
-		if v == nil {
+	if mu != nil {
+		mu.Lock()
+		defer mu.Unlock()
+	}
+	for i, v := range accounts {
+		if v == nil || i >= len(addrs) {
 			continue
 		}
-		out[keys[i]] = v
+		out[addrs[i].String()] = v
 	}
-	return out, nil
+	return nil
 }
 
 // buildWrapWSOL constructs transfer lamports -> ATA + sync_native.
@@ -269,9 +479,6 @@ func buildCloseAccount(account, destination, owner, tokenProgram solana.PublicKe
 	return solana.NewInstruction(tokenProgram, metas, data)
 }
 
-// Compute Budget Program ID
-var computeBudgetProgramID = solana.MustPublicKeyFromBase58("ComputeBudget111111111111111111111111111111")
-
 // Default compute unit limit
 const defaultComputeLimit uint32 = 200000
 
@@ -308,7 +515,7 @@ func buildComputeBudgetInstructions(options Options) []solana.Instruction {
 		data[0] = 2 // discriminator
 		binary.LittleEndian.PutUint32(data[1:], computeLimit)
 		instrs = append(instrs, solana.NewInstruction(
-			computeBudgetProgramID,
+			constants.ComputeBudgetProgramID,
 			nil, // no accounts
 			data,
 		))
@@ -320,7 +527,7 @@ func buildComputeBudgetInstructions(options Options) []solana.Instruction {
 		data[0] = 3 // discriminator
 		binary.LittleEndian.PutUint64(data[1:], pricePerCU)
 		instrs = append(instrs, solana.NewInstruction(
-			computeBudgetProgramID,
+			constants.ComputeBudgetProgramID,
 			nil, // no accounts
 			data,
 		))
@@ -329,6 +536,51 @@ func buildComputeBudgetInstructions(options Options) []solana.Instruction {
 	return instrs
 }
 
+// spliceInstructions places options.PrependInstructions before instrs and
+// options.AppendInstructions after them.
+func spliceInstructions(instrs []solana.Instruction, options Options) []solana.Instruction {
+	if len(options.PrependInstructions) == 0 && len(options.AppendInstructions) == 0 {
+		return instrs
+	}
+	out := make([]solana.Instruction, 0, len(options.PrependInstructions)+len(instrs)+len(options.AppendInstructions))
+	out = append(out, options.PrependInstructions...)
+	out = append(out, instrs...)
+	return append(out, options.AppendInstructions...)
+}
+
+// buildMemo builds an SPL Memo instruction with no signers.
+func buildMemo(memo string) solana.Instruction {
+	return solana.NewInstruction(constants.MemoProgramID, solana.AccountMetaSlice{}, []byte(memo))
+}
+
+// buildReferral returns the WithReferral transfer of options.ReferralBps of
+// solAmount from `from`, or nil if no referral is configured or it rounds to
+// zero.
+func buildReferral(from solana.PublicKey, solAmount uint64, options Options) (solana.Instruction, error) {
+	lamports, err := referralLamports(solAmount, options)
+	if err != nil || lamports == 0 {
+		return nil, err
+	}
+	return system.NewTransferInstruction(lamports, from, options.Referrer).Build(), nil
+}
+
+// referralLamports returns the referral fee on solAmount, or 0 without a
+// referrer. A ReferralBps above MaxReferralBps is rejected.
+func referralLamports(solAmount uint64, options Options) (uint64, error) {
+	if options.Referrer.IsZero() || options.ReferralBps == 0 {
+		return 0, nil
+	}
+	if options.ReferralBps > MaxReferralBps {
+		return 0, types.NewValidationError("referralBps", fmt.Sprintf("%d exceeds the maximum of %d", options.ReferralBps, MaxReferralBps))
+	}
+	lamports := new(big.Int).Mul(new(big.Int).SetUint64(solAmount), new(big.Int).SetUint64(options.ReferralBps))
+	lamports.Div(lamports, big.NewInt(10_000))
+	if !lamports.IsUint64() {
+		return 0, fmt.Errorf("referral fee on %d lamports overflows", solAmount)
+	}
+	return lamports.Uint64(), nil
+}
+
 // prependComputeBudget adds Compute Budget instructions to the beginning of instruction list.
 func prependComputeBudget(instrs []solana.Instruction, options Options) []solana.Instruction {
 	cbInstrs := buildComputeBudgetInstructions(options)
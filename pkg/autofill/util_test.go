@@ -0,0 +1,365 @@
+package autofill
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+func TestCreateATAIdempotentInstruction(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+
+	ix, err := CreateATAIdempotent(payer, payer, mint, constants.Token2022ProgramID)
+	if err != nil {
+		t.Fatalf("CreateATAIdempotent: %v", err)
+	}
+	if !ix.ProgramID().Equals(constants.AssociatedTokenProgramID) {
+		t.Fatalf("unexpected program %s", ix.ProgramID())
+	}
+	data, _ := ix.Data()
+	if len(data) != 1 || data[0] != ataCreateIdempotentDiscriminator {
+		t.Fatalf("expected CreateIdempotent data [1], got %v", data)
+	}
+	ata, _, _ := findATAWithProgram(payer, mint, constants.Token2022ProgramID, constants.AssociatedTokenProgramID)
+	accts := ix.Accounts()
+	if !accts[1].PublicKey.Equals(ata) || !accts[5].PublicKey.Equals(constants.Token2022ProgramID) {
+		t.Fatal("unexpected account metas")
+	}
+}
+
+func TestEnsureATABatchSecondCreateIsNoop(t *testing.T) {
+	client := mock.New()
+	ctx := context.Background()
+	user := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	req := func() []ataRequest {
+		return []ataRequest{{Payer: user, Wallet: user, Mint: mint, TokenProgram: constants.TokenProgramID, ATAProgram: constants.AssociatedTokenProgramID}}
+	}
+
+	first, err := ensureATABatch(ctx, client, req())
+	if err != nil {
+		t.Fatalf("first ensureATABatch: %v", err)
+	}
+	if len(first) != 1 {
+		t.Fatalf("expected one create instruction, got %d", len(first))
+	}
+	if data, _ := first[0].Data(); len(data) != 1 || data[0] != ataCreateIdempotentDiscriminator {
+		t.Fatalf("create must be idempotent so a racing create is a no-op, got data %v", data)
+	}
+
+	// Another transaction created the ATA in the meantime.
+	ata, _, _ := findATAWithProgram(user, mint, constants.TokenProgramID, constants.AssociatedTokenProgramID)
+	client.SetAccount(ata, constants.TokenProgramID, 2039280, tokenAccountData(mint, user, 42))
+
+	res, err := ensureATABatchWithBalances(ctx, client, req())
+	if err != nil {
+		t.Fatalf("second ensureATABatch: %v", err)
+	}
+	if len(res.Instructions) != 0 {
+		t.Fatalf("expected no instructions for existing ATA, got %d", len(res.Instructions))
+	}
+	if res.Balances[ata.String()] != 42 {
+		t.Fatalf("expected balance 42, got %d", res.Balances[ata.String()])
+	}
+}
+
+func TestApplyPubkeyOverrides(t *testing.T) {
+	type accounts struct {
+		BondingCurve solana.PublicKey
+		Amount       uint64
+	}
+	pk := solana.NewWallet().PublicKey()
+
+	var a accounts
+	if err := applyPubkeyOverrides(&a, map[string]solana.PublicKey{"bonding_curve": pk, "typo": pk}, false); err != nil {
+		t.Fatalf("lenient: %v", err)
+	}
+	if !a.BondingCurve.Equals(pk) {
+		t.Fatal("snake_case key not applied")
+	}
+	if err := applyPubkeyOverrides(&a, map[string]solana.PublicKey{"bondingCurve": pk, "typo": pk}, true); err == nil || !strings.Contains(err.Error(), "typo") {
+		t.Fatalf("strict: expected unknown key error, got %v", err)
+	}
+	if err := applyPubkeyOverrides(&a, map[string]solana.PublicKey{"amount": pk}, false); err == nil {
+		t.Fatal("expected error for non-pubkey field")
+	}
+	if err := applyPubkeyOverrides(a, map[string]solana.PublicKey{"amount": pk}, false); err == nil {
+		t.Fatal("expected error for non-pointer target")
+	}
+}
+
+func TestValidateOverrides(t *testing.T) {
+	type accounts struct {
+		BondingCurve solana.PublicKey
+		Mint         solana.PublicKey
+	}
+	pk := solana.NewWallet().PublicKey()
+	m := map[string]solana.PublicKey{"bonding_curve": pk, "Mint": pk, "mnit": pk, "user": pk}
+
+	for _, target := range []any{accounts{}, &accounts{}} {
+		got := ValidateOverrides(target, m)
+		if strings.Join(got, ",") != "mnit,user" {
+			t.Fatalf("%T: got %v, want [mnit user]", target, got)
+		}
+	}
+	if got := ValidateOverrides(accounts{}, map[string]solana.PublicKey{"bondingCurve": pk}); len(got) != 0 {
+		t.Fatalf("expected no unknown keys, got %v", got)
+	}
+}
+
+func TestFetchAccountsBatchChunks(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	addrs := make([]solana.PublicKey, 250)
+	for i := range addrs {
+		addrs[i] = solana.NewWallet().PublicKey()
+		if i%2 == 0 {
+			fake.setAccount(addrs[i], fakeAccount{Owner: constants.SystemProgramID, Lamports: uint64(i + 1)})
+		}
+	}
+
+	got, err := fetchAccountsBatch(context.Background(), client, addrs...)
+	if err != nil {
+		t.Fatalf("fetchAccountsBatch: %v", err)
+	}
+	if n := fake.callCount("getMultipleAccounts"); n != 3 {
+		t.Fatalf("expected 3 chunked requests, got %d", n)
+	}
+	if len(got) != 125 {
+		t.Fatalf("expected 125 existing accounts, got %d", len(got))
+	}
+	for i, a := range addrs {
+		acc, ok := got[a.String()]
+		if i%2 == 1 {
+			if ok {
+				t.Fatalf("address %d should be missing", i)
+			}
+			continue
+		}
+		if !ok || acc.Lamports != uint64(i+1) {
+			t.Fatalf("address %d: wrong account mapping", i)
+		}
+	}
+}
+
+func TestDeriveATAAuto(t *testing.T) {
+	rpc := mock.New()
+	user := solana.NewWallet().PublicKey()
+	splMint := solana.NewWallet().PublicKey()
+	t22Mint := solana.NewWallet().PublicKey()
+	rpc.SetAccount(splMint, constants.TokenProgramID, 1, make([]byte, 82))
+	rpc.SetAccount(t22Mint, constants.Token2022ProgramID, 1, make([]byte, 82))
+
+	for _, tc := range []struct {
+		mint, program solana.PublicKey
+	}{
+		{splMint, constants.TokenProgramID},
+		{t22Mint, constants.Token2022ProgramID},
+	} {
+		ata, program, err := DeriveATAAuto(context.Background(), rpc, user, tc.mint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _, _ := findATAWithProgram(user, tc.mint, tc.program, constants.AssociatedTokenProgramID)
+		if !program.Equals(tc.program) || !ata.Equals(want) {
+			t.Fatalf("mint %s: got (%s, %s), want (%s, %s)", tc.mint, ata, program, want, tc.program)
+		}
+	}
+
+	// The mint owner is cached: a second wallet costs no further reads.
+	reads := rpc.Calls("GetMultipleAccounts")
+	if _, _, err := DeriveATAAuto(context.Background(), rpc, solana.NewWallet().PublicKey(), t22Mint); err != nil {
+		t.Fatal(err)
+	}
+	if rpc.Calls("GetMultipleAccounts") != reads {
+		t.Fatal("mint owner read again")
+	}
+
+	notMint := solana.NewWallet().PublicKey()
+	rpc.SetAccount(notMint, constants.SystemProgramID, 1, nil)
+	if _, _, err := DeriveATAAuto(context.Background(), rpc, user, notMint); err == nil {
+		t.Fatal("expected an error for an account not owned by a token program")
+	}
+	if _, _, err := DeriveATAAuto(context.Background(), rpc, user, solana.NewWallet().PublicKey()); err == nil {
+		t.Fatal("expected an error for a missing mint")
+	}
+}
+
+func TestSpliceInstructionsLayout(t *testing.T) {
+	ctx := context.Background()
+	amm := mock.MustLoadFixture(mock.FixtureAmmPool)
+	client := mock.New()
+	client.Load(amm)
+	user := solana.NewWallet().PublicKey()
+	tipAccount := solana.NewWallet().PublicKey()
+	memoProgram := solana.NewWallet().PublicKey()
+	pre := solana.NewInstruction(memoProgram, nil, []byte("pre"))
+	pre2 := solana.NewInstruction(memoProgram, nil, []byte("pre2"))
+	post := solana.NewInstruction(memoProgram, nil, []byte("post"))
+	opts := []Option{
+		WithDryRun(), WithComputeUnitLimit(150_000), WithJitoTip(1_000), WithJitoTipAccount(tipAccount),
+		WithPrependInstructions(pre), WithPrependInstructions(pre2), WithAppendInstructions(post),
+	}
+
+	index := func(instrs []solana.Instruction, want solana.Instruction) int {
+		for i, ix := range instrs {
+			if ix == want {
+				return i
+			}
+		}
+		return -1
+	}
+	check := func(name string, instrs []solana.Instruction) (trade int) {
+		t.Helper()
+		if !instrs[0].ProgramID().Equals(constants.ComputeBudgetProgramID) {
+			t.Fatalf("%s: first instruction is %s, want the compute budget", name, instrs[0].ProgramID())
+		}
+		if index(instrs, pre) != 1 || index(instrs, pre2) != 2 {
+			t.Fatalf("%s: prepended at %d and %d, want 1 and 2", name, index(instrs, pre), index(instrs, pre2))
+		}
+		if !instrs[3].ProgramID().Equals(constants.AssociatedTokenProgramID) {
+			t.Fatalf("%s: instruction 3 is %s, want ATA creation after the prepended ones", name, instrs[3].ProgramID())
+		}
+		if got := index(instrs, post); got != len(instrs)-2 {
+			t.Fatalf("%s: appended at %d of %d, want just before the tip", name, got, len(instrs))
+		}
+		if !instrs[len(instrs)-1].Accounts()[1].PublicKey.Equals(tipAccount) {
+			t.Fatalf("%s: last instruction is not the tip", name)
+		}
+		for i, ix := range instrs {
+			if ix.ProgramID().Equals(pumpamm.ProgramKey) {
+				return i
+			}
+		}
+		t.Fatalf("%s: no trade instruction", name)
+		return -1
+	}
+
+	_, _, buy, err := PumpAmmBuy(ctx, client, user, amm.Address("pool"), 1_000_000, 50_000_000, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("buy", buy)
+
+	_, _, sell, err := PumpAmmSellWithSlippage(ctx, client, user, amm.Address("pool"), 1_000_000, 100, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trade := check("sell", sell)
+	// The WSOL unwrap sits between the trade and the appended instruction.
+	if unwrap := sell[trade+1]; !unwrap.ProgramID().Equals(constants.TokenProgramID) || index(sell, post) != trade+2 {
+		t.Fatalf("sell: unwrap not between trade and appended instructions")
+	}
+}
+
+func TestMemoAndReferral(t *testing.T) {
+	ctx := context.Background()
+	curve := mock.MustLoadFixture(mock.FixtureBondingCurve)
+	amm := mock.MustLoadFixture(mock.FixtureAmmPool)
+	client := mock.New()
+	client.Load(curve)
+	client.Load(amm)
+	user := solana.NewWallet().PublicKey()
+	referrer := solana.NewWallet().PublicKey()
+	opts := []Option{WithDryRun(), WithMemo("order-42"), WithReferral(referrer, 50)}
+
+	_, _, instrs, err := PumpBuy(ctx, client, user, curve.Address("mint"), 1_000_000, 100_000_000, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	memo, referral := instrs[len(instrs)-2], instrs[len(instrs)-1]
+	if data, _ := memo.Data(); !memo.ProgramID().Equals(constants.MemoProgramID) || string(data) != "order-42" {
+		t.Fatalf("second to last instruction is not the memo: %s %q", memo.ProgramID(), data)
+	}
+	data, _ := referral.Data()
+	if !referral.ProgramID().Equals(constants.SystemProgramID) || !referral.Accounts()[1].PublicKey.Equals(referrer) ||
+		binary.LittleEndian.Uint64(data[4:]) != 500_000 {
+		t.Fatalf("last instruction is not a 0.5%% referral of maxSol: %v", data)
+	}
+
+	// A dry-run sell has no minimum output, so no referral is paid.
+	_, _, instrs, err = PumpAmmSellWithSlippage(ctx, client, user, amm.Address("pool"), 1_000_000, 100, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := instrs[len(instrs)-1]; !last.ProgramID().Equals(constants.MemoProgramID) {
+		t.Fatalf("last sell instruction is %s, want the memo", last.ProgramID())
+	}
+	if ix, err := buildReferral(user, 199, Options{Referrer: referrer, ReferralBps: 50}); err != nil || ix != nil {
+		t.Fatalf("a referral that rounds to zero must be skipped: %v", err)
+	}
+
+	// Referrals above MaxReferralBps are rejected, not clamped or dropped.
+	var verr types.ValidationError
+	tooHigh := []Option{WithDryRun(), WithReferral(referrer, MaxReferralBps+1)}
+	if _, _, _, err := PumpBuy(ctx, client, user, curve.Address("mint"), 1_000_000, 100_000_000, tooHigh...); !errors.As(err, &verr) || verr.Field != "referralBps" {
+		t.Fatalf("referral above the cap: err = %v", err)
+	}
+	if _, err := referralLamports(math.MaxUint64, Options{Referrer: referrer, ReferralBps: 20_000}); !errors.As(err, &verr) {
+		t.Fatalf("referral above 100%%: err = %v", err)
+	}
+	if got, err := referralLamports(math.MaxUint64, Options{Referrer: referrer, ReferralBps: MaxReferralBps}); err != nil || got != math.MaxUint64/10 {
+		t.Fatalf("max referral of MaxUint64 = %d, %v, want %d", got, err, uint64(math.MaxUint64/10))
+	}
+}
+
+func TestSlippageMinSmallAmounts(t *testing.T) {
+	floor, ceil := &Options{}, &Options{SlippageRounding: SlippageRoundUp}
+	for _, tc := range []struct {
+		amount, bps   uint64
+		floor, ceil   uint64
+		floorRejected bool
+	}{
+		{amount: 1, bps: 100, ceil: 1, floorRejected: true},
+		{amount: 99, bps: 200, floor: 97, ceil: 98},
+		{amount: 3, bps: 5_000, floor: 1, ceil: 2},
+		{amount: 1, bps: 9_999, ceil: 1, floorRejected: true},
+		{amount: 0, bps: 100},                                                             // no expected output, nothing to protect
+		{amount: 5, bps: 10_000},                                                          // the caller accepts any fill
+		{amount: 1 << 63, bps: 50, floor: 9177255176670501928, ceil: 9177255176670501929}, // no overflow
+	} {
+		got, err := slippageMin(tc.amount, tc.bps, floor)
+		if tc.floorRejected {
+			var verr types.ValidationError
+			if !errors.As(err, &verr) {
+				t.Errorf("floor(%d, %d bps): expected a validation error, got %d, %v", tc.amount, tc.bps, got, err)
+			}
+		} else if err != nil || got != tc.floor {
+			t.Errorf("floor(%d, %d bps) = %d, %v, want %d", tc.amount, tc.bps, got, err, tc.floor)
+		}
+		if got, err := slippageMin(tc.amount, tc.bps, ceil); err != nil || got != tc.ceil {
+			t.Errorf("ceil(%d, %d bps) = %d, %v, want %d", tc.amount, tc.bps, got, err, tc.ceil)
+		}
+	}
+}
+
+func TestSlippageRoundingOnTinySell(t *testing.T) {
+	fx := mock.MustLoadFixture(mock.FixtureAmmPool)
+	client := mock.New()
+	client.Load(fx)
+	user := solana.NewWallet().PublicKey()
+	pool := fx.Address("pool")
+
+	// A 1-lamport expected output floors to a zero minimum at 1%.
+	if _, _, _, err := PumpAmmSellWithSlippage(context.Background(), client, user, pool, 10, 100, WithExpectedQuoteOut(1)); err == nil {
+		t.Fatal("expected the zero minimum to be rejected")
+	}
+	_, args, _, err := PumpAmmSellWithSlippage(context.Background(), client, user, pool, 10, 100,
+		WithExpectedQuoteOut(1), WithSlippageRounding(SlippageRoundUp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args.MinQuoteAmountOut != 1 {
+		t.Fatalf("min quote out = %d, want 1", args.MinQuoteAmountOut)
+	}
+}
@@ -0,0 +1,49 @@
+package autofill
+
+import (
+	"context"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// EnsureWSOL returns the instructions that leave owner's WSOL ATA holding at
+// least targetLamports, plus the ATA address. The ATA is created (idempotently)
+// if missing, and only the shortfall over any existing WSOL balance is wrapped.
+// If the balance already covers targetLamports, no instructions are returned.
+//
+// Example:
+//
+//	instrs, wsolATA, err := autofill.EnsureWSOL(ctx, rpc, user, 50_000_000)
+func EnsureWSOL(ctx context.Context, rpc RPC, owner solana.PublicKey, targetLamports uint64) ([]solana.Instruction, solana.PublicKey, error) {
+	if isNilRPC(rpc) {
+		return nil, solana.PublicKey{}, types.ErrNilRPC
+	}
+	if err := types.ValidatePublicKey("owner", owner); err != nil {
+		return nil, solana.PublicKey{}, err
+	}
+
+	res, err := ensureATABatchWithBalances(ctx, rpc, []ataRequest{
+		{Payer: owner, Wallet: owner, Mint: constants.WSOLMint, TokenProgram: constants.TokenProgramID, ATAProgram: constants.AssociatedTokenProgramID},
+	})
+	if err != nil {
+		return nil, solana.PublicKey{}, err
+	}
+	ata, _, err := findATAWithProgram(owner, constants.WSOLMint, constants.TokenProgramID, constants.AssociatedTokenProgramID)
+	if err != nil {
+		return nil, solana.PublicKey{}, err
+	}
+	instrs := append(res.Instructions, wrapWSOLShortfall(owner, ata, res.Balances[ata.String()], targetLamports)...)
+	return instrs, ata, nil
+}
+
+// wrapWSOLShortfall wraps only the lamports needed to bring an existing WSOL
+// balance up to target (nothing if it is already covered).
+func wrapWSOLShortfall(payer, wsolATA solana.PublicKey, existing, target uint64) []solana.Instruction {
+	if target <= existing {
+		return nil
+	}
+	return buildWrapWSOL(payer, wsolATA, target-existing)
+}
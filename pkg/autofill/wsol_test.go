@@ -0,0 +1,91 @@
+package autofill
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+)
+
+func wsolATAOf(t *testing.T, owner solana.PublicKey) solana.PublicKey {
+	t.Helper()
+	ata, _, err := findATAWithProgram(owner, constants.WSOLMint, constants.TokenProgramID, constants.AssociatedTokenProgramID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ata
+}
+
+func transferLamports(t *testing.T, ix solana.Instruction) uint64 {
+	t.Helper()
+	if !ix.ProgramID().Equals(constants.SystemProgramID) {
+		t.Fatalf("expected system transfer, got program %s", ix.ProgramID())
+	}
+	data, _ := ix.Data()
+	if len(data) != 12 {
+		t.Fatalf("unexpected transfer data length %d", len(data))
+	}
+	return binary.LittleEndian.Uint64(data[4:])
+}
+
+func TestEnsureWSOLCreatesAndWraps(t *testing.T) {
+	client := mock.New()
+	owner := solana.NewWallet().PublicKey()
+
+	instrs, ata, err := EnsureWSOL(context.Background(), client, owner, 100_000)
+	if err != nil {
+		t.Fatalf("EnsureWSOL: %v", err)
+	}
+	if !ata.Equals(wsolATAOf(t, owner)) {
+		t.Fatalf("unexpected ata %s", ata)
+	}
+	if len(instrs) != 3 {
+		t.Fatalf("expected create + transfer + sync_native, got %d instructions", len(instrs))
+	}
+	if !instrs[0].ProgramID().Equals(constants.AssociatedTokenProgramID) {
+		t.Fatal("first instruction should create the ATA")
+	}
+	if got := transferLamports(t, instrs[1]); got != 100_000 {
+		t.Fatalf("wrap amount: got %d, want 100000", got)
+	}
+}
+
+func TestEnsureWSOLTopsUpShortfall(t *testing.T) {
+	client := mock.New()
+	owner := solana.NewWallet().PublicKey()
+	ata := wsolATAOf(t, owner)
+	client.SetAccount(ata, constants.TokenProgramID, 2_069_280, tokenAccountData(constants.WSOLMint, owner, 30_000))
+
+	instrs, _, err := EnsureWSOL(context.Background(), client, owner, 100_000)
+	if err != nil {
+		t.Fatalf("EnsureWSOL: %v", err)
+	}
+	if len(instrs) != 2 {
+		t.Fatalf("expected transfer + sync_native, got %d instructions", len(instrs))
+	}
+	if got := transferLamports(t, instrs[0]); got != 70_000 {
+		t.Fatalf("wrap amount: got %d, want 70000", got)
+	}
+}
+
+func TestEnsureWSOLNoop(t *testing.T) {
+	client := mock.New()
+	owner := solana.NewWallet().PublicKey()
+	ata := wsolATAOf(t, owner)
+	client.SetAccount(ata, constants.TokenProgramID, 2_139_280, tokenAccountData(constants.WSOLMint, owner, 100_000))
+
+	instrs, got, err := EnsureWSOL(context.Background(), client, owner, 100_000)
+	if err != nil {
+		t.Fatalf("EnsureWSOL: %v", err)
+	}
+	if len(instrs) != 0 {
+		t.Fatalf("expected no instructions, got %d", len(instrs))
+	}
+	if !got.Equals(ata) {
+		t.Fatalf("unexpected ata %s", got)
+	}
+}
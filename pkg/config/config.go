@@ -2,6 +2,7 @@ package config
 
 import (
 	"io"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -47,23 +48,46 @@ type RateLimitConfig struct {
 }
 
 // RPCConfig aggregates runtime settings for RPC usage.
+//
+// Timeouts are applied per operation by deriving a child context from the
+// caller's; a zero value disables that deadline (the caller's context still
+// applies). Defaults:
+//
+//   - Timeout (20s): one-shot calls such as getAccountInfo or sendTransaction.
+//   - SimulateTimeout (10s): simulateTransaction.
+//   - ConfirmTimeout (60s): the whole WaitForConfirmation polling loop.
+//
+// Commitment applies to blockhashes and confirmation-side reads, while
+// ReadCommitment applies to the account reads and simulations autofill
+// prices trades from (empty = confirmed reads, simulations on processed
+// state). Processed reads are a slot or two fresher and answer sooner,
+// which matters when sniping, but can see state from a fork that is later
+// dropped; finalized reads are stable but ~30 slots behind.
 type RPCConfig struct {
-	Network    Network
-	RPCURL     string
-	Commitment string
-	Timeout    time.Duration
-	Retry      RetryConfig
-	RateLimit  RateLimitConfig
-	Logger     zerolog.Logger
+	Network         Network
+	RPCURL          string
+	WSURL           string // websocket endpoint; derived from the RPC URL when empty
+	ReferenceRPCURL string // endpoint whose slot IsBehind compares against; empty uses getHealth
+	Commitment      string
+	ReadCommitment  string // commitment of autofill account reads and simulations; empty keeps their defaults
+	Timeout         time.Duration
+	SimulateTimeout time.Duration
+	ConfirmTimeout  time.Duration
+	Retry           RetryConfig
+	RateLimit       RateLimitConfig
+	Programs        ProgramIDs
+	Logger          zerolog.Logger
 }
 
 // DefaultRPCConfig yields production-safe defaults (mainnet, finalized commitment).
 func DefaultRPCConfig() RPCConfig {
 	return RPCConfig{
-		Network:    NetworkMainnet,
-		RPCURL:     DefaultRPCURL(NetworkMainnet),
-		Commitment: "finalized",
-		Timeout:    20 * time.Second,
+		Network:         NetworkMainnet,
+		RPCURL:          DefaultRPCURL(NetworkMainnet),
+		Commitment:      "finalized",
+		Timeout:         20 * time.Second,
+		SimulateTimeout: 10 * time.Second,
+		ConfirmTimeout:  60 * time.Second,
 		Retry: RetryConfig{
 			Enabled:        true,
 			MaxAttempts:    3,
@@ -75,7 +99,8 @@ func DefaultRPCConfig() RPCConfig {
 			RPS:   8,
 			Burst: 16,
 		},
-		Logger: zerolog.New(io.Discard),
+		Programs: DefaultProgramIDs(NetworkMainnet),
+		Logger:   zerolog.New(io.Discard),
 	}
 }
 
@@ -86,3 +111,19 @@ func (c RPCConfig) ResolveRPCURL() string {
 	}
 	return DefaultRPCURL(c.Network)
 }
+
+// ResolveWSURL returns WSURL if set, otherwise the resolved RPC URL with its
+// scheme switched to ws/wss (the standard Solana RPC websocket endpoint).
+func (c RPCConfig) ResolveWSURL() string {
+	if c.WSURL != "" {
+		return c.WSURL
+	}
+	rpcURL := c.ResolveRPCURL()
+	if rest, ok := strings.CutPrefix(rpcURL, "https://"); ok {
+		return "wss://" + rest
+	}
+	if rest, ok := strings.CutPrefix(rpcURL, "http://"); ok {
+		return "ws://" + rest
+	}
+	return rpcURL
+}
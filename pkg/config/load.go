@@ -0,0 +1,243 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by LoadFromEnv.
+const (
+	EnvNetwork         = "PUMP_NETWORK"
+	EnvRPCURL          = "PUMP_RPC_URL"
+	EnvWSURL           = "PUMP_WS_URL"
+	EnvCommitment      = "PUMP_COMMITMENT"
+	EnvTimeout         = "PUMP_TIMEOUT"
+	EnvSimulateTimeout = "PUMP_SIMULATE_TIMEOUT"
+	EnvConfirmTimeout  = "PUMP_CONFIRM_TIMEOUT"
+	EnvRetryAttempts   = "PUMP_RETRY_ATTEMPTS"
+	EnvRetryBackoff    = "PUMP_RETRY_BACKOFF"
+	EnvRateLimitRPS    = "PUMP_RATE_LIMIT_RPS"
+	EnvRateLimitBurst  = "PUMP_RATE_LIMIT_BURST"
+)
+
+// fileConfig mirrors RPCConfig for YAML/JSON files. Nil fields keep defaults.
+type fileConfig struct {
+	Network         *string `json:"network" yaml:"network"`
+	RPCURL          *string `json:"rpc_url" yaml:"rpc_url"`
+	WSURL           *string `json:"ws_url" yaml:"ws_url"`
+	Commitment      *string `json:"commitment" yaml:"commitment"`
+	Timeout         *string `json:"timeout" yaml:"timeout"`
+	SimulateTimeout *string `json:"simulate_timeout" yaml:"simulate_timeout"`
+	ConfirmTimeout  *string `json:"confirm_timeout" yaml:"confirm_timeout"`
+	Retry           *struct {
+		Enabled        *bool   `json:"enabled" yaml:"enabled"`
+		MaxAttempts    *int    `json:"max_attempts" yaml:"max_attempts"`
+		InitialBackoff *string `json:"initial_backoff" yaml:"initial_backoff"`
+		MaxBackoff     *string `json:"max_backoff" yaml:"max_backoff"`
+		Jitter         *bool   `json:"jitter" yaml:"jitter"`
+	} `json:"retry" yaml:"retry"`
+	RateLimit *struct {
+		RPS   *float64 `json:"rps" yaml:"rps"`
+		Burst *int     `json:"burst" yaml:"burst"`
+	} `json:"rate_limit" yaml:"rate_limit"`
+}
+
+// LoadFromEnv returns DefaultRPCConfig overlaid with PUMP_* environment variables.
+// Durations use Go syntax (e.g. PUMP_TIMEOUT=30s).
+func LoadFromEnv() (RPCConfig, error) {
+	return ApplyEnv(DefaultRPCConfig())
+}
+
+// ApplyEnv overlays PUMP_* environment variables onto cfg.
+// Setting PUMP_NETWORK switches cfg to that network (see ForNetwork) before
+// other variables are applied; settings cfg already carries, such as those
+// from a config file, are kept.
+func ApplyEnv(cfg RPCConfig) (RPCConfig, error) {
+	if v, ok := lookupEnv(EnvNetwork); ok {
+		cfg = withNetwork(cfg, Network(v))
+	}
+	if v, ok := lookupEnv(EnvRPCURL); ok {
+		cfg.RPCURL = v
+	}
+	if v, ok := lookupEnv(EnvWSURL); ok {
+		cfg.WSURL = v
+	}
+	if v, ok := lookupEnv(EnvCommitment); ok {
+		cfg.Commitment = v
+	}
+	if v, ok := lookupEnv(EnvTimeout); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return cfg, fmt.Errorf("parse %s: %w", EnvTimeout, err)
+		}
+		cfg.Timeout = d
+	}
+	if v, ok := lookupEnv(EnvSimulateTimeout); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return cfg, fmt.Errorf("parse %s: %w", EnvSimulateTimeout, err)
+		}
+		cfg.SimulateTimeout = d
+	}
+	if v, ok := lookupEnv(EnvConfirmTimeout); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return cfg, fmt.Errorf("parse %s: %w", EnvConfirmTimeout, err)
+		}
+		cfg.ConfirmTimeout = d
+	}
+	if v, ok := lookupEnv(EnvRetryAttempts); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return cfg, fmt.Errorf("parse %s: %w", EnvRetryAttempts, err)
+		}
+		cfg.Retry.MaxAttempts = n
+	}
+	if v, ok := lookupEnv(EnvRetryBackoff); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return cfg, fmt.Errorf("parse %s: %w", EnvRetryBackoff, err)
+		}
+		cfg.Retry.InitialBackoff = d
+	}
+	if v, ok := lookupEnv(EnvRateLimitRPS); ok {
+		rps, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return cfg, fmt.Errorf("parse %s: %w", EnvRateLimitRPS, err)
+		}
+		cfg.RateLimit.RPS = rps
+	}
+	if v, ok := lookupEnv(EnvRateLimitBurst); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return cfg, fmt.Errorf("parse %s: %w", EnvRateLimitBurst, err)
+		}
+		cfg.RateLimit.Burst = n
+	}
+	return cfg, nil
+}
+
+// LoadFromFile returns DefaultRPCConfig overlaid with the settings in a YAML
+// or JSON file (chosen by the .json extension; anything else is read as YAML).
+//
+// Example config.yaml:
+//
+//	network: devnet
+//	rpc_url: https://my-devnet-rpc.example.com
+//	ws_url: wss://my-devnet-rpc.example.com
+//	commitment: confirmed
+//	timeout: 30s
+//	simulate_timeout: 10s
+//	confirm_timeout: 90s
+//	rate_limit:
+//	  rps: 20
+//	  burst: 40
+func LoadFromFile(path string) (RPCConfig, error) {
+	return ApplyFile(DefaultRPCConfig(), path)
+}
+
+// ApplyFile overlays the settings in a YAML or JSON file onto cfg.
+func ApplyFile(cfg RPCConfig, path string) (RPCConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("read config: %w", err)
+	}
+	var fc fileConfig
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &fc)
+	} else {
+		err = yaml.Unmarshal(data, &fc)
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("decode config %s: %w", path, err)
+	}
+
+	if fc.Network != nil {
+		cfg = withNetwork(cfg, Network(*fc.Network))
+	}
+	if fc.RPCURL != nil {
+		cfg.RPCURL = *fc.RPCURL
+	}
+	if fc.WSURL != nil {
+		cfg.WSURL = *fc.WSURL
+	}
+	if fc.Commitment != nil {
+		cfg.Commitment = *fc.Commitment
+	}
+	if fc.Timeout != nil {
+		if cfg.Timeout, err = time.ParseDuration(*fc.Timeout); err != nil {
+			return cfg, fmt.Errorf("parse timeout: %w", err)
+		}
+	}
+	if fc.SimulateTimeout != nil {
+		if cfg.SimulateTimeout, err = time.ParseDuration(*fc.SimulateTimeout); err != nil {
+			return cfg, fmt.Errorf("parse simulate_timeout: %w", err)
+		}
+	}
+	if fc.ConfirmTimeout != nil {
+		if cfg.ConfirmTimeout, err = time.ParseDuration(*fc.ConfirmTimeout); err != nil {
+			return cfg, fmt.Errorf("parse confirm_timeout: %w", err)
+		}
+	}
+	if r := fc.Retry; r != nil {
+		if r.Enabled != nil {
+			cfg.Retry.Enabled = *r.Enabled
+		}
+		if r.MaxAttempts != nil {
+			cfg.Retry.MaxAttempts = *r.MaxAttempts
+		}
+		if r.InitialBackoff != nil {
+			if cfg.Retry.InitialBackoff, err = time.ParseDuration(*r.InitialBackoff); err != nil {
+				return cfg, fmt.Errorf("parse retry.initial_backoff: %w", err)
+			}
+		}
+		if r.MaxBackoff != nil {
+			if cfg.Retry.MaxBackoff, err = time.ParseDuration(*r.MaxBackoff); err != nil {
+				return cfg, fmt.Errorf("parse retry.max_backoff: %w", err)
+			}
+		}
+		if r.Jitter != nil {
+			cfg.Retry.Jitter = *r.Jitter
+		}
+	}
+	if rl := fc.RateLimit; rl != nil {
+		if rl.RPS != nil {
+			cfg.RateLimit.RPS = *rl.RPS
+		}
+		if rl.Burst != nil {
+			cfg.RateLimit.Burst = *rl.Burst
+		}
+	}
+	return cfg, nil
+}
+
+// withNetwork switches cfg to network, changing only the settings the
+// network determines: the network itself, its program IDs, and the RPC URL
+// and commitment unless they were changed from the previous network's
+// preset. An explicit WS URL is kept; an empty one follows the RPC URL.
+// Everything else (timeouts, retry, rate limit, ...) is left as set.
+func withNetwork(cfg RPCConfig, network Network) RPCConfig {
+	prev, next := ForNetwork(cfg.Network), ForNetwork(network)
+	if cfg.RPCURL == "" || cfg.RPCURL == prev.RPCURL {
+		cfg.RPCURL = next.RPCURL
+	}
+	if cfg.Commitment == "" || cfg.Commitment == prev.Commitment {
+		cfg.Commitment = next.Commitment
+	}
+	cfg.Network = network
+	cfg.Programs = next.Programs
+	return cfg
+}
+
+func lookupEnv(key string) (string, bool) {
+	v, ok := os.LookupEnv(key)
+	v = strings.TrimSpace(v)
+	return v, ok && v != ""
+}
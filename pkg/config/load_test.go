@@ -0,0 +1,125 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadFromEnv(t *testing.T) {
+	t.Setenv(EnvRPCURL, "https://rpc.example.com")
+	t.Setenv(EnvCommitment, "confirmed")
+	t.Setenv(EnvRateLimitRPS, "25")
+	t.Setenv(EnvTimeout, "45s")
+	t.Setenv(EnvConfirmTimeout, "2m")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv: %v", err)
+	}
+	if cfg.RPCURL != "https://rpc.example.com" || cfg.Commitment != "confirmed" {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if cfg.RateLimit.RPS != 25 || cfg.Timeout != 45*time.Second {
+		t.Fatalf("unexpected rate/timeout: %v %v", cfg.RateLimit.RPS, cfg.Timeout)
+	}
+	if cfg.ConfirmTimeout != 2*time.Minute || cfg.SimulateTimeout != DefaultRPCConfig().SimulateTimeout {
+		t.Fatalf("unexpected per-op timeouts: confirm=%v simulate=%v", cfg.ConfirmTimeout, cfg.SimulateTimeout)
+	}
+	if cfg.Retry.MaxAttempts != DefaultRPCConfig().Retry.MaxAttempts {
+		t.Fatal("unset variables must keep defaults")
+	}
+
+	t.Setenv(EnvRateLimitRPS, "fast")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Fatal("expected parse error")
+	}
+}
+
+func TestLoadFromFile(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "pump.yaml")
+	if err := os.WriteFile(yamlPath, []byte("network: devnet\ntimeout: 5s\nrate_limit:\n  rps: 3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFromFile(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadFromFile yaml: %v", err)
+	}
+	if cfg.Network != NetworkDevnet || cfg.ResolveRPCURL() != DefaultRPCURL(NetworkDevnet) {
+		t.Fatalf("network preset not applied: %+v", cfg)
+	}
+	if cfg.Timeout != 5*time.Second || cfg.RateLimit.RPS != 3 || cfg.RateLimit.Burst != 16 {
+		t.Fatalf("unexpected overlay: timeout=%v rps=%v burst=%d", cfg.Timeout, cfg.RateLimit.RPS, cfg.RateLimit.Burst)
+	}
+
+	jsonPath := filepath.Join(dir, "pump.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"rpc_url":"https://x.example","retry":{"max_attempts":7}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadFromFile(jsonPath)
+	if err != nil {
+		t.Fatalf("LoadFromFile json: %v", err)
+	}
+	if cfg.RPCURL != "https://x.example" || cfg.Retry.MaxAttempts != 7 {
+		t.Fatalf("unexpected json config: %+v", cfg)
+	}
+}
+
+func TestNetworkEnvKeepsFileSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pump.yaml")
+	file := "rpc_url: https://my-rpc.example.com\ntimeout: 7s\nretry:\n  max_attempts: 9\nrate_limit:\n  rps: 3\n"
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ReadCommitment = "processed"
+	t.Setenv(EnvNetwork, string(NetworkDevnet))
+	cfg, err = ApplyEnv(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Network != NetworkDevnet || cfg.Programs != DefaultProgramIDs(NetworkDevnet) {
+		t.Fatalf("network not switched: %+v", cfg)
+	}
+	if cfg.RPCURL != "https://my-rpc.example.com" || cfg.Timeout != 7*time.Second || cfg.Retry.MaxAttempts != 9 || cfg.RateLimit.RPS != 3 {
+		t.Fatalf("file settings lost: %+v", cfg)
+	}
+	if cfg.ReadCommitment != "processed" {
+		t.Fatalf("read commitment lost: %q", cfg.ReadCommitment)
+	}
+	// The commitment was the mainnet default, so it follows the network.
+	if cfg.Commitment != ForNetwork(NetworkDevnet).Commitment {
+		t.Fatalf("commitment = %q, want the devnet default", cfg.Commitment)
+	}
+
+	// A default RPC URL follows the network too.
+	cfg, err = LoadFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.RPCURL != DefaultRPCURL(NetworkDevnet) {
+		t.Fatalf("rpc url = %q, want the devnet default", cfg.RPCURL)
+	}
+}
+
+func TestResolveWSURL(t *testing.T) {
+	cfg := DefaultRPCConfig()
+	cfg.RPCURL = "https://rpc.example.com/?key=1"
+	if got := cfg.ResolveWSURL(); got != "wss://rpc.example.com/?key=1" {
+		t.Fatalf("derived ws url: got %q", got)
+	}
+	t.Setenv(EnvWSURL, "wss://ws.example.com")
+	cfg, err := ApplyEnv(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.ResolveWSURL(); got != "wss://ws.example.com" {
+		t.Fatalf("explicit ws url: got %q", got)
+	}
+}
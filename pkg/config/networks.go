@@ -0,0 +1,101 @@
+package config
+
+import (
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpfees"
+)
+
+// ProgramIDs holds the program addresses used by the SDK for a network.
+type ProgramIDs struct {
+	Pump            solana.PublicKey
+	PumpAmm         solana.PublicKey
+	PumpFee         solana.PublicKey
+	PumpAmmFee      solana.PublicKey
+	Token           solana.PublicKey
+	Token2022       solana.PublicKey
+	AssociatedToken solana.PublicKey
+	Metadata        solana.PublicKey
+}
+
+// pumpDeployments holds the Pump program IDs of each network with an
+// official deployment. Pump deploys its programs to devnet under the mainnet
+// addresses.
+var pumpDeployments = map[Network]ProgramIDs{
+	NetworkMainnet: mainnetPumpPrograms(),
+	NetworkDevnet:  mainnetPumpPrograms(),
+}
+
+func mainnetPumpPrograms() ProgramIDs {
+	return ProgramIDs{
+		Pump:       solana.MustPublicKeyFromBase58(pump.ProgramID),
+		PumpAmm:    solana.MustPublicKeyFromBase58(pumpamm.ProgramID),
+		PumpFee:    solana.MustPublicKeyFromBase58(pumpfees.ProgramID),
+		PumpAmmFee: solana.MustPublicKeyFromBase58(pumpfees.ProgramID),
+	}
+}
+
+// DefaultProgramIDs returns the program addresses for network. Testnet and
+// custom clusters have no official Pump deployment: their Pump fields are
+// zero, so Apply keeps the current IDs until they are set explicitly.
+func DefaultProgramIDs(network Network) ProgramIDs {
+	ids := pumpDeployments[network]
+	ids.Token = solana.TokenProgramID
+	ids.Token2022 = constants.Token2022ProgramID
+	ids.AssociatedToken = solana.SPLAssociatedTokenAccountProgramID
+	ids.Metadata = constants.MetadataProgramID
+	return ids
+}
+
+// Apply installs the program IDs into the package-level addresses read by
+// autofill, quote and the generated program bindings, including the other
+// Pump programs the bindings pass as accounts, and into the constants.Lookup
+// registry. The SPL program IDs only change the constants package: the
+// bindings keep the canonical SPL programs, which are the same on every
+// cluster. Zero fields are left unchanged. Apply mutates process-wide state
+// and should be called once at startup, before any instruction is built.
+func (p ProgramIDs) Apply() {
+	addrs := constants.All()
+	set := func(name string, v solana.PublicKey, dsts ...*solana.PublicKey) {
+		if v.IsZero() {
+			return
+		}
+		for _, dst := range dsts {
+			*dst = v
+		}
+		addrs[name] = v
+	}
+	set(constants.NamePumpProgram, p.Pump, &constants.PumpProgramID, &pump.ProgramKey, &pumpamm.PumpProgramKey)
+	set(constants.NamePumpAmmProgram, p.PumpAmm, &constants.PumpAmmProgramID, &pumpamm.ProgramKey, &pump.PumpAmmProgramKey)
+	set(constants.NamePumpFeeProgram, p.PumpFee, &constants.PumpFeeProgramID, &pumpfees.ProgramKey, &pump.PumpFeesProgramKey)
+	set(constants.NamePumpAmmFeeProgram, p.PumpAmmFee, &constants.PumpAmmFeeProgramID, &pumpamm.PumpFeesProgramKey)
+	set(constants.NameTokenProgram, p.Token, &constants.TokenProgramID)
+	set(constants.NameToken2022Program, p.Token2022, &constants.Token2022ProgramID)
+	set(constants.NameAssociatedTokenProgram, p.AssociatedToken, &constants.AssociatedTokenProgramID)
+	set(constants.NameMetadataProgram, p.Metadata, &constants.MetadataProgramID)
+	constants.SetAddresses(addrs)
+}
+
+// ForNetwork returns DefaultRPCConfig adjusted for network: the public RPC
+// endpoint and the network's program IDs. Call cfg.Programs.Apply() to make
+// the SDK use those IDs.
+//
+// Example:
+//
+//	cfg := config.ForNetwork(config.NetworkDevnet)
+//	cfg.Programs.Apply()
+//	client := rpc.NewClient(cfg)
+func ForNetwork(network Network) RPCConfig {
+	cfg := DefaultRPCConfig()
+	cfg.Network = network
+	cfg.RPCURL = DefaultRPCURL(network)
+	cfg.Programs = DefaultProgramIDs(network)
+	if network == NetworkDevnet || network == NetworkTestnet {
+		// Test clusters finalize slowly; confirmed keeps integration tests snappy.
+		cfg.Commitment = "confirmed"
+	}
+	return cfg
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+)
+
+func TestForNetwork(t *testing.T) {
+	cfg := ForNetwork(NetworkDevnet)
+	if cfg.ResolveRPCURL() != "https://api.devnet.solana.com" {
+		t.Fatalf("unexpected devnet url %q", cfg.ResolveRPCURL())
+	}
+	if cfg.Programs.Pump.String() != pump.ProgramID {
+		t.Fatalf("unexpected devnet pump program %s", cfg.Programs.Pump)
+	}
+}
+
+func TestProgramIDsApply(t *testing.T) {
+	orig := DefaultProgramIDs(NetworkMainnet)
+	t.Cleanup(orig.Apply)
+
+	custom := solana.NewWallet().PublicKey()
+	ProgramIDs{Pump: custom}.Apply()
+
+	if !pump.ProgramKey.Equals(custom) || !constants.PumpProgramID.Equals(custom) {
+		t.Fatalf("pump program not overridden: %s / %s", pump.ProgramKey, constants.PumpProgramID)
+	}
+	if !constants.PumpAmmProgramID.Equals(orig.PumpAmm) {
+		t.Fatal("zero fields must not override existing IDs")
+	}
+	if pk, _ := constants.Lookup(constants.NamePumpProgram); !pk.Equals(custom) {
+		t.Fatalf("Lookup(pump_program) = %s, want %s", pk, custom)
+	}
+}
+
+func TestProgramIDsApplyReachesInstructions(t *testing.T) {
+	orig := DefaultProgramIDs(NetworkMainnet)
+	t.Cleanup(orig.Apply)
+
+	ids := ProgramIDs{
+		Pump:       solana.NewWallet().PublicKey(),
+		PumpAmm:    solana.NewWallet().PublicKey(),
+		PumpFee:    solana.NewWallet().PublicKey(),
+		PumpAmmFee: solana.NewWallet().PublicKey(),
+	}
+	ids.Apply()
+
+	key := func() solana.PublicKey { return solana.NewWallet().PublicKey() }
+	ix, err := pump.BuildBuy(pump.BuyAccounts{
+		Global: key(), FeeRecipient: key(), Mint: key(), BondingCurve: key(), AssociatedBondingCurve: key(),
+		AssociatedUser: key(), User: key(), CreatorVault: key(), EventAuthority: key(),
+		TokenProgram: key(), GlobalVolumeAccumulator: key(), UserVolumeAccumulator: key(), FeeConfig: key(),
+	}, pump.BuyArgs{Amount: 1, MaxSolCost: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	accts := ix.Accounts()
+	if !ix.ProgramID().Equals(ids.Pump) || !accts[11].PublicKey.Equals(ids.Pump) {
+		t.Fatalf("program = %s, program account = %s; want %s", ix.ProgramID(), accts[11].PublicKey, ids.Pump)
+	}
+	if !accts[15].PublicKey.Equals(ids.PumpFee) {
+		t.Fatalf("fee_program account = %s, want %s", accts[15].PublicKey, ids.PumpFee)
+	}
+	if !pumpamm.ProgramKey.Equals(ids.PumpAmm) || !pumpamm.PumpFeesProgramKey.Equals(ids.PumpAmmFee) || !pumpamm.PumpProgramKey.Equals(ids.Pump) {
+		t.Fatal("pump_amm bindings not overridden")
+	}
+	for name, want := range map[string]solana.PublicKey{
+		constants.NamePumpAmmProgram:    ids.PumpAmm,
+		constants.NamePumpFeeProgram:    ids.PumpFee,
+		constants.NamePumpAmmFeeProgram: ids.PumpAmmFee,
+	} {
+		if pk, _ := constants.Lookup(name); !pk.Equals(want) {
+			t.Errorf("Lookup(%s) = %s, want %s", name, pk, want)
+		}
+	}
+}
+
+func TestDefaultProgramIDsByNetwork(t *testing.T) {
+	if DefaultProgramIDs(NetworkDevnet) != DefaultProgramIDs(NetworkMainnet) {
+		t.Fatal("devnet shares the mainnet deployment")
+	}
+	testnet := DefaultProgramIDs(NetworkTestnet)
+	if !testnet.Pump.IsZero() || !testnet.PumpAmm.IsZero() || !testnet.PumpFee.IsZero() {
+		t.Fatalf("testnet has no Pump deployment, got %+v", testnet)
+	}
+	if !testnet.Token2022.Equals(constants.Token2022ProgramID) {
+		t.Fatal("SPL programs are set on every network")
+	}
+}
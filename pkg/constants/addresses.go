@@ -11,6 +11,8 @@ var (
 	AssociatedTokenProgramID = solana.SPLAssociatedTokenAccountProgramID
 	SysvarRentProgramID      = solana.SysVarRentPubkey
 	MetadataProgramID        = solana.MustPublicKeyFromBase58("metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s")
+	MemoProgramID            = solana.MustPublicKeyFromBase58("MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr")
+	ComputeBudgetProgramID   = solana.MustPublicKeyFromBase58("ComputeBudget111111111111111111111111111111")
 
 	// Pump.fun Program
 	PumpProgramID    = solana.MustPublicKeyFromBase58("6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P")
@@ -38,4 +40,5 @@ const (
 	SeedUserVolumeAccumulator   = "user_volume_accumulator"
 	SeedGlobalConfig            = "global_config"
 	SeedCreatorVaultAmm         = "creator_vault"
+	SeedPool                    = "pool"
 )
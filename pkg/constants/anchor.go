@@ -0,0 +1,5 @@
+package constants
+
+// AnchorEventIxTag prefixes the instruction data of Anchor events emitted
+// through a self-CPI (emit_cpi!); the event's own discriminator follows it.
+var AnchorEventIxTag = []byte{0xe4, 0x45, 0xa5, 0x2e, 0x51, 0xcb, 0x9a, 0x1d}
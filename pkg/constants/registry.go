@@ -0,0 +1,108 @@
+package constants
+
+import (
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Well-known address names accepted by Lookup.
+const (
+	NameSystemProgram          = "system_program"
+	NameTokenProgram           = "token_program"
+	NameToken2022Program       = "token_2022_program"
+	NameAssociatedTokenProgram = "associated_token_program"
+	NameSysvarRent             = "sysvar_rent"
+	NameMetadataProgram        = "metadata_program"
+	NameMemoProgram            = "memo_program"
+	NamePumpProgram            = "pump_program"
+	NamePumpFeeProgram         = "pump_fee_program"
+	NamePumpAmmProgram         = "pump_amm_program"
+	NamePumpAmmFeeProgram      = "pump_amm_fee_program"
+	NameWSOLMint               = "wsol_mint"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = MainnetAddresses()
+)
+
+// MainnetAddresses returns the mainnet well-known addresses keyed by name.
+func MainnetAddresses() map[string]solana.PublicKey {
+	return map[string]solana.PublicKey{
+		NameSystemProgram:          SystemProgramID,
+		NameTokenProgram:           TokenProgramID,
+		NameToken2022Program:       Token2022ProgramID,
+		NameAssociatedTokenProgram: AssociatedTokenProgramID,
+		NameSysvarRent:             SysvarRentProgramID,
+		NameMetadataProgram:        MetadataProgramID,
+		NameMemoProgram:            MemoProgramID,
+		NamePumpProgram:            PumpProgramID,
+		NamePumpFeeProgram:         PumpFeeProgramID,
+		NamePumpAmmProgram:         PumpAmmProgramID,
+		NamePumpAmmFeeProgram:      PumpAmmFeeProgramID,
+		NameWSOLMint:               WSOLMint,
+	}
+}
+
+// Lookup resolves a well-known address by name (case-insensitive, e.g.
+// "pump_program" or "WSOL_MINT").
+//
+// Example:
+//
+//	pumpID, ok := constants.Lookup("pump_program")
+func Lookup(name string) (solana.PublicKey, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	pk, ok := registry[strings.ToLower(name)]
+	return pk, ok
+}
+
+// All returns a copy of the well-known addresses keyed by name.
+func All() map[string]solana.PublicKey {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return maps.Clone(registry)
+}
+
+// SetAddresses replaces the backing map used by Lookup and All, e.g. when
+// switching networks. Names are lowercased; nil restores MainnetAddresses.
+// The exported program ID variables are not affected.
+func SetAddresses(m map[string]solana.PublicKey) {
+	next := MainnetAddresses()
+	if m != nil {
+		next = make(map[string]solana.PublicKey, len(m))
+		for k, v := range m {
+			next[strings.ToLower(k)] = v
+		}
+	}
+	registryMu.Lock()
+	registry = next
+	registryMu.Unlock()
+}
+
+// Seeds returns the PDA seed strings keyed by name, mirroring the Seed*
+// constants ("creator_vault" is the pump seed, "creator_vault_amm" the
+// pump_amm one).
+func Seeds() map[string]string {
+	return map[string]string{
+		"global":                    SeedGlobal,
+		"bonding_curve":             SeedBondingCurve,
+		"creator_vault":             SeedCreatorVault,
+		"mint_authority":            SeedMintAuthority,
+		"event_authority":           SeedEventAuthority,
+		"global_volume_accumulator": SeedGlobalVolumeAccumulator,
+		"user_volume_accumulator":   SeedUserVolumeAccumulator,
+		"global_config":             SeedGlobalConfig,
+		"creator_vault_amm":         SeedCreatorVaultAmm,
+		"pool":                      SeedPool,
+	}
+}
+
+// Seed resolves a PDA seed string by name (see Seeds).
+func Seed(name string) (string, bool) {
+	s, ok := Seeds()[strings.ToLower(name)]
+	return s, ok
+}
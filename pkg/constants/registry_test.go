@@ -0,0 +1,48 @@
+package constants
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestLookupAndSetAddresses(t *testing.T) {
+	if pk, ok := Lookup("PUMP_PROGRAM"); !ok || !pk.Equals(PumpProgramID) {
+		t.Fatalf("Lookup(pump_program): got %s, %v", pk, ok)
+	}
+	if _, ok := Lookup("nope"); ok {
+		t.Fatal("expected unknown name to miss")
+	}
+
+	all := All()
+	all[NamePumpProgram] = solana.PublicKey{}
+	if pk, _ := Lookup(NamePumpProgram); !pk.Equals(PumpProgramID) {
+		t.Fatal("All must return a copy")
+	}
+
+	custom := solana.NewWallet().PublicKey()
+	SetAddresses(map[string]solana.PublicKey{"Pump_Program": custom})
+	defer SetAddresses(nil)
+	if pk, ok := Lookup(NamePumpProgram); !ok || !pk.Equals(custom) {
+		t.Fatalf("after SetAddresses: got %s, %v", pk, ok)
+	}
+	if _, ok := Lookup(NameWSOLMint); ok {
+		t.Fatal("SetAddresses should replace, not merge")
+	}
+	SetAddresses(nil)
+	if pk, _ := Lookup(NamePumpProgram); !pk.Equals(PumpProgramID) {
+		t.Fatal("SetAddresses(nil) should restore mainnet")
+	}
+}
+
+func TestSeed(t *testing.T) {
+	if s, ok := Seed("creator_vault"); !ok || s != SeedCreatorVault {
+		t.Fatalf("Seed(creator_vault): got %q, %v", s, ok)
+	}
+	if s, _ := Seed("creator_vault_amm"); s != SeedCreatorVaultAmm {
+		t.Fatalf("Seed(creator_vault_amm): got %q", s)
+	}
+	if len(Seeds()) != 10 {
+		t.Fatalf("expected 10 seeds, got %d", len(Seeds()))
+	}
+}
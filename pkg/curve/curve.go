@@ -0,0 +1,268 @@
+// Package curve implements the pricing math of Pump bonding curves and Pump
+// AMM constant-product pools as pure functions of reserves and fees.
+//
+// Nothing here touches RPC: callers fetch the state (see package quote) and
+// pass the reserves in. Amounts are in base units and fees in basis points.
+// Intermediate products are computed with big.Int, so no input overflows,
+// and rounding follows the programs: outputs round down, costs and fees up.
+//
+// Example:
+//
+//	r := curve.Reserves{
+//	    VirtualTokenReserves: bc.VirtualTokenReserves,
+//	    VirtualSolReserves:   bc.VirtualSolReserves,
+//	    RealTokenReserves:    bc.RealTokenReserves,
+//	}
+//	tokens := curve.BuyTokensOut(r, 100, 1_000_000_000) // 1 SOL at 1% fees
+package curve
+
+import (
+	"math"
+	"math/big"
+	"sort"
+)
+
+// PriceScale is the fixed-point scale of SpotPrice.
+const PriceScale = 1_000_000_000
+
+const bpsDenominator = 10_000
+
+// Reserves is the state of a Pump bonding curve that prices trades.
+type Reserves struct {
+	VirtualTokenReserves uint64
+	VirtualSolReserves   uint64
+	// RealTokenReserves caps how many tokens a buy can take.
+	RealTokenReserves uint64
+	// RealSolReserves caps how many lamports a sell can pay out; only
+	// SellTokensForSolOut reads it.
+	RealSolReserves uint64
+}
+
+// PoolReserves is the state of a Pump AMM pool that prices trades: the
+// balances of its base and quote token accounts.
+type PoolReserves struct {
+	BaseReserves  uint64
+	QuoteReserves uint64
+}
+
+// BuyTokensOut returns the tokens a bonding-curve buy of solIn lamports
+// (fees included) receives, capped at the curve's real token reserves.
+func BuyTokensOut(r Reserves, feeBps, solIn uint64) uint64 {
+	net := netOfFee(solIn, feeBps)
+	return min(constantProductOut(r.VirtualTokenReserves, r.VirtualSolReserves, net), r.RealTokenReserves)
+}
+
+// SellSolOut returns the lamports, net of fees, that selling tokenIn tokens
+// to the bonding curve pays.
+func SellSolOut(r Reserves, feeBps, tokenIn uint64) uint64 {
+	gross := constantProductOut(r.VirtualSolReserves, r.VirtualTokenReserves, tokenIn)
+	return lessFee(gross, feeBps)
+}
+
+// SellTokensForSolOut returns the fewest tokens whose sale to the bonding
+// curve pays at least solOut lamports net of fees; it inverts SellSolOut.
+// MaxUint64 is returned if the curve cannot pay that much.
+func SellTokensForSolOut(r Reserves, feeBps, solOut uint64) uint64 {
+	if feeBps >= bpsDenominator {
+		return math.MaxUint64
+	}
+	// Invert fee and curve for an upper bound: gross = solOut / (1 - fee),
+	// tokens = gross * vTok / (vSol - gross), both rounded up.
+	gross := saturate(mulDivCeil(solOut, bpsDenominator, bpsDenominator-feeBps))
+	if gross >= r.VirtualSolReserves || gross > r.RealSolReserves {
+		return math.MaxUint64
+	}
+	hi := saturate(mulDivCeil(gross, r.VirtualTokenReserves, r.VirtualSolReserves-gross))
+	if SellSolOut(r, feeBps, hi) < solOut {
+		return math.MaxUint64
+	}
+	// The output is monotonic in tokens; search down to the fewest.
+	return uint64(sort.Search(int(min(hi, math.MaxInt)), func(i int) bool {
+		return SellSolOut(r, feeBps, uint64(i)) >= solOut
+	}))
+}
+
+// BuyCostForTokens returns the lamports, fees included, needed to buy
+// exactly tokenOut tokens from the bonding curve; it inverts BuyTokensOut.
+// Requests beyond the real token reserves are priced as buying the rest of
+// the supply, and 0 is returned if the curve has no tokens left.
+func BuyCostForTokens(r Reserves, feeBps, tokenOut uint64) uint64 {
+	tokenOut = min(tokenOut, r.RealTokenReserves)
+	if tokenOut == 0 || tokenOut >= r.VirtualTokenReserves {
+		return 0
+	}
+	// The program charges one lamport over the exact constant-product input.
+	solIn := mulDiv(tokenOut, r.VirtualSolReserves, r.VirtualTokenReserves-tokenOut)
+	solIn.Add(solIn, big.NewInt(1))
+	return saturate(solIn.Add(solIn, feeOf(solIn, feeBps)))
+}
+
+// AmmBuyBaseOut returns the base tokens a pool buy spending quoteIn (fees
+// included) receives.
+func AmmBuyBaseOut(p PoolReserves, feeBps, quoteIn uint64) uint64 {
+	return constantProductOut(p.BaseReserves, p.QuoteReserves, netOfFee(quoteIn, feeBps))
+}
+
+// AmmSellQuoteOut returns the quote tokens, net of fees, that selling baseIn
+// to the pool pays.
+func AmmSellQuoteOut(p PoolReserves, feeBps, baseIn uint64) uint64 {
+	gross := constantProductOut(p.QuoteReserves, p.BaseReserves, baseIn)
+	return lessFee(gross, feeBps)
+}
+
+// AmmBuyQuoteIn returns the quote tokens, fees included, needed to buy
+// exactly baseOut from the pool, or 0 if the pool cannot supply baseOut.
+// Fees are rounded up as a single rate; the program rounds the LP, protocol
+// and creator fees separately, so it can charge a unit or two more.
+func AmmBuyQuoteIn(p PoolReserves, feeBps, baseOut uint64) uint64 {
+	if baseOut == 0 || baseOut >= p.BaseReserves {
+		return 0
+	}
+	quoteIn := mulDivCeil(p.QuoteReserves, baseOut, p.BaseReserves-baseOut)
+	return saturate(quoteIn.Add(quoteIn, feeOf(quoteIn, feeBps)))
+}
+
+// SpotPrice returns quoteReserves per baseReserves scaled by PriceScale,
+// saturating at MaxUint64, or 0 for empty base reserves.
+func SpotPrice(quoteReserves, baseReserves uint64) uint64 {
+	if baseReserves == 0 {
+		return 0
+	}
+	return saturate(mulDiv(quoteReserves, PriceScale, baseReserves))
+}
+
+// ExecutionPrice returns the price a trade of quoteAmount for baseAmount
+// executes at, scaled by PriceScale like SpotPrice.
+func ExecutionPrice(quoteAmount, baseAmount uint64) uint64 {
+	return SpotPrice(quoteAmount, baseAmount)
+}
+
+// PriceImpactBps returns how far, in bps of the pool's spot price, a trade
+// of quoteAmount for baseAmount executes on the adverse side of spot: above
+// it for buys, below it for sells. Both prices are compared at PriceScale
+// without saturating, so trades priced beyond MaxUint64 still compare.
+func PriceImpactBps(p PoolReserves, quoteAmount, baseAmount uint64, isBuy bool) uint64 {
+	if p.BaseReserves == 0 || baseAmount == 0 {
+		return 0
+	}
+	spot := mulDiv(p.QuoteReserves, PriceScale, p.BaseReserves)
+	if spot.Sign() == 0 {
+		return 0
+	}
+	diff := mulDiv(quoteAmount, PriceScale, baseAmount)
+	diff.Sub(diff, spot)
+	if !isBuy {
+		diff.Neg(diff)
+	}
+	if diff.Sign() <= 0 {
+		return 0
+	}
+	diff.Mul(diff, big.NewInt(bpsDenominator))
+	return saturate(diff.Div(diff, spot))
+}
+
+// ExactInImpactBps returns the constant-product price impact, in bps, of
+// putting amountIn into reserves holding reserveIn:
+// amountIn / (reserveIn + amountIn). Empty reserves report 0.
+func ExactInImpactBps(reserveIn, amountIn uint64) uint64 {
+	if reserveIn == 0 {
+		return 0
+	}
+	den := new(big.Int).Add(new(big.Int).SetUint64(reserveIn), new(big.Int).SetUint64(amountIn))
+	return ratioBps(amountIn, den)
+}
+
+// ExactOutImpactBps returns the constant-product price impact, in bps, of
+// taking amountOut from reserves holding reserveOut: amountOut / reserveOut,
+// capped at 10000. Empty reserves report 0.
+func ExactOutImpactBps(reserveOut, amountOut uint64) uint64 {
+	if reserveOut == 0 {
+		return 0
+	}
+	return ratioBps(amountOut, new(big.Int).SetUint64(reserveOut))
+}
+
+// NetOfFee returns the part of a fee-inclusive amount left after feeBps,
+// rounded down: amount * 10000 / (10000 + feeBps).
+func NetOfFee(amount, feeBps uint64) uint64 {
+	return netOfFee(amount, feeBps)
+}
+
+// MinOut returns the least output accepted for an expected amount at
+// slippageBps, rounded down, or 0 if slippageBps allows losing everything.
+func MinOut(amount, slippageBps uint64) uint64 {
+	if slippageBps >= bpsDenominator {
+		return 0
+	}
+	return mulDiv(amount, bpsDenominator-slippageBps, bpsDenominator).Uint64()
+}
+
+// MinOutCeil is MinOut rounded up, so that a non-zero amount only yields 0
+// when slippageBps allows losing everything.
+func MinOutCeil(amount, slippageBps uint64) uint64 {
+	if slippageBps >= bpsDenominator {
+		return 0
+	}
+	return mulDivCeil(amount, bpsDenominator-slippageBps, bpsDenominator).Uint64()
+}
+
+// constantProductOut returns outReserves * in / (inReserves + in).
+func constantProductOut(outReserves, inReserves, in uint64) uint64 {
+	if outReserves == 0 || in == 0 {
+		return 0
+	}
+	den := new(big.Int).Add(new(big.Int).SetUint64(inReserves), new(big.Int).SetUint64(in))
+	num := new(big.Int).Mul(new(big.Int).SetUint64(outReserves), new(big.Int).SetUint64(in))
+	return num.Div(num, den).Uint64()
+}
+
+// netOfFee returns the part of a fee-inclusive amount left after feeBps.
+func netOfFee(amount, feeBps uint64) uint64 {
+	net := new(big.Int).Mul(new(big.Int).SetUint64(amount), big.NewInt(bpsDenominator))
+	net.Div(net, new(big.Int).Add(new(big.Int).SetUint64(feeBps), big.NewInt(bpsDenominator)))
+	return net.Uint64()
+}
+
+// lessFee returns gross minus its rounded-up fee, or 0 if the fee takes it all.
+func lessFee(gross, feeBps uint64) uint64 {
+	g := new(big.Int).SetUint64(gross)
+	fee := feeOf(g, feeBps)
+	if fee.Cmp(g) >= 0 {
+		return 0
+	}
+	return g.Sub(g, fee).Uint64()
+}
+
+// feeOf returns ceil(amount * feeBps / 10000).
+func feeOf(amount *big.Int, feeBps uint64) *big.Int {
+	fee := new(big.Int).Mul(amount, new(big.Int).SetUint64(feeBps))
+	fee.Add(fee, big.NewInt(bpsDenominator-1))
+	return fee.Div(fee, big.NewInt(bpsDenominator))
+}
+
+// ratioBps returns num * 10000 / den, capped at 10000.
+func ratioBps(num uint64, den *big.Int) uint64 {
+	v := new(big.Int).Mul(new(big.Int).SetUint64(num), big.NewInt(bpsDenominator))
+	return min(saturate(v.Div(v, den)), bpsDenominator)
+}
+
+// mulDiv returns floor(a * b / c).
+func mulDiv(a, b, c uint64) *big.Int {
+	v := new(big.Int).Mul(new(big.Int).SetUint64(a), new(big.Int).SetUint64(b))
+	return v.Div(v, new(big.Int).SetUint64(c))
+}
+
+// mulDivCeil returns ceil(a * b / c).
+func mulDivCeil(a, b, c uint64) *big.Int {
+	v := new(big.Int).Mul(new(big.Int).SetUint64(a), new(big.Int).SetUint64(b))
+	v.Add(v, new(big.Int).SetUint64(c-1))
+	return v.Div(v, new(big.Int).SetUint64(c))
+}
+
+// saturate returns v as a uint64, or MaxUint64 if it does not fit.
+func saturate(v *big.Int) uint64 {
+	if !v.IsUint64() {
+		return math.MaxUint64
+	}
+	return v.Uint64()
+}
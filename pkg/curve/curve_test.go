@@ -0,0 +1,329 @@
+package curve
+
+import (
+	"math"
+	"testing"
+)
+
+// fresh is a bonding curve with the Pump launch parameters.
+var fresh = Reserves{
+	VirtualTokenReserves: 1_073_000_000_000_000,
+	VirtualSolReserves:   30_000_000_000,
+	RealTokenReserves:    793_100_000_000_000,
+}
+
+// pool is a pump_amm pool of 1M tokens (6 decimals) against 50 SOL.
+var pool = PoolReserves{BaseReserves: 1_000_000_000_000, QuoteReserves: 50_000_000_000}
+
+func TestBuyTokensOut(t *testing.T) {
+	cases := []struct {
+		name          string
+		r             Reserves
+		feeBps, solIn uint64
+		want          uint64
+	}{
+		{"1 SOL no fee", fresh, 0, 1_000_000_000, 34_612_903_225_806},
+		{"1 SOL 1.25% fee", fresh, 125, 1_000_000_000, 34_199_203_154_141},
+		{"one lamport eaten by rounding", fresh, 95, 1, 0},
+		{"zero in", fresh, 0, 0, 0},
+		{"capped at real reserves", fresh, 0, 1_000_000_000_000_000, fresh.RealTokenReserves},
+		{"max input capped", fresh, 100, math.MaxUint64, fresh.RealTokenReserves},
+		{"empty curve", Reserves{}, 0, 1_000_000_000, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := BuyTokensOut(tc.r, tc.feeBps, tc.solIn); got != tc.want {
+				t.Fatalf("BuyTokensOut = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSellSolOut(t *testing.T) {
+	cases := []struct {
+		name            string
+		r               Reserves
+		feeBps, tokenIn uint64
+		want            uint64
+	}{
+		{"1M tokens no fee", fresh, 0, 1_000_000_000_000, 27_932_960},
+		{"1M tokens 1.25% fee", fresh, 125, 1_000_000_000_000, 27_583_798},
+		{"50M tokens", fresh, 0, 50_000_000_000_000, 1_335_707_925},
+		{"dust", fresh, 125, 1, 0},
+		{"zero in", fresh, 0, 0, 0},
+		{"fee takes all", fresh, 10_000, 1_000_000_000_000, 0},
+		{"empty curve", Reserves{}, 0, 1_000_000, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SellSolOut(tc.r, tc.feeBps, tc.tokenIn); got != tc.want {
+				t.Fatalf("SellSolOut = %d, want %d", got, tc.want)
+			}
+		})
+	}
+
+	// Even the largest sell cannot drain the virtual reserves.
+	if got := SellSolOut(fresh, 0, math.MaxUint64); got >= fresh.VirtualSolReserves {
+		t.Fatalf("max sell pays %d, want below virtual reserves %d", got, fresh.VirtualSolReserves)
+	}
+}
+
+func TestSellTokensForSolOut(t *testing.T) {
+	r := Reserves{
+		VirtualTokenReserves: 1_000_000_000_000_000,
+		VirtualSolReserves:   30_000_000_000,
+		RealSolReserves:      5_000_000_000,
+	}
+	const feeBps = 125
+
+	for _, target := range []uint64{1, 1_000, 12_345_678, 500_000_000, 4_000_000_000} {
+		tokens := SellTokensForSolOut(r, feeBps, target)
+		if tokens == math.MaxUint64 {
+			t.Fatalf("target %d: unreachable", target)
+		}
+		if got := SellSolOut(r, feeBps, tokens); got < target {
+			t.Errorf("target %d: %d tokens pay %d", target, tokens, got)
+		}
+		if got := SellSolOut(r, feeBps, tokens-1); got >= target {
+			t.Errorf("target %d: %d tokens already pay %d, want the fewest", target, tokens-1, got)
+		}
+	}
+
+	// More than the curve's real SOL reserves cannot be paid out.
+	if got := SellTokensForSolOut(r, feeBps, 6_000_000_000); got != math.MaxUint64 {
+		t.Errorf("beyond real reserves: tokens = %d, want MaxUint64", got)
+	}
+	if got := SellTokensForSolOut(r, 10_000, 1); got != math.MaxUint64 {
+		t.Errorf("fee takes all: tokens = %d, want MaxUint64", got)
+	}
+}
+
+func TestBuyCostForTokens(t *testing.T) {
+	cases := []struct {
+		name             string
+		r                Reserves
+		feeBps, tokenOut uint64
+		want             uint64
+	}{
+		{"one token", fresh, 0, 1_000_000, 28},
+		{"one base unit", fresh, 95, 1, 2},
+		{"1M tokens 1.25% fee", fresh, 125, 1_000_000_000_000, 28_334_889},
+		// ~85 SOL buys out a fresh curve.
+		{"exact remaining supply", fresh, 0, fresh.RealTokenReserves, 85_005_359_057},
+		{"clamped to remaining supply", fresh, 0, fresh.RealTokenReserves + 1, 85_005_359_057},
+		{"far over supply", fresh, 0, math.MaxUint64, 85_005_359_057},
+		{"zero out", fresh, 0, 0, 0},
+		{"sold out", Reserves{VirtualTokenReserves: 1, VirtualSolReserves: 1}, 0, 1, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := BuyCostForTokens(tc.r, tc.feeBps, tc.tokenOut); got != tc.want {
+				t.Fatalf("BuyCostForTokens = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuyCostForTokensInvertsBuy(t *testing.T) {
+	for _, feeBps := range []uint64{0, 95, 125} {
+		for _, tokens := range []uint64{1_000_000, 79_310_000_000_000, fresh.RealTokenReserves - 1} {
+			cost := BuyCostForTokens(fresh, feeBps, tokens)
+			// The cost buys at least the tokens asked for...
+			if got := BuyTokensOut(fresh, feeBps, cost); got < tokens {
+				t.Fatalf("fee %d: cost %d buys %d tokens, want >= %d", feeBps, cost, got, tokens)
+			}
+			// ...and is tight: a few lamports less falls short.
+			if got := BuyTokensOut(fresh, feeBps, cost-3); got >= tokens {
+				t.Fatalf("fee %d: cost %d not tight, %d lamports buy %d", feeBps, cost, cost-3, got)
+			}
+		}
+	}
+}
+
+func TestAmmBuyBaseOut(t *testing.T) {
+	square := PoolReserves{BaseReserves: 1_000_000, QuoteReserves: 1_000_000}
+	cases := []struct {
+		name            string
+		p               PoolReserves
+		feeBps, quoteIn uint64
+		want            uint64
+	}{
+		// 1_000_000 * 10_000 / 1_010_000
+		{"square pool no fee", square, 0, 10_000, 9_900},
+		// 9_900 net: 1_000_000 * 9_900 / 1_009_900
+		{"square pool 1% fee", square, 100, 10_000, 9_802},
+		{"1 SOL no fee", pool, 0, 1_000_000_000, 19_607_843_137},
+		{"1 SOL 1.25% fee", pool, 125, 1_000_000_000, 19_370_460_029},
+		{"zero in", pool, 0, 0, 0},
+		{"empty pool", PoolReserves{}, 0, 10_000, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := AmmBuyBaseOut(tc.p, tc.feeBps, tc.quoteIn); got != tc.want {
+				t.Fatalf("AmmBuyBaseOut = %d, want %d", got, tc.want)
+			}
+		})
+	}
+
+	if got := AmmBuyBaseOut(pool, 0, math.MaxUint64); got >= pool.BaseReserves {
+		t.Fatalf("max buy takes %d, want below the base reserves %d", got, pool.BaseReserves)
+	}
+}
+
+func TestAmmSellQuoteOut(t *testing.T) {
+	cases := []struct {
+		name           string
+		p              PoolReserves
+		feeBps, baseIn uint64
+		want           uint64
+	}{
+		{"1% of pool no fee", pool, 0, 10_000_000_000, 495_049_504},
+		{"1% of pool 1.25% fee", pool, 125, 10_000_000_000, 488_861_385},
+		{"zero in", pool, 0, 0, 0},
+		{"fee takes all", pool, 10_000, 10_000_000_000, 0},
+		{"empty pool", PoolReserves{}, 0, 10_000, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := AmmSellQuoteOut(tc.p, tc.feeBps, tc.baseIn); got != tc.want {
+				t.Fatalf("AmmSellQuoteOut = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAmmBuyQuoteIn(t *testing.T) {
+	cases := []struct {
+		name            string
+		p               PoolReserves
+		feeBps, baseOut uint64
+		want            uint64
+	}{
+		{"1% of pool no fee", pool, 0, 10_000_000_000, 505_050_506},
+		{"1% of pool 1.25% fee", pool, 125, 10_000_000_000, 511_363_638},
+		{"one base unit", pool, 125, 1, 2},
+		{"zero out", pool, 0, 0, 0},
+		{"whole pool", pool, 0, pool.BaseReserves, 0},
+		{"empty pool", PoolReserves{}, 0, 1, 0},
+		{"saturates", PoolReserves{BaseReserves: math.MaxUint64, QuoteReserves: math.MaxUint64}, 0, math.MaxUint64 - 1, math.MaxUint64},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := AmmBuyQuoteIn(tc.p, tc.feeBps, tc.baseOut); got != tc.want {
+				t.Fatalf("AmmBuyQuoteIn = %d, want %d", got, tc.want)
+			}
+		})
+	}
+
+	// Spending the quoted input buys at least baseOut.
+	for _, feeBps := range []uint64{0, 30, 125} {
+		for _, baseOut := range []uint64{1_000_000, 10_000_000_000, pool.BaseReserves / 2} {
+			in := AmmBuyQuoteIn(pool, feeBps, baseOut)
+			if got := AmmBuyBaseOut(pool, feeBps, in); got < baseOut {
+				t.Fatalf("fee %d: %d in buys %d, want >= %d", feeBps, in, got, baseOut)
+			}
+		}
+	}
+}
+
+func TestSpotPrice(t *testing.T) {
+	cases := []struct {
+		name        string
+		quote, base uint64
+		want        uint64
+	}{
+		{"fresh curve", fresh.VirtualSolReserves, fresh.VirtualTokenReserves, 27_958},
+		{"pool", pool.QuoteReserves, pool.BaseReserves, 50_000_000},
+		{"one to one", 1, 1, PriceScale},
+		{"no base", 1, 0, 0},
+		{"saturates", math.MaxUint64, 1, math.MaxUint64},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SpotPrice(tc.quote, tc.base); got != tc.want {
+				t.Fatalf("SpotPrice = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPriceImpactBps(t *testing.T) {
+	cases := []struct {
+		name        string
+		p           PoolReserves
+		quote, base uint64
+		isBuy       bool
+		want        uint64
+	}{
+		// Spot is 0.05 quote per base.
+		{"buy at spot", pool, 50, 1_000, true, 0},
+		{"buy 1% above spot", pool, 505, 10_000, true, 100},
+		{"buy below spot", pool, 495, 10_000, true, 0},
+		{"sell 1% below spot", pool, 495, 10_000, false, 100},
+		{"sell above spot", pool, 505, 10_000, false, 0},
+		{"no base out", pool, 505, 0, true, 0},
+		{"empty pool", PoolReserves{}, 505, 10_000, true, 0},
+		// Execution prices beyond MaxUint64 are compared unsaturated.
+		{"huge buy", PoolReserves{BaseReserves: 1, QuoteReserves: math.MaxUint64 / 2}, math.MaxUint64, 1, true, 10_000},
+		{"huge sell", PoolReserves{BaseReserves: 1, QuoteReserves: math.MaxUint64}, math.MaxUint64 / 4, 1, false, 7_500},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := PriceImpactBps(tc.p, tc.quote, tc.base, tc.isBuy); got != tc.want {
+				t.Fatalf("PriceImpactBps = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConstantProductImpactBps(t *testing.T) {
+	// Putting 100 into 9_900 moves the price by 100/10_000 = 1%, the same as
+	// taking the matching 1% of the other side.
+	if got := ExactInImpactBps(9_900, 100); got != 100 {
+		t.Fatalf("exact in = %d, want 100", got)
+	}
+	if got := ExactOutImpactBps(10_000, 100); got != 100 {
+		t.Fatalf("exact out = %d, want 100", got)
+	}
+	if got := ExactOutImpactBps(100, 500); got != 10_000 {
+		t.Fatalf("oversized exact out = %d, want capped 10000", got)
+	}
+	if got := ExactInImpactBps(0, 100); got != 0 {
+		t.Fatalf("empty reserves: exact in = %d, want 0", got)
+	}
+}
+
+func TestMinOut(t *testing.T) {
+	cases := []struct {
+		name                string
+		amount, slippageBps uint64
+		floor, ceil         uint64
+	}{
+		{"1%", 1_000, 100, 990, 990},
+		{"rounds", 1, 100, 0, 1},
+		{"no slippage", math.MaxUint64, 0, math.MaxUint64, math.MaxUint64},
+		// amount*(10000-bps) overflows uint64; the result must not wrap.
+		{"large amount", math.MaxUint64, 100, 18_262_276_632_972_456_098, 18_262_276_632_972_456_099},
+		{"lose everything", 1_000, 10_000, 0, 0},
+		{"beyond everything", 1_000, 20_000, 0, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := MinOut(tc.amount, tc.slippageBps); got != tc.floor {
+				t.Fatalf("MinOut = %d, want %d", got, tc.floor)
+			}
+			if got := MinOutCeil(tc.amount, tc.slippageBps); got != tc.ceil {
+				t.Fatalf("MinOutCeil = %d, want %d", got, tc.ceil)
+			}
+		})
+	}
+}
+
+func TestNetOfFee(t *testing.T) {
+	if got := NetOfFee(10_100, 100); got != 10_000 {
+		t.Fatalf("NetOfFee = %d, want 10000", got)
+	}
+	if got := NetOfFee(math.MaxUint64, 0); got != math.MaxUint64 {
+		t.Fatalf("NetOfFee without fee = %d, want MaxUint64", got)
+	}
+}
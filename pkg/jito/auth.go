@@ -0,0 +1,231 @@
+package jito
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Auth service methods of the block engine gRPC API (jito-labs/mev-protos).
+const (
+	methodGenerateAuthChallenge = "/auth.AuthService/GenerateAuthChallenge"
+	methodGenerateAuthTokens    = "/auth.AuthService/GenerateAuthTokens"
+	methodRefreshAccessToken    = "/auth.AuthService/RefreshAccessToken"
+)
+
+// roleSearcher is auth.Role SEARCHER.
+const roleSearcher = 1
+
+// tokenRefreshMargin is how long before expiry an auth token is replaced.
+const tokenRefreshMargin = 30 * time.Second
+
+// authTimeout bounds a handshake started from an HTTP request, which carries
+// no deadline of its own.
+const authTimeout = 10 * time.Second
+
+// authToken is an auth token and its expiry.
+type authToken struct {
+	value   string
+	expires time.Time
+}
+
+func (t authToken) valid(now time.Time) bool {
+	return t.value != "" && now.Add(tokenRefreshMargin).Before(t.expires)
+}
+
+// authenticator holds a searcher's block engine access token, obtained by
+// signing a challenge from the gRPC auth service and renewed before expiry.
+type authenticator struct {
+	key  solana.PrivateKey
+	conn *grpc.ClientConn
+
+	mu      sync.Mutex
+	access  authToken
+	refresh authToken
+}
+
+func newAuthenticator(key solana.PrivateKey, conn *grpc.ClientConn) *authenticator {
+	return &authenticator{key: key, conn: conn}
+}
+
+// token returns a valid access token, refreshing it or running the
+// challenge flow when it is missing or about to expire.
+func (a *authenticator) token(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now()
+	if a.access.valid(now) {
+		return a.access.value, nil
+	}
+	if a.refresh.valid(now) {
+		var req []byte
+		req = protowire.AppendTag(req, 1, protowire.BytesType)
+		req = protowire.AppendString(req, a.refresh.value)
+		var resp []byte
+		if err := a.conn.Invoke(ctx, methodRefreshAccessToken, &req, &resp); err == nil {
+			var access authToken
+			if err := protoFields(resp, func(num protowire.Number, b []byte, _ uint64) {
+				if num == 1 {
+					access = decodeToken(b)
+				}
+			}); err == nil && access.value != "" {
+				a.access = access
+				return access.value, nil
+			}
+		}
+	}
+	if err := a.authenticate(ctx); err != nil {
+		return "", err
+	}
+	return a.access.value, nil
+}
+
+// authenticate signs an auth challenge with the keypair and stores the
+// access and refresh tokens it is granted. a.mu must be held.
+func (a *authenticator) authenticate(ctx context.Context) error {
+	pubkey := a.key.PublicKey()
+
+	var req []byte
+	req = protowire.AppendTag(req, 1, protowire.VarintType)
+	req = protowire.AppendVarint(req, roleSearcher)
+	req = protowire.AppendTag(req, 2, protowire.BytesType)
+	req = protowire.AppendBytes(req, pubkey[:])
+	var resp []byte
+	if err := a.conn.Invoke(ctx, methodGenerateAuthChallenge, &req, &resp); err != nil {
+		return fmt.Errorf("generate auth challenge: %w", err)
+	}
+	var challenge string
+	if err := protoFields(resp, func(num protowire.Number, b []byte, _ uint64) {
+		if num == 1 {
+			challenge = string(b)
+		}
+	}); err != nil {
+		return fmt.Errorf("decode auth challenge: %w", err)
+	}
+
+	// The block engine expects "<pubkey>-<challenge>" signed by the keypair.
+	challenge = pubkey.String() + "-" + challenge
+	signed, err := a.key.Sign([]byte(challenge))
+	if err != nil {
+		return fmt.Errorf("sign auth challenge: %w", err)
+	}
+	req = req[:0]
+	req = protowire.AppendTag(req, 1, protowire.BytesType)
+	req = protowire.AppendString(req, challenge)
+	req = protowire.AppendTag(req, 2, protowire.BytesType)
+	req = protowire.AppendBytes(req, pubkey[:])
+	req = protowire.AppendTag(req, 3, protowire.BytesType)
+	req = protowire.AppendBytes(req, signed[:])
+	resp = nil
+	if err := a.conn.Invoke(ctx, methodGenerateAuthTokens, &req, &resp); err != nil {
+		return fmt.Errorf("generate auth tokens: %w", err)
+	}
+	var access, refresh authToken
+	if err := protoFields(resp, func(num protowire.Number, b []byte, _ uint64) {
+		switch num {
+		case 1:
+			access = decodeToken(b)
+		case 2:
+			refresh = decodeToken(b)
+		}
+	}); err != nil {
+		return fmt.Errorf("decode auth tokens: %w", err)
+	}
+	if access.value == "" {
+		return fmt.Errorf("block engine returned no access token")
+	}
+	a.access, a.refresh = access, refresh
+	return nil
+}
+
+// decodeToken decodes an auth.Token; malformed fields leave it empty.
+func decodeToken(data []byte) authToken {
+	var t authToken
+	_ = protoFields(data, func(num protowire.Number, b []byte, _ uint64) {
+		switch num {
+		case 1:
+			t.value = string(b)
+		case 2: // google.protobuf.Timestamp
+			var secs, nanos uint64
+			_ = protoFields(b, func(num protowire.Number, _ []byte, v uint64) {
+				switch num {
+				case 1:
+					secs = v
+				case 2:
+					nanos = v
+				}
+			})
+			t.expires = time.Unix(int64(secs), int64(nanos))
+		}
+	})
+	return t
+}
+
+// WithAuthKeypair authenticates requests as the searcher owning key. Before
+// the first request to each endpoint, and again before the token expires,
+// the client signs a challenge from the endpoint's gRPC auth service (port
+// 443 of the same host) and sends the granted token as a bearer token on
+// every JSON-RPC request. Authenticated searchers get higher rate limits and
+// can use private block engines.
+//
+// Access is granted per keypair: request it from Jito for the keypair's
+// public key (see https://docs.jito.wtf). Until then the challenge is
+// rejected and every request fails with the auth error.
+//
+// Example:
+//
+//	authKey, _ := solana.PrivateKeyFromSolanaKeygenFile("jito-auth.json")
+//	jc, err := jito.NewClient(jito.MainnetBlockEngine, "").WithAuthKeypair(authKey)
+func (c *Client) WithAuthKeypair(key solana.PrivateKey) (*Client, error) {
+	return c.withAuth(key, func(endpoint string) (*grpc.ClientConn, error) {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Hostname() == "" {
+			return nil, fmt.Errorf("invalid endpoint %q", endpoint)
+		}
+		return grpc.NewClient(u.Hostname()+":443",
+			grpc.WithTransportCredentials(credentials.NewTLS(nil)),
+			grpc.WithDefaultCallOptions(grpc.ForceCodec(rawCodec{})),
+		)
+	})
+}
+
+// withAuth sets up an authenticator per endpoint over the auth service
+// connection dial returns for it.
+func (c *Client) withAuth(key solana.PrivateKey, dial func(endpoint string) (*grpc.ClientConn, error)) (*Client, error) {
+	auth := make(map[string]*authenticator, len(c.endpoints))
+	for _, endpoint := range c.endpoints {
+		conn, err := dial(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("jito auth: %w", err)
+		}
+		auth[endpoint] = newAuthenticator(key, conn)
+	}
+	c.auth = auth
+	return c, nil
+}
+
+// bearerTransport adds the authenticator's access token to each request.
+type bearerTransport struct {
+	auth *authenticator
+	base http.RoundTripper
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), authTimeout)
+	token, err := t.auth.token(ctx)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("jito auth: %w", err)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(req)
+}
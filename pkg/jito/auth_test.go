@@ -0,0 +1,85 @@
+package jito
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestClientWithAuthKeypair(t *testing.T) {
+	engine, addr := startBlockEngine(t)
+	var mu sync.Mutex
+	var headers []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = append(headers, r.Header.Get("Authorization"))
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": "bundle-id"})
+	}))
+	defer srv.Close()
+
+	key, _ := solana.NewRandomPrivateKey()
+	client, err := NewClient(srv.URL, "").withAuth(key, func(string) (*grpc.ClientConn, error) {
+		return grpc.NewClient(addr,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithDefaultCallOptions(grpc.ForceCodec(rawCodec{})),
+		)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := transferTx(t, MainnetTipAccounts[0])
+	for i := 0; i < 2; i++ {
+		if _, err := client.SendBundle(context.Background(), []*solana.Transaction{tx}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(headers) != 2 || headers[0] != "Bearer access" || headers[1] != "Bearer access" {
+		t.Fatalf("authorization headers = %q", headers)
+	}
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+	// The token is fetched once and reused.
+	if len(engine.calls) != 2 || engine.calls[0] != methodGenerateAuthChallenge || engine.calls[1] != methodGenerateAuthTokens {
+		t.Fatalf("auth calls = %v", engine.calls)
+	}
+}
+
+func TestAuthenticatorRefreshesExpiredToken(t *testing.T) {
+	engine, addr := startBlockEngine(t)
+	conn, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(rawCodec{})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	key, _ := solana.NewRandomPrivateKey()
+	auth := newAuthenticator(key, conn)
+	if _, err := auth.token(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// An access token inside the refresh margin is replaced using the
+	// refresh token rather than a new challenge.
+	auth.access.expires = auth.access.expires.Add(-time.Hour)
+	if _, err := auth.token(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+	if len(engine.calls) != 3 || engine.calls[2] != methodRefreshAccessToken {
+		t.Fatalf("auth calls = %v", engine.calls)
+	}
+}
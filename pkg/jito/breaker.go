@@ -0,0 +1,81 @@
+package jito
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrJitoUnavailable is returned without contacting the block engine while
+// the circuit breaker is open.
+var ErrJitoUnavailable = errors.New("jito block engine unavailable: circuit breaker open")
+
+// circuitBreaker stops requests after threshold consecutive rate-limit
+// failures, across all endpoints, for cooldown. After the cooldown a single
+// probe request is let through (half-open): a response that is not rate
+// limited closes the breaker, another rate limit reopens it.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// WithCircuitBreaker makes the client fast-fail with ErrJitoUnavailable for
+// cooldown after threshold consecutive rate-limit failures across its
+// endpoints, instead of retrying every region during congestion. A
+// threshold of zero or less disables the breaker.
+//
+// Example:
+//
+//	client := jito.NewClientWithEndpoints(jito.MainnetBlockEngines, "").
+//	    WithCircuitBreaker(10, 5*time.Second)
+func (c *Client) WithCircuitBreaker(threshold int, cooldown time.Duration) *Client {
+	if threshold <= 0 {
+		c.breaker = nil
+		return c
+	}
+	c.breaker = &circuitBreaker{threshold: threshold, cooldown: cooldown}
+	return c
+}
+
+// allow returns ErrJitoUnavailable if a request must not be sent now. A nil
+// breaker allows everything.
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return nil
+	}
+	if time.Now().Before(b.openUntil) || b.probing {
+		return ErrJitoUnavailable
+	}
+	b.probing = true
+	return nil
+}
+
+// record updates the breaker with the outcome of a request. Only rate
+// limits count as failures; any other response shows the engine is
+// answering and closes the breaker.
+func (b *circuitBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if !isRateLimitError(err) {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
@@ -0,0 +1,70 @@
+package jito
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	var limited atomic.Bool
+	limited.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": 1}
+		if limited.Load() {
+			resp["error"] = map[string]interface{}{"code": -32097, "message": "Rate limit exceeded"}
+		} else {
+			resp["result"] = "bundle-id"
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	client := NewClientWithEndpoints([]string{srv.URL, srv.URL}, "").
+		WithRetries(10, time.Millisecond).
+		WithCircuitBreaker(3, 50*time.Millisecond)
+	txs := []*solana.Transaction{transferTx(t, MainnetTipAccounts[0])}
+
+	if _, err := client.SendBundle(context.Background(), txs); !errors.Is(err, ErrJitoUnavailable) {
+		t.Fatalf("err = %v, want ErrJitoUnavailable", err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Fatalf("sent %d requests before opening, want 3", n)
+	}
+
+	// Open: fast-fail without contacting the engine.
+	if _, err := client.SendBundle(context.Background(), txs); !errors.Is(err, ErrJitoUnavailable) {
+		t.Fatalf("open breaker: err = %v", err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Fatalf("open breaker sent %d requests", n-3)
+	}
+
+	// Half-open: one probe; a rate-limited probe reopens immediately.
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.SendBundle(context.Background(), txs); !errors.Is(err, ErrJitoUnavailable) {
+		t.Fatalf("failed probe: err = %v", err)
+	}
+	if n := requests.Load(); n != 4 {
+		t.Fatalf("half-open sent %d requests, want 1 probe", n-3)
+	}
+
+	// A successful probe closes the breaker.
+	limited.Store(false)
+	time.Sleep(60 * time.Millisecond)
+	for range 2 {
+		id, err := client.SendBundle(context.Background(), txs)
+		if err != nil || id != "bundle-id" {
+			t.Fatalf("recovered send: %q, %v", id, err)
+		}
+	}
+}
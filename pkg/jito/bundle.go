@@ -0,0 +1,151 @@
+package jito
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// systemTransferTag is the System Program Transfer instruction index.
+const systemTransferTag = 2
+
+// BundleBuilder assembles a bundle with explicit ordering and a chosen tip
+// transaction. Jito recommends tipping in the last transaction, but some
+// flows need the tip elsewhere, e.g. in a funding transaction that must come
+// first. Errors are reported by Build.
+//
+// Example:
+//
+//	bb := jito.NewBundleBuilder().
+//	    AddTransaction(fundAndTipTx).
+//	    AddTransaction(createTx).
+//	    SetTipTransaction(0)
+//	bundleID, err := client.SendBundleBuilt(ctx, bb)
+type BundleBuilder struct {
+	txs         []*solana.Transaction
+	tipIndex    int
+	tipAccounts []solana.PublicKey
+	cuBudget    uint64
+	cuStrict    bool
+}
+
+// NewBundleBuilder returns an empty builder that recognizes tips sent to
+// MainnetTipAccounts.
+func NewBundleBuilder() *BundleBuilder {
+	return &BundleBuilder{tipIndex: -1, tipAccounts: MainnetTipAccounts}
+}
+
+// AddTransaction appends a signed transaction to the bundle.
+func (bb *BundleBuilder) AddTransaction(tx *solana.Transaction) *BundleBuilder {
+	bb.txs = append(bb.txs, tx)
+	return bb
+}
+
+// SetTipTransaction marks the transaction at index, in AddTransaction order,
+// as the one carrying the tip.
+func (bb *BundleBuilder) SetTipTransaction(index int) *BundleBuilder {
+	bb.tipIndex = index
+	return bb
+}
+
+// WithTipAccounts sets the accounts a transfer must pay to count as a tip,
+// e.g. the result of Client.GetTipAccounts on testnet.
+func (bb *BundleBuilder) WithTipAccounts(accounts []solana.PublicKey) *BundleBuilder {
+	bb.tipAccounts = accounts
+	return bb
+}
+
+// Len returns the number of transactions added so far.
+func (bb *BundleBuilder) Len() int {
+	return len(bb.txs)
+}
+
+// Build validates the bundle and returns its transactions in order. The
+// bundle must hold 1 to MaxBundleTransactions transactions and exactly one
+// of them, the one set by SetTipTransaction, must transfer to a tip account.
+func (bb *BundleBuilder) Build() ([]*solana.Transaction, error) {
+	if len(bb.txs) == 0 {
+		return nil, fmt.Errorf("bundle requires at least one transaction")
+	}
+	if len(bb.txs) > MaxBundleTransactions {
+		return nil, fmt.Errorf("bundle has %d transactions, max %d", len(bb.txs), MaxBundleTransactions)
+	}
+	if bb.tipIndex < 0 || bb.tipIndex >= len(bb.txs) {
+		return nil, fmt.Errorf("tip transaction index %d out of range [0, %d)", bb.tipIndex, len(bb.txs))
+	}
+	tipped := -1
+	for i, tx := range bb.txs {
+		if tx == nil {
+			return nil, fmt.Errorf("bundle transaction %d is nil", i)
+		}
+		has, err := bb.hasTip(tx)
+		if err != nil {
+			return nil, fmt.Errorf("bundle transaction %d: %w", i, err)
+		}
+		if !has {
+			continue
+		}
+		if tipped >= 0 {
+			return nil, fmt.Errorf("bundle transactions %d and %d both pay a tip, want exactly one", tipped, i)
+		}
+		tipped = i
+	}
+	if tipped < 0 {
+		return nil, fmt.Errorf("tip transaction %d has no transfer to a tip account", bb.tipIndex)
+	}
+	if tipped != bb.tipIndex {
+		return nil, fmt.Errorf("tip is paid by transaction %d, not the tip transaction %d", tipped, bb.tipIndex)
+	}
+	return slices.Clone(bb.txs), nil
+}
+
+// hasTip reports whether tx has a system transfer to one of the tip accounts.
+func (bb *BundleBuilder) hasTip(tx *solana.Transaction) (bool, error) {
+	_, found, err := tipTransfers(tx, bb.tipAccounts)
+	return found, err
+}
+
+// tipTransfers sums the lamports of tx's system transfers to tipAccounts and
+// reports whether it has any.
+func tipTransfers(tx *solana.Transaction, tipAccounts []solana.PublicKey) (uint64, bool, error) {
+	var (
+		total uint64
+		found bool
+	)
+	msg := tx.Message
+	for _, ix := range msg.Instructions {
+		program, err := msg.Program(ix.ProgramIDIndex)
+		if err != nil {
+			return 0, false, fmt.Errorf("resolve program: %w", err)
+		}
+		data := []byte(ix.Data)
+		if !program.Equals(solana.SystemProgramID) || len(data) < 12 || binary.LittleEndian.Uint32(data) != systemTransferTag {
+			continue
+		}
+		accounts, err := ix.ResolveInstructionAccounts(&msg)
+		if err != nil {
+			return 0, false, fmt.Errorf("resolve accounts: %w", err)
+		}
+		if len(accounts) >= 2 && slices.ContainsFunc(tipAccounts, accounts[1].PublicKey.Equals) {
+			total += binary.LittleEndian.Uint64(data[4:12])
+			found = true
+		}
+	}
+	return total, found, nil
+}
+
+// SendBundleBuilt validates bb and sends it with SendBundle.
+// Returns the bundle ID.
+func (c *Client) SendBundleBuilt(ctx context.Context, bb *BundleBuilder) (string, error) {
+	if bb == nil {
+		return "", fmt.Errorf("bundle builder is nil")
+	}
+	txs, err := bb.Build()
+	if err != nil {
+		return "", err
+	}
+	return c.SendBundle(ctx, txs)
+}
@@ -0,0 +1,102 @@
+package jito
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+)
+
+func transferTx(t *testing.T, to solana.PublicKey) *solana.Transaction {
+	t.Helper()
+	payer := solana.NewWallet().PublicKey()
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{system.NewTransferInstruction(1_000, payer, to).Build()},
+		solana.Hash{},
+		solana.TransactionPayer(payer),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func TestBundleBuilder(t *testing.T) {
+	tip := transferTx(t, MainnetTipAccounts[0])
+	other := transferTx(t, solana.NewWallet().PublicKey())
+
+	txs, err := NewBundleBuilder().AddTransaction(tip).AddTransaction(other).SetTipTransaction(0).Build()
+	if err != nil {
+		t.Fatalf("tip first: %v", err)
+	}
+	if len(txs) != 2 || txs[0] != tip || txs[1] != other {
+		t.Fatal("Build changed transaction order")
+	}
+
+	tooMany := NewBundleBuilder().AddTransaction(tip).SetTipTransaction(0)
+	for tooMany.Len() <= MaxBundleTransactions {
+		tooMany.AddTransaction(other)
+	}
+
+	cases := []struct {
+		name    string
+		bb      *BundleBuilder
+		wantErr string
+	}{
+		{"empty", NewBundleBuilder(), "at least one"},
+		{"tip unset", NewBundleBuilder().AddTransaction(tip), "out of range"},
+		{"tip index wrong", NewBundleBuilder().AddTransaction(tip).AddTransaction(other).SetTipTransaction(1), "not the tip transaction"},
+		{"no tip", NewBundleBuilder().AddTransaction(other).SetTipTransaction(0), "no transfer to a tip account"},
+		{"two tips", NewBundleBuilder().AddTransaction(tip).AddTransaction(transferTx(t, MainnetTipAccounts[1])).SetTipTransaction(0), "exactly one"},
+		{"too many", tooMany, "max 5"},
+	}
+
+	for _, tc := range cases {
+		_, err := tc.bb.Build()
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("%s: err = %v, want %q", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestBundleBuilderTipAccounts(t *testing.T) {
+	custom := solana.NewWallet().PublicKey()
+	tx := transferTx(t, custom)
+	if _, err := NewBundleBuilder().AddTransaction(tx).SetTipTransaction(0).Build(); err == nil {
+		t.Fatal("expected a transfer to an unknown account not to count as a tip")
+	}
+	if _, err := NewBundleBuilder().WithTipAccounts([]solana.PublicKey{custom}).AddTransaction(tx).SetTipTransaction(0).Build(); err != nil {
+		t.Fatalf("custom tip account: %v", err)
+	}
+}
+
+func TestSendBundleDetailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": "bundle-id"})
+	}))
+	defer srv.Close()
+
+	tip := transferTx(t, MainnetTipAccounts[2])
+	tip.Signatures = []solana.Signature{{1}}
+	other := transferTx(t, solana.NewWallet().PublicKey())
+	other.Signatures = []solana.Signature{{2}}
+
+	res, err := NewClient(srv.URL, "").SendBundleDetailed(context.Background(), []*solana.Transaction{other, tip})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.BundleID != "bundle-id" || res.Endpoint != srv.URL {
+		t.Errorf("bundle %q via %q", res.BundleID, res.Endpoint)
+	}
+	if len(res.Signatures) != 2 || res.Signatures[0] != other.Signatures[0] || res.Signatures[1] != tip.Signatures[0] {
+		t.Errorf("signatures = %v", res.Signatures)
+	}
+	if res.TipLamports != 1_000 {
+		t.Errorf("tip = %d, want 1000", res.TipLamports)
+	}
+}
@@ -0,0 +1,110 @@
+package jito
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+)
+
+// DefaultBundleComputeBudget is the CU budget EstimateComputeUnits checks a
+// bundle against unless WithComputeBudget sets another. It is the per-account
+// write-lock limit of a block: trades bundled together write-lock the same
+// bonding curve or pool, so they share this limit rather than the whole
+// block's.
+const DefaultBundleComputeBudget uint64 = 12_000_000
+
+// ErrComputeBudgetExceeded is returned by EstimateComputeUnits in strict mode
+// when the bundle's simulated CU exceed the budget.
+var ErrComputeBudgetExceeded = errors.New("bundle exceeds compute unit budget")
+
+// TransactionSimulator simulates a transaction. *rpc.Client satisfies it.
+type TransactionSimulator interface {
+	SimulateTransaction(ctx context.Context, tx *solana.Transaction, opts *solanarpc.SimulateTransactionOpts) (*solanarpc.SimulateTransactionResponse, error)
+}
+
+// ComputeEstimate is the simulated compute usage of a bundle.
+type ComputeEstimate struct {
+	// PerTransaction holds the CU consumed by each transaction, in order.
+	PerTransaction []uint64
+	// Total is the sum of PerTransaction.
+	Total uint64
+	// Budget is the CU budget the total was checked against.
+	Budget uint64
+	// ExceedsBudget reports Total > Budget; treat it as a warning that the
+	// bundle may not land.
+	ExceedsBudget bool
+	// Failed lists the transactions whose simulation failed. Their units
+	// consumed up to the failure are still counted.
+	Failed []int
+}
+
+// WithComputeBudget sets the CU budget EstimateComputeUnits checks against.
+// Zero restores DefaultBundleComputeBudget.
+func (bb *BundleBuilder) WithComputeBudget(units uint64) *BundleBuilder {
+	bb.cuBudget = units
+	return bb
+}
+
+// WithStrictComputeBudget makes EstimateComputeUnits return
+// ErrComputeBudgetExceeded, alongside the estimate, when the bundle exceeds
+// its budget instead of only setting ExceedsBudget.
+func (bb *BundleBuilder) WithStrictComputeBudget(strict bool) *BundleBuilder {
+	bb.cuStrict = strict
+	return bb
+}
+
+// EstimateComputeUnits simulates each transaction of the bundle and sums the
+// CU they consume. Transactions are simulated independently against current
+// state, so one that depends on an earlier transaction of the bundle (e.g. on
+// an ATA it creates) may fail; it is listed in Failed.
+//
+// Example:
+//
+//	est, err := bb.WithStrictComputeBudget(true).EstimateComputeUnits(ctx, rpcClient)
+//	if errors.Is(err, jito.ErrComputeBudgetExceeded) {
+//	    // split the bundle
+//	}
+func (bb *BundleBuilder) EstimateComputeUnits(ctx context.Context, rpc TransactionSimulator) (*ComputeEstimate, error) {
+	if rpc == nil {
+		return nil, fmt.Errorf("rpc is nil")
+	}
+	if len(bb.txs) == 0 {
+		return nil, fmt.Errorf("bundle requires at least one transaction")
+	}
+
+	est := &ComputeEstimate{Budget: bb.cuBudget}
+	if est.Budget == 0 {
+		est.Budget = DefaultBundleComputeBudget
+	}
+	for i, tx := range bb.txs {
+		if tx == nil {
+			return nil, fmt.Errorf("bundle transaction %d is nil", i)
+		}
+		res, err := rpc.SimulateTransaction(ctx, tx, &solanarpc.SimulateTransactionOpts{
+			SigVerify:              false,
+			ReplaceRecentBlockhash: true,
+			Commitment:             solanarpc.CommitmentProcessed,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("simulate bundle transaction %d: %w", i, err)
+		}
+		if res == nil || res.Value == nil || res.Value.UnitsConsumed == nil {
+			return nil, fmt.Errorf("simulate bundle transaction %d: no units consumed reported", i)
+		}
+		if res.Value.Err != nil {
+			est.Failed = append(est.Failed, i)
+		}
+		units := *res.Value.UnitsConsumed
+		est.PerTransaction = append(est.PerTransaction, units)
+		est.Total += units
+	}
+
+	est.ExceedsBudget = est.Total > est.Budget
+	if est.ExceedsBudget && bb.cuStrict {
+		return est, fmt.Errorf("%w: %d CU simulated, budget %d", ErrComputeBudgetExceeded, est.Total, est.Budget)
+	}
+	return est, nil
+}
@@ -0,0 +1,60 @@
+package jito
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc"
+)
+
+var _ TransactionSimulator = (*rpc.Client)(nil)
+
+// unitsSimulator reports the next entry of units for each simulated
+// transaction; a zero entry reports a failed simulation of 1000 CU.
+type unitsSimulator struct {
+	units []uint64
+	calls int
+}
+
+func (s *unitsSimulator) SimulateTransaction(_ context.Context, _ *solana.Transaction, _ *solanarpc.SimulateTransactionOpts) (*solanarpc.SimulateTransactionResponse, error) {
+	u := s.units[s.calls]
+	s.calls++
+	res := &solanarpc.SimulateTransactionResult{UnitsConsumed: &u}
+	if u == 0 {
+		failed := uint64(1000)
+		res = &solanarpc.SimulateTransactionResult{UnitsConsumed: &failed, Err: "AccountNotFound"}
+	}
+	return &solanarpc.SimulateTransactionResponse{Value: res}, nil
+}
+
+func TestEstimateComputeUnits(t *testing.T) {
+	tx := transferTx(t, solana.NewWallet().PublicKey())
+	bb := NewBundleBuilder().AddTransaction(tx).AddTransaction(tx).AddTransaction(tx)
+
+	est, err := bb.EstimateComputeUnits(context.Background(), &unitsSimulator{units: []uint64{150_000, 0, 80_000}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if est.Total != 231_000 || len(est.PerTransaction) != 3 || est.Budget != DefaultBundleComputeBudget || est.ExceedsBudget {
+		t.Fatalf("unexpected estimate %+v", est)
+	}
+	if len(est.Failed) != 1 || est.Failed[0] != 1 {
+		t.Fatalf("failed = %v, want [1]", est.Failed)
+	}
+
+	bb.WithComputeBudget(200_000)
+	est, err = bb.EstimateComputeUnits(context.Background(), &unitsSimulator{units: []uint64{150_000, 0, 80_000}})
+	if err != nil || !est.ExceedsBudget {
+		t.Fatalf("lenient: err = %v, estimate %+v, want ExceedsBudget", err, est)
+	}
+
+	bb.WithStrictComputeBudget(true)
+	est, err = bb.EstimateComputeUnits(context.Background(), &unitsSimulator{units: []uint64{150_000, 0, 80_000}})
+	if !errors.Is(err, ErrComputeBudgetExceeded) || est == nil || est.Total != 231_000 {
+		t.Fatalf("strict: err = %v, estimate %+v", err, est)
+	}
+}
@@ -0,0 +1,323 @@
+package jito
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+
+	"github.com/gagliardetto/solana-go"
+	jitorpc "github.com/jito-labs/jito-go-rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// MainnetBlockEngineGRPC is the mainnet block engine's gRPC endpoint.
+const MainnetBlockEngineGRPC = "mainnet.block-engine.jito.wtf:443"
+
+// Searcher service methods of the block engine gRPC API (jito-labs/mev-protos).
+const (
+	methodSendBundle     = "/searcher.SearcherService/SendBundle"
+	methodGetTipAccounts = "/searcher.SearcherService/GetTipAccounts"
+)
+
+// GRPCClient submits bundles over the block engine's gRPC searcher API,
+// which skips the JSON-RPC gateway and supports keypair authentication. It
+// implements Sender, so it can be passed to txbuilder.Builder.WithJito in
+// place of a Client.
+//
+// The gRPC API only streams bundle results, so bundle statuses (and
+// therefore WaitForBundleConfirmation) are read over JSON-RPC from the same
+// block engine host; see WithStatusClient.
+type GRPCClient struct {
+	conn   *grpc.ClientConn
+	auth   *authenticator // nil when unauthenticated
+	status *Client
+}
+
+// NewGRPCClient creates a gRPC block engine client for endpoint (host:port,
+// e.g. MainnetBlockEngineGRPC; empty selects it). When authKeypair is set,
+// requests are authenticated as that searcher; pass nil for endpoints that
+// do not require auth. The connection uses TLS unless opts override the
+// transport credentials. No connection is made until the first request.
+//
+// Example:
+//
+//	jc, err := jito.NewGRPCClient(jito.MainnetBlockEngineGRPC, authKey)
+//	if err != nil {
+//	    return err
+//	}
+//	defer jc.Close()
+//	builder := txbuilder.NewBuilder(client, rpc.CommitmentConfirmed).WithJito(jc)
+func NewGRPCClient(endpoint string, authKeypair solana.PrivateKey, opts ...grpc.DialOption) (*GRPCClient, error) {
+	if endpoint == "" {
+		endpoint = MainnetBlockEngineGRPC
+	}
+	dialOpts := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(credentials.NewTLS(nil)),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(rawCodec{})),
+	}, opts...)
+	conn, err := grpc.NewClient(endpoint, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("jito grpc dial %s: %w", endpoint, err)
+	}
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		host = endpoint
+	}
+	c := &GRPCClient{conn: conn, status: NewClient("https://"+host+"/api/v1", "")}
+	if authKeypair != nil {
+		c.auth = newAuthenticator(authKeypair, conn)
+	}
+	return c, nil
+}
+
+// WithStatusClient sets the JSON-RPC client used for bundle statuses.
+// Defaults to the JSON-RPC API of the gRPC endpoint's host.
+func (c *GRPCClient) WithStatusClient(status *Client) *GRPCClient {
+	c.status = status
+	return c
+}
+
+// Close closes the gRPC connection.
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+// GetTipAccounts returns the list of tip accounts that can receive tips.
+func (c *GRPCClient) GetTipAccounts(ctx context.Context) ([]solana.PublicKey, error) {
+	resp, err := c.call(ctx, methodGetTipAccounts, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get tip accounts: %w", err)
+	}
+	var result []solana.PublicKey
+	err = protoFields(resp, func(num protowire.Number, b []byte, _ uint64) {
+		if num != 1 {
+			return
+		}
+		if pk, err := solana.PublicKeyFromBase58(string(b)); err == nil {
+			result = append(result, pk)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("decode tip accounts: %w", err)
+	}
+	return result, nil
+}
+
+// GetRandomTipAccount returns a random account from GetTipAccounts.
+func (c *GRPCClient) GetRandomTipAccount(ctx context.Context) (solana.PublicKey, error) {
+	accounts, err := c.GetTipAccounts(ctx)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	if len(accounts) == 0 {
+		return solana.PublicKey{}, fmt.Errorf("get random tip account: block engine returned no tip accounts")
+	}
+	return accounts[rand.Intn(len(accounts))], nil
+}
+
+// IsNil reports whether c is nil. It implements Sender.
+func (c *GRPCClient) IsNil() bool {
+	return c == nil
+}
+
+// GetRandomTipAccountLocal returns a random tip account from the pre-defined list.
+func (c *GRPCClient) GetRandomTipAccountLocal() solana.PublicKey {
+	return GetRandomTipAccountLocal()
+}
+
+// SendTransaction sends a single transaction as a bundle.
+func (c *GRPCClient) SendTransaction(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
+	result, err := c.SendTransactionWithBundleID(ctx, tx)
+	if err != nil {
+		return solana.Signature{}, err
+	}
+	return result.Signature, nil
+}
+
+// SendTransactionWithBundleID sends a transaction and returns both signature and bundle ID.
+func (c *GRPCClient) SendTransactionWithBundleID(ctx context.Context, tx *solana.Transaction) (SendResult, error) {
+	bundleID, err := c.SendBundle(ctx, []*solana.Transaction{tx})
+	if err != nil {
+		return SendResult{}, err
+	}
+	var sig solana.Signature
+	if len(tx.Signatures) > 0 {
+		sig = tx.Signatures[0]
+	}
+	return SendResult{Signature: sig, BundleID: bundleID}, nil
+}
+
+// SendBundle sends multiple transactions as an atomic bundle and returns the
+// bundle ID.
+func (c *GRPCClient) SendBundle(ctx context.Context, txs []*solana.Transaction) (string, error) {
+	if len(txs) == 0 {
+		return "", fmt.Errorf("bundle requires at least one transaction")
+	}
+	if len(txs) > MaxBundleTransactions {
+		return "", fmt.Errorf("bundle has %d transactions, max %d", len(txs), MaxBundleTransactions)
+	}
+
+	// SendBundleRequest{bundle: Bundle{packets: [Packet{data, meta: Meta{size}}]}}
+	var bundle []byte
+	for _, tx := range txs {
+		if tx == nil {
+			return "", fmt.Errorf("bundle transaction is nil")
+		}
+		data, err := tx.MarshalBinary()
+		if err != nil {
+			return "", fmt.Errorf("marshal transaction: %w", err)
+		}
+		var meta []byte
+		meta = protowire.AppendTag(meta, 1, protowire.VarintType)
+		meta = protowire.AppendVarint(meta, uint64(len(data)))
+		var packet []byte
+		packet = protowire.AppendTag(packet, 1, protowire.BytesType)
+		packet = protowire.AppendBytes(packet, data)
+		packet = protowire.AppendTag(packet, 2, protowire.BytesType)
+		packet = protowire.AppendBytes(packet, meta)
+		bundle = protowire.AppendTag(bundle, 3, protowire.BytesType)
+		bundle = protowire.AppendBytes(bundle, packet)
+	}
+	var req []byte
+	req = protowire.AppendTag(req, 1, protowire.BytesType)
+	req = protowire.AppendBytes(req, bundle)
+
+	resp, err := c.call(ctx, methodSendBundle, req)
+	if err != nil {
+		return "", fmt.Errorf("jito send bundle: %w", err)
+	}
+	var bundleID string
+	if err := protoFields(resp, func(num protowire.Number, b []byte, _ uint64) {
+		if num == 1 {
+			bundleID = string(b)
+		}
+	}); err != nil {
+		return "", fmt.Errorf("decode bundle response: %w", err)
+	}
+	return bundleID, nil
+}
+
+// SendBundleDetailed is SendBundle that also returns the transactions'
+// signatures, the endpoint that accepted the bundle and the tip it pays.
+func (c *GRPCClient) SendBundleDetailed(ctx context.Context, txs []*solana.Transaction) (BundleResult, error) {
+	res := BundleResult{Signatures: make([]solana.Signature, 0, len(txs))}
+	for i, tx := range txs {
+		if tx == nil {
+			return BundleResult{}, fmt.Errorf("bundle transaction %d is nil", i)
+		}
+		var sig solana.Signature
+		if len(tx.Signatures) > 0 {
+			sig = tx.Signatures[0]
+		}
+		res.Signatures = append(res.Signatures, sig)
+		tip, _, err := tipTransfers(tx, MainnetTipAccounts)
+		if err != nil {
+			return BundleResult{}, fmt.Errorf("bundle transaction %d: %w", i, err)
+		}
+		res.TipLamports += tip
+	}
+
+	var err error
+	if res.BundleID, err = c.SendBundle(ctx, txs); err != nil {
+		return BundleResult{}, err
+	}
+	res.Endpoint = c.conn.Target()
+	return res, nil
+}
+
+// WaitForBundleConfirmation waits for a bundle to be confirmed, polling its
+// status over JSON-RPC.
+func (c *GRPCClient) WaitForBundleConfirmation(ctx context.Context, bundleID string) error {
+	return c.status.WaitForBundleConfirmation(ctx, bundleID)
+}
+
+// WaitForBundleLanded is WaitForBundleConfirmation that also returns the slot
+// the bundle landed in.
+func (c *GRPCClient) WaitForBundleLanded(ctx context.Context, bundleID string) (uint64, error) {
+	return c.status.WaitForBundleLanded(ctx, bundleID)
+}
+
+// GetBundleStatuses returns the statuses of submitted bundles over JSON-RPC.
+func (c *GRPCClient) GetBundleStatuses(ctx context.Context, bundleIDs []string) (*jitorpc.BundleStatusResponse, error) {
+	return c.status.GetBundleStatuses(ctx, bundleIDs)
+}
+
+// call invokes a unary method with an encoded request, authenticating first
+// when the client has a keypair.
+func (c *GRPCClient) call(ctx context.Context, method string, req []byte) ([]byte, error) {
+	if c.auth != nil {
+		token, err := c.auth.token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("jito auth: %w", err)
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+	var resp []byte
+	if err := c.conn.Invoke(ctx, method, &req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// protoFields calls fn with the number of each length-delimited or varint
+// field of a protobuf message, and its bytes or value; other fields are
+// skipped.
+func protoFields(data []byte, fn func(num protowire.Number, b []byte, v uint64)) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		switch typ {
+		case protowire.BytesType:
+			b, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			fn(num, b, 0)
+			data = data[n:]
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			fn(num, nil, v)
+			data = data[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+		}
+	}
+	return nil
+}
+
+// rawCodec passes pre-encoded protobuf messages (*[]byte) through gRPC, so
+// the client needs no generated stubs for the few messages it uses.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("jito grpc: cannot marshal %T", v)
+	}
+	return *b, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("jito grpc: cannot unmarshal into %T", v)
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+func (rawCodec) Name() string { return "proto" }
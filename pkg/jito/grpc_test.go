@@ -0,0 +1,188 @@
+package jito
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// fakeBlockEngine serves the searcher and auth gRPC methods GRPCClient uses.
+type fakeBlockEngine struct {
+	t      *testing.T
+	mu     sync.Mutex
+	calls  []string
+	bundle [][]byte // transactions of the last SendBundle
+	auth   []string // authorization metadata of each SendBundle
+}
+
+func (f *fakeBlockEngine) handle(_ any, stream grpc.ServerStream) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+	var req []byte
+	if err := stream.RecvMsg(&req); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, method)
+
+	var resp []byte
+	token := func(field protowire.Number, value string) {
+		var ts []byte
+		ts = protowire.AppendTag(ts, 1, protowire.VarintType)
+		ts = protowire.AppendVarint(ts, uint64(time.Now().Add(time.Hour).Unix()))
+		var tok []byte
+		tok = protowire.AppendTag(tok, 1, protowire.BytesType)
+		tok = protowire.AppendString(tok, value)
+		tok = protowire.AppendTag(tok, 2, protowire.BytesType)
+		tok = protowire.AppendBytes(tok, ts)
+		resp = protowire.AppendTag(resp, field, protowire.BytesType)
+		resp = protowire.AppendBytes(resp, tok)
+	}
+	switch method {
+	case methodGenerateAuthChallenge:
+		resp = protowire.AppendTag(resp, 1, protowire.BytesType)
+		resp = protowire.AppendString(resp, "abc")
+	case methodGenerateAuthTokens:
+		var challenge string
+		var pubkey, sig []byte
+		_ = protoFields(req, func(num protowire.Number, b []byte, _ uint64) {
+			switch num {
+			case 1:
+				challenge = string(b)
+			case 2:
+				pubkey = b
+			case 3:
+				sig = b
+			}
+		})
+		pk := solana.PublicKeyFromBytes(pubkey)
+		if challenge != pk.String()+"-abc" || !solana.SignatureFromBytes(sig).Verify(pk, []byte(challenge)) {
+			f.t.Errorf("bad signed challenge %q", challenge)
+		}
+		token(1, "access")
+		token(2, "refresh")
+	case methodRefreshAccessToken:
+		token(1, "access")
+	case methodSendBundle:
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		f.auth = append(f.auth, strings.Join(md.Get("authorization"), ","))
+		f.bundle = nil
+		_ = protoFields(req, func(num protowire.Number, bundle []byte, _ uint64) {
+			_ = protoFields(bundle, func(num protowire.Number, packet []byte, _ uint64) {
+				if num != 3 {
+					return
+				}
+				_ = protoFields(packet, func(num protowire.Number, data []byte, _ uint64) {
+					if num == 1 {
+						f.bundle = append(f.bundle, data)
+					}
+				})
+			})
+		})
+		resp = protowire.AppendTag(resp, 1, protowire.BytesType)
+		resp = protowire.AppendString(resp, "bundle-uuid")
+	case methodGetTipAccounts:
+		for _, acc := range MainnetTipAccounts[:2] {
+			resp = protowire.AppendTag(resp, 1, protowire.BytesType)
+			resp = protowire.AppendString(resp, acc.String())
+		}
+	default:
+		f.t.Errorf("unexpected method %s", method)
+	}
+	return stream.SendMsg(&resp)
+}
+
+// startBlockEngine starts a fake block engine and returns its address.
+func startBlockEngine(t *testing.T) (*fakeBlockEngine, string) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeBlockEngine{t: t}
+	srv := grpc.NewServer(grpc.ForceServerCodec(rawCodec{}), grpc.UnknownServiceHandler(fake.handle))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return fake, lis.Addr().String()
+}
+
+// serveBlockEngine starts a fake block engine and returns a client for it.
+func serveBlockEngine(t *testing.T, key solana.PrivateKey) (*fakeBlockEngine, *GRPCClient) {
+	t.Helper()
+	fake, addr := startBlockEngine(t)
+	client, err := NewGRPCClient(addr, key, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return fake, client
+}
+
+func TestGRPCClientSendBundle(t *testing.T) {
+	key, _ := solana.NewRandomPrivateKey()
+	fake, client := serveBlockEngine(t, key)
+
+	tip := transferTx(t, MainnetTipAccounts[1])
+	tip.Signatures = []solana.Signature{{9}}
+	other := transferTx(t, solana.NewWallet().PublicKey())
+	for i := 0; i < 2; i++ {
+		id, err := client.SendBundle(context.Background(), []*solana.Transaction{other, tip})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != "bundle-uuid" {
+			t.Fatalf("bundle id = %q", id)
+		}
+	}
+	res, err := client.SendTransactionWithBundleID(context.Background(), tip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Signature != tip.Signatures[0] || res.BundleID != "bundle-uuid" {
+		t.Fatalf("send result = %+v", res)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	want, _ := tip.MarshalBinary()
+	if len(fake.bundle) != 1 || string(fake.bundle[0]) != string(want) {
+		t.Fatal("block engine did not receive the serialized transaction")
+	}
+	// One challenge flow, reused for every send.
+	if fake.calls[0] != methodGenerateAuthChallenge || fake.calls[1] != methodGenerateAuthTokens || len(fake.calls) != 5 {
+		t.Fatalf("calls = %v", fake.calls)
+	}
+	for _, auth := range fake.auth {
+		if auth != "Bearer access" {
+			t.Fatalf("authorization = %q", auth)
+		}
+	}
+}
+
+func TestGRPCClientUnauthenticated(t *testing.T) {
+	fake, client := serveBlockEngine(t, nil)
+	accounts, err := client.GetTipAccounts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 2 || accounts[0] != MainnetTipAccounts[0] {
+		t.Fatalf("tip accounts = %v", accounts)
+	}
+	if _, err := client.SendBundle(context.Background(), []*solana.Transaction{transferTx(t, MainnetTipAccounts[0])}); err != nil {
+		t.Fatal(err)
+	}
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if len(fake.calls) != 2 || fake.auth[0] != "" {
+		t.Fatalf("calls = %v, auth = %v", fake.calls, fake.auth)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -38,6 +39,10 @@ var MainnetBlockEngines = []string{
 	"https://tokyo.mainnet.block-engine.jito.wtf/api/v1",
 }
 
+// MaxBundleTransactions is the most transactions the block engine accepts in
+// one bundle.
+const MaxBundleTransactions = 5
+
 // Pre-defined Jito tip accounts (mainnet)
 // These are the official Jito tip accounts that rarely change.
 // Using these avoids RPC calls and rate limiting issues.
@@ -52,6 +57,11 @@ var MainnetTipAccounts = []solana.PublicKey{
 	solana.MustPublicKeyFromBase58("3AVi9Tg9Uo68tJfuvoKvqKNWKkC5wPdSSdeBnizKZ6jT"),
 }
 
+// IsNil reports whether c is nil. It implements Sender.
+func (c *Client) IsNil() bool {
+	return c == nil
+}
+
 // GetRandomTipAccountLocal returns a random tip account from the pre-defined list.
 // This does not make any RPC calls and avoids rate limiting.
 func GetRandomTipAccountLocal() solana.PublicKey {
@@ -65,6 +75,8 @@ type Client struct {
 	currentIndex uint32
 	maxRetries   int
 	retryDelay   time.Duration
+	breaker      *circuitBreaker           // set by WithCircuitBreaker
+	auth         map[string]*authenticator // by endpoint, set by WithAuthKeypair
 }
 
 // NewClient creates a new Jito client with the specified endpoint.
@@ -112,7 +124,11 @@ func (c *Client) WithRetries(maxRetries int, retryDelay time.Duration) *Client {
 func (c *Client) getNextClient() *jitorpc.JitoJsonRpcClient {
 	idx := atomic.AddUint32(&c.currentIndex, 1)
 	endpoint := c.endpoints[int(idx)%len(c.endpoints)]
-	return jitorpc.NewJitoJsonRpcClient(endpoint, c.uuid)
+	client := jitorpc.NewJitoJsonRpcClient(endpoint, c.uuid)
+	if auth := c.auth[endpoint]; auth != nil {
+		client.Client = &http.Client{Transport: &bearerTransport{auth: auth, base: http.DefaultTransport}}
+	}
+	return client
 }
 
 // isRateLimitError checks if the error is a rate limit error.
@@ -132,8 +148,12 @@ func isRateLimitError(err error) bool {
 func (c *Client) GetTipAccounts(ctx context.Context) ([]solana.PublicKey, error) {
 	var lastErr error
 	for i := 0; i < c.maxRetries; i++ {
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
 		client := c.getNextClient()
 		rawResp, err := client.GetTipAccounts()
+		c.breaker.record(err)
 		if err != nil {
 			lastErr = err
 			if isRateLimitError(err) {
@@ -167,8 +187,12 @@ func (c *Client) GetTipAccounts(ctx context.Context) ([]solana.PublicKey, error)
 func (c *Client) GetRandomTipAccount(ctx context.Context) (solana.PublicKey, error) {
 	var lastErr error
 	for i := 0; i < c.maxRetries; i++ {
+		if err := c.breaker.allow(); err != nil {
+			return solana.PublicKey{}, err
+		}
 		client := c.getNextClient()
 		tipAcc, err := client.GetRandomTipAccount()
+		c.breaker.record(err)
 		if err != nil {
 			lastErr = err
 			if isRateLimitError(err) {
@@ -219,10 +243,14 @@ func (c *Client) SendTransactionWithBundleID(ctx context.Context, tx *solana.Tra
 
 	var lastErr error
 	for i := 0; i < c.maxRetries; i++ {
+		if err := c.breaker.allow(); err != nil {
+			return SendResult{}, err
+		}
 		client := c.getNextClient()
 
 		// Send as a single-transaction bundle
 		rawResp, err := client.SendBundle([][]string{{txBase64}})
+		c.breaker.record(err)
 		if err != nil {
 			lastErr = err
 			if isRateLimitError(err) {
@@ -252,13 +280,20 @@ func (c *Client) SendTransactionWithBundleID(ctx context.Context, tx *solana.Tra
 // This is faster than waiting for RPC confirmation as Jito knows immediately
 // when a bundle is landed.
 func (c *Client) WaitForBundleConfirmation(ctx context.Context, bundleID string) error {
+	_, err := c.WaitForBundleLanded(ctx, bundleID)
+	return err
+}
+
+// WaitForBundleLanded is WaitForBundleConfirmation that also returns the slot
+// the bundle landed in.
+func (c *Client) WaitForBundleLanded(ctx context.Context, bundleID string) (uint64, error) {
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return 0, ctx.Err()
 		case <-ticker.C:
 			statuses, err := c.GetBundleStatuses(ctx, []string{bundleID})
 			if err != nil {
@@ -272,12 +307,12 @@ func (c *Client) WaitForBundleConfirmation(ctx context.Context, bundleID string)
 			// Check confirmation status
 			switch status.ConfirmationStatus {
 			case "confirmed", "finalized":
-				return nil
+				return uint64(status.Slot), nil
 			}
 			// Check for errors
 			if status.Err.Ok == nil {
 				// Bundle failed
-				return fmt.Errorf("bundle failed: %v", status.Err)
+				return 0, fmt.Errorf("bundle failed: %v", status.Err)
 			}
 		}
 	}
@@ -289,8 +324,67 @@ func (c *Client) WaitForBundleConfirmation(ctx context.Context, bundleID string)
 // Returns the bundle ID.
 // Automatically retries on rate limiting with endpoint rotation.
 func (c *Client) SendBundle(ctx context.Context, txs []*solana.Transaction) (string, error) {
+	bundleID, _, err := c.sendBundle(ctx, txs)
+	return bundleID, err
+}
+
+// BundleResult describes a submitted bundle, for logging and later
+// reconciliation.
+type BundleResult struct {
+	BundleID string
+	// Signatures holds the first signature of each transaction, in bundle
+	// order; they identify the transactions once the bundle lands.
+	Signatures []solana.Signature
+	// Endpoint is the block engine URL that accepted the bundle.
+	Endpoint string
+	// TipLamports is the total transferred to MainnetTipAccounts by the
+	// bundle's transactions.
+	TipLamports uint64
+}
+
+// SendBundleDetailed is SendBundle that also returns the transactions'
+// signatures, the endpoint that accepted the bundle and the tip it pays.
+//
+// Example:
+//
+//	res, err := client.SendBundleDetailed(ctx, txs)
+//	if err == nil {
+//	    log.Printf("bundle %s via %s, tip %d, sigs %v", res.BundleID, res.Endpoint, res.TipLamports, res.Signatures)
+//	}
+func (c *Client) SendBundleDetailed(ctx context.Context, txs []*solana.Transaction) (BundleResult, error) {
+	res := BundleResult{Signatures: make([]solana.Signature, 0, len(txs))}
+	for i, tx := range txs {
+		if tx == nil {
+			return BundleResult{}, fmt.Errorf("bundle transaction %d is nil", i)
+		}
+		var sig solana.Signature
+		if len(tx.Signatures) > 0 {
+			sig = tx.Signatures[0]
+		}
+		res.Signatures = append(res.Signatures, sig)
+		tip, _, err := tipTransfers(tx, MainnetTipAccounts)
+		if err != nil {
+			return BundleResult{}, fmt.Errorf("bundle transaction %d: %w", i, err)
+		}
+		res.TipLamports += tip
+	}
+
+	var err error
+	res.BundleID, res.Endpoint, err = c.sendBundle(ctx, txs)
+	if err != nil {
+		return BundleResult{}, err
+	}
+	return res, nil
+}
+
+// sendBundle sends txs as a bundle and returns the bundle ID and the
+// endpoint that accepted it.
+func (c *Client) sendBundle(ctx context.Context, txs []*solana.Transaction) (string, string, error) {
 	if len(txs) == 0 {
-		return "", fmt.Errorf("bundle requires at least one transaction")
+		return "", "", fmt.Errorf("bundle requires at least one transaction")
+	}
+	if len(txs) > MaxBundleTransactions {
+		return "", "", fmt.Errorf("bundle has %d transactions, max %d", len(txs), MaxBundleTransactions)
 	}
 
 	// Serialize all transactions to base64
@@ -298,40 +392,48 @@ func (c *Client) SendBundle(ctx context.Context, txs []*solana.Transaction) (str
 	for _, tx := range txs {
 		txBytes, err := tx.MarshalBinary()
 		if err != nil {
-			return "", fmt.Errorf("marshal transaction: %w", err)
+			return "", "", fmt.Errorf("marshal transaction: %w", err)
 		}
 		txStrings = append(txStrings, base64.StdEncoding.EncodeToString(txBytes))
 	}
 
 	var lastErr error
 	for i := 0; i < c.maxRetries; i++ {
+		if err := c.breaker.allow(); err != nil {
+			return "", "", err
+		}
 		client := c.getNextClient()
 
 		rawResp, err := client.SendBundle([][]string{txStrings})
+		c.breaker.record(err)
 		if err != nil {
 			lastErr = err
 			if isRateLimitError(err) {
 				time.Sleep(c.retryDelay)
 				continue
 			}
-			return "", fmt.Errorf("jito send bundle: %w", err)
+			return "", "", fmt.Errorf("jito send bundle: %w", err)
 		}
 
 		var bundleID string
 		if err := json.Unmarshal(rawResp, &bundleID); err != nil {
-			return "", fmt.Errorf("unmarshal bundle response: %w", err)
+			return "", "", fmt.Errorf("unmarshal bundle response: %w", err)
 		}
-		return bundleID, nil
+		return bundleID, client.BaseURL, nil
 	}
-	return "", fmt.Errorf("jito send bundle failed after %d retries: %w", c.maxRetries, lastErr)
+	return "", "", fmt.Errorf("jito send bundle failed after %d retries: %w", c.maxRetries, lastErr)
 }
 
 // GetBundleStatuses returns the statuses of submitted bundles.
 func (c *Client) GetBundleStatuses(ctx context.Context, bundleIDs []string) (*jitorpc.BundleStatusResponse, error) {
 	var lastErr error
 	for i := 0; i < c.maxRetries; i++ {
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
 		client := c.getNextClient()
 		statuses, err := client.GetBundleStatuses(bundleIDs)
+		c.breaker.record(err)
 		if err != nil {
 			lastErr = err
 			if isRateLimitError(err) {
@@ -349,8 +451,12 @@ func (c *Client) GetBundleStatuses(ctx context.Context, bundleIDs []string) (*ji
 func (c *Client) GetInflightBundleStatuses(ctx context.Context, bundleIDs []string) (json.RawMessage, error) {
 	var lastErr error
 	for i := 0; i < c.maxRetries; i++ {
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
 		client := c.getNextClient()
 		statuses, err := client.GetInflightBundleStatuses(bundleIDs)
+		c.breaker.record(err)
 		if err != nil {
 			lastErr = err
 			if isRateLimitError(err) {
@@ -0,0 +1,30 @@
+package jito
+
+import (
+	"context"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Sender is a block engine transport. Client (JSON-RPC over HTTP) and
+// GRPCClient (the searcher gRPC API) both implement it, so callers such as
+// txbuilder.Builder work with either.
+type Sender interface {
+	// GetRandomTipAccount returns a tip account fetched from the block engine.
+	GetRandomTipAccount(ctx context.Context) (solana.PublicKey, error)
+	// SendTransactionWithBundleID sends tx as a single-transaction bundle.
+	SendTransactionWithBundleID(ctx context.Context, tx *solana.Transaction) (SendResult, error)
+	// SendBundle sends txs as an atomic bundle and returns its ID.
+	SendBundle(ctx context.Context, txs []*solana.Transaction) (string, error)
+	// WaitForBundleConfirmation waits until the bundle is confirmed or fails.
+	WaitForBundleConfirmation(ctx context.Context, bundleID string) error
+	// IsNil reports whether the sender is a nil pointer. It must be safe to
+	// call on a nil receiver, so that a typed nil passed as a Sender can be
+	// told apart from a configured one.
+	IsNil() bool
+}
+
+var (
+	_ Sender = (*Client)(nil)
+	_ Sender = (*GRPCClient)(nil)
+)
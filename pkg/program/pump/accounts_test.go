@@ -0,0 +1,29 @@
+package pump
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+)
+
+func TestDecodeAccount(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Write(BondingCurveDiscriminator)
+	if err := bin.NewBorshEncoder(&buf).Encode(BondingCurve{VirtualTokenReserves: 42}); err != nil {
+		t.Fatal(err)
+	}
+
+	name, decoded, err := DecodeAccount(buf.Bytes())
+	if err != nil || name != "BondingCurve" {
+		t.Fatalf("DecodeAccount: got %q, %v", name, err)
+	}
+	if bc, ok := decoded.(*BondingCurve); !ok || bc.VirtualTokenReserves != 42 {
+		t.Fatalf("unexpected decoded value %#v", decoded)
+	}
+
+	if _, _, err := DecodeAccount(make([]byte, 16)); !errors.Is(err, ErrUnknownAccount) {
+		t.Fatalf("expected ErrUnknownAccount, got %v", err)
+	}
+}
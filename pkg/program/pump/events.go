@@ -0,0 +1,122 @@
+package pump
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	bin "github.com/gagliardetto/binary"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+)
+
+// Event discriminators from the pump IDL.
+var (
+	CreateEventDiscriminator                   = []byte{27, 114, 169, 77, 222, 235, 99, 118}
+	TradeEventDiscriminator                    = []byte{189, 219, 127, 211, 78, 230, 97, 238}
+	CompleteEventDiscriminator                 = []byte{95, 114, 97, 156, 212, 46, 152, 8}
+	CompletePumpAmmMigrationEventDiscriminator = []byte{189, 233, 93, 185, 92, 148, 234, 148}
+)
+
+// ErrEventNotFound is returned when no matching event is present.
+var ErrEventNotFound = errors.New("event not found")
+
+const programDataPrefix = "Program data: "
+
+// ParseCreateEvent returns the first CreateEvent emitted in a transaction's
+// logs ("Program data: " lines): the new mint, its bonding curve, creator,
+// metadata and initial reserves.
+func ParseCreateEvent(logs []string) (*CreateEvent, error) {
+	var ev CreateEvent
+	if err := findEvent(logs, CreateEventDiscriminator, &ev); err != nil {
+		return nil, fmt.Errorf("create event: %w", err)
+	}
+	return &ev, nil
+}
+
+// DecodeCreateEvent decodes a raw event payload (discriminator + borsh data),
+// optionally prefixed by the self-CPI event tag as found in inner instructions.
+func DecodeCreateEvent(data []byte) (*CreateEvent, error) {
+	var ev CreateEvent
+	if err := decodeEvent(data, CreateEventDiscriminator, &ev); err != nil {
+		return nil, fmt.Errorf("create event: %w", err)
+	}
+	return &ev, nil
+}
+
+// ParseTradeEvent returns the first TradeEvent emitted in a transaction's
+// logs. It reports the exact fill of a buy or sell: SolAmount, TokenAmount,
+// the protocol and creator fees and the reserves after the trade.
+func ParseTradeEvent(logs []string) (*TradeEvent, error) {
+	var ev TradeEvent
+	if err := findEvent(logs, TradeEventDiscriminator, &ev); err != nil {
+		return nil, fmt.Errorf("trade event: %w", err)
+	}
+	return &ev, nil
+}
+
+// DecodeTradeEvent decodes a raw TradeEvent payload; see DecodeCreateEvent.
+func DecodeTradeEvent(data []byte) (*TradeEvent, error) {
+	var ev TradeEvent
+	if err := decodeEvent(data, TradeEventDiscriminator, &ev); err != nil {
+		return nil, fmt.Errorf("trade event: %w", err)
+	}
+	return &ev, nil
+}
+
+// ParseCompleteEvent returns the first CompleteEvent emitted in a
+// transaction's logs: the buy that filled a bonding curve, after which the
+// curve stops trading until the token is migrated to pump AMM.
+func ParseCompleteEvent(logs []string) (*CompleteEvent, error) {
+	var ev CompleteEvent
+	if err := findEvent(logs, CompleteEventDiscriminator, &ev); err != nil {
+		return nil, fmt.Errorf("complete event: %w", err)
+	}
+	return &ev, nil
+}
+
+// ParseCompletePumpAmmMigrationEvent returns the first
+// CompletePumpAmmMigrationEvent emitted in a transaction's logs: the
+// migration of a completed curve's liquidity into the pump AMM Pool.
+func ParseCompletePumpAmmMigrationEvent(logs []string) (*CompletePumpAmmMigrationEvent, error) {
+	var ev CompletePumpAmmMigrationEvent
+	if err := findEvent(logs, CompletePumpAmmMigrationEventDiscriminator, &ev); err != nil {
+		return nil, fmt.Errorf("migration event: %w", err)
+	}
+	return &ev, nil
+}
+
+func findEvent(logs []string, disc []byte, v interface{}) error {
+	for _, line := range logs {
+		payload, ok := strings.CutPrefix(line, programDataPrefix)
+		if !ok {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(payload))
+		if err != nil {
+			continue
+		}
+		if err := decodeEvent(data, disc, v); errors.Is(err, ErrEventNotFound) {
+			continue
+		} else {
+			return err
+		}
+	}
+	return ErrEventNotFound
+}
+
+// decodeEvent borsh-decodes data into v if it carries disc. Trailing fields
+// added in newer program versions decode as zero values when absent.
+func decodeEvent(data, disc []byte, v interface{}) error {
+	data = bytes.TrimPrefix(data, constants.AnchorEventIxTag)
+	if len(data) < len(disc) || !bytes.Equal(data[:len(disc)], disc) {
+		return ErrEventNotFound
+	}
+	padded := append(append([]byte{}, data[len(disc):]...), make([]byte, 64)...)
+	if err := bin.NewBorshDecoder(padded).Decode(v); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	return nil
+}
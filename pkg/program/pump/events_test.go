@@ -0,0 +1,101 @@
+package pump
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+)
+
+func encodeEvent(t *testing.T, disc []byte, v interface{}) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.Write(disc)
+	if err := bin.NewBorshEncoder(&buf).Encode(v); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseCreateEvent(t *testing.T) {
+	want := CreateEvent{
+		Name:    "My Token",
+		Symbol:  "MTK",
+		Uri:     "https://example.com/mtk.json",
+		Mint:    solana.NewWallet().PublicKey(),
+		Creator: solana.NewWallet().PublicKey(),
+	}
+	logs := []string{
+		"Program 6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P invoke [1]",
+		"Program log: Instruction: Create",
+		"Program data: " + base64.StdEncoding.EncodeToString(encodeEvent(t, CreateEventDiscriminator, want)),
+	}
+
+	got, err := ParseCreateEvent(logs)
+	if err != nil {
+		t.Fatalf("ParseCreateEvent: %v", err)
+	}
+	if got.Name != want.Name || got.Symbol != want.Symbol || !got.Mint.Equals(want.Mint) || !got.Creator.Equals(want.Creator) {
+		t.Fatalf("got %+v, want %+v", *got, want)
+	}
+	if _, err := ParseCreateEvent(logs[:2]); !errors.Is(err, ErrEventNotFound) {
+		t.Fatalf("expected ErrEventNotFound, got %v", err)
+	}
+}
+
+func TestDecodeTradeEvent(t *testing.T) {
+	want := TradeEvent{
+		Mint:        solana.NewWallet().PublicKey(),
+		SolAmount:   100_000_000,
+		TokenAmount: 3_500_000_000,
+		IsBuy:       true,
+		User:        solana.NewWallet().PublicKey(),
+		Fee:         1_000_000,
+		IxName:      "buy",
+	}
+	data := append(append([]byte{}, constants.AnchorEventIxTag...), encodeEvent(t, TradeEventDiscriminator, want)...)
+
+	got, err := DecodeTradeEvent(data)
+	if err != nil {
+		t.Fatalf("DecodeTradeEvent: %v", err)
+	}
+	if got.SolAmount != want.SolAmount || got.TokenAmount != want.TokenAmount || !got.IsBuy || got.IxName != "buy" || !got.Mint.Equals(want.Mint) {
+		t.Fatalf("got %+v, want %+v", *got, want)
+	}
+	if _, err := DecodeTradeEvent(encodeEvent(t, CreateEventDiscriminator, CreateEvent{})); !errors.Is(err, ErrEventNotFound) {
+		t.Fatalf("expected ErrEventNotFound, got %v", err)
+	}
+}
+
+func TestParseGraduationEvents(t *testing.T) {
+	complete := CompleteEvent{Mint: solana.NewWallet().PublicKey(), BondingCurve: solana.NewWallet().PublicKey(), Timestamp: 1_700_000_000}
+	migration := CompletePumpAmmMigrationEvent{Mint: complete.Mint, BondingCurve: complete.BondingCurve, Pool: solana.NewWallet().PublicKey()}
+	logs := []string{
+		"Program data: " + base64.StdEncoding.EncodeToString(encodeEvent(t, TradeEventDiscriminator, TradeEvent{Mint: complete.Mint})),
+		"Program data: " + base64.StdEncoding.EncodeToString(encodeEvent(t, CompleteEventDiscriminator, complete)),
+		"Program data: " + base64.StdEncoding.EncodeToString(encodeEvent(t, CompletePumpAmmMigrationEventDiscriminator, migration)),
+	}
+
+	gotComplete, err := ParseCompleteEvent(logs)
+	if err != nil {
+		t.Fatalf("ParseCompleteEvent: %v", err)
+	}
+	if *gotComplete != complete {
+		t.Fatalf("got %+v, want %+v", *gotComplete, complete)
+	}
+	gotMigration, err := ParseCompletePumpAmmMigrationEvent(logs)
+	if err != nil {
+		t.Fatalf("ParseCompletePumpAmmMigrationEvent: %v", err)
+	}
+	if *gotMigration != migration {
+		t.Fatalf("got %+v, want %+v", *gotMigration, migration)
+	}
+	if _, err := ParseCompleteEvent(logs[:1]); !errors.Is(err, ErrEventNotFound) {
+		t.Fatalf("expected ErrEventNotFound, got %v", err)
+	}
+}
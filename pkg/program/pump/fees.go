@@ -0,0 +1,64 @@
+package pump
+
+import "github.com/gagliardetto/solana-go"
+
+// ProtocolFeeBps returns the protocol fee, in basis points, charged on every
+// bonding curve trade.
+func (g Global) ProtocolFeeBps() uint64 {
+	return g.FeeBasisPoints
+}
+
+// CreatorFeeBps returns the creator fee, in basis points, charged on trades
+// of curves that have a creator.
+func (g Global) CreatorFeeBps() uint64 {
+	return g.CreatorFeeBasisPoints
+}
+
+// TradeFeeBps returns the total fee, in basis points, a trade on bc pays:
+// the protocol fee plus the creator fee once the curve has a creator. The
+// fee program's tiered fees, where configured, take precedence on chain.
+func (g Global) TradeFeeBps(bc BondingCurve) uint64 {
+	fee := g.ProtocolFeeBps()
+	if !bc.Creator.IsZero() {
+		fee += g.CreatorFeeBps()
+	}
+	return fee
+}
+
+// AcceptedFeeRecipients returns the fee recipients buy and sell accept,
+// without zero or repeated keys: FeeRecipients followed by FeeRecipient, or
+// for a curve in mayhem mode ReservedFeeRecipients followed by
+// ReservedFeeRecipient. The program accepts any of them; there is no
+// rotation by slot.
+func (g Global) AcceptedFeeRecipients(mayhem bool) []solana.PublicKey {
+	if mayhem {
+		return uniqueNonZero(append(g.ReservedFeeRecipients[:], g.ReservedFeeRecipient))
+	}
+	return uniqueNonZero(append(g.FeeRecipients[:], g.FeeRecipient))
+}
+
+// ActiveFeeRecipient returns one accepted fee recipient chosen from slot, so
+// that traders spread their write locks over the recipients instead of
+// contending on the first one. It returns the zero key if none is
+// configured.
+func (g Global) ActiveFeeRecipient(slot uint64, mayhem bool) solana.PublicKey {
+	list := g.AcceptedFeeRecipients(mayhem)
+	if len(list) == 0 {
+		return solana.PublicKey{}
+	}
+	return list[slot%uint64(len(list))]
+}
+
+// uniqueNonZero returns list without its zero and repeated keys.
+func uniqueNonZero(list []solana.PublicKey) []solana.PublicKey {
+	out := make([]solana.PublicKey, 0, len(list))
+	seen := make(map[solana.PublicKey]bool, len(list))
+	for _, pk := range list {
+		if pk.IsZero() || seen[pk] {
+			continue
+		}
+		seen[pk] = true
+		out = append(out, pk)
+	}
+	return out
+}
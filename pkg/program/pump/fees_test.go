@@ -0,0 +1,62 @@
+package pump
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestGlobalFees(t *testing.T) {
+	g := Global{FeeBasisPoints: 95, CreatorFeeBasisPoints: 5}
+	if got := g.TradeFeeBps(BondingCurve{}); got != 95 {
+		t.Fatalf("fee without creator = %d, want 95", got)
+	}
+	if got := g.TradeFeeBps(BondingCurve{Creator: solana.NewWallet().PublicKey()}); got != 100 {
+		t.Fatalf("fee with creator = %d, want 100", got)
+	}
+}
+
+func TestAcceptedFeeRecipients(t *testing.T) {
+	main := solana.NewWallet().PublicKey()
+	reserved := solana.NewWallet().PublicKey()
+	var list, reservedList [7]solana.PublicKey
+	for i := range list {
+		list[i] = solana.NewWallet().PublicKey()
+		reservedList[i] = solana.NewWallet().PublicKey()
+	}
+
+	cases := []struct {
+		name   string
+		global Global
+		mayhem bool
+		want   []solana.PublicKey
+	}{
+		{"empty", Global{}, false, nil},
+		{"main recipient only", Global{FeeRecipient: main}, false, []solana.PublicKey{main}},
+		{"recipient list", Global{FeeRecipient: main, FeeRecipients: list}, false, append(list[:], main)},
+		{"main repeated in list", Global{FeeRecipient: list[0], FeeRecipients: list}, false, list[:]},
+		{"mayhem uses reserved", Global{FeeRecipient: main, FeeRecipients: list, ReservedFeeRecipient: reserved, ReservedFeeRecipients: reservedList}, true, append(reservedList[:], reserved)},
+		{"mayhem without reserved", Global{FeeRecipient: main}, true, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.global.AcceptedFeeRecipients(tc.mayhem)
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d recipients, want %d", len(got), len(tc.want))
+			}
+			for i := range got {
+				if !got[i].Equals(tc.want[i]) {
+					t.Fatalf("recipient %d = %s, want %s", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+
+	g := Global{FeeRecipient: main, FeeRecipients: list}
+	if got := g.ActiveFeeRecipient(10, false); !got.Equals(list[2]) {
+		t.Fatalf("slot 10 of 8 recipients = %s, want %s", got, list[2])
+	}
+	if got := (Global{}).ActiveFeeRecipient(10, false); !got.IsZero() {
+		t.Fatalf("expected zero key without recipients, got %s", got)
+	}
+}
@@ -1,16 +1,29 @@
 // Code generated by internal/gen; DO NOT EDIT.
-// Generated at 2025-12-07T17:54:48Z
+// Generated at 2026-10-16T20:03:39Z
 
 package pump
 
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
 )
 
+// appendAccountMeta appends a meta for key to dst, overwriting the meta left
+// in the spare capacity of dst by an earlier build if there is one.
+func appendAccountMeta(dst []*solana.AccountMeta, key solana.PublicKey, writable, signer bool) []*solana.AccountMeta {
+	if n := len(dst); n < cap(dst) {
+		if m := dst[:n+1][n]; m != nil {
+			m.PublicKey, m.IsWritable, m.IsSigner = key, writable, signer
+			return dst[:n+1]
+		}
+	}
+	return append(dst, solana.NewAccountMeta(key, writable, signer))
+}
+
 var AdminSetCreatorDiscriminator = []byte{69, 25, 171, 142, 57, 239, 13, 4}
 
 type AdminSetCreatorArgs struct {
@@ -27,17 +40,57 @@ type AdminSetCreatorAccounts struct {
 }
 
 func (a AdminSetCreatorAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 6)
-	metas = append(metas, solana.NewAccountMeta(a.AdminSetCreatorAuthority, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.Global, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Mint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.BondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 6))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a AdminSetCreatorAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.AdminSetCreatorAuthority, false, true)
+	dst = appendAccountMeta(dst, a.Global, false, false)
+	dst = appendAccountMeta(dst, a.Mint, false, false)
+	dst = appendAccountMeta(dst, a.BondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a AdminSetCreatorAccounts) Validate() error {
+	var missing []string
+	if a.AdminSetCreatorAuthority.IsZero() {
+		missing = append(missing, "admin_set_creator_authority")
+	}
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
+	}
+	if a.Mint.IsZero() {
+		missing = append(missing, "mint")
+	}
+	if a.BondingCurve.IsZero() {
+		missing = append(missing, "bonding_curve")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("admin_set_creator: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildAdminSetCreator(accounts AdminSetCreatorAccounts, args AdminSetCreatorArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(AdminSetCreatorDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -82,23 +135,61 @@ type AdminSetIdlAuthorityAccounts struct {
 	Program        solana.PublicKey
 }
 
+var defaultAdminSetIdlAuthoritySystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
 func (a AdminSetIdlAuthorityAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 7)
-	metas = append(metas, solana.NewAccountMeta(a.Authority, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.Global, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.IdlAccount, true, false))
-	var defaultAdminSetIdlAuthoritySystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 7))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a AdminSetIdlAuthorityAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Authority, false, true)
+	dst = appendAccountMeta(dst, a.Global, false, false)
+	dst = appendAccountMeta(dst, a.IdlAccount, true, false)
+	dst = appendAccountMeta(dst, defaultAdminSetIdlAuthoritySystemProgram, false, false)
+	dst = appendAccountMeta(dst, a.ProgramSigner, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a AdminSetIdlAuthorityAccounts) Validate() error {
+	var missing []string
+	if a.Authority.IsZero() {
+		missing = append(missing, "authority")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultAdminSetIdlAuthoritySystemProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.ProgramSigner, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
+	}
+	if a.IdlAccount.IsZero() {
+		missing = append(missing, "idl_account")
+	}
+	if a.ProgramSigner.IsZero() {
+		missing = append(missing, "program_signer")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("admin_set_idl_authority: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildAdminSetIdlAuthority(accounts AdminSetIdlAuthorityAccounts, args AdminSetIdlAuthorityArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(AdminSetIdlAuthorityDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -143,30 +234,72 @@ type AdminUpdateTokenIncentivesAccounts struct {
 	Program                     solana.PublicKey
 }
 
+var defaultAdminUpdateTokenIncentivesAssociatedTokenProgram = solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+
+var defaultAdminUpdateTokenIncentivesSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
 func (a AdminUpdateTokenIncentivesAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 10)
-	metas = append(metas, solana.NewAccountMeta(a.Authority, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.Global, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalVolumeAccumulator, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Mint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalIncentiveTokenAccount, true, false))
-	var defaultAdminUpdateTokenIncentivesAssociatedTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 10))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a AdminUpdateTokenIncentivesAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Authority, true, true)
+	dst = appendAccountMeta(dst, a.Global, false, false)
+	dst = appendAccountMeta(dst, a.GlobalVolumeAccumulator, true, false)
+	dst = appendAccountMeta(dst, a.Mint, false, false)
+	dst = appendAccountMeta(dst, a.GlobalIncentiveTokenAccount, true, false)
+	dst = appendAccountMeta(dst, defaultAdminUpdateTokenIncentivesAssociatedTokenProgram, false, false)
+	dst = appendAccountMeta(dst, defaultAdminUpdateTokenIncentivesSystemProgram, false, false)
+	dst = appendAccountMeta(dst, a.TokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a AdminUpdateTokenIncentivesAccounts) Validate() error {
+	var missing []string
+	if a.Authority.IsZero() {
+		missing = append(missing, "authority")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultAdminUpdateTokenIncentivesAssociatedTokenProgram(), false, false))
-	var defaultAdminUpdateTokenIncentivesSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultAdminUpdateTokenIncentivesSystemProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.TokenProgram, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.GlobalVolumeAccumulator.IsZero() {
+		missing = append(missing, "global_volume_accumulator")
+	}
+	if a.Mint.IsZero() {
+		missing = append(missing, "mint")
+	}
+	if a.GlobalIncentiveTokenAccount.IsZero() {
+		missing = append(missing, "global_incentive_token_account")
+	}
+	if a.TokenProgram.IsZero() {
+		missing = append(missing, "token_program")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("admin_update_token_incentives: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildAdminUpdateTokenIncentives(accounts AdminUpdateTokenIncentivesAccounts, args AdminUpdateTokenIncentivesArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(AdminUpdateTokenIncentivesDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -229,40 +362,91 @@ type BuyAccounts struct {
 	FeeProgram              solana.PublicKey
 }
 
+var defaultBuySystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
 func (a BuyAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 16)
-	metas = append(metas, solana.NewAccountMeta(a.Global, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.FeeRecipient, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Mint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.BondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.AssociatedBondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.AssociatedUser, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.User, true, true))
-	var defaultBuySystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 16))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a BuyAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Global, false, false)
+	dst = appendAccountMeta(dst, a.FeeRecipient, true, false)
+	dst = appendAccountMeta(dst, a.Mint, false, false)
+	dst = appendAccountMeta(dst, a.BondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.AssociatedBondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.AssociatedUser, true, false)
+	dst = appendAccountMeta(dst, a.User, true, true)
+	dst = appendAccountMeta(dst, defaultBuySystemProgram, false, false)
+	dst = appendAccountMeta(dst, a.TokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.CreatorVault, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, ProgramKey, false, false)
+	dst = appendAccountMeta(dst, a.GlobalVolumeAccumulator, false, false)
+	dst = appendAccountMeta(dst, a.UserVolumeAccumulator, true, false)
+	dst = appendAccountMeta(dst, a.FeeConfig, false, false)
+	dst = appendAccountMeta(dst, PumpFeesProgramKey, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a BuyAccounts) Validate() error {
+	var missing []string
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultBuySystemProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.TokenProgram, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.CreatorVault, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	var defaultBuyProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P")
+	if a.FeeRecipient.IsZero() {
+		missing = append(missing, "fee_recipient")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultBuyProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalVolumeAccumulator, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserVolumeAccumulator, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.FeeConfig, false, false))
-	var defaultBuyFeeProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("pfeeUxB6jkeY1Hxd7CsFCAjcbHA9rWtchMGdZ6VojVZ")
+	if a.Mint.IsZero() {
+		missing = append(missing, "mint")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultBuyFeeProgram(), false, false))
-	return metas
+	if a.BondingCurve.IsZero() {
+		missing = append(missing, "bonding_curve")
+	}
+	if a.AssociatedBondingCurve.IsZero() {
+		missing = append(missing, "associated_bonding_curve")
+	}
+	if a.AssociatedUser.IsZero() {
+		missing = append(missing, "associated_user")
+	}
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.TokenProgram.IsZero() {
+		missing = append(missing, "token_program")
+	}
+	if a.CreatorVault.IsZero() {
+		missing = append(missing, "creator_vault")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.GlobalVolumeAccumulator.IsZero() {
+		missing = append(missing, "global_volume_accumulator")
+	}
+	if a.UserVolumeAccumulator.IsZero() {
+		missing = append(missing, "user_volume_accumulator")
+	}
+	if a.FeeConfig.IsZero() {
+		missing = append(missing, "fee_config")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("buy: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildBuy(accounts BuyAccounts, args BuyArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(BuyDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -353,40 +537,91 @@ type BuyExactSolInAccounts struct {
 	FeeProgram              solana.PublicKey
 }
 
+var defaultBuyExactSolInSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
 func (a BuyExactSolInAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 16)
-	metas = append(metas, solana.NewAccountMeta(a.Global, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.FeeRecipient, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Mint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.BondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.AssociatedBondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.AssociatedUser, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.User, true, true))
-	var defaultBuyExactSolInSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 16))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a BuyExactSolInAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Global, false, false)
+	dst = appendAccountMeta(dst, a.FeeRecipient, true, false)
+	dst = appendAccountMeta(dst, a.Mint, false, false)
+	dst = appendAccountMeta(dst, a.BondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.AssociatedBondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.AssociatedUser, true, false)
+	dst = appendAccountMeta(dst, a.User, true, true)
+	dst = appendAccountMeta(dst, defaultBuyExactSolInSystemProgram, false, false)
+	dst = appendAccountMeta(dst, a.TokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.CreatorVault, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, ProgramKey, false, false)
+	dst = appendAccountMeta(dst, a.GlobalVolumeAccumulator, false, false)
+	dst = appendAccountMeta(dst, a.UserVolumeAccumulator, true, false)
+	dst = appendAccountMeta(dst, a.FeeConfig, false, false)
+	dst = appendAccountMeta(dst, PumpFeesProgramKey, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a BuyExactSolInAccounts) Validate() error {
+	var missing []string
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultBuyExactSolInSystemProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.TokenProgram, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.CreatorVault, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	var defaultBuyExactSolInProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P")
+	if a.FeeRecipient.IsZero() {
+		missing = append(missing, "fee_recipient")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultBuyExactSolInProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalVolumeAccumulator, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserVolumeAccumulator, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.FeeConfig, false, false))
-	var defaultBuyExactSolInFeeProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("pfeeUxB6jkeY1Hxd7CsFCAjcbHA9rWtchMGdZ6VojVZ")
+	if a.Mint.IsZero() {
+		missing = append(missing, "mint")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultBuyExactSolInFeeProgram(), false, false))
-	return metas
+	if a.BondingCurve.IsZero() {
+		missing = append(missing, "bonding_curve")
+	}
+	if a.AssociatedBondingCurve.IsZero() {
+		missing = append(missing, "associated_bonding_curve")
+	}
+	if a.AssociatedUser.IsZero() {
+		missing = append(missing, "associated_user")
+	}
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.TokenProgram.IsZero() {
+		missing = append(missing, "token_program")
+	}
+	if a.CreatorVault.IsZero() {
+		missing = append(missing, "creator_vault")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.GlobalVolumeAccumulator.IsZero() {
+		missing = append(missing, "global_volume_accumulator")
+	}
+	if a.UserVolumeAccumulator.IsZero() {
+		missing = append(missing, "user_volume_accumulator")
+	}
+	if a.FeeConfig.IsZero() {
+		missing = append(missing, "fee_config")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("buy_exact_sol_in: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildBuyExactSolIn(accounts BuyExactSolInAccounts, args BuyExactSolInArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(BuyExactSolInDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -469,36 +704,77 @@ type ClaimTokenIncentivesAccounts struct {
 	Payer                       solana.PublicKey
 }
 
+var defaultClaimTokenIncentivesSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
+var defaultClaimTokenIncentivesAssociatedTokenProgram = solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+
 func (a ClaimTokenIncentivesAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 12)
-	metas = append(metas, solana.NewAccountMeta(a.User, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserAta, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalVolumeAccumulator, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalIncentiveTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserVolumeAccumulator, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Mint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.TokenProgram, false, false))
-	var defaultClaimTokenIncentivesSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 12))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a ClaimTokenIncentivesAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.User, false, false)
+	dst = appendAccountMeta(dst, a.UserAta, true, false)
+	dst = appendAccountMeta(dst, a.GlobalVolumeAccumulator, false, false)
+	dst = appendAccountMeta(dst, a.GlobalIncentiveTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.UserVolumeAccumulator, true, false)
+	dst = appendAccountMeta(dst, a.Mint, false, false)
+	dst = appendAccountMeta(dst, a.TokenProgram, false, false)
+	dst = appendAccountMeta(dst, defaultClaimTokenIncentivesSystemProgram, false, false)
+	dst = appendAccountMeta(dst, defaultClaimTokenIncentivesAssociatedTokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, ProgramKey, false, false)
+	dst = appendAccountMeta(dst, a.Payer, true, true)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a ClaimTokenIncentivesAccounts) Validate() error {
+	var missing []string
+	if a.User.IsZero() {
+		missing = append(missing, "user")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultClaimTokenIncentivesSystemProgram(), false, false))
-	var defaultClaimTokenIncentivesAssociatedTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	if a.UserAta.IsZero() {
+		missing = append(missing, "user_ata")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultClaimTokenIncentivesAssociatedTokenProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	var defaultClaimTokenIncentivesProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P")
+	if a.GlobalVolumeAccumulator.IsZero() {
+		missing = append(missing, "global_volume_accumulator")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultClaimTokenIncentivesProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Payer, true, true))
-	return metas
+	if a.GlobalIncentiveTokenAccount.IsZero() {
+		missing = append(missing, "global_incentive_token_account")
+	}
+	if a.UserVolumeAccumulator.IsZero() {
+		missing = append(missing, "user_volume_accumulator")
+	}
+	if a.Mint.IsZero() {
+		missing = append(missing, "mint")
+	}
+	if a.TokenProgram.IsZero() {
+		missing = append(missing, "token_program")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Payer.IsZero() {
+		missing = append(missing, "payer")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("claim_token_incentives: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildClaimTokenIncentives(accounts ClaimTokenIncentivesAccounts, args ClaimTokenIncentivesArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(ClaimTokenIncentivesDiscriminator)
 	data := buf.Bytes()
@@ -552,15 +828,49 @@ type CloseUserVolumeAccumulatorAccounts struct {
 }
 
 func (a CloseUserVolumeAccumulatorAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 4)
-	metas = append(metas, solana.NewAccountMeta(a.User, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.UserVolumeAccumulator, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 4))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a CloseUserVolumeAccumulatorAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.User, true, true)
+	dst = appendAccountMeta(dst, a.UserVolumeAccumulator, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a CloseUserVolumeAccumulatorAccounts) Validate() error {
+	var missing []string
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.UserVolumeAccumulator.IsZero() {
+		missing = append(missing, "user_volume_accumulator")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("close_user_volume_accumulator: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildCloseUserVolumeAccumulator(accounts CloseUserVolumeAccumulatorAccounts, args CloseUserVolumeAccumulatorArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(CloseUserVolumeAccumulatorDiscriminator)
 	data := buf.Bytes()
@@ -592,21 +902,53 @@ type CollectCreatorFeeAccounts struct {
 	Program        solana.PublicKey
 }
 
+var defaultCollectCreatorFeeSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
 func (a CollectCreatorFeeAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 5)
-	metas = append(metas, solana.NewAccountMeta(a.Creator, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.CreatorVault, true, false))
-	var defaultCollectCreatorFeeSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 5))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a CollectCreatorFeeAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Creator, true, false)
+	dst = appendAccountMeta(dst, a.CreatorVault, true, false)
+	dst = appendAccountMeta(dst, defaultCollectCreatorFeeSystemProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a CollectCreatorFeeAccounts) Validate() error {
+	var missing []string
+	if a.Creator.IsZero() {
+		missing = append(missing, "creator")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCollectCreatorFeeSystemProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.CreatorVault.IsZero() {
+		missing = append(missing, "creator_vault")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("collect_creator_fee: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildCollectCreatorFee(accounts CollectCreatorFeeAccounts, args CollectCreatorFeeArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(CollectCreatorFeeDiscriminator)
 	data := buf.Bytes()
@@ -652,46 +994,85 @@ type CreateAccounts struct {
 	Program                solana.PublicKey
 }
 
+var defaultCreateMplTokenMetadata = solana.MustPublicKeyFromBase58("metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s")
+
+var defaultCreateSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
+var defaultCreateTokenProgram = solana.MustPublicKeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+var defaultCreateAssociatedTokenProgram = solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+
+var defaultCreateRent = solana.MustPublicKeyFromBase58("SysvarRent111111111111111111111111111111111")
+
 func (a CreateAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 14)
-	metas = append(metas, solana.NewAccountMeta(a.Mint, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.MintAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.BondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.AssociatedBondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Global, false, false))
-	var defaultCreateMplTokenMetadata = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 14))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a CreateAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Mint, true, true)
+	dst = appendAccountMeta(dst, a.MintAuthority, false, false)
+	dst = appendAccountMeta(dst, a.BondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.AssociatedBondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.Global, false, false)
+	dst = appendAccountMeta(dst, defaultCreateMplTokenMetadata, false, false)
+	dst = appendAccountMeta(dst, a.Metadata, true, false)
+	dst = appendAccountMeta(dst, a.User, true, true)
+	dst = appendAccountMeta(dst, defaultCreateSystemProgram, false, false)
+	dst = appendAccountMeta(dst, defaultCreateTokenProgram, false, false)
+	dst = appendAccountMeta(dst, defaultCreateAssociatedTokenProgram, false, false)
+	dst = appendAccountMeta(dst, defaultCreateRent, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a CreateAccounts) Validate() error {
+	var missing []string
+	if a.Mint.IsZero() {
+		missing = append(missing, "mint")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCreateMplTokenMetadata(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Metadata, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.User, true, true))
-	var defaultCreateSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	if a.MintAuthority.IsZero() {
+		missing = append(missing, "mint_authority")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCreateSystemProgram(), false, false))
-	var defaultCreateTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	if a.BondingCurve.IsZero() {
+		missing = append(missing, "bonding_curve")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCreateTokenProgram(), false, false))
-	var defaultCreateAssociatedTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	if a.AssociatedBondingCurve.IsZero() {
+		missing = append(missing, "associated_bonding_curve")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCreateAssociatedTokenProgram(), false, false))
-	var defaultCreateRent = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("SysvarRent111111111111111111111111111111111")
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCreateRent(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.Metadata.IsZero() {
+		missing = append(missing, "metadata")
+	}
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("create: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildCreate(accounts CreateAccounts, args CreateArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(CreateDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -771,44 +1152,94 @@ type CreateV2Accounts struct {
 	Program                solana.PublicKey
 }
 
+var defaultCreateV2SystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
+var defaultCreateV2TokenProgram = solana.MustPublicKeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+
+var defaultCreateV2AssociatedTokenProgram = solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+
+var defaultCreateV2MayhemProgramId = solana.MustPublicKeyFromBase58("MAyhSmzXzV1pTf7LsNkrNwkWKTo4ougAJ1PPg47MD4e")
+
 func (a CreateV2Accounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 16)
-	metas = append(metas, solana.NewAccountMeta(a.Mint, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.MintAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.BondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.AssociatedBondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Global, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.User, true, true))
-	var defaultCreateV2SystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 16))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a CreateV2Accounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Mint, true, true)
+	dst = appendAccountMeta(dst, a.MintAuthority, false, false)
+	dst = appendAccountMeta(dst, a.BondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.AssociatedBondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.Global, false, false)
+	dst = appendAccountMeta(dst, a.User, true, true)
+	dst = appendAccountMeta(dst, defaultCreateV2SystemProgram, false, false)
+	dst = appendAccountMeta(dst, defaultCreateV2TokenProgram, false, false)
+	dst = appendAccountMeta(dst, defaultCreateV2AssociatedTokenProgram, false, false)
+	dst = appendAccountMeta(dst, defaultCreateV2MayhemProgramId, true, false)
+	dst = appendAccountMeta(dst, a.GlobalParams, false, false)
+	dst = appendAccountMeta(dst, a.SolVault, true, false)
+	dst = appendAccountMeta(dst, a.MayhemState, true, false)
+	dst = appendAccountMeta(dst, a.MayhemTokenVault, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a CreateV2Accounts) Validate() error {
+	var missing []string
+	if a.Mint.IsZero() {
+		missing = append(missing, "mint")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCreateV2SystemProgram(), false, false))
-	var defaultCreateV2TokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+	if a.MintAuthority.IsZero() {
+		missing = append(missing, "mint_authority")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCreateV2TokenProgram(), false, false))
-	var defaultCreateV2AssociatedTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	if a.BondingCurve.IsZero() {
+		missing = append(missing, "bonding_curve")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCreateV2AssociatedTokenProgram(), false, false))
-	var defaultCreateV2MayhemProgramId = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("MAyhSmzXzV1pTf7LsNkrNwkWKTo4ougAJ1PPg47MD4e")
+	if a.AssociatedBondingCurve.IsZero() {
+		missing = append(missing, "associated_bonding_curve")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCreateV2MayhemProgramId(), true, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalParams, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.SolVault, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.MayhemState, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.MayhemTokenVault, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
+	}
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.GlobalParams.IsZero() {
+		missing = append(missing, "global_params")
+	}
+	if a.SolVault.IsZero() {
+		missing = append(missing, "sol_vault")
+	}
+	if a.MayhemState.IsZero() {
+		missing = append(missing, "mayhem_state")
+	}
+	if a.MayhemTokenVault.IsZero() {
+		missing = append(missing, "mayhem_token_vault")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("create_v2: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildCreateV2(accounts CreateV2Accounts, args CreateV2Args) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(CreateV2Discriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -890,21 +1321,53 @@ type ExtendAccountAccounts struct {
 	Program        solana.PublicKey
 }
 
+var defaultExtendAccountSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
 func (a ExtendAccountAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 5)
-	metas = append(metas, solana.NewAccountMeta(a.Account, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.User, false, true))
-	var defaultExtendAccountSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 5))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a ExtendAccountAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Account, true, false)
+	dst = appendAccountMeta(dst, a.User, false, true)
+	dst = appendAccountMeta(dst, defaultExtendAccountSystemProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a ExtendAccountAccounts) Validate() error {
+	var missing []string
+	if a.Account.IsZero() {
+		missing = append(missing, "account")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultExtendAccountSystemProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("extend_account: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildExtendAccount(accounts ExtendAccountAccounts, args ExtendAccountArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(ExtendAccountDiscriminator)
 	data := buf.Bytes()
@@ -930,22 +1393,57 @@ type InitUserVolumeAccumulatorAccounts struct {
 	Program               solana.PublicKey
 }
 
+var defaultInitUserVolumeAccumulatorSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
 func (a InitUserVolumeAccumulatorAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 6)
-	metas = append(metas, solana.NewAccountMeta(a.Payer, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.User, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserVolumeAccumulator, true, false))
-	var defaultInitUserVolumeAccumulatorSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 6))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a InitUserVolumeAccumulatorAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Payer, true, true)
+	dst = appendAccountMeta(dst, a.User, false, false)
+	dst = appendAccountMeta(dst, a.UserVolumeAccumulator, true, false)
+	dst = appendAccountMeta(dst, defaultInitUserVolumeAccumulatorSystemProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a InitUserVolumeAccumulatorAccounts) Validate() error {
+	var missing []string
+	if a.Payer.IsZero() {
+		missing = append(missing, "payer")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultInitUserVolumeAccumulatorSystemProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.UserVolumeAccumulator.IsZero() {
+		missing = append(missing, "user_volume_accumulator")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("init_user_volume_accumulator: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildInitUserVolumeAccumulator(accounts InitUserVolumeAccumulatorAccounts, args InitUserVolumeAccumulatorArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(InitUserVolumeAccumulatorDiscriminator)
 	data := buf.Bytes()
@@ -975,19 +1473,45 @@ type InitializeAccounts struct {
 	SystemProgram solana.PublicKey
 }
 
+var defaultInitializeSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
 func (a InitializeAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 3)
-	metas = append(metas, solana.NewAccountMeta(a.Global, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.User, true, true))
-	var defaultInitializeSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 3))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a InitializeAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Global, true, false)
+	dst = appendAccountMeta(dst, a.User, true, true)
+	dst = appendAccountMeta(dst, defaultInitializeSystemProgram, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a InitializeAccounts) Validate() error {
+	var missing []string
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultInitializeSystemProgram(), false, false))
-	return metas
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("initialize: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildInitialize(accounts InitializeAccounts, args InitializeArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(InitializeDiscriminator)
 	data := buf.Bytes()
@@ -1031,60 +1555,122 @@ type MigrateAccounts struct {
 	Program                  solana.PublicKey
 }
 
+var defaultMigrateSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
+var defaultMigrateTokenProgram = solana.MustPublicKeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+var defaultMigrateWsolMint = solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
+
+var defaultMigrateToken2022Program = solana.MustPublicKeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+
+var defaultMigrateAssociatedTokenProgram = solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+
 func (a MigrateAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 24)
-	metas = append(metas, solana.NewAccountMeta(a.Global, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.WithdrawAuthority, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Mint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.BondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.AssociatedBondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.User, false, true))
-	var defaultMigrateSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 24))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a MigrateAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Global, false, false)
+	dst = appendAccountMeta(dst, a.WithdrawAuthority, true, false)
+	dst = appendAccountMeta(dst, a.Mint, false, false)
+	dst = appendAccountMeta(dst, a.BondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.AssociatedBondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.User, false, true)
+	dst = appendAccountMeta(dst, defaultMigrateSystemProgram, false, false)
+	dst = appendAccountMeta(dst, defaultMigrateTokenProgram, false, false)
+	dst = appendAccountMeta(dst, PumpAmmProgramKey, false, false)
+	dst = appendAccountMeta(dst, a.Pool, true, false)
+	dst = appendAccountMeta(dst, a.PoolAuthority, true, false)
+	dst = appendAccountMeta(dst, a.PoolAuthorityMintAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolAuthorityWsolAccount, true, false)
+	dst = appendAccountMeta(dst, a.AmmGlobalConfig, false, false)
+	dst = appendAccountMeta(dst, defaultMigrateWsolMint, false, false)
+	dst = appendAccountMeta(dst, a.LpMint, true, false)
+	dst = appendAccountMeta(dst, a.UserPoolTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolBaseTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolQuoteTokenAccount, true, false)
+	dst = appendAccountMeta(dst, defaultMigrateToken2022Program, false, false)
+	dst = appendAccountMeta(dst, defaultMigrateAssociatedTokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.PumpAmmEventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a MigrateAccounts) Validate() error {
+	var missing []string
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultMigrateSystemProgram(), false, false))
-	var defaultMigrateTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	if a.WithdrawAuthority.IsZero() {
+		missing = append(missing, "withdraw_authority")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultMigrateTokenProgram(), false, false))
-	var defaultMigratePumpAmm = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("pAMMBay6oceH9fJKBRHGP5D4bD4sWpmSwMn52FMfXEA")
+	if a.Mint.IsZero() {
+		missing = append(missing, "mint")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultMigratePumpAmm(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Pool, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolAuthority, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolAuthorityMintAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolAuthorityWsolAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.AmmGlobalConfig, false, false))
-	var defaultMigrateWsolMint = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
+	if a.BondingCurve.IsZero() {
+		missing = append(missing, "bonding_curve")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultMigrateWsolMint(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.LpMint, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserPoolTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolBaseTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolQuoteTokenAccount, true, false))
-	var defaultMigrateToken2022Program = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+	if a.AssociatedBondingCurve.IsZero() {
+		missing = append(missing, "associated_bonding_curve")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultMigrateToken2022Program(), false, false))
-	var defaultMigrateAssociatedTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	if a.User.IsZero() {
+		missing = append(missing, "user")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultMigrateAssociatedTokenProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.PumpAmmEventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.Pool.IsZero() {
+		missing = append(missing, "pool")
+	}
+	if a.PoolAuthority.IsZero() {
+		missing = append(missing, "pool_authority")
+	}
+	if a.PoolAuthorityMintAccount.IsZero() {
+		missing = append(missing, "pool_authority_mint_account")
+	}
+	if a.PoolAuthorityWsolAccount.IsZero() {
+		missing = append(missing, "pool_authority_wsol_account")
+	}
+	if a.AmmGlobalConfig.IsZero() {
+		missing = append(missing, "amm_global_config")
+	}
+	if a.LpMint.IsZero() {
+		missing = append(missing, "lp_mint")
+	}
+	if a.UserPoolTokenAccount.IsZero() {
+		missing = append(missing, "user_pool_token_account")
+	}
+	if a.PoolBaseTokenAccount.IsZero() {
+		missing = append(missing, "pool_base_token_account")
+	}
+	if a.PoolQuoteTokenAccount.IsZero() {
+		missing = append(missing, "pool_quote_token_account")
+	}
+	if a.PumpAmmEventAuthority.IsZero() {
+		missing = append(missing, "pump_amm_event_authority")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("migrate: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildMigrate(accounts MigrateAccounts, args MigrateArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(MigrateDiscriminator)
 	data := buf.Bytes()
@@ -1218,38 +1804,83 @@ type SellAccounts struct {
 	FeeProgram             solana.PublicKey
 }
 
+var defaultSellSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
 func (a SellAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 14)
-	metas = append(metas, solana.NewAccountMeta(a.Global, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.FeeRecipient, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Mint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.BondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.AssociatedBondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.AssociatedUser, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.User, true, true))
-	var defaultSellSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 14))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a SellAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Global, false, false)
+	dst = appendAccountMeta(dst, a.FeeRecipient, true, false)
+	dst = appendAccountMeta(dst, a.Mint, false, false)
+	dst = appendAccountMeta(dst, a.BondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.AssociatedBondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.AssociatedUser, true, false)
+	dst = appendAccountMeta(dst, a.User, true, true)
+	dst = appendAccountMeta(dst, defaultSellSystemProgram, false, false)
+	dst = appendAccountMeta(dst, a.CreatorVault, true, false)
+	dst = appendAccountMeta(dst, a.TokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, ProgramKey, false, false)
+	dst = appendAccountMeta(dst, a.FeeConfig, false, false)
+	dst = appendAccountMeta(dst, PumpFeesProgramKey, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a SellAccounts) Validate() error {
+	var missing []string
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultSellSystemProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.CreatorVault, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.TokenProgram, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	var defaultSellProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P")
+	if a.FeeRecipient.IsZero() {
+		missing = append(missing, "fee_recipient")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultSellProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.FeeConfig, false, false))
-	var defaultSellFeeProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("pfeeUxB6jkeY1Hxd7CsFCAjcbHA9rWtchMGdZ6VojVZ")
+	if a.Mint.IsZero() {
+		missing = append(missing, "mint")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultSellFeeProgram(), false, false))
-	return metas
+	if a.BondingCurve.IsZero() {
+		missing = append(missing, "bonding_curve")
+	}
+	if a.AssociatedBondingCurve.IsZero() {
+		missing = append(missing, "associated_bonding_curve")
+	}
+	if a.AssociatedUser.IsZero() {
+		missing = append(missing, "associated_user")
+	}
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.CreatorVault.IsZero() {
+		missing = append(missing, "creator_vault")
+	}
+	if a.TokenProgram.IsZero() {
+		missing = append(missing, "token_program")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.FeeConfig.IsZero() {
+		missing = append(missing, "fee_config")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("sell: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildSell(accounts SellAccounts, args SellArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(SellDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -1317,18 +1948,61 @@ type SetCreatorAccounts struct {
 }
 
 func (a SetCreatorAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 7)
-	metas = append(metas, solana.NewAccountMeta(a.SetCreatorAuthority, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.Global, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Mint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Metadata, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.BondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 7))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a SetCreatorAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.SetCreatorAuthority, false, true)
+	dst = appendAccountMeta(dst, a.Global, false, false)
+	dst = appendAccountMeta(dst, a.Mint, false, false)
+	dst = appendAccountMeta(dst, a.Metadata, false, false)
+	dst = appendAccountMeta(dst, a.BondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a SetCreatorAccounts) Validate() error {
+	var missing []string
+	if a.SetCreatorAuthority.IsZero() {
+		missing = append(missing, "set_creator_authority")
+	}
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
+	}
+	if a.Mint.IsZero() {
+		missing = append(missing, "mint")
+	}
+	if a.Metadata.IsZero() {
+		missing = append(missing, "metadata")
+	}
+	if a.BondingCurve.IsZero() {
+		missing = append(missing, "bonding_curve")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("set_creator: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildSetCreator(accounts SetCreatorAccounts, args SetCreatorArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(SetCreatorDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -1378,16 +2052,53 @@ type SetMetaplexCreatorAccounts struct {
 }
 
 func (a SetMetaplexCreatorAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 5)
-	metas = append(metas, solana.NewAccountMeta(a.Mint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Metadata, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.BondingCurve, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 5))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a SetMetaplexCreatorAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Mint, false, false)
+	dst = appendAccountMeta(dst, a.Metadata, false, false)
+	dst = appendAccountMeta(dst, a.BondingCurve, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a SetMetaplexCreatorAccounts) Validate() error {
+	var missing []string
+	if a.Mint.IsZero() {
+		missing = append(missing, "mint")
+	}
+	if a.Metadata.IsZero() {
+		missing = append(missing, "metadata")
+	}
+	if a.BondingCurve.IsZero() {
+		missing = append(missing, "bonding_curve")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("set_metaplex_creator: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildSetMetaplexCreator(accounts SetMetaplexCreatorAccounts, args SetMetaplexCreatorArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(SetMetaplexCreatorDiscriminator)
 	data := buf.Bytes()
@@ -1439,15 +2150,49 @@ type SetParamsAccounts struct {
 }
 
 func (a SetParamsAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 4)
-	metas = append(metas, solana.NewAccountMeta(a.Global, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Authority, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 4))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a SetParamsAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Global, true, false)
+	dst = appendAccountMeta(dst, a.Authority, true, true)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a SetParamsAccounts) Validate() error {
+	var missing []string
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
+	}
+	if a.Authority.IsZero() {
+		missing = append(missing, "authority")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("set_params: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildSetParams(accounts SetParamsAccounts, args SetParamsArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(SetParamsDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -1483,15 +2228,49 @@ type SetReservedFeeRecipientsAccounts struct {
 }
 
 func (a SetReservedFeeRecipientsAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 4)
-	metas = append(metas, solana.NewAccountMeta(a.Global, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Authority, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 4))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a SetReservedFeeRecipientsAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Global, true, false)
+	dst = appendAccountMeta(dst, a.Authority, false, true)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a SetReservedFeeRecipientsAccounts) Validate() error {
+	var missing []string
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
+	}
+	if a.Authority.IsZero() {
+		missing = append(missing, "authority")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("set_reserved_fee_recipients: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildSetReservedFeeRecipients(accounts SetReservedFeeRecipientsAccounts, args SetReservedFeeRecipientsArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(SetReservedFeeRecipientsDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -1526,16 +2305,53 @@ type SyncUserVolumeAccumulatorAccounts struct {
 }
 
 func (a SyncUserVolumeAccumulatorAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 5)
-	metas = append(metas, solana.NewAccountMeta(a.User, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalVolumeAccumulator, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserVolumeAccumulator, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 5))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a SyncUserVolumeAccumulatorAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.User, false, false)
+	dst = appendAccountMeta(dst, a.GlobalVolumeAccumulator, false, false)
+	dst = appendAccountMeta(dst, a.UserVolumeAccumulator, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a SyncUserVolumeAccumulatorAccounts) Validate() error {
+	var missing []string
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.GlobalVolumeAccumulator.IsZero() {
+		missing = append(missing, "global_volume_accumulator")
+	}
+	if a.UserVolumeAccumulator.IsZero() {
+		missing = append(missing, "user_volume_accumulator")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("sync_user_volume_accumulator: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildSyncUserVolumeAccumulator(accounts SyncUserVolumeAccumulatorAccounts, args SyncUserVolumeAccumulatorArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(SyncUserVolumeAccumulatorDiscriminator)
 	data := buf.Bytes()
@@ -1575,15 +2391,49 @@ type ToggleCreateV2Accounts struct {
 }
 
 func (a ToggleCreateV2Accounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 4)
-	metas = append(metas, solana.NewAccountMeta(a.Global, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Authority, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 4))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a ToggleCreateV2Accounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Global, true, false)
+	dst = appendAccountMeta(dst, a.Authority, true, true)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a ToggleCreateV2Accounts) Validate() error {
+	var missing []string
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
+	}
+	if a.Authority.IsZero() {
+		missing = append(missing, "authority")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("toggle_create_v2: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildToggleCreateV2(accounts ToggleCreateV2Accounts, args ToggleCreateV2Args) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(ToggleCreateV2Discriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -1619,15 +2469,49 @@ type ToggleMayhemModeAccounts struct {
 }
 
 func (a ToggleMayhemModeAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 4)
-	metas = append(metas, solana.NewAccountMeta(a.Global, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Authority, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 4))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a ToggleMayhemModeAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Global, true, false)
+	dst = appendAccountMeta(dst, a.Authority, true, true)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a ToggleMayhemModeAccounts) Validate() error {
+	var missing []string
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
+	}
+	if a.Authority.IsZero() {
+		missing = append(missing, "authority")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("toggle_mayhem_mode: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildToggleMayhemMode(accounts ToggleMayhemModeAccounts, args ToggleMayhemModeArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(ToggleMayhemModeDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -1662,16 +2546,53 @@ type UpdateGlobalAuthorityAccounts struct {
 }
 
 func (a UpdateGlobalAuthorityAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 5)
-	metas = append(metas, solana.NewAccountMeta(a.Global, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Authority, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.NewAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 5))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a UpdateGlobalAuthorityAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Global, true, false)
+	dst = appendAccountMeta(dst, a.Authority, false, true)
+	dst = appendAccountMeta(dst, a.NewAuthority, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a UpdateGlobalAuthorityAccounts) Validate() error {
+	var missing []string
+	if a.Global.IsZero() {
+		missing = append(missing, "global")
+	}
+	if a.Authority.IsZero() {
+		missing = append(missing, "authority")
+	}
+	if a.NewAuthority.IsZero() {
+		missing = append(missing, "new_authority")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("update_global_authority: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildUpdateGlobalAuthority(accounts UpdateGlobalAuthorityAccounts, args UpdateGlobalAuthorityArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(UpdateGlobalAuthorityDiscriminator)
 	data := buf.Bytes()
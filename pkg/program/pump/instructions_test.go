@@ -0,0 +1,79 @@
+package pump
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestBuildBuyValidatesAccounts(t *testing.T) {
+	key := func() solana.PublicKey { return solana.NewWallet().PublicKey() }
+	accts := BuyAccounts{
+		Global: key(), FeeRecipient: key(), Mint: key(), BondingCurve: key(), AssociatedBondingCurve: key(),
+		AssociatedUser: key(), User: key(), CreatorVault: key(), EventAuthority: key(),
+		TokenProgram: key(), GlobalVolumeAccumulator: key(), UserVolumeAccumulator: key(), FeeConfig: key(),
+	}
+	if _, err := BuildBuy(accts, BuyArgs{Amount: 1, MaxSolCost: 1}); err != nil {
+		t.Fatalf("complete accounts: %v", err)
+	}
+
+	accts.Mint = solana.PublicKey{}
+	accts.CreatorVault = solana.PublicKey{}
+
+	_, err := BuildBuy(accts, BuyArgs{Amount: 1, MaxSolCost: 1})
+	if err == nil || !strings.Contains(err.Error(), "missing accounts: mint, creator_vault") {
+		t.Fatalf("err = %v, want missing mint and creator_vault", err)
+	}
+}
+
+func TestToAccountMetasFixedAddresses(t *testing.T) {
+	metas := BuyAccounts{SystemProgram: solana.NewWallet().PublicKey()}.ToAccountMetas()
+	if got := metas[7].PublicKey; !got.Equals(solana.SystemProgramID) {
+		t.Fatalf("system_program = %s, want %s", got, solana.SystemProgramID)
+	}
+	if got := metas[11].PublicKey; !got.Equals(ProgramKey) {
+		t.Fatalf("program = %s, want %s", got, ProgramKey)
+	}
+}
+
+func TestAppendAccountMetasReuse(t *testing.T) {
+	a := BuyAccounts{User: solana.NewWallet().PublicKey()}
+	metas := a.AppendAccountMetas(nil)
+	first := metas[6]
+	// Metas from ToAccountMetas are the instruction's own.
+	built := a.ToAccountMetas()
+
+	b := BuyAccounts{User: solana.NewWallet().PublicKey()}
+	metas = b.AppendAccountMetas(metas[:0])
+	if metas[6] != first || !metas[6].PublicKey.Equals(b.User) || !metas[6].IsSigner {
+		t.Fatalf("user meta not reused in place: %+v", metas[6])
+	}
+	if len(metas) != len(b.ToAccountMetas()) {
+		t.Fatalf("len = %d, want %d", len(metas), len(b.ToAccountMetas()))
+	}
+	if !built[6].PublicKey.Equals(a.User) {
+		t.Fatalf("ToAccountMetas result changed by reuse: user = %s, want %s", built[6].PublicKey, a.User)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { metas = a.AppendAccountMetas(metas[:0]) }); allocs != 0 {
+		t.Fatalf("reusing dst allocated %.0f times per build, want 0", allocs)
+	}
+}
+
+func BenchmarkBuyToAccountMetas(b *testing.B) {
+	a := BuyAccounts{User: solana.NewWallet().PublicKey()}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = a.ToAccountMetas()
+	}
+}
+
+func BenchmarkBuyAppendAccountMetas(b *testing.B) {
+	a := BuyAccounts{User: solana.NewWallet().PublicKey()}
+	metas := a.AppendAccountMetas(nil)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		metas = a.AppendAccountMetas(metas[:0])
+	}
+}
@@ -1,5 +1,5 @@
 // Code generated by internal/gen; DO NOT EDIT.
-// Generated at 2025-12-07T17:54:48Z
+// Generated at 2026-10-16T20:02:00Z
 
 package pump
 
@@ -10,3 +10,11 @@ const ProgramName string = "pump"
 const ProgramVersion string = "0.1.0"
 
 var ProgramKey = solana.MustPublicKeyFromBase58(ProgramID)
+
+// Other Pump programs this program's instructions pass as accounts or derive
+// PDAs under. Like ProgramKey, they may be changed to target another
+// deployment before instructions are built.
+var (
+	PumpAmmProgramKey  = solana.MustPublicKeyFromBase58("pAMMBay6oceH9fJKBRHGP5D4bD4sWpmSwMn52FMfXEA")
+	PumpFeesProgramKey = solana.MustPublicKeyFromBase58("pfeeUxB6jkeY1Hxd7CsFCAjcbHA9rWtchMGdZ6VojVZ")
+)
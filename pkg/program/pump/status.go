@@ -0,0 +1,111 @@
+package pump
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// BondingCurveStatus summarizes how close a bonding curve is to graduating
+// (migrating to pump_amm).
+type BondingCurveStatus struct {
+	Address solana.PublicKey `json:"address"`
+	Curve   BondingCurve     `json:"curve"`
+
+	Complete bool `json:"complete"`
+
+	VirtualSolReserves   uint64 `json:"virtual_sol_reserves"`
+	VirtualTokenReserves uint64 `json:"virtual_token_reserves"`
+	RealSolReserves      uint64 `json:"real_sol_reserves"`
+	RealTokenReserves    uint64 `json:"real_token_reserves"`
+
+	// GraduationThresholdLamports is the real SOL reserves at which the curve
+	// completes, i.e. RealSolReserves plus the (pre-fee) cost of buying all
+	// remaining real tokens.
+	GraduationThresholdLamports uint64 `json:"graduation_threshold_lamports"`
+	// ProgressBps is RealSolReserves / GraduationThresholdLamports in basis points (0-10000).
+	ProgressBps uint64 `json:"progress_bps"`
+}
+
+// FetchBondingCurveStatus loads the bonding curve for mint and computes its
+// graduation progress.
+//
+// Example:
+//
+//	status, err := pump.FetchBondingCurveStatus(ctx, client.Raw(), mint)
+//	fmt.Printf("%.2f%% to graduation\n", float64(status.ProgressBps)/100)
+func FetchBondingCurveStatus(ctx context.Context, client *rpc.Client, mint solana.PublicKey) (BondingCurveStatus, error) {
+	if client == nil {
+		return BondingCurveStatus{}, fmt.Errorf("rpc client is nil")
+	}
+	addr, _, err := solana.FindProgramAddress([][]byte{[]byte("bonding-curve"), mint[:]}, ProgramKey)
+	if err != nil {
+		return BondingCurveStatus{}, fmt.Errorf("derive bonding curve: %w", err)
+	}
+	info, err := client.GetAccountInfoWithOpts(ctx, addr, &rpc.GetAccountInfoOpts{Commitment: rpc.CommitmentConfirmed})
+	if err != nil {
+		return BondingCurveStatus{}, fmt.Errorf("fetch bonding curve %s: %w", addr, err)
+	}
+	if info == nil || info.Value == nil || info.Value.Data == nil {
+		return BondingCurveStatus{}, fmt.Errorf("bonding curve not found for mint %s", mint)
+	}
+	var bc BondingCurve
+	if err := bc.Unmarshal(info.Value.Data.GetBinary()); err != nil {
+		return BondingCurveStatus{}, fmt.Errorf("decode bonding curve: %w", err)
+	}
+	status := NewBondingCurveStatus(bc)
+	status.Address = addr
+	return status, nil
+}
+
+// NewBondingCurveStatus computes the graduation status of a decoded curve.
+func NewBondingCurveStatus(bc BondingCurve) BondingCurveStatus {
+	s := BondingCurveStatus{
+		Curve:                bc,
+		Complete:             bc.Complete,
+		VirtualSolReserves:   bc.VirtualSolReserves,
+		VirtualTokenReserves: bc.VirtualTokenReserves,
+		RealSolReserves:      bc.RealSolReserves,
+		RealTokenReserves:    bc.RealTokenReserves,
+	}
+	if bc.Complete || bc.RealTokenReserves == 0 {
+		s.Complete = true
+		s.GraduationThresholdLamports = bc.RealSolReserves
+		s.ProgressBps = 10_000
+		return s
+	}
+	remaining := solToBuyAll(bc.VirtualSolReserves, bc.VirtualTokenReserves, bc.RealTokenReserves)
+	s.GraduationThresholdLamports = bc.RealSolReserves + remaining
+	if s.GraduationThresholdLamports > 0 {
+		p := new(big.Int).SetUint64(bc.RealSolReserves)
+		p.Mul(p, big.NewInt(10_000))
+		s.ProgressBps = p.Div(p, new(big.Int).SetUint64(s.GraduationThresholdLamports)).Uint64()
+	}
+	return s
+}
+
+// GraduationThresholdLamports returns the real SOL a fresh curve accumulates
+// before completing, derived from the initial reserves in Global
+// (about 85 SOL with the launch parameters).
+func GraduationThresholdLamports(g Global) uint64 {
+	return solToBuyAll(g.InitialVirtualSolReserves, g.InitialVirtualTokenReserves, g.InitialRealTokenReserves)
+}
+
+// solToBuyAll returns ceil(vSol * tokens / (vToken - tokens)), the pre-fee SOL
+// needed to buy tokens from a constant-product curve.
+func solToBuyAll(vSol, vToken, tokens uint64) uint64 {
+	if tokens == 0 || tokens >= vToken {
+		return 0
+	}
+	num := new(big.Int).Mul(new(big.Int).SetUint64(vSol), new(big.Int).SetUint64(tokens))
+	den := new(big.Int).SetUint64(vToken - tokens)
+	num.Add(num, new(big.Int).Sub(den, big.NewInt(1)))
+	num.Quo(num, den)
+	if !num.IsUint64() {
+		return 0
+	}
+	return num.Uint64()
+}
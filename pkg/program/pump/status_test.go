@@ -0,0 +1,55 @@
+package pump
+
+import "testing"
+
+// Launch parameters of a fresh pump curve.
+const (
+	testInitialVirtualSol   = 30_000_000_000
+	testInitialVirtualToken = 1_073_000_000_000_000
+	testInitialRealToken    = 793_100_000_000_000
+)
+
+func TestGraduationThreshold(t *testing.T) {
+	got := GraduationThresholdLamports(Global{
+		InitialVirtualSolReserves:   testInitialVirtualSol,
+		InitialVirtualTokenReserves: testInitialVirtualToken,
+		InitialRealTokenReserves:    testInitialRealToken,
+	})
+	// ~85.005 SOL
+	if got < 85_000_000_000 || got > 85_010_000_000 {
+		t.Fatalf("unexpected graduation threshold %d", got)
+	}
+}
+
+func TestNewBondingCurveStatus(t *testing.T) {
+	fresh := NewBondingCurveStatus(BondingCurve{
+		VirtualSolReserves:   testInitialVirtualSol,
+		VirtualTokenReserves: testInitialVirtualToken,
+		RealTokenReserves:    testInitialRealToken,
+	})
+	if fresh.Complete || fresh.ProgressBps != 0 {
+		t.Fatalf("fresh curve: %+v", fresh)
+	}
+
+	// Halfway in real SOL: buy tokens worth ~42.5 SOL.
+	solIn := uint64(42_502_679_528)
+	// tokens out = vToken * solIn / (vSol + solIn)
+	bought := uint64(float64(testInitialVirtualToken) * float64(solIn) / float64(testInitialVirtualSol+solIn))
+	mid := NewBondingCurveStatus(BondingCurve{
+		VirtualSolReserves:   testInitialVirtualSol + solIn,
+		VirtualTokenReserves: testInitialVirtualToken - bought,
+		RealSolReserves:      solIn,
+		RealTokenReserves:    testInitialRealToken - bought,
+	})
+	if mid.ProgressBps < 4_990 || mid.ProgressBps > 5_010 {
+		t.Fatalf("expected ~50%% progress, got %d bps", mid.ProgressBps)
+	}
+	if mid.GraduationThresholdLamports < fresh.GraduationThresholdLamports-1_000 || mid.GraduationThresholdLamports > fresh.GraduationThresholdLamports+1_000 {
+		t.Fatalf("threshold should be stable along the curve: %d vs %d", mid.GraduationThresholdLamports, fresh.GraduationThresholdLamports)
+	}
+
+	done := NewBondingCurveStatus(BondingCurve{Complete: true, RealSolReserves: 85_005_000_000})
+	if !done.Complete || done.ProgressBps != 10_000 {
+		t.Fatalf("complete curve: %+v", done)
+	}
+}
@@ -1,10 +1,11 @@
 // Code generated by internal/gen; DO NOT EDIT.
-// Generated at 2025-12-07T17:54:49Z
+// Generated at 2026-10-16T17:42:34Z
 
 package pumpamm
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
 	bin "github.com/gagliardetto/binary"
@@ -112,3 +113,54 @@ func (a *UserVolumeAccumulator) Unmarshal(data []byte) error {
 func (a *UserVolumeAccumulator) Address(pubkey solana.PublicKey) solana.PublicKey {
 	return pubkey
 }
+
+// ErrUnknownAccount is returned by DecodeAccount for data whose discriminator
+// matches no account of this program.
+var ErrUnknownAccount = errors.New("unknown account discriminator")
+
+// DecodeAccount identifies account data by its discriminator and decodes it,
+// returning the account type name and a pointer to the decoded struct.
+func DecodeAccount(data []byte) (string, interface{}, error) {
+	if len(data) < 8 {
+		return "", nil, fmt.Errorf("account data too short")
+	}
+	switch {
+	case bytes.Equal(data[:8], BondingCurveDiscriminator):
+		var a BondingCurve
+		if err := a.Unmarshal(data); err != nil {
+			return "BondingCurve", nil, err
+		}
+		return "BondingCurve", &a, nil
+	case bytes.Equal(data[:8], FeeConfigDiscriminator):
+		var a FeeConfig
+		if err := a.Unmarshal(data); err != nil {
+			return "FeeConfig", nil, err
+		}
+		return "FeeConfig", &a, nil
+	case bytes.Equal(data[:8], GlobalConfigDiscriminator):
+		var a GlobalConfig
+		if err := a.Unmarshal(data); err != nil {
+			return "GlobalConfig", nil, err
+		}
+		return "GlobalConfig", &a, nil
+	case bytes.Equal(data[:8], GlobalVolumeAccumulatorDiscriminator):
+		var a GlobalVolumeAccumulator
+		if err := a.Unmarshal(data); err != nil {
+			return "GlobalVolumeAccumulator", nil, err
+		}
+		return "GlobalVolumeAccumulator", &a, nil
+	case bytes.Equal(data[:8], PoolDiscriminator):
+		var a Pool
+		if err := a.Unmarshal(data); err != nil {
+			return "Pool", nil, err
+		}
+		return "Pool", &a, nil
+	case bytes.Equal(data[:8], UserVolumeAccumulatorDiscriminator):
+		var a UserVolumeAccumulator
+		if err := a.Unmarshal(data); err != nil {
+			return "UserVolumeAccumulator", nil, err
+		}
+		return "UserVolumeAccumulator", &a, nil
+	}
+	return "", nil, ErrUnknownAccount
+}
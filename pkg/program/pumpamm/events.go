@@ -0,0 +1,98 @@
+package pumpamm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	bin "github.com/gagliardetto/binary"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+)
+
+// Event discriminators from the pump_amm IDL.
+var (
+	BuyEventDiscriminator  = []byte{103, 244, 82, 31, 44, 245, 119, 119}
+	SellEventDiscriminator = []byte{62, 47, 55, 10, 165, 3, 220, 42}
+)
+
+// ErrEventNotFound is returned when no matching event is present.
+var ErrEventNotFound = errors.New("event not found")
+
+const programDataPrefix = "Program data: "
+
+// ParseBuyEvent returns the first BuyEvent emitted in a transaction's logs
+// ("Program data: " lines). It reports the exact fill: BaseAmountOut,
+// QuoteAmountIn, UserQuoteAmountIn and the LP, protocol and creator fees.
+func ParseBuyEvent(logs []string) (*BuyEvent, error) {
+	var ev BuyEvent
+	if err := findEvent(logs, BuyEventDiscriminator, &ev); err != nil {
+		return nil, fmt.Errorf("buy event: %w", err)
+	}
+	return &ev, nil
+}
+
+// ParseSellEvent returns the first SellEvent emitted in a transaction's logs.
+// It reports the exact fill: BaseAmountIn, QuoteAmountOut, UserQuoteAmountOut
+// and the LP, protocol and creator fees.
+func ParseSellEvent(logs []string) (*SellEvent, error) {
+	var ev SellEvent
+	if err := findEvent(logs, SellEventDiscriminator, &ev); err != nil {
+		return nil, fmt.Errorf("sell event: %w", err)
+	}
+	return &ev, nil
+}
+
+// DecodeBuyEvent decodes a raw event payload (discriminator + borsh data),
+// optionally prefixed by the self-CPI event tag as found in inner instructions.
+func DecodeBuyEvent(data []byte) (*BuyEvent, error) {
+	var ev BuyEvent
+	if err := decodeEvent(data, BuyEventDiscriminator, &ev); err != nil {
+		return nil, fmt.Errorf("buy event: %w", err)
+	}
+	return &ev, nil
+}
+
+// DecodeSellEvent decodes a raw SellEvent payload; see DecodeBuyEvent.
+func DecodeSellEvent(data []byte) (*SellEvent, error) {
+	var ev SellEvent
+	if err := decodeEvent(data, SellEventDiscriminator, &ev); err != nil {
+		return nil, fmt.Errorf("sell event: %w", err)
+	}
+	return &ev, nil
+}
+
+func findEvent(logs []string, disc []byte, v interface{}) error {
+	for _, line := range logs {
+		payload, ok := strings.CutPrefix(line, programDataPrefix)
+		if !ok {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(payload))
+		if err != nil {
+			continue
+		}
+		if err := decodeEvent(data, disc, v); errors.Is(err, ErrEventNotFound) {
+			continue
+		} else {
+			return err
+		}
+	}
+	return ErrEventNotFound
+}
+
+// decodeEvent borsh-decodes data into v if it carries disc. Trailing fields
+// added in newer program versions decode as zero values when absent.
+func decodeEvent(data, disc []byte, v interface{}) error {
+	data = bytes.TrimPrefix(data, constants.AnchorEventIxTag)
+	if len(data) < len(disc) || !bytes.Equal(data[:len(disc)], disc) {
+		return ErrEventNotFound
+	}
+	padded := append(append([]byte{}, data[len(disc):]...), make([]byte, 64)...)
+	if err := bin.NewBorshDecoder(padded).Decode(v); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	return nil
+}
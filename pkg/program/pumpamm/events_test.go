@@ -0,0 +1,72 @@
+package pumpamm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+)
+
+func encodeEvent(t *testing.T, disc []byte, v interface{}) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.Write(disc)
+	if err := bin.NewBorshEncoder(&buf).Encode(v); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseSellEvent(t *testing.T) {
+	want := SellEvent{
+		BaseAmountIn:       1_000_000,
+		QuoteAmountOut:     52_000,
+		LpFee:              104,
+		ProtocolFee:        26,
+		CoinCreatorFee:     13,
+		UserQuoteAmountOut: 51_857,
+		Pool:               solana.NewWallet().PublicKey(),
+	}
+	data := encodeEvent(t, SellEventDiscriminator, want)
+	logs := []string{
+		"Program pAMMBay6oceH9fJKBRHGP5D4bD4sWpmSwMn52FMfXEA invoke [1]",
+		"Program log: Instruction: Sell",
+		"Program data: " + base64.StdEncoding.EncodeToString(data),
+	}
+
+	got, err := ParseSellEvent(logs)
+	if err != nil {
+		t.Fatalf("ParseSellEvent: %v", err)
+	}
+	if *got != want {
+		t.Fatalf("got %+v, want %+v", *got, want)
+	}
+	if _, err := ParseBuyEvent(logs); !errors.Is(err, ErrEventNotFound) {
+		t.Fatalf("expected ErrEventNotFound for buy, got %v", err)
+	}
+}
+
+func TestDecodeBuyEventFromCPI(t *testing.T) {
+	want := BuyEvent{BaseAmountOut: 42, QuoteAmountIn: 7, UserQuoteAmountIn: 8, IxName: "buy"}
+	data := append(append([]byte{}, constants.AnchorEventIxTag...), encodeEvent(t, BuyEventDiscriminator, want)...)
+
+	got, err := DecodeBuyEvent(data)
+	if err != nil {
+		t.Fatalf("DecodeBuyEvent: %v", err)
+	}
+	if got.BaseAmountOut != 42 || got.UserQuoteAmountIn != 8 || got.IxName != "buy" {
+		t.Fatalf("unexpected event %+v", got)
+	}
+
+	// Older layouts without the trailing fields still decode.
+	short := encodeEvent(t, BuyEventDiscriminator, want)
+	short = short[:len(short)-len("buy")-4-8-8-8-8-8-1]
+	if got, err = DecodeBuyEvent(short); err != nil || got.BaseAmountOut != 42 || got.TrackVolume {
+		t.Fatalf("short layout: %+v, %v", got, err)
+	}
+}
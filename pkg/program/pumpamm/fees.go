@@ -0,0 +1,71 @@
+package pumpamm
+
+import "github.com/gagliardetto/solana-go"
+
+// LpFeeBps returns the fee, in basis points, paid to liquidity providers on
+// every swap.
+func (c GlobalConfig) LpFeeBps() uint64 {
+	return c.LpFeeBasisPoints
+}
+
+// ProtocolFeeBps returns the protocol fee, in basis points, charged on every
+// swap.
+func (c GlobalConfig) ProtocolFeeBps() uint64 {
+	return c.ProtocolFeeBasisPoints
+}
+
+// CoinCreatorFeeBps returns the creator fee, in basis points, charged on
+// swaps of pools that have a coin creator.
+func (c GlobalConfig) CoinCreatorFeeBps() uint64 {
+	return c.CoinCreatorFeeBasisPoints
+}
+
+// TradeFeeBps returns the total fee, in basis points, a swap on pool pays:
+// LP and protocol fees plus the creator fee once the pool has a coin
+// creator. The fee program's tiered fees, where configured, take precedence
+// on chain.
+func (c GlobalConfig) TradeFeeBps(pool Pool) uint64 {
+	fee := c.LpFeeBps() + c.ProtocolFeeBps()
+	if !pool.CoinCreator.IsZero() {
+		fee += c.CoinCreatorFeeBps()
+	}
+	return fee
+}
+
+// AcceptedProtocolFeeRecipients returns the protocol fee recipients buy and
+// sell accept, without zero or repeated keys: ProtocolFeeRecipients, or for
+// a pool in mayhem mode ReservedFeeRecipients followed by
+// ReservedFeeRecipient. The program accepts any of them; there is no
+// rotation by slot.
+func (c GlobalConfig) AcceptedProtocolFeeRecipients(mayhem bool) []solana.PublicKey {
+	if mayhem {
+		return uniqueNonZero(append(c.ReservedFeeRecipients[:], c.ReservedFeeRecipient))
+	}
+	return uniqueNonZero(c.ProtocolFeeRecipients[:])
+}
+
+// ActiveProtocolFeeRecipient returns one accepted protocol fee recipient of
+// a regular pool chosen from slot, so that traders spread their write locks
+// over the recipients instead of contending on the first one. It returns
+// the zero key if none is configured.
+func (c GlobalConfig) ActiveProtocolFeeRecipient(slot uint64) solana.PublicKey {
+	list := c.AcceptedProtocolFeeRecipients(false)
+	if len(list) == 0 {
+		return solana.PublicKey{}
+	}
+	return list[slot%uint64(len(list))]
+}
+
+// uniqueNonZero returns list without its zero and repeated keys.
+func uniqueNonZero(list []solana.PublicKey) []solana.PublicKey {
+	out := make([]solana.PublicKey, 0, len(list))
+	seen := make(map[solana.PublicKey]bool, len(list))
+	for _, pk := range list {
+		if pk.IsZero() || seen[pk] {
+			continue
+		}
+		seen[pk] = true
+		out = append(out, pk)
+	}
+	return out
+}
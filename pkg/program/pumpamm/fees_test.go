@@ -0,0 +1,40 @@
+package pumpamm
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestGlobalConfigFees(t *testing.T) {
+	c := GlobalConfig{LpFeeBasisPoints: 20, ProtocolFeeBasisPoints: 5, CoinCreatorFeeBasisPoints: 5}
+	if got := c.TradeFeeBps(Pool{}); got != 25 {
+		t.Fatalf("fee without creator = %d, want 25", got)
+	}
+	if got := c.TradeFeeBps(Pool{CoinCreator: solana.NewWallet().PublicKey()}); got != 30 {
+		t.Fatalf("fee with creator = %d, want 30", got)
+	}
+}
+
+func TestAcceptedProtocolFeeRecipients(t *testing.T) {
+	var list [8]solana.PublicKey
+	list[0] = solana.NewWallet().PublicKey()
+	list[1] = solana.NewWallet().PublicKey()
+	list[2] = list[0]
+	reserved := solana.NewWallet().PublicKey()
+	c := GlobalConfig{ProtocolFeeRecipients: list, ReservedFeeRecipient: reserved}
+
+	got := c.AcceptedProtocolFeeRecipients(false)
+	if len(got) != 2 || !got[0].Equals(list[0]) || !got[1].Equals(list[1]) {
+		t.Fatalf("recipients = %v, want [%s %s]", got, list[0], list[1])
+	}
+	if got := c.AcceptedProtocolFeeRecipients(true); len(got) != 1 || !got[0].Equals(reserved) {
+		t.Fatalf("mayhem recipients = %v, want [%s]", got, reserved)
+	}
+	if got := c.ActiveProtocolFeeRecipient(3); !got.Equals(list[1]) {
+		t.Fatalf("slot 3 recipient = %s, want %s", got, list[1])
+	}
+	if got := (GlobalConfig{}).ActiveProtocolFeeRecipient(3); !got.IsZero() {
+		t.Fatalf("expected zero key without recipients, got %s", got)
+	}
+}
@@ -1,5 +1,5 @@
 // Code generated by internal/gen; DO NOT EDIT.
-// Generated at 2025-12-07T17:54:49Z
+// Generated at 2026-10-16T20:03:39Z
 
 package pumpamm
 
@@ -7,11 +7,24 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"strings"
 
 	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
 )
 
+// appendAccountMeta appends a meta for key to dst, overwriting the meta left
+// in the spare capacity of dst by an earlier build if there is one.
+func appendAccountMeta(dst []*solana.AccountMeta, key solana.PublicKey, writable, signer bool) []*solana.AccountMeta {
+	if n := len(dst); n < cap(dst) {
+		if m := dst[:n+1][n]; m != nil {
+			m.PublicKey, m.IsWritable, m.IsSigner = key, writable, signer
+			return dst[:n+1]
+		}
+	}
+	return append(dst, solana.NewAccountMeta(key, writable, signer))
+}
+
 var AdminSetCoinCreatorDiscriminator = []byte{242, 40, 117, 145, 73, 96, 105, 104}
 
 type AdminSetCoinCreatorArgs struct {
@@ -27,16 +40,53 @@ type AdminSetCoinCreatorAccounts struct {
 }
 
 func (a AdminSetCoinCreatorAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 5)
-	metas = append(metas, solana.NewAccountMeta(a.AdminSetCoinCreatorAuthority, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalConfig, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Pool, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 5))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a AdminSetCoinCreatorAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.AdminSetCoinCreatorAuthority, false, true)
+	dst = appendAccountMeta(dst, a.GlobalConfig, false, false)
+	dst = appendAccountMeta(dst, a.Pool, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a AdminSetCoinCreatorAccounts) Validate() error {
+	var missing []string
+	if a.AdminSetCoinCreatorAuthority.IsZero() {
+		missing = append(missing, "admin_set_coin_creator_authority")
+	}
+	if a.GlobalConfig.IsZero() {
+		missing = append(missing, "global_config")
+	}
+	if a.Pool.IsZero() {
+		missing = append(missing, "pool")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("admin_set_coin_creator: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildAdminSetCoinCreator(accounts AdminSetCoinCreatorAccounts, args AdminSetCoinCreatorArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(AdminSetCoinCreatorDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -75,30 +125,72 @@ type AdminUpdateTokenIncentivesAccounts struct {
 	Program                     solana.PublicKey
 }
 
+var defaultAdminUpdateTokenIncentivesAssociatedTokenProgram = solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+
+var defaultAdminUpdateTokenIncentivesSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
 func (a AdminUpdateTokenIncentivesAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 10)
-	metas = append(metas, solana.NewAccountMeta(a.Admin, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalConfig, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalVolumeAccumulator, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Mint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalIncentiveTokenAccount, true, false))
-	var defaultAdminUpdateTokenIncentivesAssociatedTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 10))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a AdminUpdateTokenIncentivesAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Admin, true, true)
+	dst = appendAccountMeta(dst, a.GlobalConfig, false, false)
+	dst = appendAccountMeta(dst, a.GlobalVolumeAccumulator, true, false)
+	dst = appendAccountMeta(dst, a.Mint, false, false)
+	dst = appendAccountMeta(dst, a.GlobalIncentiveTokenAccount, true, false)
+	dst = appendAccountMeta(dst, defaultAdminUpdateTokenIncentivesAssociatedTokenProgram, false, false)
+	dst = appendAccountMeta(dst, defaultAdminUpdateTokenIncentivesSystemProgram, false, false)
+	dst = appendAccountMeta(dst, a.TokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a AdminUpdateTokenIncentivesAccounts) Validate() error {
+	var missing []string
+	if a.Admin.IsZero() {
+		missing = append(missing, "admin")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultAdminUpdateTokenIncentivesAssociatedTokenProgram(), false, false))
-	var defaultAdminUpdateTokenIncentivesSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	if a.GlobalConfig.IsZero() {
+		missing = append(missing, "global_config")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultAdminUpdateTokenIncentivesSystemProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.TokenProgram, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.GlobalVolumeAccumulator.IsZero() {
+		missing = append(missing, "global_volume_accumulator")
+	}
+	if a.Mint.IsZero() {
+		missing = append(missing, "mint")
+	}
+	if a.GlobalIncentiveTokenAccount.IsZero() {
+		missing = append(missing, "global_incentive_token_account")
+	}
+	if a.TokenProgram.IsZero() {
+		missing = append(missing, "token_program")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("admin_update_token_incentives: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildAdminUpdateTokenIncentives(accounts AdminUpdateTokenIncentivesAccounts, args AdminUpdateTokenIncentivesArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(AdminUpdateTokenIncentivesDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -168,51 +260,118 @@ type BuyAccounts struct {
 	FeeProgram                       solana.PublicKey
 }
 
+var defaultBuySystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
+var defaultBuyAssociatedTokenProgram = solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+
 func (a BuyAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 23)
-	metas = append(metas, solana.NewAccountMeta(a.Pool, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.User, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalConfig, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.BaseMint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.QuoteMint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserBaseTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserQuoteTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolBaseTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolQuoteTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.ProtocolFeeRecipient, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.ProtocolFeeRecipientTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.BaseTokenProgram, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.QuoteTokenProgram, false, false))
-	var defaultBuySystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 23))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a BuyAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Pool, true, false)
+	dst = appendAccountMeta(dst, a.User, true, true)
+	dst = appendAccountMeta(dst, a.GlobalConfig, false, false)
+	dst = appendAccountMeta(dst, a.BaseMint, false, false)
+	dst = appendAccountMeta(dst, a.QuoteMint, false, false)
+	dst = appendAccountMeta(dst, a.UserBaseTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.UserQuoteTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolBaseTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolQuoteTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.ProtocolFeeRecipient, false, false)
+	dst = appendAccountMeta(dst, a.ProtocolFeeRecipientTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.BaseTokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.QuoteTokenProgram, false, false)
+	dst = appendAccountMeta(dst, defaultBuySystemProgram, false, false)
+	dst = appendAccountMeta(dst, defaultBuyAssociatedTokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, ProgramKey, false, false)
+	dst = appendAccountMeta(dst, a.CoinCreatorVaultAta, true, false)
+	dst = appendAccountMeta(dst, a.CoinCreatorVaultAuthority, false, false)
+	dst = appendAccountMeta(dst, a.GlobalVolumeAccumulator, false, false)
+	dst = appendAccountMeta(dst, a.UserVolumeAccumulator, true, false)
+	dst = appendAccountMeta(dst, a.FeeConfig, false, false)
+	dst = appendAccountMeta(dst, PumpFeesProgramKey, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a BuyAccounts) Validate() error {
+	var missing []string
+	if a.Pool.IsZero() {
+		missing = append(missing, "pool")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultBuySystemProgram(), false, false))
-	var defaultBuyAssociatedTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	if a.User.IsZero() {
+		missing = append(missing, "user")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultBuyAssociatedTokenProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	var defaultBuyProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("pAMMBay6oceH9fJKBRHGP5D4bD4sWpmSwMn52FMfXEA")
+	if a.GlobalConfig.IsZero() {
+		missing = append(missing, "global_config")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultBuyProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.CoinCreatorVaultAta, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.CoinCreatorVaultAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalVolumeAccumulator, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserVolumeAccumulator, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.FeeConfig, false, false))
-	var defaultBuyFeeProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("pfeeUxB6jkeY1Hxd7CsFCAjcbHA9rWtchMGdZ6VojVZ")
+	if a.BaseMint.IsZero() {
+		missing = append(missing, "base_mint")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultBuyFeeProgram(), false, false))
-	return metas
+	if a.QuoteMint.IsZero() {
+		missing = append(missing, "quote_mint")
+	}
+	if a.UserBaseTokenAccount.IsZero() {
+		missing = append(missing, "user_base_token_account")
+	}
+	if a.UserQuoteTokenAccount.IsZero() {
+		missing = append(missing, "user_quote_token_account")
+	}
+	if a.PoolBaseTokenAccount.IsZero() {
+		missing = append(missing, "pool_base_token_account")
+	}
+	if a.PoolQuoteTokenAccount.IsZero() {
+		missing = append(missing, "pool_quote_token_account")
+	}
+	if a.ProtocolFeeRecipient.IsZero() {
+		missing = append(missing, "protocol_fee_recipient")
+	}
+	if a.ProtocolFeeRecipientTokenAccount.IsZero() {
+		missing = append(missing, "protocol_fee_recipient_token_account")
+	}
+	if a.BaseTokenProgram.IsZero() {
+		missing = append(missing, "base_token_program")
+	}
+	if a.QuoteTokenProgram.IsZero() {
+		missing = append(missing, "quote_token_program")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.CoinCreatorVaultAta.IsZero() {
+		missing = append(missing, "coin_creator_vault_ata")
+	}
+	if a.CoinCreatorVaultAuthority.IsZero() {
+		missing = append(missing, "coin_creator_vault_authority")
+	}
+	if a.GlobalVolumeAccumulator.IsZero() {
+		missing = append(missing, "global_volume_accumulator")
+	}
+	if a.UserVolumeAccumulator.IsZero() {
+		missing = append(missing, "user_volume_accumulator")
+	}
+	if a.FeeConfig.IsZero() {
+		missing = append(missing, "fee_config")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("buy: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildBuy(accounts BuyAccounts, args BuyArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(BuyDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -305,51 +464,118 @@ type BuyExactQuoteInAccounts struct {
 	FeeProgram                       solana.PublicKey
 }
 
+var defaultBuyExactQuoteInSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
+var defaultBuyExactQuoteInAssociatedTokenProgram = solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+
 func (a BuyExactQuoteInAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 23)
-	metas = append(metas, solana.NewAccountMeta(a.Pool, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.User, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalConfig, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.BaseMint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.QuoteMint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserBaseTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserQuoteTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolBaseTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolQuoteTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.ProtocolFeeRecipient, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.ProtocolFeeRecipientTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.BaseTokenProgram, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.QuoteTokenProgram, false, false))
-	var defaultBuyExactQuoteInSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 23))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a BuyExactQuoteInAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Pool, true, false)
+	dst = appendAccountMeta(dst, a.User, true, true)
+	dst = appendAccountMeta(dst, a.GlobalConfig, false, false)
+	dst = appendAccountMeta(dst, a.BaseMint, false, false)
+	dst = appendAccountMeta(dst, a.QuoteMint, false, false)
+	dst = appendAccountMeta(dst, a.UserBaseTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.UserQuoteTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolBaseTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolQuoteTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.ProtocolFeeRecipient, false, false)
+	dst = appendAccountMeta(dst, a.ProtocolFeeRecipientTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.BaseTokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.QuoteTokenProgram, false, false)
+	dst = appendAccountMeta(dst, defaultBuyExactQuoteInSystemProgram, false, false)
+	dst = appendAccountMeta(dst, defaultBuyExactQuoteInAssociatedTokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, ProgramKey, false, false)
+	dst = appendAccountMeta(dst, a.CoinCreatorVaultAta, true, false)
+	dst = appendAccountMeta(dst, a.CoinCreatorVaultAuthority, false, false)
+	dst = appendAccountMeta(dst, a.GlobalVolumeAccumulator, false, false)
+	dst = appendAccountMeta(dst, a.UserVolumeAccumulator, true, false)
+	dst = appendAccountMeta(dst, a.FeeConfig, false, false)
+	dst = appendAccountMeta(dst, PumpFeesProgramKey, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a BuyExactQuoteInAccounts) Validate() error {
+	var missing []string
+	if a.Pool.IsZero() {
+		missing = append(missing, "pool")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultBuyExactQuoteInSystemProgram(), false, false))
-	var defaultBuyExactQuoteInAssociatedTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	if a.User.IsZero() {
+		missing = append(missing, "user")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultBuyExactQuoteInAssociatedTokenProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	var defaultBuyExactQuoteInProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("pAMMBay6oceH9fJKBRHGP5D4bD4sWpmSwMn52FMfXEA")
+	if a.GlobalConfig.IsZero() {
+		missing = append(missing, "global_config")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultBuyExactQuoteInProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.CoinCreatorVaultAta, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.CoinCreatorVaultAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalVolumeAccumulator, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserVolumeAccumulator, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.FeeConfig, false, false))
-	var defaultBuyExactQuoteInFeeProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("pfeeUxB6jkeY1Hxd7CsFCAjcbHA9rWtchMGdZ6VojVZ")
+	if a.BaseMint.IsZero() {
+		missing = append(missing, "base_mint")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultBuyExactQuoteInFeeProgram(), false, false))
-	return metas
+	if a.QuoteMint.IsZero() {
+		missing = append(missing, "quote_mint")
+	}
+	if a.UserBaseTokenAccount.IsZero() {
+		missing = append(missing, "user_base_token_account")
+	}
+	if a.UserQuoteTokenAccount.IsZero() {
+		missing = append(missing, "user_quote_token_account")
+	}
+	if a.PoolBaseTokenAccount.IsZero() {
+		missing = append(missing, "pool_base_token_account")
+	}
+	if a.PoolQuoteTokenAccount.IsZero() {
+		missing = append(missing, "pool_quote_token_account")
+	}
+	if a.ProtocolFeeRecipient.IsZero() {
+		missing = append(missing, "protocol_fee_recipient")
+	}
+	if a.ProtocolFeeRecipientTokenAccount.IsZero() {
+		missing = append(missing, "protocol_fee_recipient_token_account")
+	}
+	if a.BaseTokenProgram.IsZero() {
+		missing = append(missing, "base_token_program")
+	}
+	if a.QuoteTokenProgram.IsZero() {
+		missing = append(missing, "quote_token_program")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.CoinCreatorVaultAta.IsZero() {
+		missing = append(missing, "coin_creator_vault_ata")
+	}
+	if a.CoinCreatorVaultAuthority.IsZero() {
+		missing = append(missing, "coin_creator_vault_authority")
+	}
+	if a.GlobalVolumeAccumulator.IsZero() {
+		missing = append(missing, "global_volume_accumulator")
+	}
+	if a.UserVolumeAccumulator.IsZero() {
+		missing = append(missing, "user_volume_accumulator")
+	}
+	if a.FeeConfig.IsZero() {
+		missing = append(missing, "fee_config")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("buy_exact_quote_in: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildBuyExactQuoteIn(accounts BuyExactQuoteInAccounts, args BuyExactQuoteInArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(BuyExactQuoteInDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -427,36 +653,77 @@ type ClaimTokenIncentivesAccounts struct {
 	Payer                       solana.PublicKey
 }
 
+var defaultClaimTokenIncentivesSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
+var defaultClaimTokenIncentivesAssociatedTokenProgram = solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+
 func (a ClaimTokenIncentivesAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 12)
-	metas = append(metas, solana.NewAccountMeta(a.User, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserAta, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalVolumeAccumulator, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalIncentiveTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserVolumeAccumulator, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Mint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.TokenProgram, false, false))
-	var defaultClaimTokenIncentivesSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 12))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a ClaimTokenIncentivesAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.User, false, false)
+	dst = appendAccountMeta(dst, a.UserAta, true, false)
+	dst = appendAccountMeta(dst, a.GlobalVolumeAccumulator, false, false)
+	dst = appendAccountMeta(dst, a.GlobalIncentiveTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.UserVolumeAccumulator, true, false)
+	dst = appendAccountMeta(dst, a.Mint, false, false)
+	dst = appendAccountMeta(dst, a.TokenProgram, false, false)
+	dst = appendAccountMeta(dst, defaultClaimTokenIncentivesSystemProgram, false, false)
+	dst = appendAccountMeta(dst, defaultClaimTokenIncentivesAssociatedTokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, ProgramKey, false, false)
+	dst = appendAccountMeta(dst, a.Payer, true, true)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a ClaimTokenIncentivesAccounts) Validate() error {
+	var missing []string
+	if a.User.IsZero() {
+		missing = append(missing, "user")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultClaimTokenIncentivesSystemProgram(), false, false))
-	var defaultClaimTokenIncentivesAssociatedTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	if a.UserAta.IsZero() {
+		missing = append(missing, "user_ata")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultClaimTokenIncentivesAssociatedTokenProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	var defaultClaimTokenIncentivesProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("pAMMBay6oceH9fJKBRHGP5D4bD4sWpmSwMn52FMfXEA")
+	if a.GlobalVolumeAccumulator.IsZero() {
+		missing = append(missing, "global_volume_accumulator")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultClaimTokenIncentivesProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Payer, true, true))
-	return metas
+	if a.GlobalIncentiveTokenAccount.IsZero() {
+		missing = append(missing, "global_incentive_token_account")
+	}
+	if a.UserVolumeAccumulator.IsZero() {
+		missing = append(missing, "user_volume_accumulator")
+	}
+	if a.Mint.IsZero() {
+		missing = append(missing, "mint")
+	}
+	if a.TokenProgram.IsZero() {
+		missing = append(missing, "token_program")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Payer.IsZero() {
+		missing = append(missing, "payer")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("claim_token_incentives: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildClaimTokenIncentives(accounts ClaimTokenIncentivesAccounts, args ClaimTokenIncentivesArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(ClaimTokenIncentivesDiscriminator)
 	data := buf.Bytes()
@@ -510,15 +777,49 @@ type CloseUserVolumeAccumulatorAccounts struct {
 }
 
 func (a CloseUserVolumeAccumulatorAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 4)
-	metas = append(metas, solana.NewAccountMeta(a.User, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.UserVolumeAccumulator, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 4))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a CloseUserVolumeAccumulatorAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.User, true, true)
+	dst = appendAccountMeta(dst, a.UserVolumeAccumulator, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a CloseUserVolumeAccumulatorAccounts) Validate() error {
+	var missing []string
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.UserVolumeAccumulator.IsZero() {
+		missing = append(missing, "user_volume_accumulator")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("close_user_volume_accumulator: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildCloseUserVolumeAccumulator(accounts CloseUserVolumeAccumulatorAccounts, args CloseUserVolumeAccumulatorArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(CloseUserVolumeAccumulatorDiscriminator)
 	data := buf.Bytes()
@@ -554,19 +855,65 @@ type CollectCoinCreatorFeeAccounts struct {
 }
 
 func (a CollectCoinCreatorFeeAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 8)
-	metas = append(metas, solana.NewAccountMeta(a.QuoteMint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.QuoteTokenProgram, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.CoinCreator, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.CoinCreatorVaultAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.CoinCreatorVaultAta, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.CoinCreatorTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 8))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a CollectCoinCreatorFeeAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.QuoteMint, false, false)
+	dst = appendAccountMeta(dst, a.QuoteTokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.CoinCreator, false, false)
+	dst = appendAccountMeta(dst, a.CoinCreatorVaultAuthority, false, false)
+	dst = appendAccountMeta(dst, a.CoinCreatorVaultAta, true, false)
+	dst = appendAccountMeta(dst, a.CoinCreatorTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a CollectCoinCreatorFeeAccounts) Validate() error {
+	var missing []string
+	if a.QuoteMint.IsZero() {
+		missing = append(missing, "quote_mint")
+	}
+	if a.QuoteTokenProgram.IsZero() {
+		missing = append(missing, "quote_token_program")
+	}
+	if a.CoinCreator.IsZero() {
+		missing = append(missing, "coin_creator")
+	}
+	if a.CoinCreatorVaultAuthority.IsZero() {
+		missing = append(missing, "coin_creator_vault_authority")
+	}
+	if a.CoinCreatorVaultAta.IsZero() {
+		missing = append(missing, "coin_creator_vault_ata")
+	}
+	if a.CoinCreatorTokenAccount.IsZero() {
+		missing = append(missing, "coin_creator_token_account")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("collect_coin_creator_fee: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildCollectCoinCreatorFee(accounts CollectCoinCreatorFeeAccounts, args CollectCoinCreatorFeeArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(CollectCoinCreatorFeeDiscriminator)
 	data := buf.Bytes()
@@ -612,25 +959,52 @@ type CreateConfigAccounts struct {
 	Program        solana.PublicKey
 }
 
+var defaultCreateConfigAdmin = solana.MustPublicKeyFromBase58("8LWu7QM2dGR1G8nKDHthckea57bkCzXyBTAKPJUBDHo8")
+
+var defaultCreateConfigSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
 func (a CreateConfigAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 5)
-	var defaultCreateConfigAdmin = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("8LWu7QM2dGR1G8nKDHthckea57bkCzXyBTAKPJUBDHo8")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 5))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a CreateConfigAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, defaultCreateConfigAdmin, true, false)
+	dst = appendAccountMeta(dst, a.GlobalConfig, true, false)
+	dst = appendAccountMeta(dst, defaultCreateConfigSystemProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a CreateConfigAccounts) Validate() error {
+	var missing []string
+	if a.GlobalConfig.IsZero() {
+		missing = append(missing, "global_config")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCreateConfigAdmin(), true, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalConfig, true, false))
-	var defaultCreateConfigSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCreateConfigSystemProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("create_config: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildCreateConfig(accounts CreateConfigAccounts, args CreateConfigArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(CreateConfigDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -683,42 +1057,103 @@ type CreatePoolAccounts struct {
 	Program                solana.PublicKey
 }
 
+var defaultCreatePoolSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
+var defaultCreatePoolToken2022Program = solana.MustPublicKeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+
+var defaultCreatePoolAssociatedTokenProgram = solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+
 func (a CreatePoolAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 18)
-	metas = append(metas, solana.NewAccountMeta(a.Pool, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalConfig, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Creator, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.BaseMint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.QuoteMint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.LpMint, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserBaseTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserQuoteTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserPoolTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolBaseTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolQuoteTokenAccount, true, false))
-	var defaultCreatePoolSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 18))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a CreatePoolAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Pool, true, false)
+	dst = appendAccountMeta(dst, a.GlobalConfig, false, false)
+	dst = appendAccountMeta(dst, a.Creator, true, true)
+	dst = appendAccountMeta(dst, a.BaseMint, false, false)
+	dst = appendAccountMeta(dst, a.QuoteMint, false, false)
+	dst = appendAccountMeta(dst, a.LpMint, true, false)
+	dst = appendAccountMeta(dst, a.UserBaseTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.UserQuoteTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.UserPoolTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolBaseTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolQuoteTokenAccount, true, false)
+	dst = appendAccountMeta(dst, defaultCreatePoolSystemProgram, false, false)
+	dst = appendAccountMeta(dst, defaultCreatePoolToken2022Program, false, false)
+	dst = appendAccountMeta(dst, a.BaseTokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.QuoteTokenProgram, false, false)
+	dst = appendAccountMeta(dst, defaultCreatePoolAssociatedTokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a CreatePoolAccounts) Validate() error {
+	var missing []string
+	if a.Pool.IsZero() {
+		missing = append(missing, "pool")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCreatePoolSystemProgram(), false, false))
-	var defaultCreatePoolToken2022Program = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+	if a.GlobalConfig.IsZero() {
+		missing = append(missing, "global_config")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCreatePoolToken2022Program(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.BaseTokenProgram, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.QuoteTokenProgram, false, false))
-	var defaultCreatePoolAssociatedTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	if a.Creator.IsZero() {
+		missing = append(missing, "creator")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultCreatePoolAssociatedTokenProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.BaseMint.IsZero() {
+		missing = append(missing, "base_mint")
+	}
+	if a.QuoteMint.IsZero() {
+		missing = append(missing, "quote_mint")
+	}
+	if a.LpMint.IsZero() {
+		missing = append(missing, "lp_mint")
+	}
+	if a.UserBaseTokenAccount.IsZero() {
+		missing = append(missing, "user_base_token_account")
+	}
+	if a.UserQuoteTokenAccount.IsZero() {
+		missing = append(missing, "user_quote_token_account")
+	}
+	if a.UserPoolTokenAccount.IsZero() {
+		missing = append(missing, "user_pool_token_account")
+	}
+	if a.PoolBaseTokenAccount.IsZero() {
+		missing = append(missing, "pool_base_token_account")
+	}
+	if a.PoolQuoteTokenAccount.IsZero() {
+		missing = append(missing, "pool_quote_token_account")
+	}
+	if a.BaseTokenProgram.IsZero() {
+		missing = append(missing, "base_token_program")
+	}
+	if a.QuoteTokenProgram.IsZero() {
+		missing = append(missing, "quote_token_program")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("create_pool: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildCreatePool(accounts CreatePoolAccounts, args CreatePoolArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(CreatePoolDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -805,35 +1240,92 @@ type DepositAccounts struct {
 	Program               solana.PublicKey
 }
 
+var defaultDepositTokenProgram = solana.MustPublicKeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+var defaultDepositToken2022Program = solana.MustPublicKeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+
 func (a DepositAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 15)
-	metas = append(metas, solana.NewAccountMeta(a.Pool, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalConfig, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.User, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.BaseMint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.QuoteMint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.LpMint, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserBaseTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserQuoteTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserPoolTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolBaseTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolQuoteTokenAccount, true, false))
-	var defaultDepositTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 15))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a DepositAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Pool, true, false)
+	dst = appendAccountMeta(dst, a.GlobalConfig, false, false)
+	dst = appendAccountMeta(dst, a.User, false, true)
+	dst = appendAccountMeta(dst, a.BaseMint, false, false)
+	dst = appendAccountMeta(dst, a.QuoteMint, false, false)
+	dst = appendAccountMeta(dst, a.LpMint, true, false)
+	dst = appendAccountMeta(dst, a.UserBaseTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.UserQuoteTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.UserPoolTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolBaseTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolQuoteTokenAccount, true, false)
+	dst = appendAccountMeta(dst, defaultDepositTokenProgram, false, false)
+	dst = appendAccountMeta(dst, defaultDepositToken2022Program, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a DepositAccounts) Validate() error {
+	var missing []string
+	if a.Pool.IsZero() {
+		missing = append(missing, "pool")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultDepositTokenProgram(), false, false))
-	var defaultDepositToken2022Program = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+	if a.GlobalConfig.IsZero() {
+		missing = append(missing, "global_config")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultDepositToken2022Program(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.BaseMint.IsZero() {
+		missing = append(missing, "base_mint")
+	}
+	if a.QuoteMint.IsZero() {
+		missing = append(missing, "quote_mint")
+	}
+	if a.LpMint.IsZero() {
+		missing = append(missing, "lp_mint")
+	}
+	if a.UserBaseTokenAccount.IsZero() {
+		missing = append(missing, "user_base_token_account")
+	}
+	if a.UserQuoteTokenAccount.IsZero() {
+		missing = append(missing, "user_quote_token_account")
+	}
+	if a.UserPoolTokenAccount.IsZero() {
+		missing = append(missing, "user_pool_token_account")
+	}
+	if a.PoolBaseTokenAccount.IsZero() {
+		missing = append(missing, "pool_base_token_account")
+	}
+	if a.PoolQuoteTokenAccount.IsZero() {
+		missing = append(missing, "pool_quote_token_account")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("deposit: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildDeposit(accounts DepositAccounts, args DepositArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(DepositDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -867,15 +1359,49 @@ type DisableAccounts struct {
 }
 
 func (a DisableAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 4)
-	metas = append(metas, solana.NewAccountMeta(a.Admin, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalConfig, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 4))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a DisableAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Admin, false, true)
+	dst = appendAccountMeta(dst, a.GlobalConfig, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a DisableAccounts) Validate() error {
+	var missing []string
+	if a.Admin.IsZero() {
+		missing = append(missing, "admin")
+	}
+	if a.GlobalConfig.IsZero() {
+		missing = append(missing, "global_config")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("disable: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildDisable(accounts DisableAccounts, args DisableArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(DisableDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -903,21 +1429,53 @@ type ExtendAccountAccounts struct {
 	Program        solana.PublicKey
 }
 
+var defaultExtendAccountSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
 func (a ExtendAccountAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 5)
-	metas = append(metas, solana.NewAccountMeta(a.Account, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.User, false, true))
-	var defaultExtendAccountSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 5))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a ExtendAccountAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Account, true, false)
+	dst = appendAccountMeta(dst, a.User, false, true)
+	dst = appendAccountMeta(dst, defaultExtendAccountSystemProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a ExtendAccountAccounts) Validate() error {
+	var missing []string
+	if a.Account.IsZero() {
+		missing = append(missing, "account")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultExtendAccountSystemProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("extend_account: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildExtendAccount(accounts ExtendAccountAccounts, args ExtendAccountArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(ExtendAccountDiscriminator)
 	data := buf.Bytes()
@@ -943,22 +1501,57 @@ type InitUserVolumeAccumulatorAccounts struct {
 	Program               solana.PublicKey
 }
 
+var defaultInitUserVolumeAccumulatorSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
 func (a InitUserVolumeAccumulatorAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 6)
-	metas = append(metas, solana.NewAccountMeta(a.Payer, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.User, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserVolumeAccumulator, true, false))
-	var defaultInitUserVolumeAccumulatorSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 6))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a InitUserVolumeAccumulatorAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Payer, true, true)
+	dst = appendAccountMeta(dst, a.User, false, false)
+	dst = appendAccountMeta(dst, a.UserVolumeAccumulator, true, false)
+	dst = appendAccountMeta(dst, defaultInitUserVolumeAccumulatorSystemProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a InitUserVolumeAccumulatorAccounts) Validate() error {
+	var missing []string
+	if a.Payer.IsZero() {
+		missing = append(missing, "payer")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultInitUserVolumeAccumulatorSystemProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.UserVolumeAccumulator.IsZero() {
+		missing = append(missing, "user_volume_accumulator")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("init_user_volume_accumulator: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildInitUserVolumeAccumulator(accounts InitUserVolumeAccumulatorAccounts, args InitUserVolumeAccumulatorArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(InitUserVolumeAccumulatorDiscriminator)
 	data := buf.Bytes()
@@ -1009,49 +1602,110 @@ type SellAccounts struct {
 	FeeProgram                       solana.PublicKey
 }
 
+var defaultSellSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
+var defaultSellAssociatedTokenProgram = solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+
 func (a SellAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 21)
-	metas = append(metas, solana.NewAccountMeta(a.Pool, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.User, true, true))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalConfig, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.BaseMint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.QuoteMint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserBaseTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserQuoteTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolBaseTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolQuoteTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.ProtocolFeeRecipient, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.ProtocolFeeRecipientTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.BaseTokenProgram, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.QuoteTokenProgram, false, false))
-	var defaultSellSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 21))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a SellAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Pool, true, false)
+	dst = appendAccountMeta(dst, a.User, true, true)
+	dst = appendAccountMeta(dst, a.GlobalConfig, false, false)
+	dst = appendAccountMeta(dst, a.BaseMint, false, false)
+	dst = appendAccountMeta(dst, a.QuoteMint, false, false)
+	dst = appendAccountMeta(dst, a.UserBaseTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.UserQuoteTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolBaseTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolQuoteTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.ProtocolFeeRecipient, false, false)
+	dst = appendAccountMeta(dst, a.ProtocolFeeRecipientTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.BaseTokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.QuoteTokenProgram, false, false)
+	dst = appendAccountMeta(dst, defaultSellSystemProgram, false, false)
+	dst = appendAccountMeta(dst, defaultSellAssociatedTokenProgram, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, ProgramKey, false, false)
+	dst = appendAccountMeta(dst, a.CoinCreatorVaultAta, true, false)
+	dst = appendAccountMeta(dst, a.CoinCreatorVaultAuthority, false, false)
+	dst = appendAccountMeta(dst, a.FeeConfig, false, false)
+	dst = appendAccountMeta(dst, PumpFeesProgramKey, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a SellAccounts) Validate() error {
+	var missing []string
+	if a.Pool.IsZero() {
+		missing = append(missing, "pool")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultSellSystemProgram(), false, false))
-	var defaultSellAssociatedTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	if a.User.IsZero() {
+		missing = append(missing, "user")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultSellAssociatedTokenProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	var defaultSellProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("pAMMBay6oceH9fJKBRHGP5D4bD4sWpmSwMn52FMfXEA")
+	if a.GlobalConfig.IsZero() {
+		missing = append(missing, "global_config")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultSellProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.CoinCreatorVaultAta, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.CoinCreatorVaultAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.FeeConfig, false, false))
-	var defaultSellFeeProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("pfeeUxB6jkeY1Hxd7CsFCAjcbHA9rWtchMGdZ6VojVZ")
+	if a.BaseMint.IsZero() {
+		missing = append(missing, "base_mint")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultSellFeeProgram(), false, false))
-	return metas
+	if a.QuoteMint.IsZero() {
+		missing = append(missing, "quote_mint")
+	}
+	if a.UserBaseTokenAccount.IsZero() {
+		missing = append(missing, "user_base_token_account")
+	}
+	if a.UserQuoteTokenAccount.IsZero() {
+		missing = append(missing, "user_quote_token_account")
+	}
+	if a.PoolBaseTokenAccount.IsZero() {
+		missing = append(missing, "pool_base_token_account")
+	}
+	if a.PoolQuoteTokenAccount.IsZero() {
+		missing = append(missing, "pool_quote_token_account")
+	}
+	if a.ProtocolFeeRecipient.IsZero() {
+		missing = append(missing, "protocol_fee_recipient")
+	}
+	if a.ProtocolFeeRecipientTokenAccount.IsZero() {
+		missing = append(missing, "protocol_fee_recipient_token_account")
+	}
+	if a.BaseTokenProgram.IsZero() {
+		missing = append(missing, "base_token_program")
+	}
+	if a.QuoteTokenProgram.IsZero() {
+		missing = append(missing, "quote_token_program")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.CoinCreatorVaultAta.IsZero() {
+		missing = append(missing, "coin_creator_vault_ata")
+	}
+	if a.CoinCreatorVaultAuthority.IsZero() {
+		missing = append(missing, "coin_creator_vault_authority")
+	}
+	if a.FeeConfig.IsZero() {
+		missing = append(missing, "fee_config")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("sell: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildSell(accounts SellAccounts, args SellArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(SellDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -1110,16 +1764,53 @@ type SetCoinCreatorAccounts struct {
 }
 
 func (a SetCoinCreatorAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 5)
-	metas = append(metas, solana.NewAccountMeta(a.Pool, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Metadata, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.BondingCurve, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 5))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a SetCoinCreatorAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Pool, true, false)
+	dst = appendAccountMeta(dst, a.Metadata, false, false)
+	dst = appendAccountMeta(dst, a.BondingCurve, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a SetCoinCreatorAccounts) Validate() error {
+	var missing []string
+	if a.Pool.IsZero() {
+		missing = append(missing, "pool")
+	}
+	if a.Metadata.IsZero() {
+		missing = append(missing, "metadata")
+	}
+	if a.BondingCurve.IsZero() {
+		missing = append(missing, "bonding_curve")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("set_coin_creator: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildSetCoinCreator(accounts SetCoinCreatorAccounts, args SetCoinCreatorArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(SetCoinCreatorDiscriminator)
 	data := buf.Bytes()
@@ -1138,7 +1829,7 @@ func DeriveSetCoinCreatorBondingCurvePDA(accounts SetCoinCreatorAccounts, args S
 	seeds := make([][]byte, 0, 2)
 	seeds = append(seeds, []byte{98, 111, 110, 100, 105, 110, 103, 45, 99, 117, 114, 118, 101})
 	seeds = append(seeds, accounts.Pool[:])
-	return solana.FindProgramAddress(seeds, solana.PublicKeyFromBytes([]byte{1, 86, 224, 246, 147, 102, 90, 207, 68, 219, 21, 104, 191, 23, 91, 170, 81, 137, 203, 151, 245, 210, 255, 59, 101, 93, 43, 182, 253, 109, 24, 176}))
+	return solana.FindProgramAddress(seeds, PumpProgramKey)
 }
 
 func DeriveSetCoinCreatorEventAuthorityPDA(accounts SetCoinCreatorAccounts, args SetCoinCreatorArgs) (solana.PublicKey, uint8, error) {
@@ -1161,15 +1852,49 @@ type SetReservedFeeRecipientsAccounts struct {
 }
 
 func (a SetReservedFeeRecipientsAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 4)
-	metas = append(metas, solana.NewAccountMeta(a.GlobalConfig, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Admin, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 4))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a SetReservedFeeRecipientsAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.GlobalConfig, true, false)
+	dst = appendAccountMeta(dst, a.Admin, false, true)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a SetReservedFeeRecipientsAccounts) Validate() error {
+	var missing []string
+	if a.GlobalConfig.IsZero() {
+		missing = append(missing, "global_config")
+	}
+	if a.Admin.IsZero() {
+		missing = append(missing, "admin")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("set_reserved_fee_recipients: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildSetReservedFeeRecipients(accounts SetReservedFeeRecipientsAccounts, args SetReservedFeeRecipientsArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(SetReservedFeeRecipientsDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -1204,16 +1929,53 @@ type SyncUserVolumeAccumulatorAccounts struct {
 }
 
 func (a SyncUserVolumeAccumulatorAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 5)
-	metas = append(metas, solana.NewAccountMeta(a.User, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalVolumeAccumulator, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserVolumeAccumulator, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 5))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a SyncUserVolumeAccumulatorAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.User, false, false)
+	dst = appendAccountMeta(dst, a.GlobalVolumeAccumulator, false, false)
+	dst = appendAccountMeta(dst, a.UserVolumeAccumulator, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a SyncUserVolumeAccumulatorAccounts) Validate() error {
+	var missing []string
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.GlobalVolumeAccumulator.IsZero() {
+		missing = append(missing, "global_volume_accumulator")
+	}
+	if a.UserVolumeAccumulator.IsZero() {
+		missing = append(missing, "user_volume_accumulator")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("sync_user_volume_accumulator: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildSyncUserVolumeAccumulator(accounts SyncUserVolumeAccumulatorAccounts, args SyncUserVolumeAccumulatorArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(SyncUserVolumeAccumulatorDiscriminator)
 	data := buf.Bytes()
@@ -1253,15 +2015,49 @@ type ToggleMayhemModeAccounts struct {
 }
 
 func (a ToggleMayhemModeAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 4)
-	metas = append(metas, solana.NewAccountMeta(a.Admin, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalConfig, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 4))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a ToggleMayhemModeAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Admin, false, true)
+	dst = appendAccountMeta(dst, a.GlobalConfig, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a ToggleMayhemModeAccounts) Validate() error {
+	var missing []string
+	if a.Admin.IsZero() {
+		missing = append(missing, "admin")
+	}
+	if a.GlobalConfig.IsZero() {
+		missing = append(missing, "global_config")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("toggle_mayhem_mode: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildToggleMayhemMode(accounts ToggleMayhemModeAccounts, args ToggleMayhemModeArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(ToggleMayhemModeDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -1290,16 +2086,53 @@ type UpdateAdminAccounts struct {
 }
 
 func (a UpdateAdminAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 5)
-	metas = append(metas, solana.NewAccountMeta(a.Admin, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalConfig, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.NewAdmin, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 5))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a UpdateAdminAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Admin, false, true)
+	dst = appendAccountMeta(dst, a.GlobalConfig, true, false)
+	dst = appendAccountMeta(dst, a.NewAdmin, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a UpdateAdminAccounts) Validate() error {
+	var missing []string
+	if a.Admin.IsZero() {
+		missing = append(missing, "admin")
+	}
+	if a.GlobalConfig.IsZero() {
+		missing = append(missing, "global_config")
+	}
+	if a.NewAdmin.IsZero() {
+		missing = append(missing, "new_admin")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("update_admin: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildUpdateAdmin(accounts UpdateAdminAccounts, args UpdateAdminArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(UpdateAdminDiscriminator)
 	data := buf.Bytes()
@@ -1330,15 +2163,49 @@ type UpdateFeeConfigAccounts struct {
 }
 
 func (a UpdateFeeConfigAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 4)
-	metas = append(metas, solana.NewAccountMeta(a.Admin, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalConfig, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 4))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a UpdateFeeConfigAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Admin, false, true)
+	dst = appendAccountMeta(dst, a.GlobalConfig, true, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a UpdateFeeConfigAccounts) Validate() error {
+	var missing []string
+	if a.Admin.IsZero() {
+		missing = append(missing, "admin")
+	}
+	if a.GlobalConfig.IsZero() {
+		missing = append(missing, "global_config")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("update_fee_config: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildUpdateFeeConfig(accounts UpdateFeeConfigAccounts, args UpdateFeeConfigArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(UpdateFeeConfigDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -1380,35 +2247,92 @@ type WithdrawAccounts struct {
 	Program               solana.PublicKey
 }
 
+var defaultWithdrawTokenProgram = solana.MustPublicKeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+var defaultWithdrawToken2022Program = solana.MustPublicKeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+
 func (a WithdrawAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 15)
-	metas = append(metas, solana.NewAccountMeta(a.Pool, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.GlobalConfig, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.User, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.BaseMint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.QuoteMint, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.LpMint, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserBaseTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserQuoteTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.UserPoolTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolBaseTokenAccount, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.PoolQuoteTokenAccount, true, false))
-	var defaultWithdrawTokenProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 15))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a WithdrawAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Pool, true, false)
+	dst = appendAccountMeta(dst, a.GlobalConfig, false, false)
+	dst = appendAccountMeta(dst, a.User, false, true)
+	dst = appendAccountMeta(dst, a.BaseMint, false, false)
+	dst = appendAccountMeta(dst, a.QuoteMint, false, false)
+	dst = appendAccountMeta(dst, a.LpMint, true, false)
+	dst = appendAccountMeta(dst, a.UserBaseTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.UserQuoteTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.UserPoolTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolBaseTokenAccount, true, false)
+	dst = appendAccountMeta(dst, a.PoolQuoteTokenAccount, true, false)
+	dst = appendAccountMeta(dst, defaultWithdrawTokenProgram, false, false)
+	dst = appendAccountMeta(dst, defaultWithdrawToken2022Program, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a WithdrawAccounts) Validate() error {
+	var missing []string
+	if a.Pool.IsZero() {
+		missing = append(missing, "pool")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultWithdrawTokenProgram(), false, false))
-	var defaultWithdrawToken2022Program = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+	if a.GlobalConfig.IsZero() {
+		missing = append(missing, "global_config")
 	}
-
-	metas = append(metas, solana.NewAccountMeta(defaultWithdrawToken2022Program(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	if a.User.IsZero() {
+		missing = append(missing, "user")
+	}
+	if a.BaseMint.IsZero() {
+		missing = append(missing, "base_mint")
+	}
+	if a.QuoteMint.IsZero() {
+		missing = append(missing, "quote_mint")
+	}
+	if a.LpMint.IsZero() {
+		missing = append(missing, "lp_mint")
+	}
+	if a.UserBaseTokenAccount.IsZero() {
+		missing = append(missing, "user_base_token_account")
+	}
+	if a.UserQuoteTokenAccount.IsZero() {
+		missing = append(missing, "user_quote_token_account")
+	}
+	if a.UserPoolTokenAccount.IsZero() {
+		missing = append(missing, "user_pool_token_account")
+	}
+	if a.PoolBaseTokenAccount.IsZero() {
+		missing = append(missing, "pool_base_token_account")
+	}
+	if a.PoolQuoteTokenAccount.IsZero() {
+		missing = append(missing, "pool_quote_token_account")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("withdraw: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildWithdraw(accounts WithdrawAccounts, args WithdrawArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(WithdrawDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
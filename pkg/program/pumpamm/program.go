@@ -1,5 +1,5 @@
 // Code generated by internal/gen; DO NOT EDIT.
-// Generated at 2025-12-07T17:54:49Z
+// Generated at 2026-10-16T20:02:01Z
 
 package pumpamm
 
@@ -10,3 +10,11 @@ const ProgramName string = "pump_amm"
 const ProgramVersion string = "0.1.0"
 
 var ProgramKey = solana.MustPublicKeyFromBase58(ProgramID)
+
+// Other Pump programs this program's instructions pass as accounts or derive
+// PDAs under. Like ProgramKey, they may be changed to target another
+// deployment before instructions are built.
+var (
+	PumpFeesProgramKey = solana.MustPublicKeyFromBase58("pfeeUxB6jkeY1Hxd7CsFCAjcbHA9rWtchMGdZ6VojVZ")
+	PumpProgramKey     = solana.MustPublicKeyFromBase58("6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P")
+)
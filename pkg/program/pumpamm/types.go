@@ -1,5 +1,5 @@
 // Code generated by internal/gen; DO NOT EDIT.
-// Generated at 2025-12-07T17:54:49Z
+// Generated at 2026-10-16T17:42:34Z
 
 package pumpamm
 
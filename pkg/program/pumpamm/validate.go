@@ -0,0 +1,60 @@
+package pumpamm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// ValidateBuyAccounts checks that every account BuildBuy encodes from a is
+// set: none may be the zero key, and none may be the program's own key,
+// which autofill code uses as a placeholder for PDAs not derived yet. The
+// system, associated token, program and fee program accounts have fixed
+// addresses and are not checked.
+func ValidateBuyAccounts(a BuyAccounts) error {
+	var zero, placeholder []string
+	for _, f := range []struct {
+		name string
+		key  solana.PublicKey
+	}{
+		{"pool", a.Pool},
+		{"user", a.User},
+		{"global_config", a.GlobalConfig},
+		{"base_mint", a.BaseMint},
+		{"quote_mint", a.QuoteMint},
+		{"user_base_token_account", a.UserBaseTokenAccount},
+		{"user_quote_token_account", a.UserQuoteTokenAccount},
+		{"pool_base_token_account", a.PoolBaseTokenAccount},
+		{"pool_quote_token_account", a.PoolQuoteTokenAccount},
+		{"protocol_fee_recipient", a.ProtocolFeeRecipient},
+		{"protocol_fee_recipient_token_account", a.ProtocolFeeRecipientTokenAccount},
+		{"base_token_program", a.BaseTokenProgram},
+		{"quote_token_program", a.QuoteTokenProgram},
+		{"event_authority", a.EventAuthority},
+		{"coin_creator_vault_ata", a.CoinCreatorVaultAta},
+		{"coin_creator_vault_authority", a.CoinCreatorVaultAuthority},
+		{"global_volume_accumulator", a.GlobalVolumeAccumulator},
+		{"user_volume_accumulator", a.UserVolumeAccumulator},
+		{"fee_config", a.FeeConfig},
+	} {
+		switch {
+		case f.key.IsZero():
+			zero = append(zero, f.name)
+		case f.key.Equals(ProgramKey):
+			placeholder = append(placeholder, f.name)
+		}
+	}
+
+	var problems []string
+	if len(zero) > 0 {
+		problems = append(problems, "zero: "+strings.Join(zero, ", "))
+	}
+	if len(placeholder) > 0 {
+		problems = append(problems, "program key placeholder: "+strings.Join(placeholder, ", "))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid buy accounts (%s)", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package pumpamm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestValidateBuyAccounts(t *testing.T) {
+	key := func() solana.PublicKey { return solana.NewWallet().PublicKey() }
+	accts := BuyAccounts{
+		Pool: key(), User: key(), GlobalConfig: key(), BaseMint: key(), QuoteMint: key(),
+		UserBaseTokenAccount: key(), UserQuoteTokenAccount: key(), PoolBaseTokenAccount: key(), PoolQuoteTokenAccount: key(),
+		ProtocolFeeRecipient: key(), ProtocolFeeRecipientTokenAccount: key(), BaseTokenProgram: key(), QuoteTokenProgram: key(),
+		EventAuthority: key(), CoinCreatorVaultAta: key(), CoinCreatorVaultAuthority: key(),
+		GlobalVolumeAccumulator: key(), UserVolumeAccumulator: key(), FeeConfig: key(),
+	}
+	if err := ValidateBuyAccounts(accts); err != nil {
+		t.Fatalf("complete accounts: %v", err)
+	}
+
+	accts.FeeConfig = solana.PublicKey{}
+	accts.CoinCreatorVaultAuthority = ProgramKey
+	err := ValidateBuyAccounts(accts)
+	if err == nil {
+		t.Fatal("expected an error for zero and placeholder accounts")
+	}
+	for _, want := range []string{"zero: fee_config", "program key placeholder: coin_creator_vault_authority"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+}
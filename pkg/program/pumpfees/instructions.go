@@ -1,16 +1,29 @@
 // Code generated by internal/gen; DO NOT EDIT.
-// Generated at 2025-12-07T17:54:49Z
+// Generated at 2026-10-16T20:03:39Z
 
 package pumpfees
 
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
 )
 
+// appendAccountMeta appends a meta for key to dst, overwriting the meta left
+// in the spare capacity of dst by an earlier build if there is one.
+func appendAccountMeta(dst []*solana.AccountMeta, key solana.PublicKey, writable, signer bool) []*solana.AccountMeta {
+	if n := len(dst); n < cap(dst) {
+		if m := dst[:n+1][n]; m != nil {
+			m.PublicKey, m.IsWritable, m.IsSigner = key, writable, signer
+			return dst[:n+1]
+		}
+	}
+	return append(dst, solana.NewAccountMeta(key, writable, signer))
+}
+
 var GetFeesDiscriminator = []byte{231, 37, 126, 85, 207, 91, 63, 52}
 
 type GetFeesArgs struct {
@@ -25,13 +38,41 @@ type GetFeesAccounts struct {
 }
 
 func (a GetFeesAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 2)
-	metas = append(metas, solana.NewAccountMeta(a.FeeConfig, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.ConfigProgramId, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 2))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a GetFeesAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.FeeConfig, false, false)
+	dst = appendAccountMeta(dst, a.ConfigProgramId, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a GetFeesAccounts) Validate() error {
+	var missing []string
+	if a.FeeConfig.IsZero() {
+		missing = append(missing, "fee_config")
+	}
+	if a.ConfigProgramId.IsZero() {
+		missing = append(missing, "config_program_id")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("get_fees: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildGetFees(accounts GetFeesAccounts, args GetFeesArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(GetFeesDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -61,26 +102,56 @@ type InitializeFeeConfigAccounts struct {
 	Program         solana.PublicKey
 }
 
+var defaultInitializeFeeConfigAdmin = solana.MustPublicKeyFromBase58("8LWu7QM2dGR1G8nKDHthckea57bkCzXyBTAKPJUBDHo8")
+
+var defaultInitializeFeeConfigSystemProgram = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+
 func (a InitializeFeeConfigAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 6)
-	var defaultInitializeFeeConfigAdmin = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("8LWu7QM2dGR1G8nKDHthckea57bkCzXyBTAKPJUBDHo8")
-	}
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 6))
+}
 
-	metas = append(metas, solana.NewAccountMeta(defaultInitializeFeeConfigAdmin(), true, false))
-	metas = append(metas, solana.NewAccountMeta(a.FeeConfig, true, false))
-	var defaultInitializeFeeConfigSystemProgram = func() solana.PublicKey {
-		return solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
-	}
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a InitializeFeeConfigAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, defaultInitializeFeeConfigAdmin, true, false)
+	dst = appendAccountMeta(dst, a.FeeConfig, true, false)
+	dst = appendAccountMeta(dst, defaultInitializeFeeConfigSystemProgram, false, false)
+	dst = appendAccountMeta(dst, a.ConfigProgramId, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
 
-	metas = append(metas, solana.NewAccountMeta(defaultInitializeFeeConfigSystemProgram(), false, false))
-	metas = append(metas, solana.NewAccountMeta(a.ConfigProgramId, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+// Validate returns an error naming every required account that is the zero key.
+func (a InitializeFeeConfigAccounts) Validate() error {
+	var missing []string
+	if a.FeeConfig.IsZero() {
+		missing = append(missing, "fee_config")
+	}
+	if a.ConfigProgramId.IsZero() {
+		missing = append(missing, "config_program_id")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("initialize_fee_config: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildInitializeFeeConfig(accounts InitializeFeeConfigAccounts, args InitializeFeeConfigArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(InitializeFeeConfigDiscriminator)
 	data := buf.Bytes()
@@ -114,17 +185,57 @@ type UpdateAdminAccounts struct {
 }
 
 func (a UpdateAdminAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 6)
-	metas = append(metas, solana.NewAccountMeta(a.Admin, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.FeeConfig, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.NewAdmin, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.ConfigProgramId, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 6))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a UpdateAdminAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.Admin, false, true)
+	dst = appendAccountMeta(dst, a.FeeConfig, true, false)
+	dst = appendAccountMeta(dst, a.NewAdmin, false, false)
+	dst = appendAccountMeta(dst, a.ConfigProgramId, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a UpdateAdminAccounts) Validate() error {
+	var missing []string
+	if a.Admin.IsZero() {
+		missing = append(missing, "admin")
+	}
+	if a.FeeConfig.IsZero() {
+		missing = append(missing, "fee_config")
+	}
+	if a.NewAdmin.IsZero() {
+		missing = append(missing, "new_admin")
+	}
+	if a.ConfigProgramId.IsZero() {
+		missing = append(missing, "config_program_id")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("update_admin: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildUpdateAdmin(accounts UpdateAdminAccounts, args UpdateAdminArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(UpdateAdminDiscriminator)
 	data := buf.Bytes()
@@ -160,16 +271,53 @@ type UpdateFeeConfigAccounts struct {
 }
 
 func (a UpdateFeeConfigAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 5)
-	metas = append(metas, solana.NewAccountMeta(a.FeeConfig, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Admin, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.ConfigProgramId, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 5))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a UpdateFeeConfigAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.FeeConfig, true, false)
+	dst = appendAccountMeta(dst, a.Admin, false, true)
+	dst = appendAccountMeta(dst, a.ConfigProgramId, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a UpdateFeeConfigAccounts) Validate() error {
+	var missing []string
+	if a.FeeConfig.IsZero() {
+		missing = append(missing, "fee_config")
+	}
+	if a.Admin.IsZero() {
+		missing = append(missing, "admin")
+	}
+	if a.ConfigProgramId.IsZero() {
+		missing = append(missing, "config_program_id")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("update_fee_config: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildUpdateFeeConfig(accounts UpdateFeeConfigAccounts, args UpdateFeeConfigArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(UpdateFeeConfigDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -208,16 +356,53 @@ type UpsertFeeTiersAccounts struct {
 }
 
 func (a UpsertFeeTiersAccounts) ToAccountMetas() []*solana.AccountMeta {
-	metas := make([]*solana.AccountMeta, 0, 5)
-	metas = append(metas, solana.NewAccountMeta(a.FeeConfig, true, false))
-	metas = append(metas, solana.NewAccountMeta(a.Admin, false, true))
-	metas = append(metas, solana.NewAccountMeta(a.ConfigProgramId, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.EventAuthority, false, false))
-	metas = append(metas, solana.NewAccountMeta(a.Program, false, false))
-	return metas
+	return a.AppendAccountMetas(make([]*solana.AccountMeta, 0, 5))
+}
+
+// AppendAccountMetas appends the account metas to dst and returns the
+// extended slice. Metas already in the spare capacity of dst are
+// overwritten in place rather than allocated, so building repeatedly into
+// metas[:0] does not allocate. The caller owns dst and its metas: an
+// instruction built from an earlier result, or any copy of its meta
+// pointers, changes when dst is reused, so finish with it (sign and
+// serialize) first. ToAccountMetas always returns new metas.
+func (a UpsertFeeTiersAccounts) AppendAccountMetas(dst []*solana.AccountMeta) []*solana.AccountMeta {
+	dst = appendAccountMeta(dst, a.FeeConfig, true, false)
+	dst = appendAccountMeta(dst, a.Admin, false, true)
+	dst = appendAccountMeta(dst, a.ConfigProgramId, false, false)
+	dst = appendAccountMeta(dst, a.EventAuthority, false, false)
+	dst = appendAccountMeta(dst, a.Program, false, false)
+	return dst
+}
+
+// Validate returns an error naming every required account that is the zero key.
+func (a UpsertFeeTiersAccounts) Validate() error {
+	var missing []string
+	if a.FeeConfig.IsZero() {
+		missing = append(missing, "fee_config")
+	}
+	if a.Admin.IsZero() {
+		missing = append(missing, "admin")
+	}
+	if a.ConfigProgramId.IsZero() {
+		missing = append(missing, "config_program_id")
+	}
+	if a.EventAuthority.IsZero() {
+		missing = append(missing, "event_authority")
+	}
+	if a.Program.IsZero() {
+		missing = append(missing, "program")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("upsert_fee_tiers: missing accounts: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func BuildUpsertFeeTiers(accounts UpsertFeeTiersAccounts, args UpsertFeeTiersArgs) (solana.Instruction, error) {
+	if err := accounts.Validate(); err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(make([]byte, 0, 128))
 	buf.Write(UpsertFeeTiersDiscriminator)
 	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
@@ -0,0 +1,79 @@
+//go:build roundtrip
+
+// Code generated by internal/gen; DO NOT EDIT.
+// Generated at 2026-10-16T17:42:34Z
+
+package pumpfees
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+)
+
+// unsupportedField returns the path of the first interface{} field in t,
+// descending only into the structs of package pkg.
+func unsupportedField(t reflect.Type, pkg, path string) string {
+	switch t.Kind() {
+	case reflect.Interface:
+		return path
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return unsupportedField(t.Elem(), pkg, path)
+	case reflect.Struct:
+		if t.PkgPath() != pkg {
+			return ""
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if p := unsupportedField(t.Field(i).Type, pkg, path+"."+t.Field(i).Name); p != "" {
+				return p
+			}
+		}
+	}
+	return ""
+}
+
+func roundTrip(v, out interface{}) (err error) {
+	t := reflect.TypeOf(v).Elem()
+	if p := unsupportedField(t, t.PkgPath(), ""); p != "" {
+		return fmt.Errorf("field %s has unsupported IDL type interface{}", p[1:])
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	var buf bytes.Buffer
+	if err := bin.NewBorshEncoder(&buf).Encode(v); err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+	if err := bin.NewBorshDecoder(buf.Bytes()).Decode(out); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	if !reflect.DeepEqual(v, out) {
+		return fmt.Errorf("round trip mismatch: %#v != %#v", v, out)
+	}
+	return nil
+}
+
+func TestBorshRoundTrip(t *testing.T) {
+	cases := []struct {
+		name   string
+		v, out interface{}
+	}{
+		{"FeeConfig", &FeeConfig{}, &FeeConfig{}},
+		{"FeeTier", &FeeTier{}, &FeeTier{}},
+		{"Fees", &Fees{}, &Fees{}},
+		{"InitializeFeeConfigEvent", &InitializeFeeConfigEvent{}, &InitializeFeeConfigEvent{}},
+		{"UpdateAdminEvent", &UpdateAdminEvent{}, &UpdateAdminEvent{}},
+		{"UpdateFeeConfigEvent", &UpdateFeeConfigEvent{}, &UpdateFeeConfigEvent{}},
+		{"UpsertFeeTiersEvent", &UpsertFeeTiersEvent{}, &UpsertFeeTiersEvent{}},
+	}
+	for _, tc := range cases {
+		if err := roundTrip(tc.v, tc.out); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		}
+	}
+}
@@ -18,7 +18,6 @@ package quote
 import (
 	"context"
 	"fmt"
-	"math/big"
 
 	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
@@ -27,6 +26,7 @@ import (
 
 	"github.com/ninja0404/pump-go-sdk/pkg/autofill"
 	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/curve"
 	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
 	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
 	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
@@ -52,6 +52,10 @@ type QuoteResult struct {
 
 	// ExecutionPrice is the effective price after this trade (quote per base, scaled by 1e9).
 	ExecutionPrice uint64
+
+	// OutDecimals is the decimals of the output mint, for displaying
+	// ExpectedOut and MinOut.
+	OutDecimals uint8
 }
 
 // AmmBuyQuote estimates the token output for a given SOL input on Pump AMM.
@@ -97,6 +101,10 @@ func AmmBuyQuote(ctx context.Context, rpc *sdkrpc.Client, signer wallet.Signer,
 	}
 
 	spotPrice, execPrice, impact := calculatePriceMetrics(poolState, quoteLamports, simOut, true)
+	decimals, err := rpc.GetMintDecimals(ctx, poolState.BaseMint)
+	if err != nil {
+		return nil, fmt.Errorf("base mint decimals: %w", err)
+	}
 
 	return &QuoteResult{
 		ExpectedOut:    simOut,
@@ -104,6 +112,7 @@ func AmmBuyQuote(ctx context.Context, rpc *sdkrpc.Client, signer wallet.Signer,
 		PriceImpactBps: impact,
 		SpotPrice:      spotPrice,
 		ExecutionPrice: execPrice,
+		OutDecimals:    decimals,
 	}, nil
 }
 
@@ -154,6 +163,10 @@ func AmmSellQuote(ctx context.Context, rpc *sdkrpc.Client, signer wallet.Signer,
 	}
 
 	spotPrice, execPrice, impact := calculatePriceMetrics(poolState, quoteOut, baseAmount, false)
+	decimals, err := rpc.GetMintDecimals(ctx, poolState.QuoteMint)
+	if err != nil {
+		return nil, fmt.Errorf("quote mint decimals: %w", err)
+	}
 
 	return &QuoteResult{
 		ExpectedOut:    quoteOut,
@@ -161,6 +174,7 @@ func AmmSellQuote(ctx context.Context, rpc *sdkrpc.Client, signer wallet.Signer,
 		PriceImpactBps: impact,
 		SpotPrice:      spotPrice,
 		ExecutionPrice: execPrice,
+		OutDecimals:    decimals,
 	}, nil
 }
 
@@ -187,18 +201,7 @@ func PumpBuyQuote(ctx context.Context, rpc *sdkrpc.Client, mint solana.PublicKey
 		return 0, err
 	}
 
-	// Calculate using bonding curve formula
-	// tokens_out = (sol_in * virtual_token_reserves) / (virtual_sol_reserves + sol_in)
-	solIn := new(big.Int).SetUint64(solLamports)
-	tokenReserves := new(big.Int).SetUint64(bc.VirtualTokenReserves)
-	solReserves := new(big.Int).SetUint64(bc.VirtualSolReserves)
-
-	numerator := new(big.Int).Mul(solIn, tokenReserves)
-	denominator := new(big.Int).Add(solReserves, solIn)
-
-	tokensOut := new(big.Int).Div(numerator, denominator)
-
-	return tokensOut.Uint64(), nil
+	return curve.BuyTokensOut(curveReserves(bc), 0, solLamports), nil
 }
 
 // PumpSellQuote estimates the SOL output for a given token input on Pump bonding curve.
@@ -224,18 +227,43 @@ func PumpSellQuote(ctx context.Context, rpc *sdkrpc.Client, mint solana.PublicKe
 		return 0, err
 	}
 
-	// Calculate using bonding curve formula
-	// sol_out = (token_in * virtual_sol_reserves) / (virtual_token_reserves + token_in)
-	tokenIn := new(big.Int).SetUint64(tokenAmount)
-	tokenReserves := new(big.Int).SetUint64(bc.VirtualTokenReserves)
-	solReserves := new(big.Int).SetUint64(bc.VirtualSolReserves)
+	return curve.SellSolOut(curveReserves(bc), 0, tokenAmount), nil
+}
 
-	numerator := new(big.Int).Mul(tokenIn, solReserves)
-	denominator := new(big.Int).Add(tokenReserves, tokenIn)
+// PumpBuyCostForTokens returns the lamports (including protocol and creator
+// fees) needed to buy exactly tokenAmount tokens from the Pump bonding curve at
+// current reserves. It is the inverse of PumpBuyQuote and is meant for setting
+// a tight maxSol on PumpBuy.
+//
+// If tokenAmount exceeds the curve's remaining real token reserves, the cost
+// of buying the remaining supply is returned instead.
+//
+// Example:
+//
+//	cost, err := quote.PumpBuyCostForTokens(ctx, rpc, mint, 1_000_000_000)
+//	maxSol := cost + cost/100 // 1% headroom
+//	accts, args, instrs, err := autofill.PumpBuy(ctx, rpc, user, mint, 1_000_000_000, maxSol)
+func PumpBuyCostForTokens(ctx context.Context, rpc *sdkrpc.Client, mint solana.PublicKey, tokenAmount uint64) (uint64, error) {
+	if rpc == nil {
+		return 0, types.ErrNilRPC
+	}
+	if tokenAmount == 0 {
+		return 0, types.NewValidationError("tokenAmount", "must be greater than 0")
+	}
 
-	solOut := new(big.Int).Div(numerator, denominator)
+	bc, err := fetchBondingCurve(ctx, rpc, mint)
+	if err != nil {
+		return 0, err
+	}
+	if bc.Complete {
+		return 0, fmt.Errorf("bonding curve for %s is complete", mint)
+	}
+	global, err := fetchPumpGlobal(ctx, rpc)
+	if err != nil {
+		return 0, err
+	}
 
-	return solOut.Uint64(), nil
+	return curve.BuyCostForTokens(curveReserves(bc), global.TradeFeeBps(bc), tokenAmount), nil
 }
 
 // GetAmmPoolPrice returns the current spot price of an AMM pool.
@@ -251,16 +279,10 @@ func GetAmmPoolPrice(ctx context.Context, rpc *sdkrpc.Client, pool solana.Public
 		return 0, err
 	}
 
-	// price = quote_reserves / base_reserves (scaled by 1e9)
 	if poolState.BaseReserves == 0 {
 		return 0, fmt.Errorf("pool has zero base reserves")
 	}
-
-	price := new(big.Int).SetUint64(poolState.QuoteReserves)
-	price.Mul(price, big.NewInt(1e9))
-	price.Div(price, new(big.Int).SetUint64(poolState.BaseReserves))
-
-	return price.Uint64(), nil
+	return curve.SpotPrice(poolState.QuoteReserves, poolState.BaseReserves), nil
 }
 
 // GetPumpPrice returns the current spot price of a Pump bonding curve.
@@ -280,12 +302,7 @@ func GetPumpPrice(ctx context.Context, rpc *sdkrpc.Client, mint solana.PublicKey
 		return 0, fmt.Errorf("bonding curve has zero token reserves")
 	}
 
-	// price = virtual_sol_reserves / virtual_token_reserves (scaled by 1e9)
-	price := new(big.Int).SetUint64(bc.VirtualSolReserves)
-	price.Mul(price, big.NewInt(1e9))
-	price.Div(price, new(big.Int).SetUint64(bc.VirtualTokenReserves))
-
-	return price.Uint64(), nil
+	return curve.SpotPrice(bc.VirtualSolReserves, bc.VirtualTokenReserves), nil
 }
 
 // --- internal helpers ---
@@ -293,6 +310,24 @@ func GetPumpPrice(ctx context.Context, rpc *sdkrpc.Client, mint solana.PublicKey
 type poolReserves struct {
 	BaseReserves  uint64
 	QuoteReserves uint64
+	CoinCreator   solana.PublicKey
+	BaseMint      solana.PublicKey
+	QuoteMint     solana.PublicKey
+}
+
+// pool returns the reserves that price pool trades.
+func (r poolReserves) pool() curve.PoolReserves {
+	return curve.PoolReserves{BaseReserves: r.BaseReserves, QuoteReserves: r.QuoteReserves}
+}
+
+// curveReserves returns the reserves that price trades on bc.
+func curveReserves(bc pump.BondingCurve) curve.Reserves {
+	return curve.Reserves{
+		VirtualTokenReserves: bc.VirtualTokenReserves,
+		VirtualSolReserves:   bc.VirtualSolReserves,
+		RealTokenReserves:    bc.RealTokenReserves,
+		RealSolReserves:      bc.RealSolReserves,
+	}
 }
 
 func fetchPoolState(ctx context.Context, rpc *sdkrpc.Client, pool solana.PublicKey) (poolReserves, error) {
@@ -333,7 +368,13 @@ func fetchPoolState(ctx context.Context, rpc *sdkrpc.Client, pool solana.PublicK
 		}
 	}
 
-	return poolReserves{BaseReserves: baseReserves, QuoteReserves: quoteReserves}, nil
+	return poolReserves{
+		BaseReserves:  baseReserves,
+		QuoteReserves: quoteReserves,
+		CoinCreator:   state.CoinCreator,
+		BaseMint:      state.BaseMint,
+		QuoteMint:     state.QuoteMint,
+	}, nil
 }
 
 func fetchBondingCurve(ctx context.Context, rpc *sdkrpc.Client, mint solana.PublicKey) (pump.BondingCurve, error) {
@@ -363,39 +404,39 @@ func fetchBondingCurve(ctx context.Context, rpc *sdkrpc.Client, mint solana.Publ
 	return bc, nil
 }
 
-func calculatePriceMetrics(reserves poolReserves, quoteAmount, baseAmount uint64, isBuy bool) (spotPrice, execPrice, impactBps uint64) {
-	if reserves.BaseReserves == 0 || baseAmount == 0 {
-		return 0, 0, 0
+func fetchPumpGlobal(ctx context.Context, rpc *sdkrpc.Client) (pump.Global, error) {
+	var global pump.Global
+
+	globalAddr, _, err := solana.FindProgramAddress([][]byte{[]byte(constants.SeedGlobal)}, pump.ProgramKey)
+	if err != nil {
+		return global, fmt.Errorf("derive global: %w", err)
 	}
 
-	// Spot price = quote_reserves / base_reserves (scaled by 1e9)
-	spot := new(big.Int).SetUint64(reserves.QuoteReserves)
-	spot.Mul(spot, big.NewInt(1e9))
-	spot.Div(spot, new(big.Int).SetUint64(reserves.BaseReserves))
-	spotPrice = spot.Uint64()
-
-	// Execution price = quote_amount / base_amount (scaled by 1e9)
-	exec := new(big.Int).SetUint64(quoteAmount)
-	exec.Mul(exec, big.NewInt(1e9))
-	exec.Div(exec, new(big.Int).SetUint64(baseAmount))
-	execPrice = exec.Uint64()
-
-	// Price impact calculation
-	if spotPrice > 0 {
-		if isBuy {
-			// For buys, execution price > spot price means negative impact
-			if execPrice > spotPrice {
-				impactBps = (execPrice - spotPrice) * 10000 / spotPrice
-			}
-		} else {
-			// For sells, execution price < spot price means negative impact
-			if spotPrice > execPrice {
-				impactBps = (spotPrice - execPrice) * 10000 / spotPrice
-			}
-		}
+	info, err := rpc.Raw().GetAccountInfo(ctx, globalAddr)
+	if err != nil {
+		return global, err
+	}
+	if info == nil || info.Value == nil || info.Value.Data == nil {
+		return global, fmt.Errorf("pump global account not found")
 	}
 
-	return spotPrice, execPrice, impactBps
+	if err := global.Unmarshal(info.Value.Data.GetBinary()); err != nil {
+		return global, fmt.Errorf("decode global: %w", err)
+	}
+
+	return global, nil
+}
+
+// calculatePriceMetrics returns the spot and execution prices, in quote
+// units per base unit scaled by 1e9 (saturating at MaxUint64), and the
+// execution price's adverse deviation from spot in bps.
+func calculatePriceMetrics(reserves poolReserves, quoteAmount, baseAmount uint64, isBuy bool) (spotPrice, execPrice, impactBps uint64) {
+	if reserves.BaseReserves == 0 || baseAmount == 0 {
+		return 0, 0, 0
+	}
+	spotPrice = curve.SpotPrice(reserves.QuoteReserves, reserves.BaseReserves)
+	execPrice = curve.ExecutionPrice(quoteAmount, baseAmount)
+	return spotPrice, execPrice, curve.PriceImpactBps(reserves.pool(), quoteAmount, baseAmount, isBuy)
 }
 
 func simulateQuoteOut(ctx context.Context, rpc *sdkrpc.Client, signer wallet.Signer, quoteATA solana.PublicKey, ix solana.Instruction) (uint64, error) {
@@ -456,9 +497,7 @@ func simulateQuoteOut(ctx context.Context, rpc *sdkrpc.Client, signer wallet.Sig
 	return postAcc.Amount - pre, nil
 }
 
+// applySlippage returns floor(amount*(10000-slippageBps)/10000).
 func applySlippage(amount uint64, slippageBps uint64) uint64 {
-	if slippageBps >= 10000 {
-		return 0
-	}
-	return amount * (10000 - slippageBps) / 10000
+	return curve.MinOut(amount, slippageBps)
 }
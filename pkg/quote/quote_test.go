@@ -0,0 +1,47 @@
+package quote
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/curve"
+)
+
+func TestNewQuoteResult(t *testing.T) {
+	reserves := poolReserves{BaseReserves: 1_000_000, QuoteReserves: 1_000_000}
+	q := newQuoteResult(reserves, 10_000, curve.AmmBuyBaseOut(reserves.pool(), 0, 10_000), 100, true)
+	if q.MinOut != applySlippage(q.ExpectedOut, 100) {
+		t.Fatalf("min out = %d, want slippage-adjusted %d", q.MinOut, applySlippage(q.ExpectedOut, 100))
+	}
+	if q.SpotPrice != 1_000_000_000 {
+		t.Fatalf("spot price = %d, want 1e9", q.SpotPrice)
+	}
+	if q.ExecutionPrice <= q.SpotPrice || q.PriceImpactBps == 0 {
+		t.Fatalf("expected positive impact, got exec %d spot %d impact %d", q.ExecutionPrice, q.SpotPrice, q.PriceImpactBps)
+	}
+}
+
+func TestSlippageAndPriceNearMaxUint64(t *testing.T) {
+	// amount*(10000-bps) overflows uint64; the result must not wrap.
+	if got, want := applySlippage(math.MaxUint64, 100), uint64(18262276632972456098); got != want {
+		t.Fatalf("applySlippage(MaxUint64, 100) = %d, want %d", got, want)
+	}
+	if got := applySlippage(math.MaxUint64, 0); got != math.MaxUint64 {
+		t.Fatalf("applySlippage(MaxUint64, 0) = %d, want MaxUint64", got)
+	}
+
+	// Prices above MaxUint64 saturate instead of truncating, and the impact
+	// is computed from the untruncated prices.
+	reserves := poolReserves{BaseReserves: 1, QuoteReserves: math.MaxUint64 / 2}
+	spot, exec, impact := calculatePriceMetrics(reserves, math.MaxUint64, 1, true)
+	if spot != math.MaxUint64 || exec != math.MaxUint64 {
+		t.Fatalf("spot %d exec %d, want both saturated", spot, exec)
+	}
+	if impact != 10_000 {
+		t.Fatalf("impact = %d bps, want 10000", impact)
+	}
+	_, _, impact = calculatePriceMetrics(poolReserves{BaseReserves: 1, QuoteReserves: math.MaxUint64}, math.MaxUint64/4, 1, false)
+	if impact != 7_500 {
+		t.Fatalf("sell impact = %d bps, want 7500", impact)
+	}
+}
@@ -0,0 +1,126 @@
+package quote
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/curve"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// PumpBuyQuoteReadOnly quotes a bonding-curve buy of solLamports (fees
+// included) from on-chain state alone: no signer and no simulation. Fees are
+// taken from the pump Global account; MinOut applies slippageBps.
+//
+// Example:
+//
+//	q, err := quote.PumpBuyQuoteReadOnly(ctx, rpc, mint, 100_000_000, 100)
+//	fmt.Println(q.ExpectedOut, q.MinOut, q.PriceImpactBps)
+func PumpBuyQuoteReadOnly(ctx context.Context, rpc *sdkrpc.Client, mint solana.PublicKey, solLamports, slippageBps uint64) (*QuoteResult, error) {
+	if rpc == nil {
+		return nil, types.ErrNilRPC
+	}
+	if solLamports == 0 {
+		return nil, types.NewValidationError("solLamports", "must be greater than 0")
+	}
+	if err := types.ValidateSlippage(slippageBps); err != nil {
+		return nil, err
+	}
+
+	bc, err := fetchBondingCurve(ctx, rpc, mint)
+	if err != nil {
+		return nil, err
+	}
+	if bc.Complete {
+		return nil, fmt.Errorf("bonding curve for %s is complete", mint)
+	}
+	global, err := fetchPumpGlobal(ctx, rpc)
+	if err != nil {
+		return nil, err
+	}
+	decimals, err := rpc.GetMintDecimals(ctx, mint)
+	if err != nil {
+		return nil, err
+	}
+	out := curve.BuyTokensOut(curveReserves(bc), global.TradeFeeBps(bc), solLamports)
+	reserves := poolReserves{BaseReserves: bc.VirtualTokenReserves, QuoteReserves: bc.VirtualSolReserves}
+	q := newQuoteResult(reserves, solLamports, out, slippageBps, true)
+	q.OutDecimals = decimals
+	return q, nil
+}
+
+// AmmBuyQuoteReadOnly quotes a pump_amm buy spending quoteLamports (fees
+// included) from pool reserves alone: no signer and no simulation. Fees are
+// the flat GlobalConfig LP, protocol and (if the pool has a coin creator)
+// creator rates; tiered fees from the fee program can make the actual fill
+// slightly better. MinOut applies slippageBps.
+func AmmBuyQuoteReadOnly(ctx context.Context, rpc *sdkrpc.Client, pool solana.PublicKey, quoteLamports, slippageBps uint64) (*QuoteResult, error) {
+	if rpc == nil {
+		return nil, types.ErrNilRPC
+	}
+	if quoteLamports == 0 {
+		return nil, types.NewValidationError("quoteLamports", "must be greater than 0")
+	}
+	if err := types.ValidateSlippage(slippageBps); err != nil {
+		return nil, err
+	}
+
+	reserves, err := fetchPoolState(ctx, rpc, pool)
+	if err != nil {
+		return nil, fmt.Errorf("fetch pool state: %w", err)
+	}
+	cfg, err := fetchAmmGlobalConfig(ctx, rpc)
+	if err != nil {
+		return nil, err
+	}
+	feeBps := cfg.LpFeeBps() + cfg.ProtocolFeeBps()
+	if !reserves.CoinCreator.IsZero() {
+		feeBps += cfg.CoinCreatorFeeBps()
+	}
+
+	decimals, err := rpc.GetMintDecimals(ctx, reserves.BaseMint)
+	if err != nil {
+		return nil, fmt.Errorf("base mint decimals: %w", err)
+	}
+	out := curve.AmmBuyBaseOut(reserves.pool(), feeBps, quoteLamports)
+	q := newQuoteResult(reserves, quoteLamports, out, slippageBps, true)
+	q.OutDecimals = decimals
+	return q, nil
+}
+
+// newQuoteResult assembles a QuoteResult for quoteAmount in and baseOut out.
+func newQuoteResult(reserves poolReserves, quoteAmount, baseOut, slippageBps uint64, isBuy bool) *QuoteResult {
+	spot, exec, impact := calculatePriceMetrics(reserves, quoteAmount, baseOut, isBuy)
+	return &QuoteResult{
+		ExpectedOut:    baseOut,
+		MinOut:         applySlippage(baseOut, slippageBps),
+		PriceImpactBps: impact,
+		SpotPrice:      spot,
+		ExecutionPrice: exec,
+	}
+}
+
+func fetchAmmGlobalConfig(ctx context.Context, rpc *sdkrpc.Client) (pumpamm.GlobalConfig, error) {
+	var cfg pumpamm.GlobalConfig
+
+	addr, _, err := solana.FindProgramAddress([][]byte{[]byte(constants.SeedGlobalConfig)}, pumpamm.ProgramKey)
+	if err != nil {
+		return cfg, fmt.Errorf("derive global config: %w", err)
+	}
+	info, err := rpc.Raw().GetAccountInfo(ctx, addr)
+	if err != nil {
+		return cfg, err
+	}
+	if info == nil || info.Value == nil || info.Value.Data == nil {
+		return cfg, types.ErrGlobalConfigNotFound
+	}
+	if err := cfg.Unmarshal(info.Value.Data.GetBinary()); err != nil {
+		return cfg, fmt.Errorf("decode global config: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,155 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+)
+
+// CacheStats counts account cache lookups. Only addresses with a TTL are
+// counted; other accounts always go to the node.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// HitRate returns Hits / (Hits + Misses), or 0 before any lookup.
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// accountCache holds accounts fetched for addresses with a TTL.
+type accountCache struct {
+	ttls    map[solana.PublicKey]time.Duration
+	mu      sync.Mutex
+	entries map[solana.PublicKey]cacheEntry
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+}
+
+type cacheEntry struct {
+	account *solanarpc.Account
+	expires time.Time
+}
+
+// NewCachingClient returns a client sharing inner's connection, limits and
+// retry policy that memoizes the accounts listed in ttls, keyed by base58
+// address, for their TTL. GetMultipleAccounts serves those from memory while
+// always reading every other account, so mutable state such as bonding
+// curves, pools and ATAs stays fresh. Invalid addresses are ignored.
+//
+// Example:
+//
+//	cached := rpc.NewCachingClient(client, autofill.StaticAccountTTLs(time.Minute))
+//	accts, args, instrs, err := autofill.PumpBuy(ctx, cached, user, mint, amount, maxSol)
+//	log.Printf("cache hit rate %.2f", cached.CacheStats().HitRate())
+func NewCachingClient(inner *Client, ttls map[string]time.Duration) *Client {
+	cache := &accountCache{
+		ttls:    make(map[solana.PublicKey]time.Duration, len(ttls)),
+		entries: make(map[solana.PublicKey]cacheEntry),
+	}
+	for addr, ttl := range ttls {
+		pk, err := solana.PublicKeyFromBase58(addr)
+		if err != nil || ttl <= 0 {
+			continue
+		}
+		cache.ttls[pk] = ttl
+	}
+	c := *inner
+	c.cache = cache
+	return &c
+}
+
+// CacheStats returns the account cache's hit and miss counts, or zero for a
+// client without a cache.
+func (c *Client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+	return CacheStats{Hits: c.cache.hits.Load(), Misses: c.cache.misses.Load()}
+}
+
+// GetMultipleAccounts returns the accounts at addrs, in order, with nil for
+// missing ones. On a caching client, unexpired cached accounts are not
+// requested again.
+func (c *Client) GetMultipleAccounts(ctx context.Context, addrs []solana.PublicKey, commitment solanarpc.CommitmentType) ([]*solanarpc.Account, error) {
+	out := make([]*solanarpc.Account, len(addrs))
+	fetch := addrs
+	var fetchIdx []int
+	if c.cache != nil {
+		fetch, fetchIdx = nil, nil
+		now := time.Now()
+		for i, addr := range addrs {
+			if acc, ok := c.cache.get(addr, now); ok {
+				out[i] = acc
+				continue
+			}
+			fetch = append(fetch, addr)
+			fetchIdx = append(fetchIdx, i)
+		}
+		if len(fetch) == 0 {
+			return out, nil
+		}
+	}
+
+	var res *solanarpc.GetMultipleAccountsResult
+	err := c.call(ctx, "getMultipleAccounts", func(ctx context.Context) error {
+		var err error
+		res, err = c.raw.GetMultipleAccountsWithOpts(ctx, fetch, &solanarpc.GetMultipleAccountsOpts{Commitment: commitment})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for j, acc := range res.Value {
+		if j >= len(fetch) {
+			break
+		}
+		i := j
+		if fetchIdx != nil {
+			i = fetchIdx[j]
+		}
+		out[i] = acc
+		if c.cache != nil {
+			c.cache.put(fetch[j], acc, time.Now())
+		}
+	}
+	return out, nil
+}
+
+// get returns the cached account for addr if addr has a TTL and the entry
+// has not expired, counting a hit or miss for such addresses.
+func (ac *accountCache) get(addr solana.PublicKey, now time.Time) (*solanarpc.Account, bool) {
+	if _, ok := ac.ttls[addr]; !ok {
+		return nil, false
+	}
+	ac.mu.Lock()
+	e, ok := ac.entries[addr]
+	ac.mu.Unlock()
+	if ok && now.Before(e.expires) {
+		ac.hits.Add(1)
+		return e.account, true
+	}
+	ac.misses.Add(1)
+	return nil, false
+}
+
+// put stores acc for addr if addr has a TTL. Missing accounts are not cached,
+// so an account created later is picked up on the next read.
+func (ac *accountCache) put(addr solana.PublicKey, acc *solanarpc.Account, now time.Time) {
+	ttl, ok := ac.ttls[addr]
+	if !ok || acc == nil {
+		return
+	}
+	ac.mu.Lock()
+	ac.entries[addr] = cacheEntry{account: acc, expires: now.Add(ttl)}
+	ac.mu.Unlock()
+}
@@ -13,14 +13,18 @@ import (
 	"golang.org/x/time/rate"
 
 	"github.com/ninja0404/pump-go-sdk/pkg/config"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
 )
 
 // Client wraps solana-go rpc.Client with retry, timeout, and rate limiting.
 type Client struct {
 	raw     *solanarpc.Client
+	ref     *solanarpc.Client // reference node for IsBehind, if configured
 	cfg     config.RPCConfig
 	limiter *rate.Limiter
 	log     zerolog.Logger
+	cache   *accountCache // set by NewCachingClient
+	mints   *mintDecimalsCache
 }
 
 // NewClient builds a configured Client.
@@ -42,14 +46,37 @@ func NewClient(cfg config.RPCConfig) *Client {
 		log = zerolog.Nop()
 	}
 
+	var ref *solanarpc.Client
+	if cfg.ReferenceRPCURL != "" {
+		ref = solanarpc.New(cfg.ReferenceRPCURL)
+	}
+
 	return &Client{
 		raw:     rpcClient,
+		ref:     ref,
 		cfg:     cfg,
 		limiter: limiter,
 		log:     log,
+		mints:   newMintDecimalsCache(),
 	}
 }
 
+// Logger returns the client's logger.
+func (c *Client) Logger() zerolog.Logger {
+	return c.log
+}
+
+// ConfirmTimeout returns the configured deadline for confirmation polling.
+func (c *Client) ConfirmTimeout() time.Duration {
+	return c.cfg.ConfirmTimeout
+}
+
+// ReadCommitment returns the configured commitment for account reads and
+// simulations (RPCConfig.ReadCommitment), or "" to leave callers' defaults.
+func (c *Client) ReadCommitment() solanarpc.CommitmentType {
+	return solanarpc.CommitmentType(c.cfg.ReadCommitment)
+}
+
 // Raw exposes the underlying solana-go client.
 func (c *Client) Raw() *solanarpc.Client {
 	return c.raw
@@ -88,17 +115,129 @@ func (c *Client) SimulateTransaction(ctx context.Context, tx *solana.Transaction
 	return res, err
 }
 
+// GetRecentPrioritizationFees returns per-slot prioritization fees (micro-lamports
+// per CU) observed for transactions that write-locked all of the given accounts.
+// An empty accounts list returns cluster-wide fees.
+func (c *Client) GetRecentPrioritizationFees(ctx context.Context, accounts []solana.PublicKey) ([]solanarpc.PriorizationFeeResult, error) {
+	var out []solanarpc.PriorizationFeeResult
+	err := c.call(ctx, "getRecentPrioritizationFees", func(ctx context.Context) error {
+		var err error
+		out, err = c.raw.GetRecentPrioritizationFees(ctx, accounts)
+		return err
+	})
+	return out, err
+}
+
+// GetTransaction fetches a confirmed transaction, including its logs and
+// inner instructions.
+func (c *Client) GetTransaction(ctx context.Context, sig solana.Signature, opts *solanarpc.GetTransactionOpts) (*solanarpc.GetTransactionResult, error) {
+	var out *solanarpc.GetTransactionResult
+	err := c.call(ctx, "getTransaction", func(ctx context.Context) error {
+		var err error
+		out, err = c.raw.GetTransaction(ctx, sig, opts)
+		return err
+	})
+	return out, err
+}
+
+// GetMinimumBalanceForRentExemption returns the lamports an account of
+// dataSize bytes needs to be rent exempt.
+func (c *Client) GetMinimumBalanceForRentExemption(ctx context.Context, dataSize uint64) (uint64, error) {
+	var out uint64
+	err := c.call(ctx, "getMinimumBalanceForRentExemption", func(ctx context.Context) error {
+		var err error
+		out, err = c.raw.GetMinimumBalanceForRentExemption(ctx, dataSize, solanarpc.CommitmentType(c.cfg.Commitment))
+		return err
+	})
+	return out, err
+}
+
+// GetBlockHeight returns the current block height at the given commitment.
+func (c *Client) GetBlockHeight(ctx context.Context, commitment solanarpc.CommitmentType) (uint64, error) {
+	var out uint64
+	err := c.call(ctx, "getBlockHeight", func(ctx context.Context) error {
+		var err error
+		out, err = c.raw.GetBlockHeight(ctx, commitment)
+		return err
+	})
+	return out, err
+}
+
+// GetBalance returns the lamports of addr at the given commitment.
+func (c *Client) GetBalance(ctx context.Context, addr solana.PublicKey, commitment solanarpc.CommitmentType) (uint64, error) {
+	var out uint64
+	err := c.call(ctx, "getBalance", func(ctx context.Context) error {
+		res, err := c.raw.GetBalance(ctx, addr, commitment)
+		if err != nil {
+			return err
+		}
+		out = res.Value
+		return nil
+	})
+	return out, err
+}
+
+// GetTokenAccountsByOwner returns the token accounts owner holds under the
+// token program programID, base64 encoded, at confirmed commitment.
+func (c *Client) GetTokenAccountsByOwner(ctx context.Context, owner, programID solana.PublicKey) ([]*solanarpc.TokenAccount, error) {
+	var out []*solanarpc.TokenAccount
+	err := c.call(ctx, "getTokenAccountsByOwner", func(ctx context.Context) error {
+		res, err := c.raw.GetTokenAccountsByOwner(ctx, owner,
+			&solanarpc.GetTokenAccountsConfig{ProgramId: &programID},
+			&solanarpc.GetTokenAccountsOpts{
+				Commitment: solanarpc.CommitmentConfirmed,
+				Encoding:   solana.EncodingBase64,
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if res != nil {
+			out = res.Value
+		}
+		return nil
+	})
+	return out, err
+}
+
+// GetSlot returns the current slot at the given commitment.
+func (c *Client) GetSlot(ctx context.Context, commitment solanarpc.CommitmentType) (uint64, error) {
+	var out uint64
+	err := c.call(ctx, "getSlot", func(ctx context.Context) error {
+		var err error
+		out, err = c.raw.GetSlot(ctx, commitment)
+		return err
+	})
+	return out, err
+}
+
 func (c *Client) call(ctx context.Context, op string, fn func(context.Context) error) error {
-	ctx = c.withTimeout(ctx)
+	start := time.Now()
+	attempts, err := c.callWithRetry(ctx, op, fn)
+	if e := c.log.Debug(); e.Enabled() {
+		e.Str("op", op).
+			Dur("duration", time.Since(start)).
+			Int("attempts", attempts).
+			Err(err).
+			Msg("rpc call")
+	}
+	return err
+}
+
+// callWithRetry runs fn under the client's timeout, rate limit and retry
+// policy and returns the number of attempts made.
+func (c *Client) callWithRetry(ctx context.Context, op string, fn func(context.Context) error) (int, error) {
+	ctx, cancel := c.withTimeout(ctx, op)
+	defer cancel()
 
 	if c.limiter != nil {
 		if err := c.limiter.Wait(ctx); err != nil {
-			return err
+			return 0, err
 		}
 	}
 
 	if !c.cfg.Retry.Enabled {
-		return fn(ctx)
+		return 1, fn(ctx)
 	}
 
 	attempts := c.cfg.Retry.MaxAttempts
@@ -110,11 +249,11 @@ func (c *Client) call(ctx context.Context, op string, fn func(context.Context) e
 	for i := 0; i < attempts; i++ {
 		err = fn(ctx)
 		if err == nil {
-			return nil
+			return i + 1, nil
 		}
 
 		if !retryable(err) || i == attempts-1 {
-			break
+			return i + 1, fmt.Errorf("%s failed after %d attempts: %w", op, i+1, err)
 		}
 		backoff := c.backoff(i)
 		c.log.Debug().
@@ -126,19 +265,29 @@ func (c *Client) call(ctx context.Context, op string, fn func(context.Context) e
 
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return i + 1, ctx.Err()
 		case <-time.After(backoff):
 		}
 	}
-	return fmt.Errorf("%s failed after %d attempts: %w", op, attempts, err)
+	return attempts, fmt.Errorf("%s failed after %d attempts: %w", op, attempts, err)
+}
+
+// withTimeout derives a child context bounded by the timeout configured for op.
+func (c *Client) withTimeout(ctx context.Context, op string) (context.Context, context.CancelFunc) {
+	timeout := c.timeoutFor(op)
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
 }
 
-func (c *Client) withTimeout(ctx context.Context) context.Context {
-	if c.cfg.Timeout <= 0 {
-		return ctx
+func (c *Client) timeoutFor(op string) time.Duration {
+	switch op {
+	case "simulateTransaction":
+		return c.cfg.SimulateTimeout
+	default:
+		return c.cfg.Timeout
 	}
-	ctxWithTimeout, _ := context.WithTimeout(ctx, c.cfg.Timeout)
-	return ctxWithTimeout
 }
 
 func (c *Client) backoff(attempt int) time.Duration {
@@ -170,6 +319,8 @@ func retryable(err error) bool {
 	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 		return false
 	}
-	// Conservative: retry on all other errors to keep liveness unless caller decides otherwise.
-	return true
+	// Deterministic failures (insufficient funds, slippage, bad accounts)
+	// fail the same way again; anything unrecognized is retried to keep
+	// liveness.
+	return types.IsRetryableError(err)
 }
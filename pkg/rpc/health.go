@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// nodeBehindCode is the JSON-RPC error code of an unhealthy, lagging node.
+const nodeBehindCode = -32005
+
+// GetHealth returns nil if the node reports itself healthy. A node lagging
+// the cluster returns an error wrapping types.ErrNodeBehind with the number
+// of slots it is behind, when reported. Nodes only judge their health
+// against trusted validators and tolerate a lag (128 slots by default), so
+// a healthy node can still be somewhat behind; see IsBehind.
+func (c *Client) GetHealth(ctx context.Context) error {
+	_, err := c.health(ctx)
+	return err
+}
+
+// health is GetHealth that also returns the reported lag, 0 if unknown.
+func (c *Client) health(ctx context.Context) (uint64, error) {
+	var out string
+	err := c.call(ctx, "getHealth", func(ctx context.Context) error {
+		var err error
+		out, err = c.raw.GetHealth(ctx)
+		return err
+	})
+	if err != nil {
+		var rpcErr *jsonrpc.RPCError
+		if !errors.As(err, &rpcErr) || rpcErr.Code != nodeBehindCode {
+			return 0, err
+		}
+		if lag, ok := slotsBehind(rpcErr); ok {
+			return lag, fmt.Errorf("%w: %d slots behind", types.ErrNodeBehind, lag)
+		}
+		return 0, fmt.Errorf("%w: %s", types.ErrNodeBehind, rpcErr.Message)
+	}
+	if out != solanarpc.HealthOk {
+		return 0, fmt.Errorf("node health %q", out)
+	}
+	return 0, nil
+}
+
+// IsBehind reports whether the node is more than maxSlotLag slots behind.
+// With RPCConfig.ReferenceRPCURL set, the node's processed slot is compared
+// against the reference node's; otherwise the lag reported by GetHealth is
+// used. When behind is true, err wraps types.ErrNodeBehind and carries the
+// lag; any other error means the lag could not be measured.
+//
+// Example:
+//
+//	if behind, err := client.IsBehind(ctx, 20); behind {
+//	    log.Printf("switching endpoint: %v", err)
+//	}
+func (c *Client) IsBehind(ctx context.Context, maxSlotLag uint64) (bool, error) {
+	if c.ref == nil {
+		lag, err := c.health(ctx)
+		if err == nil || !errors.Is(err, types.ErrNodeBehind) {
+			return false, err
+		}
+		if lag > 0 && lag <= maxSlotLag {
+			return false, nil
+		}
+		return true, err
+	}
+
+	slot, err := c.GetSlot(ctx, solanarpc.CommitmentProcessed)
+	if err != nil {
+		return false, err
+	}
+	var refSlot uint64
+	err = c.call(ctx, "getSlot", func(ctx context.Context) error {
+		var err error
+		refSlot, err = c.ref.GetSlot(ctx, solanarpc.CommitmentProcessed)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("reference slot: %w", err)
+	}
+	if refSlot <= slot || refSlot-slot <= maxSlotLag {
+		return false, nil
+	}
+	return true, fmt.Errorf("%w: %d slots behind reference (node %d, reference %d)", types.ErrNodeBehind, refSlot-slot, slot, refSlot)
+}
+
+// slotsBehind extracts the lag from a node-behind error's data,
+// {"numSlotsBehind": n}.
+func slotsBehind(rpcErr *jsonrpc.RPCError) (uint64, bool) {
+	data, _ := rpcErr.Data.(map[string]interface{})
+	switch n := data["numSlotsBehind"].(type) {
+	case json.Number:
+		v, err := n.Int64()
+		return uint64(v), err == nil && v > 0
+	case float64:
+		return uint64(n), n > 0
+	}
+	return 0, false
+}
@@ -0,0 +1,94 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/config"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// serveNode starts a JSON-RPC server answering getSlot with slot and
+// getHealth with ok, or with a node-behind error when behind > 0.
+func serveNode(t *testing.T, slot, behind uint64) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch {
+		case req.Method == "getSlot":
+			resp["result"] = slot
+		case req.Method == "getHealth" && behind > 0:
+			resp["error"] = map[string]interface{}{
+				"code":    -32005,
+				"message": "Node is behind",
+				"data":    map[string]interface{}{"numSlotsBehind": behind},
+			}
+		case req.Method == "getHealth":
+			resp["result"] = "ok"
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func healthClient(url, reference string) *Client {
+	cfg := config.DefaultRPCConfig()
+	cfg.RPCURL = url
+	cfg.ReferenceRPCURL = reference
+	cfg.RateLimit.RPS = 0
+	cfg.Retry.Enabled = false
+	return NewClient(cfg)
+}
+
+func TestGetHealth(t *testing.T) {
+	ctx := context.Background()
+	if err := healthClient(serveNode(t, 100, 0), "").GetHealth(ctx); err != nil {
+		t.Fatalf("healthy node: %v", err)
+	}
+	err := healthClient(serveNode(t, 100, 42), "").GetHealth(ctx)
+	if !errors.Is(err, types.ErrNodeBehind) || err.Error() != "rpc node is behind: 42 slots behind" {
+		t.Fatalf("lagging node: %v", err)
+	}
+	if types.ClassifyError(err) != types.ErrorClassTransient {
+		t.Fatalf("class = %v, want transient", types.ClassifyError(err))
+	}
+}
+
+func TestIsBehind(t *testing.T) {
+	ctx := context.Background()
+	reference := serveNode(t, 1_050, 0)
+
+	behind, err := healthClient(serveNode(t, 1_000, 0), reference).IsBehind(ctx, 20)
+	if !behind || !errors.Is(err, types.ErrNodeBehind) {
+		t.Fatalf("50 slots behind reference: behind %v, err %v", behind, err)
+	}
+	if err.Error() != "rpc node is behind: 50 slots behind reference (node 1000, reference 1050)" {
+		t.Fatalf("error lacks the lag: %v", err)
+	}
+	if behind, err := healthClient(serveNode(t, 1_040, 0), reference).IsBehind(ctx, 20); behind || err != nil {
+		t.Fatalf("10 slots behind reference: behind %v, err %v", behind, err)
+	}
+
+	// Without a reference the lag getHealth reports is used.
+	if behind, err := healthClient(serveNode(t, 0, 10), "").IsBehind(ctx, 20); behind || err != nil {
+		t.Fatalf("reported 10 slots behind: behind %v, err %v", behind, err)
+	}
+	if behind, err := healthClient(serveNode(t, 0, 30), "").IsBehind(ctx, 20); !behind || !errors.Is(err, types.ErrNodeBehind) {
+		t.Fatalf("reported 30 slots behind: behind %v, err %v", behind, err)
+	}
+}
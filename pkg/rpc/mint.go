@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// SPL mint layout. Token-2022 mints share these first 82 bytes and append
+// their extensions after the account type byte at offset 165.
+const (
+	mintSize              = 82
+	mintDecimalsOffset    = 44
+	mintInitializedOffset = 45
+)
+
+// mintDecimalsCache remembers the decimals of mints already read. Decimals
+// cannot change after initialization, so entries never expire.
+type mintDecimalsCache struct {
+	mu       sync.Mutex
+	decimals map[solana.PublicKey]uint8
+}
+
+func newMintDecimalsCache() *mintDecimalsCache {
+	return &mintDecimalsCache{decimals: make(map[solana.PublicKey]uint8)}
+}
+
+// GetMintDecimals returns the decimals of mint, an SPL Token or Token-2022
+// mint. The first read of each mint goes to the node; later reads are served
+// from memory, shared with clients derived by NewCachingClient.
+func (c *Client) GetMintDecimals(ctx context.Context, mint solana.PublicKey) (uint8, error) {
+	if c.mints != nil {
+		c.mints.mu.Lock()
+		d, ok := c.mints.decimals[mint]
+		c.mints.mu.Unlock()
+		if ok {
+			return d, nil
+		}
+	}
+
+	accts, err := c.GetMultipleAccounts(ctx, []solana.PublicKey{mint}, solanarpc.CommitmentType(c.cfg.Commitment))
+	if err != nil {
+		return 0, err
+	}
+	if len(accts) == 0 || accts[0] == nil || accts[0].Data == nil {
+		return 0, fmt.Errorf("%w: %s", types.ErrMintNotFound, mint)
+	}
+	d, err := decodeMintDecimals(accts[0].Owner, accts[0].Data.GetBinary())
+	if err != nil {
+		return 0, fmt.Errorf("mint %s: %w", mint, err)
+	}
+
+	if c.mints != nil {
+		c.mints.mu.Lock()
+		c.mints.decimals[mint] = d
+		c.mints.mu.Unlock()
+	}
+	return d, nil
+}
+
+// decodeMintDecimals reads decimals from the base mint layout of an account
+// owned by the Token or Token-2022 program, ignoring any extensions.
+func decodeMintDecimals(owner solana.PublicKey, data []byte) (uint8, error) {
+	if !owner.Equals(constants.TokenProgramID) && !owner.Equals(constants.Token2022ProgramID) {
+		return 0, fmt.Errorf("owner %s is not a token program", owner)
+	}
+	if len(data) < mintSize {
+		return 0, fmt.Errorf("account data is %d bytes, too short for a mint", len(data))
+	}
+	if data[mintInitializedOffset] == 0 {
+		return 0, types.ErrAccountNotInitialized
+	}
+	return data[mintDecimalsOffset], nil
+}
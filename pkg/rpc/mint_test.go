@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/config"
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+)
+
+// serveAccount starts a JSON-RPC server answering getMultipleAccounts with a
+// single account and returns a client for it and the request counter.
+func serveAccount(t *testing.T, owner solana.PublicKey, data []byte) (*Client, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Method != "getMultipleAccounts" {
+			t.Errorf("unexpected method %s", req.Method)
+		}
+		calls.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result": map[string]interface{}{
+				"context": map[string]interface{}{"slot": 1},
+				"value": []interface{}{map[string]interface{}{
+					"owner":      owner.String(),
+					"lamports":   1_461_600,
+					"data":       []string{base64.StdEncoding.EncodeToString(data), "base64"},
+					"executable": false,
+					"rentEpoch":  0,
+				}},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := config.DefaultRPCConfig()
+	cfg.RPCURL = srv.URL
+	cfg.RateLimit.RPS = 0
+	cfg.Retry.Enabled = false
+	return NewClient(cfg), &calls
+}
+
+// mintData returns an initialized SPL mint with the given decimals.
+func mintData(decimals uint8) []byte {
+	data := make([]byte, mintSize)
+	binary.LittleEndian.PutUint64(data[36:], 1_000_000_000_000_000)
+	data[mintDecimalsOffset] = decimals
+	data[mintInitializedOffset] = 1
+	return data
+}
+
+func TestGetMintDecimalsToken2022Extensions(t *testing.T) {
+	// Base mint, zero padding up to the account type byte at 165, then a
+	// MetadataPointer extension (type 18, 64 bytes).
+	data := append(mintData(6), make([]byte, 165-mintSize)...)
+	data = append(data, 1)
+	ext := make([]byte, 4+64)
+	binary.LittleEndian.PutUint16(ext[0:], 18)
+	binary.LittleEndian.PutUint16(ext[2:], 64)
+	data = append(data, ext...)
+
+	client, calls := serveAccount(t, constants.Token2022ProgramID, data)
+	mint := solana.NewWallet().PublicKey()
+	for i := 0; i < 2; i++ {
+		d, err := client.GetMintDecimals(context.Background(), mint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d != 6 {
+			t.Fatalf("decimals = %d, want 6", d)
+		}
+	}
+	// Clients derived by NewCachingClient share the decimals cache.
+	if _, err := NewCachingClient(client, nil).GetMintDecimals(context.Background(), mint); err != nil {
+		t.Fatal(err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("mint fetched %d times, want 1", n)
+	}
+}
+
+func TestGetMintDecimalsSPL(t *testing.T) {
+	client, _ := serveAccount(t, constants.TokenProgramID, mintData(9))
+	d, err := client.GetMintDecimals(context.Background(), solana.NewWallet().PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != 9 {
+		t.Fatalf("decimals = %d, want 9", d)
+	}
+}
+
+func TestGetMintDecimalsRejectsNonMint(t *testing.T) {
+	client, _ := serveAccount(t, constants.SystemProgramID, mintData(6))
+	if _, err := client.GetMintDecimals(context.Background(), solana.NewWallet().PublicKey()); err == nil {
+		t.Fatal("expected an error for an account not owned by a token program")
+	}
+}
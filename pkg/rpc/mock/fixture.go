@@ -0,0 +1,117 @@
+package mock
+
+import (
+	"embed"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Embedded fixtures, loaded by name with LoadFixture.
+const (
+	// FixtureBondingCurve is a pump bonding curve about a third of the way
+	// to completion: the pump Global account, the mint, its bonding curve
+	// and associated bonding curve token account. Accounts are named
+	// "global", "mint", "bonding_curve" and "associated_bonding_curve".
+	FixtureBondingCurve = "bonding_curve"
+	// FixtureAmmPool is a graduated pump_amm pool quoted in WSOL: the
+	// GlobalConfig account, both mints, the pool and its token accounts.
+	// Accounts are named "global_config", "base_mint", "quote_mint",
+	// "pool", "pool_base_token_account" and "pool_quote_token_account".
+	FixtureAmmPool = "amm_pool"
+)
+
+//go:embed fixtures/*.json
+var fixtureFS embed.FS
+
+// Fixture is a named set of account states.
+type Fixture struct {
+	Description string           `json:"description"`
+	Accounts    []FixtureAccount `json:"accounts"`
+}
+
+// FixtureAccount is an account of a Fixture. In JSON, keys are base58 and
+// Data is base64, as returned by getAccountInfo.
+type FixtureAccount struct {
+	Name     string           `json:"name"`
+	Address  solana.PublicKey `json:"address"`
+	Owner    solana.PublicKey `json:"owner"`
+	Lamports uint64           `json:"lamports"`
+	Data     []byte           `json:"-"`
+}
+
+type fixtureAccountJSON struct {
+	Name     string           `json:"name"`
+	Address  solana.PublicKey `json:"address"`
+	Owner    solana.PublicKey `json:"owner"`
+	Lamports uint64           `json:"lamports"`
+	Data     string           `json:"data"`
+}
+
+// MarshalJSON encodes the account with base64 data.
+func (a FixtureAccount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fixtureAccountJSON{
+		Name:     a.Name,
+		Address:  a.Address,
+		Owner:    a.Owner,
+		Lamports: a.Lamports,
+		Data:     base64.StdEncoding.EncodeToString(a.Data),
+	})
+}
+
+// UnmarshalJSON decodes an account with base64 data.
+func (a *FixtureAccount) UnmarshalJSON(b []byte) error {
+	var raw fixtureAccountJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	data, err := base64.StdEncoding.DecodeString(raw.Data)
+	if err != nil {
+		return fmt.Errorf("account %q data: %w", raw.Name, err)
+	}
+	*a = FixtureAccount{Name: raw.Name, Address: raw.Address, Owner: raw.Owner, Lamports: raw.Lamports, Data: data}
+	return nil
+}
+
+// LoadFixture returns the embedded fixture name (see FixtureBondingCurve and
+// FixtureAmmPool).
+func LoadFixture(name string) (*Fixture, error) {
+	b, err := fixtureFS.ReadFile("fixtures/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("fixture %q: %w", name, err)
+	}
+	var fx Fixture
+	if err := json.Unmarshal(b, &fx); err != nil {
+		return nil, fmt.Errorf("decode fixture %q: %w", name, err)
+	}
+	return &fx, nil
+}
+
+// MustLoadFixture is LoadFixture that panics on error, for tests.
+func MustLoadFixture(name string) *Fixture {
+	fx, err := LoadFixture(name)
+	if err != nil {
+		panic(err)
+	}
+	return fx
+}
+
+// Account returns the account called name, or nil.
+func (f *Fixture) Account(name string) *FixtureAccount {
+	for i := range f.Accounts {
+		if f.Accounts[i].Name == name {
+			return &f.Accounts[i]
+		}
+	}
+	return nil
+}
+
+// Address returns the address of the account called name, or the zero key.
+func (f *Fixture) Address(name string) solana.PublicKey {
+	if acc := f.Account(name); acc != nil {
+		return acc.Address
+	}
+	return solana.PublicKey{}
+}
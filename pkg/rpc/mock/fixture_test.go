@@ -0,0 +1,216 @@
+package mock
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+)
+
+var update = flag.Bool("update", false, "rewrite fixture files")
+
+// TestFixtures checks the embedded fixtures against the states built here,
+// so a layout change in the program types shows up as a fixture diff.
+func TestFixtures(t *testing.T) {
+	for name, build := range map[string]func(*testing.T) *Fixture{
+		FixtureBondingCurve: bondingCurveFixture,
+		FixtureAmmPool:      ammPoolFixture,
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := json.MarshalIndent(build(t), "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			path := filepath.Join("fixtures", name+".json")
+			if *update {
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s differs from the built fixture; rerun with -update and review the diff", path)
+			}
+			if _, err := LoadFixture(name); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// fixtureKey returns a stable key for label, so regenerated fixtures keep
+// their addresses.
+func fixtureKey(label string) solana.PublicKey {
+	sum := sha256.Sum256([]byte("pump-go-sdk mock " + label))
+	return solana.PublicKeyFromBytes(sum[:])
+}
+
+func bondingCurveFixture(t *testing.T) *Fixture {
+	mint := fixtureKey("bonding curve mint")
+	global, _, err := pump.DeriveBuyGlobalPDA(pump.BuyAccounts{}, pump.BuyArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	curve, _, err := pump.DeriveBuyBondingCurvePDA(pump.BuyAccounts{Mint: mint}, pump.BuyArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assocCurve, _, err := solana.FindAssociatedTokenAddress(curve, mint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := pump.Global{
+		Initialized:                 true,
+		Authority:                   fixtureKey("global authority"),
+		FeeRecipient:                fixtureKey("fee recipient 0"),
+		InitialVirtualTokenReserves: 1_073_000_000_000_000,
+		InitialVirtualSolReserves:   30_000_000_000,
+		InitialRealTokenReserves:    793_100_000_000_000,
+		TokenTotalSupply:            1_000_000_000_000_000,
+		FeeBasisPoints:              95,
+		WithdrawAuthority:           fixtureKey("withdraw authority"),
+		EnableMigrate:               true,
+		PoolMigrationFee:            15_000_001,
+		CreatorFeeBasisPoints:       30,
+		SetCreatorAuthority:         fixtureKey("set creator authority"),
+		AdminSetCreatorAuthority:    fixtureKey("admin set creator authority"),
+		CreateV2Enabled:             true,
+		ReservedFeeRecipient:        fixtureKey("reserved fee recipient 0"),
+		MayhemModeEnabled:           true,
+	}
+	for i := range g.FeeRecipients {
+		g.FeeRecipients[i] = fixtureKey("fee recipient " + string(rune('1'+i)))
+	}
+	for i := range g.ReservedFeeRecipients {
+		g.ReservedFeeRecipients[i] = fixtureKey("reserved fee recipient " + string(rune('1'+i)))
+	}
+	// 28 SOL into the curve: k = 1_073_000_000_000_000 * 30_000_000_000.
+	bc := pump.BondingCurve{
+		VirtualTokenReserves: 555_000_000_000_000,
+		VirtualSolReserves:   58_000_000_000,
+		RealTokenReserves:    275_100_000_000_000,
+		RealSolReserves:      28_000_000_000,
+		TokenTotalSupply:     1_000_000_000_000_000,
+		Creator:              fixtureKey("coin creator"),
+	}
+
+	return &Fixture{
+		Description: "pump bonding curve with 28 SOL of real reserves, SPL Token mint",
+		Accounts: []FixtureAccount{
+			{Name: "global", Address: global, Owner: pump.ProgramKey, Lamports: 7_182_720, Data: encodeAccount(t, pump.GlobalDiscriminator, g)},
+			{Name: "mint", Address: mint, Owner: constants.TokenProgramID, Lamports: 1_461_600, Data: mintData(g.TokenTotalSupply, 6)},
+			{Name: "bonding_curve", Address: curve, Owner: pump.ProgramKey, Lamports: 28_001_484_400, Data: encodeAccount(t, pump.BondingCurveDiscriminator, bc)},
+			{Name: "associated_bonding_curve", Address: assocCurve, Owner: constants.TokenProgramID, Lamports: 2_039_280, Data: tokenAccountData(mint, curve, bc.RealTokenReserves+g.TokenTotalSupply-g.InitialRealTokenReserves)},
+		},
+	}
+}
+
+func ammPoolFixture(t *testing.T) *Fixture {
+	baseMint := fixtureKey("amm base mint")
+	creator, _, err := solana.FindProgramAddress([][]byte{[]byte("pool-authority"), baseMint[:]}, pump.ProgramKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalConfig, _, err := solana.FindProgramAddress([][]byte{[]byte(constants.SeedGlobalConfig)}, pumpamm.ProgramKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := pumpamm.Pool{
+		Creator:     creator,
+		BaseMint:    baseMint,
+		QuoteMint:   constants.WSOLMint,
+		LpMint:      fixtureKey("amm lp mint"),
+		LpSupply:    4_193_388_284_743,
+		CoinCreator: fixtureKey("coin creator"),
+	}
+	var index [2]byte
+	binary.LittleEndian.PutUint16(index[:], p.Index)
+	pool, bump, err := solana.FindProgramAddress([][]byte{
+		[]byte(constants.SeedPool), index[:], p.Creator[:], p.BaseMint[:], p.QuoteMint[:],
+	}, pumpamm.ProgramKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.PoolBump = bump
+	if p.PoolBaseTokenAccount, _, err = solana.FindAssociatedTokenAddress(pool, p.BaseMint); err != nil {
+		t.Fatal(err)
+	}
+	if p.PoolQuoteTokenAccount, _, err = solana.FindAssociatedTokenAddress(pool, p.QuoteMint); err != nil {
+		t.Fatal(err)
+	}
+
+	gc := pumpamm.GlobalConfig{
+		Admin:                        fixtureKey("amm admin"),
+		LpFeeBasisPoints:             20,
+		ProtocolFeeBasisPoints:       5,
+		CoinCreatorFeeBasisPoints:    5,
+		AdminSetCoinCreatorAuthority: fixtureKey("amm admin set coin creator authority"),
+		ReservedFeeRecipient:         fixtureKey("amm reserved fee recipient 0"),
+	}
+	for i := range gc.ProtocolFeeRecipients {
+		gc.ProtocolFeeRecipients[i] = fixtureKey("amm protocol fee recipient " + string(rune('0'+i)))
+	}
+	for i := range gc.ReservedFeeRecipients {
+		gc.ReservedFeeRecipients[i] = fixtureKey("amm reserved fee recipient " + string(rune('1'+i)))
+	}
+
+	const poolBase, poolQuote = 206_900_000_000_000, 84_990_359_932
+	return &Fixture{
+		Description: "graduated pump_amm pool quoted in WSOL, SPL Token base mint",
+		Accounts: []FixtureAccount{
+			{Name: "global_config", Address: globalConfig, Owner: pumpamm.ProgramKey, Lamports: 4_600_560, Data: encodeAccount(t, pumpamm.GlobalConfigDiscriminator, gc)},
+			{Name: "base_mint", Address: baseMint, Owner: constants.TokenProgramID, Lamports: 1_461_600, Data: mintData(1_000_000_000_000_000, 6)},
+			{Name: "quote_mint", Address: constants.WSOLMint, Owner: constants.TokenProgramID, Lamports: 1_461_600, Data: mintData(0, 9)},
+			{Name: "pool", Address: pool, Owner: pumpamm.ProgramKey, Lamports: 3_841_920, Data: encodeAccount(t, pumpamm.PoolDiscriminator, p)},
+			{Name: "pool_base_token_account", Address: p.PoolBaseTokenAccount, Owner: constants.TokenProgramID, Lamports: 2_039_280, Data: tokenAccountData(p.BaseMint, pool, poolBase)},
+			{Name: "pool_quote_token_account", Address: p.PoolQuoteTokenAccount, Owner: constants.TokenProgramID, Lamports: 2_039_280 + poolQuote, Data: tokenAccountData(p.QuoteMint, pool, poolQuote)},
+		},
+	}
+}
+
+// encodeAccount encodes v as Anchor account data with discriminator disc.
+func encodeAccount(t *testing.T, disc []byte, v interface{}) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.Write(disc)
+	if err := bin.NewBorshEncoder(&buf).Encode(v); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// mintData encodes an initialized SPL mint with no authorities.
+func mintData(supply uint64, decimals uint8) []byte {
+	data := make([]byte, 82)
+	binary.LittleEndian.PutUint64(data[36:44], supply)
+	data[44] = decimals
+	data[45] = 1 // is_initialized
+	return data
+}
+
+// tokenAccountData encodes an initialized SPL token account.
+func tokenAccountData(mint, owner solana.PublicKey, amount uint64) []byte {
+	data := make([]byte, 165)
+	copy(data[0:32], mint[:])
+	copy(data[32:64], owner[:])
+	binary.LittleEndian.PutUint64(data[64:72], amount)
+	data[108] = 1 // AccountState::Initialized
+	return data
+}
@@ -0,0 +1,260 @@
+// Package mock provides an in-memory RPC for deterministic tests of autofill
+// and quote logic. A Client serves accounts from memory and satisfies
+// autofill.RPC, so it can be passed wherever those packages take an RPC.
+//
+// Accounts are set directly or loaded from the embedded fixtures (see
+// LoadFixture):
+//
+//	client := mock.New()
+//	fx := mock.MustLoadFixture(mock.FixtureBondingCurve)
+//	client.Load(fx)
+//	accts, args, instrs, err := autofill.PumpBuy(ctx, client, user, fx.Address("mint"), amount, maxSol)
+package mock
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpfees"
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+)
+
+// ErrNoSimulator is returned by SimulateTransaction when Simulate is unset.
+var ErrNoSimulator = errors.New("mock: no simulator configured")
+
+// tokenAccountOwnerOffset is the offset of the owner field in a token account.
+const tokenAccountOwnerOffset = 32
+
+// accountDecoders decode accounts of the programs whose program accounts are
+// decoded by sdkrpc.Client.GetProgramAccountsFiltered.
+var accountDecoders = map[solana.PublicKey]func([]byte) (string, interface{}, error){
+	pump.ProgramKey:     pump.DecodeAccount,
+	pumpamm.ProgramKey:  pumpamm.DecodeAccount,
+	pumpfees.ProgramKey: pumpfees.DecodeAccount,
+}
+
+// Client is an in-memory RPC. The zero value is not usable; use New.
+type Client struct {
+	// Simulate, if set, answers SimulateTransaction.
+	Simulate func(ctx context.Context, tx *solana.Transaction, opts *solanarpc.SimulateTransactionOpts) (*solanarpc.SimulateTransactionResponse, error)
+
+	mu       sync.Mutex
+	accounts map[solana.PublicKey]*solanarpc.Account
+	calls    map[string]int
+	hidden   map[solana.PublicKey]int
+	reads    map[solana.PublicKey]int
+}
+
+// New returns an empty Client.
+func New() *Client {
+	return &Client{
+		accounts: make(map[solana.PublicKey]*solanarpc.Account),
+		calls:    make(map[string]int),
+		hidden:   make(map[solana.PublicKey]int),
+		reads:    make(map[solana.PublicKey]int),
+	}
+}
+
+// SetAccount stores an account, replacing any at addr.
+func (c *Client) SetAccount(addr, owner solana.PublicKey, lamports uint64, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accounts[addr] = &solanarpc.Account{
+		Owner:    owner,
+		Lamports: lamports,
+		Data:     solanarpc.DataBytesOrJSONFromBytes(bytes.Clone(data)),
+	}
+}
+
+// SetBalance stores a system account holding lamports, as for a wallet.
+func (c *Client) SetBalance(addr solana.PublicKey, lamports uint64) {
+	c.SetAccount(addr, constants.SystemProgramID, lamports, nil)
+}
+
+// DeleteAccount removes the account at addr.
+func (c *Client) DeleteAccount(addr solana.PublicKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.accounts, addr)
+}
+
+// Hide makes the account at addr read as missing through
+// GetMultipleAccounts for its next n reads, like a freshly created account
+// the node has not seen yet.
+func (c *Client) Hide(addr solana.PublicKey, n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hidden[addr] = n
+}
+
+// Load stores every account of fx.
+func (c *Client) Load(fx *Fixture) {
+	for _, acc := range fx.Accounts {
+		c.SetAccount(acc.Address, acc.Owner, acc.Lamports, acc.Data)
+	}
+}
+
+// Calls returns how many times method (e.g. "GetMultipleAccounts") was called.
+func (c *Client) Calls(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[method]
+}
+
+// Reads returns how many times addr was requested through
+// GetMultipleAccounts, hidden reads included.
+func (c *Client) Reads(addr solana.PublicKey) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.reads[addr]
+}
+
+func (c *Client) record(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[method]++
+}
+
+// account returns a copy of the account at addr, or nil.
+func (c *Client) account(addr solana.PublicKey) *solanarpc.Account {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	acc, ok := c.accounts[addr]
+	if !ok {
+		return nil
+	}
+	out := *acc
+	return &out
+}
+
+// read counts a GetMultipleAccounts read of addr and returns a copy of the
+// account there, or nil if it is missing or hidden.
+func (c *Client) read(addr solana.PublicKey) *solanarpc.Account {
+	c.mu.Lock()
+	c.reads[addr]++
+	if c.hidden[addr] > 0 {
+		c.hidden[addr]--
+		c.mu.Unlock()
+		return nil
+	}
+	c.mu.Unlock()
+	return c.account(addr)
+}
+
+// snapshot returns copies of every stored account.
+func (c *Client) snapshot() map[solana.PublicKey]*solanarpc.Account {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[solana.PublicKey]*solanarpc.Account, len(c.accounts))
+	for addr, acc := range c.accounts {
+		cp := *acc
+		out[addr] = &cp
+	}
+	return out
+}
+
+// GetMultipleAccounts returns the accounts at addrs, in order, with nil for
+// missing ones. The commitment is ignored.
+func (c *Client) GetMultipleAccounts(ctx context.Context, addrs []solana.PublicKey, _ solanarpc.CommitmentType) ([]*solanarpc.Account, error) {
+	c.record("GetMultipleAccounts")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	out := make([]*solanarpc.Account, len(addrs))
+	for i, addr := range addrs {
+		out[i] = c.read(addr)
+	}
+	return out, nil
+}
+
+// GetBalance returns the lamports of the account at addr, or 0 if it does
+// not exist.
+func (c *Client) GetBalance(ctx context.Context, addr solana.PublicKey, _ solanarpc.CommitmentType) (uint64, error) {
+	c.record("GetBalance")
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if acc := c.account(addr); acc != nil {
+		return acc.Lamports, nil
+	}
+	return 0, nil
+}
+
+// GetTokenAccountsByOwner returns the stored accounts owned by programID
+// whose token account owner field is owner.
+func (c *Client) GetTokenAccountsByOwner(ctx context.Context, owner, programID solana.PublicKey) ([]*solanarpc.TokenAccount, error) {
+	c.record("GetTokenAccountsByOwner")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var out []*solanarpc.TokenAccount
+	for addr, acc := range c.snapshot() {
+		data := acc.Data.GetBinary()
+		if !acc.Owner.Equals(programID) || len(data) < tokenAccountOwnerOffset+32 {
+			continue
+		}
+		if !bytes.Equal(data[tokenAccountOwnerOffset:tokenAccountOwnerOffset+32], owner[:]) {
+			continue
+		}
+		out = append(out, &solanarpc.TokenAccount{Pubkey: addr, Account: *acc})
+	}
+	return out, nil
+}
+
+// GetProgramAccountsFiltered returns the stored accounts owned by programID
+// that match every data size and memcmp filter, decoded as
+// sdkrpc.Client.GetProgramAccountsFiltered decodes them.
+func (c *Client) GetProgramAccountsFiltered(ctx context.Context, programID solana.PublicKey, filters []solanarpc.RPCFilter) ([]sdkrpc.ProgramAccount, error) {
+	c.record("GetProgramAccountsFiltered")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	decode := accountDecoders[programID]
+	var out []sdkrpc.ProgramAccount
+	for addr, acc := range c.snapshot() {
+		if !acc.Owner.Equals(programID) || !matchFilters(acc.Data.GetBinary(), filters) {
+			continue
+		}
+		pa := sdkrpc.ProgramAccount{Pubkey: addr, Account: acc}
+		if decode != nil {
+			if name, decoded, err := decode(acc.Data.GetBinary()); err == nil {
+				pa.Name, pa.Decoded = name, decoded
+			}
+		}
+		out = append(out, pa)
+	}
+	return out, nil
+}
+
+// matchFilters reports whether data matches every filter.
+func matchFilters(data []byte, filters []solanarpc.RPCFilter) bool {
+	for _, f := range filters {
+		if f.DataSize != 0 && uint64(len(data)) != f.DataSize {
+			return false
+		}
+		if m := f.Memcmp; m != nil {
+			end := m.Offset + uint64(len(m.Bytes))
+			if end > uint64(len(data)) || !bytes.Equal(data[m.Offset:end], m.Bytes) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SimulateTransaction calls Simulate, or returns ErrNoSimulator if it is unset.
+func (c *Client) SimulateTransaction(ctx context.Context, tx *solana.Transaction, opts *solanarpc.SimulateTransactionOpts) (*solanarpc.SimulateTransactionResponse, error) {
+	c.record("SimulateTransaction")
+	if c.Simulate == nil {
+		return nil, ErrNoSimulator
+	}
+	return c.Simulate(ctx, tx, opts)
+}
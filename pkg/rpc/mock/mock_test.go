@@ -0,0 +1,129 @@
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/autofill"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/rpc/mock"
+)
+
+var _ autofill.RPC = (*mock.Client)(nil)
+
+func TestPumpBuyFromFixture(t *testing.T) {
+	fx := mock.MustLoadFixture(mock.FixtureBondingCurve)
+	client := mock.New()
+	client.Load(fx)
+	user := solana.NewWallet().PublicKey()
+
+	accts, _, instrs, err := autofill.PumpBuy(context.Background(), client, user, fx.Address("mint"), 1_000_000, 100_000_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !accts.BondingCurve.Equals(fx.Address("bonding_curve")) || !accts.AssociatedBondingCurve.Equals(fx.Address("associated_bonding_curve")) {
+		t.Fatalf("accounts do not match the fixture: %+v", accts)
+	}
+	if !accts.TokenProgram.Equals(fx.Account("mint").Owner) {
+		t.Fatalf("token program = %s", accts.TokenProgram)
+	}
+	if len(instrs) == 0 {
+		t.Fatal("expected instructions")
+	}
+	if client.Calls("GetMultipleAccounts") == 0 {
+		t.Fatal("expected reads through the mock")
+	}
+}
+
+func TestPumpBuyMissingCurve(t *testing.T) {
+	fx := mock.MustLoadFixture(mock.FixtureBondingCurve)
+	client := mock.New()
+	client.Load(fx)
+	client.DeleteAccount(fx.Address("bonding_curve"))
+
+	_, _, _, err := autofill.PumpBuy(context.Background(), client, solana.NewWallet().PublicKey(), fx.Address("mint"), 1_000_000, 100_000_000)
+	if err == nil {
+		t.Fatal("expected an error for a missing bonding curve")
+	}
+}
+
+func TestFindPoolsFromFixture(t *testing.T) {
+	fx := mock.MustLoadFixture(mock.FixtureAmmPool)
+	client := mock.New()
+	client.Load(fx)
+
+	pools, err := autofill.FindPoolsByBaseMint(context.Background(), client, fx.Address("base_mint"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools) != 1 || !pools[0].Address.Equals(fx.Address("pool")) {
+		t.Fatalf("pools = %+v, want %s", pools, fx.Address("pool"))
+	}
+}
+
+func TestPumpAmmSellFromFixture(t *testing.T) {
+	fx := mock.MustLoadFixture(mock.FixtureAmmPool)
+	client := mock.New()
+	client.Load(fx)
+
+	accts, _, _, err := autofill.PumpAmmSell(context.Background(), client, solana.NewWallet().PublicKey(), fx.Address("pool"), 1_000_000, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !accts.PoolQuoteTokenAccount.Equals(fx.Address("pool_quote_token_account")) || !accts.BaseMint.Equals(fx.Address("base_mint")) {
+		t.Fatalf("accounts do not match the fixture: %+v", accts)
+	}
+}
+
+func TestSimulateWithoutSimulator(t *testing.T) {
+	_, err := mock.New().SimulateTransaction(context.Background(), &solana.Transaction{}, nil)
+	if !errors.Is(err, mock.ErrNoSimulator) {
+		t.Fatalf("err = %v, want ErrNoSimulator", err)
+	}
+}
+
+func TestDecodedProgramAccounts(t *testing.T) {
+	fx := mock.MustLoadFixture(mock.FixtureBondingCurve)
+	client := mock.New()
+	client.Load(fx)
+
+	accounts, err := client.GetProgramAccountsFiltered(context.Background(), pump.ProgramKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 2 {
+		t.Fatalf("got %d pump accounts, want global and bonding curve", len(accounts))
+	}
+	for _, acc := range accounts {
+		if acc.Decoded == nil {
+			t.Fatalf("account %s not decoded", acc.Pubkey)
+		}
+	}
+}
+
+func TestHideAndReads(t *testing.T) {
+	ctx := context.Background()
+	client := mock.New()
+	addr := solana.NewWallet().PublicKey()
+	client.SetBalance(addr, 1)
+	client.Hide(addr, 2)
+
+	for i, wantFound := range []bool{false, false, true, true} {
+		accounts, err := client.GetMultipleAccounts(ctx, []solana.PublicKey{addr}, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found := accounts[0] != nil; found != wantFound {
+			t.Fatalf("read %d: found = %v, want %v", i, found, wantFound)
+		}
+	}
+	if n := client.Reads(addr); n != 4 {
+		t.Fatalf("Reads = %d, want 4", n)
+	}
+	if n := client.Reads(solana.NewWallet().PublicKey()); n != 0 {
+		t.Fatalf("Reads of an unread address = %d, want 0", n)
+	}
+}
@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpfees"
+)
+
+// ProgramAccount is an account returned by GetProgramAccountsFiltered.
+type ProgramAccount struct {
+	Pubkey  solana.PublicKey
+	Account *solanarpc.Account
+	// Name is the account type matched by its discriminator, e.g. "Pool",
+	// or empty if the program or discriminator is unknown.
+	Name string
+	// Decoded points to the decoded account struct, e.g. *pumpamm.Pool, or
+	// is nil when Name is empty or the data does not decode.
+	Decoded interface{}
+}
+
+// accountDecoders are the generated account registries of the programs
+// GetProgramAccountsFiltered decodes.
+var accountDecoders = map[solana.PublicKey]struct {
+	decode  func([]byte) (string, interface{}, error)
+	unknown error
+}{
+	pump.ProgramKey:     {pump.DecodeAccount, pump.ErrUnknownAccount},
+	pumpamm.ProgramKey:  {pumpamm.DecodeAccount, pumpamm.ErrUnknownAccount},
+	pumpfees.ProgramKey: {pumpfees.DecodeAccount, pumpfees.ErrUnknownAccount},
+}
+
+// DataSizeFilter matches accounts whose data is exactly size bytes.
+func DataSizeFilter(size uint64) solanarpc.RPCFilter {
+	return solanarpc.RPCFilter{DataSize: size}
+}
+
+// MemcmpFilter matches accounts whose data contains b at offset.
+func MemcmpFilter(offset uint64, b []byte) solanarpc.RPCFilter {
+	return solanarpc.RPCFilter{Memcmp: &solanarpc.RPCFilterMemcmp{Offset: offset, Bytes: solana.Base58(b)}}
+}
+
+// GetProgramAccountsFiltered returns the accounts owned by programID that
+// match every filter, decoding those of the pump, pump AMM and pump fees
+// programs by their discriminator. Accounts that do not decode are returned
+// with an empty Name.
+//
+// getProgramAccounts scans the whole program and is slow or disabled on
+// many public nodes; always pass a discriminator or data size filter.
+//
+// Example:
+//
+//	accts, err := client.GetProgramAccountsFiltered(ctx, pump.ProgramKey, []solanarpc.RPCFilter{
+//	    rpc.MemcmpFilter(0, pump.BondingCurveDiscriminator),
+//	    rpc.MemcmpFilter(creatorOffset, creator.Bytes()),
+//	})
+func (c *Client) GetProgramAccountsFiltered(ctx context.Context, programID solana.PublicKey, filters []solanarpc.RPCFilter) ([]ProgramAccount, error) {
+	var res solanarpc.GetProgramAccountsResult
+	err := c.call(ctx, "getProgramAccounts", func(ctx context.Context) error {
+		var err error
+		res, err = c.raw.GetProgramAccountsWithOpts(ctx, programID, &solanarpc.GetProgramAccountsOpts{
+			Commitment: solanarpc.CommitmentType(c.cfg.Commitment),
+			Encoding:   solana.EncodingBase64,
+			Filters:    filters,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dec, known := accountDecoders[programID]
+	out := make([]ProgramAccount, 0, len(res))
+	for _, ka := range res {
+		if ka == nil {
+			continue
+		}
+		pa := ProgramAccount{Pubkey: ka.Pubkey, Account: ka.Account}
+		if known && ka.Account != nil && ka.Account.Data != nil {
+			name, decoded, err := dec.decode(ka.Account.Data.GetBinary())
+			if err == nil {
+				pa.Name, pa.Decoded = name, decoded
+			} else if !errors.Is(err, dec.unknown) {
+				c.log.Debug().Err(err).Str("account", ka.Pubkey.String()).Str("type", name).Msg("decode program account")
+			}
+		}
+		out = append(out, pa)
+	}
+	return out, nil
+}
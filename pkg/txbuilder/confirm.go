@@ -0,0 +1,138 @@
+package txbuilder
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+const (
+	// DefaultConfirmInterval is the base delay between signature status polls.
+	DefaultConfirmInterval = 100 * time.Millisecond
+	// DefaultConfirmJitter is the maximum random delay added to each poll so
+	// many waiters do not hit the RPC in lockstep.
+	DefaultConfirmJitter = 25 * time.Millisecond
+
+	// blockHeightCheckInterval throttles getBlockHeight calls while a
+	// signature is still unknown (roughly one slot).
+	blockHeightCheckInterval = 400 * time.Millisecond
+)
+
+// WithConfirmPolling sets the signature status polling interval and the
+// maximum random jitter added to each poll. Zero values keep the defaults
+// (DefaultConfirmInterval, DefaultConfirmJitter); a negative jitter disables it.
+func (b *Builder) WithConfirmPolling(interval, jitter time.Duration) *Builder {
+	b.confirmInterval = interval
+	b.confirmJitter = jitter
+	return b
+}
+
+// WithConfirmMaxElapsed bounds how long WaitForConfirmation polls before
+// returning types.ErrConfirmationTimeout, independently of ctx. Zero uses
+// the client's ConfirmTimeout.
+func (b *Builder) WithConfirmMaxElapsed(d time.Duration) *Builder {
+	b.confirmMaxElapsed = d
+	return b
+}
+
+// WaitForConfirmationUntil is WaitForConfirmation for a transaction whose
+// blockhash is valid up to lastValidBlockHeight (from GetLatestBlockhash).
+// Once the chain passes that height without the signature being seen, the
+// transaction can no longer land and types.ErrBlockhashExpired is returned
+// instead of polling until the deadline. Zero disables the check.
+func (b *Builder) WaitForConfirmationUntil(ctx context.Context, sig solana.Signature, level ConfirmationLevel, lastValidBlockHeight uint64) error {
+	if b.client == nil {
+		return fmt.Errorf("rpc client is nil")
+	}
+
+	maxElapsed := b.confirmMaxElapsed
+	if maxElapsed <= 0 {
+		maxElapsed = b.client.ConfirmTimeout()
+	}
+	var deadline <-chan time.Time
+	if maxElapsed > 0 {
+		timer := time.NewTimer(maxElapsed)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	start := time.Now()
+	var lastHeightCheck time.Time
+	poll := time.NewTimer(b.confirmDelay())
+	defer poll.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			return fmt.Errorf("%w: %s not %s after %s", types.ErrConfirmationTimeout, sig, level, time.Since(start).Round(time.Millisecond))
+		case <-poll.C:
+		}
+
+		done, err := b.checkStatus(ctx, sig, level, start)
+		if done {
+			return err
+		}
+		if lastValidBlockHeight > 0 && time.Since(lastHeightCheck) >= blockHeightCheckInterval {
+			lastHeightCheck = time.Now()
+			height, err := b.client.GetBlockHeight(ctx, toCommitment(level))
+			if err == nil && height > lastValidBlockHeight {
+				// The signature may have landed since the last poll.
+				if done, err := b.checkStatus(ctx, sig, level, start); done {
+					return err
+				}
+				b.settleDuplicate(sig)
+				b.log.Debug().Stringer("sig", sig).Uint64("block_height", height).Uint64("last_valid", lastValidBlockHeight).Msg("tx expired")
+				return fmt.Errorf("%w: %s (block height %d > last valid %d)", types.ErrBlockhashExpired, sig, height, lastValidBlockHeight)
+			}
+		}
+		poll.Reset(b.confirmDelay())
+	}
+}
+
+// checkStatus polls sig once. done reports whether waiting is over, with a
+// nil error on confirmation; transient RPC errors and unknown signatures
+// keep waiting.
+func (b *Builder) checkStatus(ctx context.Context, sig solana.Signature, level ConfirmationLevel, start time.Time) (done bool, err error) {
+	resp, err := b.client.Raw().GetSignatureStatuses(ctx, true, sig)
+	if err != nil || resp == nil || len(resp.Value) == 0 || resp.Value[0] == nil {
+		return false, nil
+	}
+	status := resp.Value[0]
+	if status.Err != nil {
+		b.settleDuplicate(sig)
+		b.log.Debug().Stringer("sig", sig).Interface("err", status.Err).Msg("tx failed on-chain")
+		return true, fmt.Errorf("transaction failed: %v", status.Err)
+	}
+	if !reached(status.ConfirmationStatus, level) {
+		return false, nil
+	}
+	b.settleDuplicate(sig)
+	b.log.Debug().
+		Stringer("sig", sig).
+		Str("status", string(status.ConfirmationStatus)).
+		Dur("elapsed", time.Since(start)).
+		Msg("tx confirmed")
+	return true, nil
+}
+
+// confirmDelay returns the next poll delay: the interval plus random jitter.
+func (b *Builder) confirmDelay() time.Duration {
+	interval, jitter := b.confirmInterval, b.confirmJitter
+	if interval <= 0 {
+		interval = DefaultConfirmInterval
+	}
+	if jitter == 0 {
+		jitter = DefaultConfirmJitter
+	}
+	if jitter > 0 {
+		interval += rand.N(jitter)
+	}
+	return interval
+}
@@ -0,0 +1,109 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+	"github.com/ninja0404/pump-go-sdk/pkg/wallet"
+)
+
+func unknownSignatureStatus(json.RawMessage) (interface{}, error) {
+	return rpcContext([]interface{}{nil}), nil
+}
+
+func TestWaitForConfirmationTimesOut(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("getSignatureStatuses", unknownSignatureStatus)
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed).
+		WithConfirmPolling(5*time.Millisecond, 2*time.Millisecond).
+		WithConfirmMaxElapsed(60 * time.Millisecond)
+
+	start := time.Now()
+	err := b.WaitForConfirmation(context.Background(), testTx(t).Signatures[0], ConfirmationConfirmed)
+	if !errors.Is(err, types.ErrConfirmationTimeout) {
+		t.Fatalf("expected ErrConfirmationTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("timeout took too long: %s", elapsed)
+	}
+	if n := fake.callCount("getSignatureStatuses"); n < 3 {
+		t.Fatalf("expected repeated polling, got %d calls", n)
+	}
+}
+
+func TestWaitForConfirmationUntilDetectsExpiry(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("getSignatureStatuses", unknownSignatureStatus)
+	fake.handle("getBlockHeight", func(json.RawMessage) (interface{}, error) {
+		return 1_001, nil
+	})
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed).
+		WithConfirmPolling(5*time.Millisecond, -1).
+		WithConfirmMaxElapsed(5 * time.Second)
+
+	err := b.WaitForConfirmationUntil(context.Background(), testTx(t).Signatures[0], ConfirmationConfirmed, 1_000)
+	if !errors.Is(err, types.ErrBlockhashExpired) {
+		t.Fatalf("expected ErrBlockhashExpired, got %v", err)
+	}
+}
+
+func TestWaitForConfirmationConfirms(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("getSignatureStatuses", func(json.RawMessage) (interface{}, error) {
+		return rpcContext([]interface{}{map[string]interface{}{
+			"slot": 1, "confirmations": nil, "err": nil, "confirmationStatus": "confirmed",
+		}}), nil
+	})
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed).WithConfirmPolling(time.Millisecond, -1)
+	if err := b.WaitForConfirmation(context.Background(), testTx(t).Signatures[0], ConfirmationConfirmed); err != nil {
+		t.Fatalf("WaitForConfirmation: %v", err)
+	}
+}
+
+func TestSentTransactionExpiryIsTracked(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("getLatestBlockhash", func(json.RawMessage) (interface{}, error) {
+		return rpcContext(map[string]interface{}{"blockhash": solana.Hash{7}.String(), "lastValidBlockHeight": 500}), nil
+	})
+	fake.handle("getSignatureStatuses", unknownSignatureStatus)
+	fake.handle("getBlockHeight", func(json.RawMessage) (interface{}, error) {
+		return 501, nil
+	})
+
+	key, _ := solana.NewRandomPrivateKey()
+	payer := wallet.NewLocalFromPrivateKey(key)
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed).
+		WithConfirmPolling(5*time.Millisecond, -1).
+		WithConfirmMaxElapsed(5 * time.Second)
+
+	ix := system.NewTransferInstruction(1, payer.PublicKey(), payer.PublicKey()).Build()
+	tx, err := b.BuildTransaction(context.Background(), payer.PublicKey(), ix)
+	if err != nil {
+		t.Fatalf("BuildTransaction: %v", err)
+	}
+	if lastValid, ok := b.LastValidBlockHeight(tx); !ok || lastValid != 500 {
+		t.Fatalf("LastValidBlockHeight: got %d, %v", lastValid, ok)
+	}
+	if err := SignTransaction(context.Background(), tx, payer); err != nil {
+		t.Fatal(err)
+	}
+	fake.handle("sendTransaction", func(json.RawMessage) (interface{}, error) {
+		return tx.Signatures[0].String(), nil
+	})
+
+	sig, err := b.SendViaRPC(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("SendViaRPC: %v", err)
+	}
+	if err := b.WaitForConfirmation(context.Background(), sig, ConfirmationConfirmed); !errors.Is(err, types.ErrBlockhashExpired) {
+		t.Fatalf("expected ErrBlockhashExpired, got %v", err)
+	}
+}
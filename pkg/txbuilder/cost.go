@@ -0,0 +1,184 @@
+package txbuilder
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+)
+
+const (
+	// lamportsPerSignature is the base fee per transaction signature.
+	lamportsPerSignature = 5000
+	// setComputeUnitLimitDiscriminator is the ComputeBudget SetComputeUnitLimit tag.
+	setComputeUnitLimitDiscriminator = 2
+	// defaultInstructionCUs and maxTransactionCUs are the runtime's compute
+	// limits when no SetComputeUnitLimit is given.
+	defaultInstructionCUs = 200_000
+	maxTransactionCUs     = 1_400_000
+	// tokenAccountSize is an SPL token account; Token-2022 ATAs also carry
+	// the account type byte and the ImmutableOwner extension.
+	tokenAccountSize     = 165
+	token2022AccountSize = 170
+)
+
+// CostBreakdown is the SOL a transaction can cost its fee payer, in lamports.
+type CostBreakdown struct {
+	// Signatures is the number of required signatures.
+	Signatures int
+	// BaseFee is 5000 lamports per signature.
+	BaseFee uint64
+	// ComputeUnitLimit is the requested limit, or the runtime default.
+	ComputeUnitLimit uint32
+	// ComputeUnitPrice is the priority price in micro-lamports per CU.
+	ComputeUnitPrice uint64
+	// PriorityFee is ComputeUnitPrice × ComputeUnitLimit, rounded up.
+	PriorityFee uint64
+	// ATACreations counts associated token accounts the transaction creates
+	// that do not exist yet; ATARent is their rent-exempt deposit.
+	ATACreations int
+	ATARent      uint64
+	// Transfers is SOL moved out of the fee payer by system transfers, such
+	// as WSOL wraps and Jito tips.
+	Transfers uint64
+	// TradeMax is the most SOL pump bonding-curve buys may spend
+	// (max_sol_cost or spendable_sol_in). Pool trades pay through WSOL and
+	// are counted in Transfers.
+	TradeMax uint64
+}
+
+// Fees returns the network fees: base fee plus priority fee.
+func (c CostBreakdown) Fees() uint64 {
+	return c.BaseFee + c.PriorityFee
+}
+
+// Total returns the worst-case SOL spent by the fee payer.
+func (c CostBreakdown) Total() uint64 {
+	return c.Fees() + c.ATARent + c.Transfers + c.TradeMax
+}
+
+// EstimateCost returns the worst-case cost of tx to its fee payer: base and
+// priority fees read from the message and its compute budget instructions,
+// rent for associated token accounts it would create, SOL transferred out
+// and the SOL cap of pump buys. The transaction need not be signed.
+//
+// Rent refunds (closed accounts) and trade proceeds are not subtracted.
+//
+// Example:
+//
+//	cost, err := txbuilder.EstimateCost(ctx, rpc, tx)
+//	if err == nil && cost.Total() > maxCost {
+//	    return fmt.Errorf("trade would cost %d lamports", cost.Total())
+//	}
+func EstimateCost(ctx context.Context, rpc *sdkrpc.Client, tx *solana.Transaction) (*CostBreakdown, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("transaction is nil")
+	}
+	msg := tx.Message
+	if len(msg.AccountKeys) == 0 {
+		return nil, fmt.Errorf("transaction has no account keys")
+	}
+	payer := msg.AccountKeys[0]
+	cost := &CostBreakdown{Signatures: int(msg.Header.NumRequiredSignatures)}
+	cost.BaseFee = uint64(cost.Signatures) * lamportsPerSignature
+
+	var (
+		limit, budgetIxs uint32
+		hasLimit         bool
+		ataSizes         = make(map[solana.PublicKey]uint64)
+	)
+	for _, ix := range msg.Instructions {
+		program, err := msg.Program(ix.ProgramIDIndex)
+		if err != nil {
+			return nil, fmt.Errorf("resolve program: %w", err)
+		}
+		accounts, err := ix.ResolveInstructionAccounts(&msg)
+		if err != nil {
+			return nil, fmt.Errorf("resolve accounts: %w", err)
+		}
+		data := []byte(ix.Data)
+
+		switch {
+		case program.Equals(constants.ComputeBudgetProgramID):
+			budgetIxs++
+			switch {
+			case len(data) >= 5 && data[0] == setComputeUnitLimitDiscriminator:
+				limit, hasLimit = binary.LittleEndian.Uint32(data[1:]), true
+			case len(data) >= 9 && data[0] == setComputeUnitPriceDiscriminator:
+				cost.ComputeUnitPrice = binary.LittleEndian.Uint64(data[1:])
+			}
+		case program.Equals(solana.SPLAssociatedTokenAccountProgramID):
+			// Accounts: payer, ata, wallet, mint, system program, token program.
+			if len(accounts) >= 6 && accounts[0].PublicKey.Equals(payer) {
+				size := uint64(tokenAccountSize)
+				if accounts[5].PublicKey.Equals(constants.Token2022ProgramID) {
+					size = token2022AccountSize
+				}
+				ataSizes[accounts[1].PublicKey] = size
+			}
+		case program.Equals(solana.SystemProgramID):
+			if len(data) >= 12 && binary.LittleEndian.Uint32(data) == 2 && len(accounts) >= 1 && accounts[0].PublicKey.Equals(payer) {
+				cost.Transfers += binary.LittleEndian.Uint64(data[4:])
+			}
+		case program.Equals(pump.ProgramKey):
+			switch {
+			case len(data) >= 24 && bytes.HasPrefix(data, pump.BuyDiscriminator):
+				cost.TradeMax += binary.LittleEndian.Uint64(data[16:]) // after amount
+			case len(data) >= 16 && bytes.HasPrefix(data, pump.BuyExactSolInDiscriminator):
+				cost.TradeMax += binary.LittleEndian.Uint64(data[8:])
+			}
+		}
+	}
+
+	if hasLimit {
+		cost.ComputeUnitLimit = limit
+	} else {
+		cost.ComputeUnitLimit = uint32(min(uint64(len(msg.Instructions)-int(budgetIxs))*defaultInstructionCUs, maxTransactionCUs))
+	}
+	cost.PriorityFee = (cost.ComputeUnitPrice*uint64(cost.ComputeUnitLimit) + 999_999) / 1_000_000
+
+	if len(ataSizes) > 0 {
+		if rpc == nil {
+			return nil, fmt.Errorf("rpc client is nil")
+		}
+		if err := addATARent(ctx, rpc, cost, ataSizes); err != nil {
+			return nil, err
+		}
+	}
+	return cost, nil
+}
+
+// addATARent adds the rent of the ATAs in sizes that do not exist yet.
+func addATARent(ctx context.Context, rpc *sdkrpc.Client, cost *CostBreakdown, sizes map[solana.PublicKey]uint64) error {
+	addrs := make([]solana.PublicKey, 0, len(sizes))
+	for addr := range sizes {
+		addrs = append(addrs, addr)
+	}
+	existing, err := rpc.Raw().GetMultipleAccounts(ctx, addrs...)
+	if err != nil {
+		return fmt.Errorf("get ata accounts: %w", err)
+	}
+	rent := make(map[uint64]uint64)
+	for i, addr := range addrs {
+		if existing != nil && i < len(existing.Value) && existing.Value[i] != nil {
+			continue // idempotent create is a no-op
+		}
+		size := sizes[addr]
+		if _, ok := rent[size]; !ok {
+			lamports, err := rpc.GetMinimumBalanceForRentExemption(ctx, size)
+			if err != nil {
+				return fmt.Errorf("get rent exemption: %w", err)
+			}
+			rent[size] = lamports
+		}
+		cost.ATACreations++
+		cost.ATARent += rent[size]
+	}
+	return nil
+}
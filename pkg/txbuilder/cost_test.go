@@ -0,0 +1,88 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+)
+
+func TestEstimateCost(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("getMultipleAccounts", func(json.RawMessage) (interface{}, error) {
+		return rpcContext([]interface{}{nil}), nil
+	})
+	fake.handle("getMinimumBalanceForRentExemption", func(json.RawMessage) (interface{}, error) {
+		return 2_039_280, nil
+	})
+
+	payer := solana.NewWallet().PublicKey()
+	ata := solana.NewWallet().PublicKey()
+	limit := make([]byte, 5)
+	limit[0] = setComputeUnitLimitDiscriminator
+	binary.LittleEndian.PutUint32(limit[1:], 100_000)
+	buy := append(append([]byte{}, pump.BuyDiscriminator...), make([]byte, 16)...)
+	binary.LittleEndian.PutUint64(buy[8:], 1_000_000)   // amount
+	binary.LittleEndian.PutUint64(buy[16:], 50_000_000) // max_sol_cost
+
+	instrs := []solana.Instruction{
+		solana.NewInstruction(constants.ComputeBudgetProgramID, nil, limit),
+		newSetComputeUnitPrice(25_000),
+		solana.NewInstruction(solana.SPLAssociatedTokenAccountProgramID, solana.AccountMetaSlice{
+			solana.NewAccountMeta(payer, true, true),
+			solana.NewAccountMeta(ata, true, false),
+			solana.NewAccountMeta(payer, false, false),
+			solana.NewAccountMeta(solana.NewWallet().PublicKey(), false, false),
+			solana.NewAccountMeta(solana.SystemProgramID, false, false),
+			solana.NewAccountMeta(solana.TokenProgramID, false, false),
+		}, []byte{1}),
+		system.NewTransferInstruction(1_000_000, payer, solana.NewWallet().PublicKey()).Build(),
+		solana.NewInstruction(pump.ProgramKey, solana.AccountMetaSlice{solana.NewAccountMeta(payer, true, true)}, buy),
+	}
+	tx, err := solana.NewTransaction(instrs, solana.Hash{1}, solana.TransactionPayer(payer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cost, err := EstimateCost(context.Background(), client, tx)
+	if err != nil {
+		t.Fatalf("EstimateCost: %v", err)
+	}
+	want := CostBreakdown{
+		Signatures:       1,
+		BaseFee:          5000,
+		ComputeUnitLimit: 100_000,
+		ComputeUnitPrice: 25_000,
+		PriorityFee:      2500, // 25_000 µlamports × 100_000 CU
+		ATACreations:     1,
+		ATARent:          2_039_280,
+		Transfers:        1_000_000,
+		TradeMax:         50_000_000,
+	}
+	if *cost != want {
+		t.Fatalf("cost = %+v\nwant   %+v", *cost, want)
+	}
+	if got := cost.Total(); got != 5000+2500+2_039_280+1_000_000+50_000_000 {
+		t.Fatalf("total = %d", got)
+	}
+}
+
+func TestEstimateCostDefaultLimit(t *testing.T) {
+	_, client := newFakeRPC(t)
+	tx := testTx(t)
+	tx.Message.Instructions = append(tx.Message.Instructions, tx.Message.Instructions[0])
+
+	cost, err := EstimateCost(context.Background(), client, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cost.ComputeUnitLimit != 2*defaultInstructionCUs || cost.PriorityFee != 0 {
+		t.Fatalf("limit = %d, priority = %d; want %d, 0", cost.ComputeUnitLimit, cost.PriorityFee, 2*defaultInstructionCUs)
+	}
+}
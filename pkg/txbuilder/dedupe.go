@@ -0,0 +1,142 @@
+package txbuilder
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+)
+
+// DefaultDedupeTTL is how long WithDedupe remembers a sent transaction. It
+// roughly matches blockhash validity, after which the first send can no
+// longer land.
+const DefaultDedupeTTL = 90 * time.Second
+
+// ErrDuplicateSend is returned when WithDedupe blocks a transaction that is
+// logically identical to one still awaiting confirmation. The send call
+// returns the pending transaction's signature alongside it so the caller
+// can wait on that instead:
+//
+//	sig, err := builder.Send(ctx, tx)
+//	if errors.Is(err, txbuilder.ErrDuplicateSend) {
+//	    err = builder.WaitForConfirmation(ctx, sig, txbuilder.ConfirmationConfirmed)
+//	}
+var ErrDuplicateSend = errors.New("duplicate send: identical transaction still pending")
+
+// WithDedupe refuses to send a transaction whose fee payer and first
+// (non compute budget) instruction match one sent within DefaultDedupeTTL
+// that has not been confirmed yet. This guards retry loops that re-build a
+// sell with a fresh blockhash from submitting it twice.
+//
+// The guard is best-effort and in-process only: it does not see sends from
+// other processes or builders, entries are forgotten after the TTL even if
+// the first transaction never confirmed, and two intentionally identical
+// trades (same payer, accounts and amounts) are treated as one until the
+// first is confirmed via WaitForConfirmation.
+func (b *Builder) WithDedupe() *Builder {
+	b.dedupe = newDedupeCache(DefaultDedupeTTL)
+	return b
+}
+
+type dedupeKey struct {
+	payer solana.PublicKey
+	ix    [sha256.Size]byte
+}
+
+type pendingSend struct {
+	sig     solana.Signature
+	expires time.Time
+}
+
+// dedupeCache tracks in-flight transactions by intent.
+type dedupeCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	pending map[dedupeKey]pendingSend
+}
+
+func newDedupeCache(ttl time.Duration) *dedupeCache {
+	return &dedupeCache{ttl: ttl, pending: make(map[dedupeKey]pendingSend)}
+}
+
+// reserve records tx as in flight. If an identical transaction is already
+// pending it returns that transaction's signature and ErrDuplicateSend.
+func (d *dedupeCache) reserve(tx *solana.Transaction) (dedupeKey, solana.Signature, error) {
+	key, ok := dedupeKeyOf(tx)
+	if !ok {
+		return key, solana.Signature{}, nil
+	}
+	var sig solana.Signature
+	if len(tx.Signatures) > 0 {
+		sig = tx.Signatures[0]
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := time.Now()
+	for k, p := range d.pending {
+		if now.After(p.expires) {
+			delete(d.pending, k)
+		}
+	}
+	if p, exists := d.pending[key]; exists && p.sig != sig {
+		return key, p.sig, fmt.Errorf("%w (sig %s)", ErrDuplicateSend, p.sig)
+	}
+	d.pending[key] = pendingSend{sig: sig, expires: now.Add(d.ttl)}
+	return key, sig, nil
+}
+
+// release forgets key, e.g. after the send itself failed.
+func (d *dedupeCache) release(key dedupeKey) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.pending, key)
+}
+
+// settle forgets the entry for sig once it is confirmed or failed on-chain.
+func (d *dedupeCache) settle(sig solana.Signature) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for k, p := range d.pending {
+		if p.sig == sig {
+			delete(d.pending, k)
+		}
+	}
+}
+
+// dedupeKeyOf hashes the fee payer and the first instruction that is not a
+// compute budget instruction (those vary between retries). Account indexes
+// are resolved to keys so differing key order does not matter.
+func dedupeKeyOf(tx *solana.Transaction) (dedupeKey, bool) {
+	msg := tx.Message
+	if len(msg.AccountKeys) == 0 {
+		return dedupeKey{}, false
+	}
+	keyAt := func(i uint16) []byte {
+		if int(i) < len(msg.AccountKeys) {
+			return msg.AccountKeys[i].Bytes()
+		}
+		return []byte{byte(i), byte(i >> 8)}
+	}
+	for _, ix := range msg.Instructions {
+		program := keyAt(ix.ProgramIDIndex)
+		if solana.PublicKeyFromBytes(program).Equals(constants.ComputeBudgetProgramID) {
+			continue
+		}
+		h := sha256.New()
+		h.Write(program)
+		for _, idx := range ix.Accounts {
+			h.Write(keyAt(idx))
+		}
+		h.Write(ix.Data)
+		key := dedupeKey{payer: msg.AccountKeys[0]}
+		copy(key.ix[:], h.Sum(nil))
+		return key, true
+	}
+	return dedupeKey{}, false
+}
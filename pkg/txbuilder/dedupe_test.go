@@ -0,0 +1,81 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+)
+
+func signedTransfer(t *testing.T, key solana.PrivateKey, blockhash solana.Hash, extra ...solana.Instruction) *solana.Transaction {
+	t.Helper()
+	payer := key.PublicKey()
+	ix := solana.NewInstruction(solana.SystemProgramID, solana.AccountMetaSlice{
+		solana.NewAccountMeta(payer, true, true),
+	}, []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0})
+	tx, err := solana.NewTransaction(append(extra, ix), blockhash, solana.TransactionPayer(payer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Sign(func(pk solana.PublicKey) *solana.PrivateKey { return &key }); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func TestDedupeBlocksSecondIntent(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("sendTransaction", func(params json.RawMessage) (interface{}, error) {
+		return solana.Signature{}.String(), nil
+	})
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed).WithDedupe()
+	ctx := context.Background()
+	key, _ := solana.NewRandomPrivateKey()
+
+	first := signedTransfer(t, key, solana.Hash{1})
+	if _, err := b.SendViaRPC(ctx, first); err != nil {
+		t.Fatalf("first send: %v", err)
+	}
+	// Same intent, fresh blockhash and an extra compute budget instruction.
+	retry := signedTransfer(t, key, solana.Hash{2}, newSetComputeUnitPrice(1000))
+	pending, err := b.SendViaRPC(ctx, retry)
+	if !errors.Is(err, ErrDuplicateSend) {
+		t.Fatalf("expected ErrDuplicateSend, got %v", err)
+	}
+	if pending != first.Signatures[0] {
+		t.Fatalf("expected pending signature %s, got %s", first.Signatures[0], pending)
+	}
+	if n := fake.callCount("sendTransaction"); n != 1 {
+		t.Fatalf("expected one send, got %d", n)
+	}
+
+	// Re-sending the very same signed transaction is allowed.
+	if _, err := b.SendViaRPC(ctx, first); err != nil {
+		t.Fatalf("re-send of identical tx: %v", err)
+	}
+
+	b.settleDuplicate(first.Signatures[0])
+	if _, err := b.SendViaRPC(ctx, retry); err != nil {
+		t.Fatalf("send after first settled: %v", err)
+	}
+}
+
+func TestDedupeReleasesOnSendFailure(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("sendTransaction", func(params json.RawMessage) (interface{}, error) {
+		return nil, errors.New("node is behind")
+	})
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed).WithDedupe()
+	key, _ := solana.NewRandomPrivateKey()
+
+	if _, err := b.SendViaRPC(context.Background(), signedTransfer(t, key, solana.Hash{1})); err == nil {
+		t.Fatal("expected send error")
+	}
+	_, err := b.SendViaRPC(context.Background(), signedTransfer(t, key, solana.Hash{2}))
+	if errors.Is(err, ErrDuplicateSend) {
+		t.Fatal("a failed send must not block the retry")
+	}
+}
@@ -0,0 +1,132 @@
+package txbuilder
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+)
+
+// getTransaction lookups right after confirmation are retried for up to
+// eventFetchAttempts * eventFetchInterval.
+const (
+	eventFetchAttempts = 15
+	eventFetchInterval = 200 * time.Millisecond
+)
+
+// TransactionEvents holds what a landed transaction emitted.
+type TransactionEvents struct {
+	Signature solana.Signature
+	Slot      uint64
+	// Fee is the transaction fee paid, in lamports.
+	Fee uint64
+	// Logs are the program logs; Anchor events emitted with emit! appear
+	// as "Program data: <base64>" lines (see pumpamm.ParseBuyEvent).
+	Logs []string
+	// EventData holds raw Anchor event payloads (discriminator + data) from
+	// both "Program data:" logs and self-CPI inner instructions (emit_cpi!),
+	// ready for decoders such as pumpamm.DecodeSellEvent.
+	EventData [][]byte
+}
+
+// SendAndConfirmWithEvents sends tx, waits for level and then fetches the
+// landed transaction's logs and event payloads, giving exact fills instead
+// of inferring them from balance deltas.
+//
+// Example:
+//
+//	sig, evs, err := builder.SendAndConfirmWithEvents(ctx, tx, txbuilder.ConfirmationConfirmed)
+//	for _, data := range evs.EventData {
+//	    if ev, err := pumpamm.DecodeSellEvent(data); err == nil {
+//	        fmt.Println("received", ev.UserQuoteAmountOut)
+//	    }
+//	}
+func (b *Builder) SendAndConfirmWithEvents(ctx context.Context, tx *solana.Transaction, level ConfirmationLevel) (solana.Signature, *TransactionEvents, error) {
+	sig, err := b.SendAndConfirm(ctx, tx, level)
+	if err != nil {
+		return sig, nil, err
+	}
+	evs, err := b.FetchTransactionEvents(ctx, sig, level)
+	if err != nil {
+		return sig, nil, err
+	}
+	return sig, evs, nil
+}
+
+// FetchTransactionEvents loads the logs and event payloads of a confirmed
+// transaction. A just-confirmed transaction may take a moment to be served
+// by getTransaction, so lookups are retried for a few seconds.
+func (b *Builder) FetchTransactionEvents(ctx context.Context, sig solana.Signature, level ConfirmationLevel) (*TransactionEvents, error) {
+	if b.client == nil {
+		return nil, fmt.Errorf("rpc client is nil")
+	}
+	commitment := toCommitment(level)
+	if commitment == solanarpc.CommitmentProcessed {
+		// getTransaction does not support processed.
+		commitment = solanarpc.CommitmentConfirmed
+	}
+	res, err := fetchTransaction(ctx, b.client, sig, commitment)
+	if err != nil {
+		return nil, err
+	}
+	return transactionEvents(sig, res), nil
+}
+
+// fetchTransaction loads a landed transaction with its metadata, retrying
+// for a few seconds while the node has not indexed it yet. Versioned
+// transactions are supported.
+func fetchTransaction(ctx context.Context, client *sdkrpc.Client, sig solana.Signature, commitment solanarpc.CommitmentType) (*solanarpc.GetTransactionResult, error) {
+	maxVersion := uint64(0)
+	opts := &solanarpc.GetTransactionOpts{
+		Encoding:                       solana.EncodingBase64,
+		Commitment:                     commitment,
+		MaxSupportedTransactionVersion: &maxVersion,
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < eventFetchAttempts; attempt++ {
+		res, err := client.GetTransaction(ctx, sig, opts)
+		if err == nil && res != nil && res.Meta != nil {
+			return res, nil
+		}
+		if err == nil {
+			err = solanarpc.ErrNotFound
+		}
+		lastErr = err
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("get transaction %s: %w", sig, ctx.Err())
+		case <-time.After(eventFetchInterval):
+		}
+	}
+	return nil, fmt.Errorf("get transaction %s: %w", sig, lastErr)
+}
+
+func transactionEvents(sig solana.Signature, res *solanarpc.GetTransactionResult) *TransactionEvents {
+	out := &TransactionEvents{Signature: sig, Slot: res.Slot, Fee: res.Meta.Fee, Logs: res.Meta.LogMessages}
+	for _, line := range res.Meta.LogMessages {
+		payload, ok := strings.CutPrefix(line, "Program data: ")
+		if !ok {
+			continue
+		}
+		if data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(payload)); err == nil {
+			out.EventData = append(out.EventData, data)
+		}
+	}
+	for _, inner := range res.Meta.InnerInstructions {
+		for _, ix := range inner.Instructions {
+			if bytes.HasPrefix(ix.Data, constants.AnchorEventIxTag) {
+				out.EventData = append(out.EventData, ix.Data[len(constants.AnchorEventIxTag):])
+			}
+		}
+	}
+	return out
+}
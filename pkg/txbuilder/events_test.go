@@ -0,0 +1,64 @@
+package txbuilder
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+)
+
+func TestFetchTransactionEvents(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	tx := testTx(t)
+	raw, _ := tx.MarshalBinary()
+	logged := []byte{1, 2, 3, 4, 5, 6, 7, 8, 42}
+	cpi := []byte{8, 7, 6, 5, 4, 3, 2, 1, 7}
+
+	calls := 0
+	fake.handle("getTransaction", func(json.RawMessage) (interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, nil // not yet indexed
+		}
+		return map[string]interface{}{
+			"slot": 77,
+			"meta": map[string]interface{}{
+				"err":          nil,
+				"fee":          5000,
+				"preBalances":  []uint64{},
+				"postBalances": []uint64{},
+				"logMessages": []string{
+					"Program log: Instruction: Sell",
+					"Program data: " + base64.StdEncoding.EncodeToString(logged),
+				},
+				"innerInstructions": []interface{}{map[string]interface{}{
+					"index": 0,
+					"instructions": []interface{}{map[string]interface{}{
+						"programIdIndex": 1,
+						"accounts":       []int{},
+						"data":           solana.Base58(append(append([]byte{}, constants.AnchorEventIxTag...), cpi...)).String(),
+					}},
+				}},
+			},
+			"transaction": []string{base64.StdEncoding.EncodeToString(raw), "base64"},
+		}, nil
+	})
+
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed)
+	evs, err := b.FetchTransactionEvents(context.Background(), tx.Signatures[0], ConfirmationConfirmed)
+	if err != nil {
+		t.Fatalf("FetchTransactionEvents: %v", err)
+	}
+	if evs.Slot != 77 || len(evs.Logs) != 2 {
+		t.Fatalf("unexpected result %+v", evs)
+	}
+	if len(evs.EventData) != 2 || !bytes.Equal(evs.EventData[0], logged) || !bytes.Equal(evs.EventData[1], cpi) {
+		t.Fatalf("unexpected event payloads %v", evs.EventData)
+	}
+}
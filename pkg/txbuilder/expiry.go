@@ -0,0 +1,156 @@
+package txbuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+)
+
+// ErrBlockhashTooOld is returned by CheckBlockhashAge when more slots than
+// allowed have passed since the transaction's blockhash was fetched.
+var ErrBlockhashTooOld = errors.New("blockhash older than the allowed age")
+
+// expiryRetention is how long blockhash validity is remembered; a blockhash
+// is only valid for ~150 blocks (about a minute).
+const expiryRetention = 3 * time.Minute
+
+// expiryTracker remembers the last valid block height of blockhashes fetched
+// by BuildTransaction and of the signatures sent with them, so confirmation
+// can tell "not yet landed" from "can no longer land". The slot each
+// blockhash was fetched at is kept to measure its age.
+type expiryTracker struct {
+	mu     sync.Mutex
+	byHash map[solana.Hash]expiryEntry
+	bySig  map[solana.Signature]expiryEntry
+}
+
+type expiryEntry struct {
+	lastValid uint64
+	slot      uint64
+	at        time.Time
+}
+
+func newExpiryTracker() *expiryTracker {
+	return &expiryTracker{
+		byHash: make(map[solana.Hash]expiryEntry),
+		bySig:  make(map[solana.Signature]expiryEntry),
+	}
+}
+
+// recordBlockhash stores the validity of a blockhash fetched at slot.
+func (e *expiryTracker) recordBlockhash(hash solana.Hash, lastValid, slot uint64) {
+	if e == nil || lastValid == 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.pruneLocked(time.Now())
+	e.byHash[hash] = expiryEntry{lastValid: lastValid, slot: slot, at: time.Now()}
+}
+
+// bindSignature associates a sent transaction's signature with the validity
+// of its blockhash, if known.
+func (e *expiryTracker) bindSignature(tx *solana.Transaction) {
+	if e == nil || tx == nil || len(tx.Signatures) == 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if entry, ok := e.byHash[tx.Message.RecentBlockhash]; ok {
+		e.bySig[tx.Signatures[0]] = entry
+	}
+}
+
+func (e *expiryTracker) forBlockhash(hash solana.Hash) (uint64, bool) {
+	entry, ok := e.entry(hash)
+	return entry.lastValid, ok
+}
+
+func (e *expiryTracker) entry(hash solana.Hash) (expiryEntry, bool) {
+	if e == nil {
+		return expiryEntry{}, false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	entry, ok := e.byHash[hash]
+	return entry, ok
+}
+
+// forSignature returns the last valid block height for sig, or 0 if unknown.
+func (e *expiryTracker) forSignature(sig solana.Signature) uint64 {
+	if e == nil {
+		return 0
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.bySig[sig].lastValid
+}
+
+func (e *expiryTracker) pruneLocked(now time.Time) {
+	for k, v := range e.byHash {
+		if now.Sub(v.at) > expiryRetention {
+			delete(e.byHash, k)
+		}
+	}
+	for k, v := range e.bySig {
+		if now.Sub(v.at) > expiryRetention {
+			delete(e.bySig, k)
+		}
+	}
+}
+
+// LastValidBlockHeight returns the last block height at which tx's blockhash
+// is valid, as reported by GetLatestBlockhash when this builder built it.
+// ok is false for transactions built elsewhere or more than a few minutes ago.
+//
+// Example:
+//
+//	tx, _ := builder.BuildTransaction(ctx, payer, instrs...)
+//	lastValid, _ := builder.LastValidBlockHeight(tx)
+func (b *Builder) LastValidBlockHeight(tx *solana.Transaction) (uint64, bool) {
+	if tx == nil {
+		return 0, false
+	}
+	return b.expiry.forBlockhash(tx.Message.RecentBlockhash)
+}
+
+// BlockhashSlot returns the slot at which this builder fetched tx's
+// blockhash. ok is false for transactions built elsewhere, more than a few
+// minutes ago, or when the RPC node reported no slot.
+func (b *Builder) BlockhashSlot(tx *solana.Transaction) (uint64, bool) {
+	if tx == nil {
+		return 0, false
+	}
+	entry, ok := b.expiry.entry(tx.Message.RecentBlockhash)
+	return entry.slot, ok && entry.slot > 0
+}
+
+// CheckBlockhashAge returns ErrBlockhashTooOld if the cluster's processed
+// slot is more than maxSlots past the slot tx's blockhash was fetched at,
+// e.g. after a slow remote signer, so a trade priced at build time is not
+// sent stale. It is a client-side check before sending: once sent, the
+// transaction can land until its blockhash expires (about 150 blocks), and
+// neither pump program takes a deadline to enforce on chain. An error is
+// also returned if the blockhash's slot is unknown (see BlockhashSlot).
+func (b *Builder) CheckBlockhashAge(ctx context.Context, tx *solana.Transaction, maxSlots uint64) error {
+	built, ok := b.BlockhashSlot(tx)
+	if !ok {
+		return fmt.Errorf("blockhash slot unknown: transaction not built by this builder")
+	}
+	if b.client == nil {
+		return fmt.Errorf("rpc client is nil")
+	}
+	slot, err := b.client.GetSlot(ctx, solanarpc.CommitmentProcessed)
+	if err != nil {
+		return fmt.Errorf("get slot: %w", err)
+	}
+	if slot > built+maxSlots {
+		return fmt.Errorf("%w: fetched at slot %d, now %d (max %d slots)", ErrBlockhashTooOld, built, slot, maxSlots)
+	}
+	return nil
+}
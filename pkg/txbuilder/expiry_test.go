@@ -0,0 +1,39 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+)
+
+func TestCheckBlockhashAge(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("getLatestBlockhash", func(json.RawMessage) (interface{}, error) {
+		return rpcContext(map[string]interface{}{"blockhash": solana.Hash{7}.String(), "lastValidBlockHeight": 500}), nil
+	})
+	fake.handle("getSlot", func(json.RawMessage) (interface{}, error) { return 5, nil })
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed)
+	payer := solana.NewWallet().PublicKey()
+	tx, err := b.BuildTransaction(context.Background(), payer, system.NewTransferInstruction(1, payer, payer).Build())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if slot, ok := b.BlockhashSlot(tx); !ok || slot != 1 {
+		t.Fatalf("blockhash slot = %d, %v; want 1", slot, ok)
+	}
+	if err := b.CheckBlockhashAge(context.Background(), tx, 4); err != nil {
+		t.Fatalf("4 slots old within 4: %v", err)
+	}
+	if err := b.CheckBlockhashAge(context.Background(), tx, 3); !errors.Is(err, ErrBlockhashTooOld) {
+		t.Fatalf("expected ErrBlockhashTooOld, got %v", err)
+	}
+	if err := b.CheckBlockhashAge(context.Background(), testTx(t), 100); err == nil {
+		t.Fatal("expected an error for a transaction built elsewhere")
+	}
+}
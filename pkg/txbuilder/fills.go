@@ -0,0 +1,109 @@
+package txbuilder
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	sdkrpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+)
+
+// TokensReceived returns how many base units of mint the landed transaction
+// sig added to owner's token accounts, summed over all of them: post minus
+// pre balance, or 0 if the balance did not grow (e.g. for a sell). It reads
+// the transaction's token balance metadata, so it reports the actual fill
+// rather than the quoted one. A failed transaction receives nothing.
+//
+// Example:
+//
+//	sig, err := builder.BuildSignSendAndConfirm(ctx, signer, nil, txbuilder.ConfirmationConfirmed, instrs...)
+//	tokens, err := txbuilder.TokensReceived(ctx, rpc, sig, signer.PublicKey(), mint)
+func TokensReceived(ctx context.Context, rpc *sdkrpc.Client, sig solana.Signature, owner, mint solana.PublicKey) (uint64, error) {
+	if rpc == nil {
+		return 0, fmt.Errorf("rpc client is nil")
+	}
+	res, err := fetchTransaction(ctx, rpc, sig, solanarpc.CommitmentConfirmed)
+	if err != nil {
+		return 0, err
+	}
+	pre, err := tokenBalance(res.Meta.PreTokenBalances, owner, mint)
+	if err != nil {
+		return 0, err
+	}
+	post, err := tokenBalance(res.Meta.PostTokenBalances, owner, mint)
+	if err != nil {
+		return 0, err
+	}
+	if post <= pre {
+		return 0, nil
+	}
+	return post - pre, nil
+}
+
+// SolDelta returns the change in owner's lamports caused by the landed
+// transaction sig, excluding the transaction fee when owner paid it, and
+// that fee. For a sell the delta is the SOL received; for a buy it is
+// negative, the SOL spent including rent for accounts created. The balance
+// change including the fee is delta - fee. Accounts loaded through address
+// lookup tables of versioned transactions are included.
+//
+// Example:
+//
+//	delta, fee, err := txbuilder.SolDelta(ctx, rpc, sig, signer.PublicKey())
+//	log.Printf("received %d lamports, paid %d in fees", delta, fee)
+func SolDelta(ctx context.Context, rpc *sdkrpc.Client, sig solana.Signature, owner solana.PublicKey) (delta int64, fee uint64, err error) {
+	if rpc == nil {
+		return 0, 0, fmt.Errorf("rpc client is nil")
+	}
+	res, err := fetchTransaction(ctx, rpc, sig, solanarpc.CommitmentConfirmed)
+	if err != nil {
+		return 0, 0, err
+	}
+	tx, err := res.Transaction.GetTransaction()
+	if err != nil {
+		return 0, 0, fmt.Errorf("decode transaction %s: %w", sig, err)
+	}
+
+	// Balances index the static keys followed by the writable, then
+	// read-only, addresses loaded from lookup tables.
+	keys := append(solana.PublicKeySlice{}, tx.Message.AccountKeys...)
+	keys = append(keys, res.Meta.LoadedAddresses.Writable...)
+	keys = append(keys, res.Meta.LoadedAddresses.ReadOnly...)
+	idx := -1
+	for i, key := range keys {
+		if key.Equals(owner) {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 || idx >= len(res.Meta.PreBalances) || idx >= len(res.Meta.PostBalances) {
+		return 0, 0, fmt.Errorf("account %s not in transaction %s", owner, sig)
+	}
+
+	delta = int64(res.Meta.PostBalances[idx]) - int64(res.Meta.PreBalances[idx])
+	if idx == 0 {
+		// The fee payer is always the first account.
+		fee = res.Meta.Fee
+		delta += int64(fee)
+	}
+	return delta, fee, nil
+}
+
+// tokenBalance sums the balances in balances of owner's accounts for mint.
+func tokenBalance(balances []solanarpc.TokenBalance, owner, mint solana.PublicKey) (uint64, error) {
+	var total uint64
+	for _, b := range balances {
+		if b.Owner == nil || !b.Owner.Equals(owner) || !b.Mint.Equals(mint) || b.UiTokenAmount == nil {
+			continue
+		}
+		amount, err := strconv.ParseUint(b.UiTokenAmount.Amount, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parse token balance of account %d: %w", b.AccountIndex, err)
+		}
+		total += amount
+	}
+	return total, nil
+}
@@ -0,0 +1,110 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// serveLandedTx answers getTransaction with tx and meta.
+func serveLandedTx(t *testing.T, fake *fakeRPC, tx *solana.Transaction, meta map[string]interface{}) {
+	t.Helper()
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.handle("getTransaction", func(json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{
+			"slot":        9,
+			"meta":        meta,
+			"transaction": []string{base64.StdEncoding.EncodeToString(raw), "base64"},
+		}, nil
+	})
+}
+
+func tokenBalanceJSON(index int, owner, mint solana.PublicKey, amount string) map[string]interface{} {
+	return map[string]interface{}{
+		"accountIndex":  index,
+		"owner":         owner.String(),
+		"mint":          mint.String(),
+		"uiTokenAmount": map[string]interface{}{"amount": amount, "decimals": 6},
+	}
+}
+
+func TestTokensReceived(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	tx := testTx(t)
+	owner := tx.Message.AccountKeys[0]
+	mint, other := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	serveLandedTx(t, fake, tx, map[string]interface{}{
+		"err":          nil,
+		"fee":          5000,
+		"preBalances":  []uint64{1_000_000},
+		"postBalances": []uint64{995_000},
+		"preTokenBalances": []interface{}{
+			tokenBalanceJSON(1, owner, mint, "100"),
+			tokenBalanceJSON(2, other, mint, "7"),
+		},
+		// A second account of the owner and another mint's balance.
+		"postTokenBalances": []interface{}{
+			tokenBalanceJSON(1, owner, mint, "350"),
+			tokenBalanceJSON(3, owner, mint, "50"),
+			tokenBalanceJSON(2, other, mint, "1"),
+			tokenBalanceJSON(4, owner, other, "999"),
+		},
+	})
+
+	ctx := context.Background()
+	got, err := TokensReceived(ctx, client, tx.Signatures[0], owner, mint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 300 {
+		t.Fatalf("received %d, want 300 across the owner's accounts", got)
+	}
+	// Another owner's balance fell: nothing received.
+	if got, err := TokensReceived(ctx, client, tx.Signatures[0], other, mint); err != nil || got != 0 {
+		t.Fatalf("decreased balance: got %d, %v", got, err)
+	}
+}
+
+func TestSolDelta(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	tx := testTx(t)
+	payer := tx.Message.AccountKeys[0]
+	loaded := solana.NewWallet().PublicKey()
+	serveLandedTx(t, fake, tx, map[string]interface{}{
+		"err":          nil,
+		"fee":          5000,
+		"preBalances":  []uint64{1_000_000, 1, 500},
+		"postBalances": []uint64{1_095_000, 1, 200},
+		// A versioned transaction's lookup-table account follows the static
+		// keys (the payer and the system program).
+		"loadedAddresses": map[string]interface{}{
+			"writable": []string{loaded.String()},
+			"readonly": []string{},
+		},
+	})
+
+	ctx := context.Background()
+	delta, fee, err := SolDelta(ctx, client, tx.Signatures[0], payer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delta != 100_000 || fee != 5000 {
+		t.Fatalf("payer delta %d fee %d, want 100000 net of the 5000 fee", delta, fee)
+	}
+	delta, fee, err = SolDelta(ctx, client, tx.Signatures[0], loaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delta != -300 || fee != 0 {
+		t.Fatalf("loaded account delta %d fee %d, want -300 and no fee", delta, fee)
+	}
+	if _, _, err := SolDelta(ctx, client, tx.Signatures[0], solana.NewWallet().PublicKey()); err == nil {
+		t.Fatal("expected an error for an account not in the transaction")
+	}
+}
@@ -0,0 +1,67 @@
+package txbuilder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// DefaultJitoConfirmTimeout is how long SendAndConfirm waits for Jito to
+// report a bundle confirmed, with WithJitoConfirmation, before falling back
+// to RPC polling.
+const DefaultJitoConfirmTimeout = 5 * time.Second
+
+// WithJitoConfirmation makes SendAndConfirm, when a Jito client is
+// configured, confirm through Jito's GetBundleStatuses, which usually knows
+// a bundle landed before RPC nodes do. Jito is trusted only when it reports
+// the bundle confirmed or finalized; if it has not within the Jito
+// confirmation timeout, or reports anything else, confirmation falls back to
+// RPC polling as without this option. Finalized confirmation always uses RPC.
+func (b *Builder) WithJitoConfirmation(enabled bool) *Builder {
+	b.jitoConfirm = enabled
+	return b
+}
+
+// WithJitoConfirmTimeout bounds how long WithJitoConfirmation waits for Jito
+// before falling back to RPC polling. Zero uses DefaultJitoConfirmTimeout.
+func (b *Builder) WithJitoConfirmTimeout(d time.Duration) *Builder {
+	b.jitoConfirmTimeout = d
+	return b
+}
+
+// sendAndConfirmViaJito sends tx via Jito and confirms it through the bundle
+// status, falling back to RPC polling when that is inconclusive.
+func (b *Builder) sendAndConfirmViaJito(ctx context.Context, tx *solana.Transaction, level ConfirmationLevel) (solana.Signature, error) {
+	if b.preflightSim {
+		if err := b.simulateSigned(ctx, tx); err != nil {
+			return solana.Signature{}, err
+		}
+	}
+	res, err := b.sendViaJito(ctx, tx)
+	if err != nil {
+		return res.Signature, err
+	}
+
+	timeout := b.jitoConfirmTimeout
+	if timeout <= 0 {
+		timeout = DefaultJitoConfirmTimeout
+	}
+	jitoCtx, cancel := context.WithTimeout(ctx, timeout)
+	err = b.jitoClient.WaitForBundleConfirmation(jitoCtx, res.BundleID)
+	cancel()
+	if err == nil {
+		b.log.Debug().Stringer("sig", res.Signature).Str("bundle_id", res.BundleID).Msg("tx confirmed (jito)")
+		return res.Signature, nil
+	}
+	if ctx.Err() != nil {
+		return res.Signature, fmt.Errorf("confirmation failed: %w, sig: %v", ctx.Err(), res.Signature)
+	}
+
+	b.log.Debug().Err(err).Str("bundle_id", res.BundleID).Msg("jito confirmation inconclusive, polling rpc")
+	if err = b.WaitForConfirmation(ctx, res.Signature, level); err != nil {
+		return res.Signature, fmt.Errorf("confirmation failed: %w, sig: %v", err, res.Signature)
+	}
+	return res.Signature, nil
+}
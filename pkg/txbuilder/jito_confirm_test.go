@@ -0,0 +1,102 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/jito"
+)
+
+func TestJitoConfirmation(t *testing.T) {
+	confirmedSignature := func(json.RawMessage) (interface{}, error) {
+		return rpcContext([]interface{}{map[string]interface{}{
+			"slot": 1, "confirmations": nil, "err": nil, "confirmationStatus": "confirmed",
+		}}), nil
+	}
+	cases := []struct {
+		name        string
+		bundle      []interface{} // getBundleStatuses value
+		wantRPCPoll bool
+	}{
+		{"jito confirms", []interface{}{map[string]interface{}{
+			"bundle_id": "bundle-1", "slot": 9, "confirmation_status": "confirmed", "err": map[string]interface{}{"Ok": nil},
+		}}, false},
+		{"jito inconclusive", []interface{}{}, true},
+	}
+	for _, tc := range cases {
+		fake, client := newFakeRPC(t)
+		fake.handle("sendBundle", func(json.RawMessage) (interface{}, error) { return "bundle-1", nil })
+		fake.handle("getBundleStatuses", func(json.RawMessage) (interface{}, error) {
+			return rpcContext(tc.bundle), nil
+		})
+		fake.handle("getSignatureStatuses", confirmedSignature)
+
+		b := NewBuilder(client, solanarpc.CommitmentConfirmed).
+			WithJito(jito.NewClient(fake.url, "").WithRetries(1, 0)).
+			WithJitoConfirmation(true).
+			WithJitoConfirmTimeout(300*time.Millisecond).
+			WithConfirmPolling(time.Millisecond, -1)
+
+		tx := testTx(t)
+		sig, err := b.SendAndConfirm(context.Background(), tx, ConfirmationConfirmed)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if sig != tx.Signatures[0] {
+			t.Fatalf("%s: sig = %s", tc.name, sig)
+		}
+		if polled := fake.callCount("getSignatureStatuses") > 0; polled != tc.wantRPCPoll {
+			t.Errorf("%s: rpc polled = %v, want %v", tc.name, polled, tc.wantRPCPoll)
+		}
+		if fake.callCount("getBundleStatuses") == 0 {
+			t.Errorf("%s: jito bundle status never checked", tc.name)
+		}
+	}
+}
+
+func TestJitoConfirmationDisabledUsesRPC(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("sendBundle", func(json.RawMessage) (interface{}, error) { return "bundle-1", nil })
+	fake.handle("getSignatureStatuses", func(json.RawMessage) (interface{}, error) {
+		return rpcContext([]interface{}{map[string]interface{}{
+			"slot": 1, "confirmations": nil, "err": nil, "confirmationStatus": "confirmed",
+		}}), nil
+	})
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed).
+		WithJito(jito.NewClient(fake.url, "").WithRetries(1, 0)).
+		WithConfirmPolling(time.Millisecond, -1)
+
+	if _, err := b.SendAndConfirm(context.Background(), testTx(t), ConfirmationConfirmed); err != nil {
+		t.Fatal(err)
+	}
+	if fake.callCount("getBundleStatuses") != 0 || fake.callCount("getSignatureStatuses") == 0 {
+		t.Fatal("without WithJitoConfirmation, confirmation must poll rpc only")
+	}
+}
+
+func TestWithJitoSenders(t *testing.T) {
+	var nilClient *jito.Client
+	var nilGRPC *jito.GRPCClient
+	for name, s := range map[string]jito.Sender{"nil": nil, "nil client": nilClient, "nil grpc client": nilGRPC} {
+		b := NewBuilder(nil, solanarpc.CommitmentConfirmed).WithJito(s)
+		if b.HasJito() || b.JitoSender() != nil || b.JitoClient() != nil {
+			t.Errorf("%s: Jito should be disabled", name)
+		}
+	}
+
+	client := jito.NewClient("http://localhost", "")
+	b := NewBuilder(nil, solanarpc.CommitmentConfirmed).WithJito(client)
+	if b.JitoClient() != client || b.JitoSender() != client {
+		t.Fatal("JSON-RPC client not returned by the accessors")
+	}
+
+	grpcClient := &jito.GRPCClient{}
+	b = NewBuilder(nil, solanarpc.CommitmentConfirmed).WithJito(grpcClient)
+	if !b.HasJito() || b.JitoSender() != grpcClient || b.JitoClient() != nil {
+		t.Fatal("gRPC client should be returned by JitoSender only")
+	}
+}
@@ -0,0 +1,64 @@
+package txbuilder
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// WithAutoLookupTable makes BuildTransaction fall back to a v0 transaction
+// resolving accounts through the address lookup table at table when the
+// legacy transaction would exceed the size limit. Transactions that fit stay
+// legacy. The table must already exist, be active and hold the accounts to
+// compress (pump, pump_amm and token programs, global accounts, pools); it
+// is read each time a fallback is needed, so extending it takes effect
+// immediately.
+//
+// Example:
+//
+//	builder := txbuilder.NewBuilder(client, rpc.CommitmentConfirmed).WithAutoLookupTable(table)
+//	sig, err := builder.BuildSignSend(ctx, signer, nil, instrs...) // v0 only if needed
+func (b *Builder) WithAutoLookupTable(table solana.PublicKey) *Builder {
+	b.lookupTable = table
+	return b
+}
+
+// buildV0 rebuilds instructions as a v0 transaction that resolves accounts
+// through the WithAutoLookupTable table.
+func (b *Builder) buildV0(ctx context.Context, feePayer solana.PublicKey, blockhash solana.Hash, instructions []solana.Instruction) (*solana.Transaction, error) {
+	accts, err := b.client.GetMultipleAccounts(ctx, []solana.PublicKey{b.lookupTable}, b.commitment)
+	if err != nil {
+		return nil, fmt.Errorf("fetch lookup table %s: %w", b.lookupTable, err)
+	}
+	if len(accts) == 0 || accts[0] == nil || accts[0].Data == nil {
+		return nil, fmt.Errorf("lookup table %s: %w", b.lookupTable, types.ErrAccountNotFound)
+	}
+	table, err := addresslookuptable.DecodeAddressLookupTableState(accts[0].Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("decode lookup table %s: %w", b.lookupTable, err)
+	}
+	if table.DeactivationSlot != math.MaxUint64 {
+		return nil, fmt.Errorf("lookup table %s is deactivated", b.lookupTable)
+	}
+
+	tx, err := solana.NewTransaction(instructions, blockhash,
+		solana.TransactionPayer(feePayer),
+		solana.TransactionAddressTables(map[solana.PublicKey]solana.PublicKeySlice{b.lookupTable: table.Addresses}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("build v0 transaction: %w", err)
+	}
+	if err := b.checkSize(tx); err != nil {
+		return nil, fmt.Errorf("v0 with lookup table %s: %w; add the transaction's accounts to the table or split the instructions", b.lookupTable, err)
+	}
+	b.log.Debug().
+		Stringer("lookup_table", b.lookupTable).
+		Int("table_addresses", len(table.Addresses)).
+		Msg("tx rebuilt as v0")
+	return tx, nil
+}
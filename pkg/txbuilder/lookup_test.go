@@ -0,0 +1,97 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// lookupTableData encodes an active address lookup table holding addrs.
+func lookupTableData(addrs []solana.PublicKey) []byte {
+	data := make([]byte, 56, 56+32*len(addrs))
+	binary.LittleEndian.PutUint32(data[0:], 1)
+	binary.LittleEndian.PutUint64(data[4:], math.MaxUint64) // not deactivated
+	for _, a := range addrs {
+		data = append(data, a[:]...)
+	}
+	return data
+}
+
+func TestBuildTransactionAutoLookupTable(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("getLatestBlockhash", func(json.RawMessage) (interface{}, error) {
+		return rpcContext(map[string]interface{}{"blockhash": solana.Hash{7}.String(), "lastValidBlockHeight": 500}), nil
+	})
+	accounts := make([]solana.PublicKey, 40)
+	metas := make(solana.AccountMetaSlice, len(accounts))
+	for i := range accounts {
+		accounts[i] = solana.NewWallet().PublicKey()
+		metas[i] = solana.NewAccountMeta(accounts[i], false, false)
+	}
+	var mu sync.Mutex
+	tableAddrs := accounts
+	fake.handle("getMultipleAccounts", func(json.RawMessage) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return rpcContext([]interface{}{map[string]interface{}{
+			"owner":      solana.AddressLookupTableProgramID.String(),
+			"lamports":   1,
+			"data":       []string{base64.StdEncoding.EncodeToString(lookupTableData(tableAddrs)), "base64"},
+			"executable": false,
+			"rentEpoch":  0,
+		}}), nil
+	})
+	payer := solana.NewWallet().PublicKey()
+	ix := solana.NewInstruction(constants.MemoProgramID, metas, []byte("wide"))
+	ctx := context.Background()
+
+	// 40 accounts do not fit a legacy transaction.
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed)
+	_, err := b.BuildTransaction(ctx, payer, ix)
+	if !errors.Is(err, types.ErrTransactionTooLarge) || !strings.Contains(err.Error(), "WithAutoLookupTable") {
+		t.Fatalf("expected ErrTransactionTooLarge suggesting a lookup table, got %v", err)
+	}
+
+	table := solana.NewWallet().PublicKey()
+	b.WithAutoLookupTable(table)
+	tx, err := b.BuildTransaction(ctx, payer, ix)
+	if err != nil {
+		t.Fatalf("BuildTransaction with lookup table: %v", err)
+	}
+	if !tx.Message.IsVersioned() || len(tx.Message.AddressTableLookups) != 1 || !tx.Message.AddressTableLookups[0].AccountKey.Equals(table) {
+		t.Fatalf("expected a v0 transaction using %s, got %+v", table, tx.Message.AddressTableLookups)
+	}
+	if err := b.checkSize(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Small transactions stay legacy and never read the table.
+	reads := fake.callCount("getMultipleAccounts")
+	small, err := b.BuildTransaction(ctx, payer, solana.NewInstruction(constants.MemoProgramID, metas[:2], []byte("narrow")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if small.Message.IsVersioned() || fake.callCount("getMultipleAccounts") != reads {
+		t.Fatal("a transaction under the limit was upgraded")
+	}
+
+	// A table missing most accounts cannot shrink the transaction enough.
+	mu.Lock()
+	tableAddrs = accounts[:5]
+	mu.Unlock()
+	if _, err := b.BuildTransaction(ctx, payer, ix); !errors.Is(err, types.ErrTransactionTooLarge) {
+		t.Fatalf("expected ErrTransactionTooLarge with a sparse table, got %v", err)
+	}
+}
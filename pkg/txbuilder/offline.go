@@ -0,0 +1,65 @@
+package txbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/wallet"
+)
+
+// BroadcastSignedFile sends a transaction signed offline (see
+// wallet.SignToFile). Every required signature must be present and valid;
+// the transaction is sent as-is through Send.
+//
+// Example:
+//
+//	sig, err := builder.BroadcastSignedFile(ctx, "signed.b64")
+func (b *Builder) BroadcastSignedFile(ctx context.Context, path string) (solana.Signature, error) {
+	tx, err := wallet.ReadTransactionFile(path)
+	if err != nil {
+		return solana.Signature{}, err
+	}
+	if len(tx.Signatures) != int(tx.Message.Header.NumRequiredSignatures) {
+		return solana.Signature{}, fmt.Errorf("transaction has %d of %d required signatures", len(tx.Signatures), tx.Message.Header.NumRequiredSignatures)
+	}
+	if err := tx.VerifySignatures(); err != nil {
+		return solana.Signature{}, fmt.Errorf("verify signatures: %w", err)
+	}
+	return b.Send(ctx, tx)
+}
+
+// BuildAndSignSerialized builds and signs a transaction like BuildSignSend
+// but does not send it. It returns the wire encoding of the signed
+// transaction and its signature, for submission through a backend the
+// builder does not know about, e.g. a third-party relay. Most relays expect
+// the bytes base64 encoded.
+//
+// The blockhash is fetched now, so the transaction must be submitted within
+// about a minute; LastValidBlockHeight reports its exact validity.
+//
+// Example:
+//
+//	raw, sig, err := builder.BuildAndSignSerialized(ctx, payer, nil, instrs...)
+//	relay.Submit(base64.StdEncoding.EncodeToString(raw))
+//	err = builder.WaitForConfirmation(ctx, sig, txbuilder.ConfirmationConfirmed)
+func (b *Builder) BuildAndSignSerialized(ctx context.Context, feePayer wallet.Signer, signers []wallet.Signer, instructions ...solana.Instruction) ([]byte, solana.Signature, error) {
+	if feePayer == nil {
+		return nil, solana.Signature{}, fmt.Errorf("fee payer is required")
+	}
+	tx, err := b.BuildTransaction(ctx, feePayer.PublicKey(), instructions...)
+	if err != nil {
+		return nil, solana.Signature{}, err
+	}
+	allSigners := append([]wallet.Signer{feePayer}, signers...)
+	if err := SignTransaction(ctx, tx, allSigners...); err != nil {
+		return nil, solana.Signature{}, err
+	}
+	b.logSigned(tx)
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, solana.Signature{}, fmt.Errorf("encode transaction: %w", err)
+	}
+	return raw, tx.Signatures[0], nil
+}
@@ -0,0 +1,78 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/wallet"
+)
+
+func TestBroadcastSignedFile(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	tx := testTx(t)
+	fake.handle("sendTransaction", func(json.RawMessage) (interface{}, error) {
+		return tx.Signatures[0].String(), nil
+	})
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed)
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	signed := filepath.Join(dir, "signed.b64")
+	if err := wallet.WriteTransactionFile(tx, signed); err != nil {
+		t.Fatal(err)
+	}
+	sig, err := b.BroadcastSignedFile(ctx, signed)
+	if err != nil {
+		t.Fatalf("broadcast: %v", err)
+	}
+	if sig != tx.Signatures[0] {
+		t.Fatalf("sig = %s, want %s", sig, tx.Signatures[0])
+	}
+
+	tx.Signatures[0] = solana.Signature{1}
+	tampered := filepath.Join(dir, "tampered.b64")
+	if err := wallet.WriteTransactionFile(tx, tampered); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.BroadcastSignedFile(ctx, tampered); err == nil {
+		t.Fatal("expected verification error for a bad signature")
+	}
+	if n := fake.callCount("sendTransaction"); n != 1 {
+		t.Fatalf("sends = %d, want 1", n)
+	}
+}
+
+func TestBuildAndSignSerialized(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("getLatestBlockhash", func(json.RawMessage) (interface{}, error) {
+		return rpcContext(map[string]interface{}{"blockhash": solana.Hash{7}.String(), "lastValidBlockHeight": 500}), nil
+	})
+	key, _ := solana.NewRandomPrivateKey()
+	payer := wallet.NewLocalFromPrivateKey(key)
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed)
+
+	ix := system.NewTransferInstruction(1, payer.PublicKey(), payer.PublicKey()).Build()
+	raw, sig, err := b.BuildAndSignSerialized(context.Background(), payer, nil, ix)
+	if err != nil {
+		t.Fatalf("BuildAndSignSerialized: %v", err)
+	}
+	tx, err := solana.TransactionFromBytes(raw)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if err := tx.VerifySignatures(); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if tx.Signatures[0] != sig || tx.Message.RecentBlockhash != (solana.Hash{7}) {
+		t.Fatalf("unexpected transaction: sig %s, blockhash %s", tx.Signatures[0], tx.Message.RecentBlockhash)
+	}
+	if n := fake.callCount("sendTransaction"); n != 0 {
+		t.Fatalf("expected nothing sent, got %d sends", n)
+	}
+}
@@ -0,0 +1,125 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+)
+
+// setComputeUnitPriceDiscriminator is the ComputeBudget SetComputeUnitPrice tag.
+const setComputeUnitPriceDiscriminator = 3
+
+// maxPriorityFeeAccounts is the RPC limit on accounts for getRecentPrioritizationFees.
+const maxPriorityFeeAccounts = 128
+
+// WithAutoPriorityFee prices each transaction built by BuildTransaction at the
+// given percentile (0-100) of recent prioritization fees for the writable
+// accounts it touches, so trades are priced against the specific pool/mint's
+// fee pressure. Pass 0 to disable.
+//
+// If the instructions already contain a SetComputeUnitPrice, they are left
+// unchanged. If the RPC call fails or returns no non-zero samples, the
+// fallback set by WithAutoPriorityFeeFallback is used (default: no price
+// instruction is added).
+func (b *Builder) WithAutoPriorityFee(percentile float64) *Builder {
+	b.autoFeePercentile = math.Max(0, math.Min(100, percentile))
+	return b
+}
+
+// WithAutoPriorityFeeFallback sets the micro-lamports per CU used by
+// WithAutoPriorityFee when no recent fee data is available.
+func (b *Builder) WithAutoPriorityFeeFallback(microLamportsPerCU uint64) *Builder {
+	b.autoFeeFallback = microLamportsPerCU
+	return b
+}
+
+// applyAutoPriorityFee prepends a SetComputeUnitPrice instruction priced from
+// recent fees when auto priority fees are enabled.
+func (b *Builder) applyAutoPriorityFee(ctx context.Context, instructions []solana.Instruction) []solana.Instruction {
+	if b.autoFeePercentile <= 0 || hasComputeUnitPrice(instructions) {
+		return instructions
+	}
+
+	price := b.autoFeeFallback
+	fees, err := b.client.GetRecentPrioritizationFees(ctx, writableAccounts(instructions))
+	if err == nil {
+		samples := make([]uint64, 0, len(fees))
+		for _, f := range fees {
+			if f.PrioritizationFee > 0 {
+				samples = append(samples, f.PrioritizationFee)
+			}
+		}
+		if len(samples) > 0 {
+			price = feePercentile(samples, b.autoFeePercentile)
+		}
+	}
+	if price == 0 {
+		return instructions
+	}
+	return append([]solana.Instruction{newSetComputeUnitPrice(price)}, instructions...)
+}
+
+// feePercentile returns the nearest-rank percentile p (0-100] of samples.
+func feePercentile(samples []uint64, p float64) uint64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := append([]uint64(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// writableAccounts returns the distinct writable accounts across instructions,
+// capped at the RPC limit.
+func writableAccounts(instructions []solana.Instruction) []solana.PublicKey {
+	seen := make(map[solana.PublicKey]struct{})
+	var out []solana.PublicKey
+	for _, ix := range instructions {
+		for _, meta := range ix.Accounts() {
+			if !meta.IsWritable {
+				continue
+			}
+			if _, ok := seen[meta.PublicKey]; ok {
+				continue
+			}
+			seen[meta.PublicKey] = struct{}{}
+			out = append(out, meta.PublicKey)
+			if len(out) == maxPriorityFeeAccounts {
+				return out
+			}
+		}
+	}
+	return out
+}
+
+func hasComputeUnitPrice(instructions []solana.Instruction) bool {
+	for _, ix := range instructions {
+		if !ix.ProgramID().Equals(constants.ComputeBudgetProgramID) {
+			continue
+		}
+		data, err := ix.Data()
+		if err == nil && len(data) > 0 && data[0] == setComputeUnitPriceDiscriminator {
+			return true
+		}
+	}
+	return false
+}
+
+func newSetComputeUnitPrice(microLamports uint64) solana.Instruction {
+	data := make([]byte, 9)
+	data[0] = setComputeUnitPriceDiscriminator
+	binary.LittleEndian.PutUint64(data[1:], microLamports)
+	return solana.NewInstruction(constants.ComputeBudgetProgramID, nil, data)
+}
@@ -0,0 +1,46 @@
+package txbuilder
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+)
+
+func TestFeePercentile(t *testing.T) {
+	samples := []uint64{50, 10, 40, 20, 30}
+	cases := []struct {
+		p    float64
+		want uint64
+	}{
+		{1, 10},
+		{50, 30},
+		{75, 40},
+		{100, 50},
+	}
+	for _, tc := range cases {
+		if got := feePercentile(samples, tc.p); got != tc.want {
+			t.Errorf("p%.0f: got %d, want %d", tc.p, got, tc.want)
+		}
+	}
+	if got := feePercentile(nil, 50); got != 0 {
+		t.Errorf("empty samples: got %d, want 0", got)
+	}
+}
+
+func TestHasComputeUnitPrice(t *testing.T) {
+	ix := newSetComputeUnitPrice(1234)
+	data, _ := ix.Data()
+	if binary.LittleEndian.Uint64(data[1:]) != 1234 {
+		t.Fatal("unexpected price encoding")
+	}
+	if !hasComputeUnitPrice([]solana.Instruction{ix}) {
+		t.Fatal("expected SetComputeUnitPrice to be detected")
+	}
+	limit := solana.NewInstruction(constants.ComputeBudgetProgramID, nil, []byte{2, 0, 0, 0, 0})
+	if hasComputeUnitPrice([]solana.Instruction{limit}) {
+		t.Fatal("SetComputeUnitLimit must not count as a price instruction")
+	}
+}
@@ -0,0 +1,109 @@
+package txbuilder
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// RetryGuard prevents double execution when a trade is rebuilt and re-sent
+// after a perceived failure (a confirmation timeout, a dropped send). Each
+// attempt is re-signed over a fresh blockhash and so has a new signature, yet
+// an earlier attempt may still land. The guard remembers the signature of
+// every attempt and, before each re-send, checks whether any of them already
+// executed.
+//
+// Example:
+//
+//	guard := builder.NewRetryGuard()
+//	for attempt := 0; attempt < 3; attempt++ {
+//	    tx, _ := builder.BuildTransaction(ctx, payer.PublicKey(), instrs...)
+//	    _ = txbuilder.SignTransaction(ctx, tx, payer)
+//	    sig, landed, err := guard.Send(ctx, tx)
+//	    if err != nil || landed {
+//	        return sig, err // landed: sig is the earlier attempt that executed
+//	    }
+//	    if builder.WaitForConfirmation(ctx, sig, txbuilder.ConfirmationConfirmed) == nil {
+//	        return sig, nil
+//	    }
+//	}
+type RetryGuard struct {
+	b    *Builder
+	mu   sync.Mutex
+	sigs []solana.Signature
+}
+
+// NewRetryGuard returns an empty guard for one logical transaction.
+func (b *Builder) NewRetryGuard() *RetryGuard {
+	return &RetryGuard{b: b}
+}
+
+// Track records sig as an attempt, for attempts sent without Send.
+func (g *RetryGuard) Track(sig solana.Signature) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, s := range g.sigs {
+		if s == sig {
+			return
+		}
+	}
+	g.sigs = append(g.sigs, sig)
+}
+
+// Signatures returns the signatures of all attempts, oldest first.
+func (g *RetryGuard) Signatures() []solana.Signature {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]solana.Signature(nil), g.sigs...)
+}
+
+// Landed reports the first tracked attempt that executed successfully, at
+// any commitment: even a processed signature will almost always be
+// confirmed, and re-sending would then trade twice. Attempts that failed on
+// chain do not count. An error means the statuses could not be read, and a
+// re-send is not known to be safe.
+func (g *RetryGuard) Landed(ctx context.Context) (solana.Signature, bool, error) {
+	sigs := g.Signatures()
+	if len(sigs) == 0 {
+		return solana.Signature{}, false, nil
+	}
+	if g.b.client == nil {
+		return solana.Signature{}, false, fmt.Errorf("rpc client is nil")
+	}
+	for start := 0; start < len(sigs); start += maxSignatureStatuses {
+		batch := sigs[start:min(start+maxSignatureStatuses, len(sigs))]
+		resp, err := g.b.client.Raw().GetSignatureStatuses(ctx, true, batch...)
+		if err != nil {
+			return solana.Signature{}, false, fmt.Errorf("get signature statuses: %w", err)
+		}
+		for i, status := range resp.Value {
+			if i < len(batch) && status != nil && status.Err == nil {
+				return batch[i], true, nil
+			}
+		}
+	}
+	return solana.Signature{}, false, nil
+}
+
+// Send sends tx unless an earlier attempt already landed, in which case it
+// returns that attempt's signature with landed set and tx is not sent.
+// Otherwise tx's signature is tracked and the result of Builder.Send
+// returned.
+func (g *RetryGuard) Send(ctx context.Context, tx *solana.Transaction) (sig solana.Signature, landed bool, err error) {
+	sig, landed, err = g.Landed(ctx)
+	if err != nil || landed {
+		if landed {
+			g.b.log.Debug().Stringer("sig", sig).Msg("earlier attempt landed, re-send skipped")
+		}
+		return sig, landed, err
+	}
+	if tx == nil || len(tx.Signatures) == 0 {
+		return solana.Signature{}, false, fmt.Errorf("transaction is not signed")
+	}
+	// Track before sending: a send that errors may still reach the leader.
+	g.Track(tx.Signatures[0])
+	sig, err = g.b.Send(ctx, tx)
+	return sig, false, err
+}
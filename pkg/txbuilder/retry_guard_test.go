@@ -0,0 +1,81 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+)
+
+func TestRetryGuardSkipsResendAfterLanding(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	first, second, third := testTx(t), testTx(t), testTx(t)
+
+	var mu sync.Mutex
+	statuses := map[string]map[string]interface{}{}
+	fake.handle("getSignatureStatuses", func(params json.RawMessage) (interface{}, error) {
+		var p []json.RawMessage
+		var sigs []string
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(p[0], &sigs); err != nil {
+			return nil, err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		value := make([]interface{}, len(sigs))
+		for i, sig := range sigs {
+			if s, ok := statuses[sig]; ok {
+				value[i] = s
+			}
+		}
+		return rpcContext(value), nil
+	})
+	fake.handle("sendTransaction", func(params json.RawMessage) (interface{}, error) {
+		var p []json.RawMessage
+		var raw string
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(p[0], &raw); err != nil {
+			return nil, err
+		}
+		tx, err := solana.TransactionFromBase64(raw)
+		if err != nil {
+			return nil, err
+		}
+		return tx.Signatures[0].String(), nil
+	})
+	setStatus := func(sig solana.Signature, err interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		statuses[sig.String()] = map[string]interface{}{"slot": 5, "confirmations": 0, "err": err, "confirmationStatus": "processed"}
+	}
+
+	guard := NewBuilder(client, solanarpc.CommitmentConfirmed).NewRetryGuard()
+	ctx := context.Background()
+	if sig, landed, err := guard.Send(ctx, first); err != nil || landed || sig != first.Signatures[0] {
+		t.Fatalf("first send: %s %v %v", sig, landed, err)
+	}
+	// A failed attempt did not trade, so re-sending is safe.
+	setStatus(first.Signatures[0], map[string]interface{}{"InstructionError": []interface{}{0, "InvalidArgument"}})
+	if _, landed, err := guard.Send(ctx, second); err != nil || landed {
+		t.Fatalf("second send: landed %v, err %v", landed, err)
+	}
+	// The second attempt landed after all: the third must not be sent.
+	setStatus(second.Signatures[0], nil)
+	sig, landed, err := guard.Send(ctx, third)
+	if err != nil || !landed || sig != second.Signatures[0] {
+		t.Fatalf("third send: %s %v %v, want the second attempt's signature", sig, landed, err)
+	}
+	if n := fake.callCount("sendTransaction"); n != 2 {
+		t.Fatalf("sent %d transactions, want 2", n)
+	}
+	if got := guard.Signatures(); len(got) != 2 || got[0] != first.Signatures[0] || got[1] != second.Signatures[0] {
+		t.Fatalf("tracked %v", got)
+	}
+}
@@ -0,0 +1,108 @@
+package txbuilder
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/config"
+	wraprpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+)
+
+// fakeRPC is a minimal JSON-RPC server for builder tests. Each method is
+// answered by its handler; unhandled methods return a JSON-RPC error.
+type fakeRPC struct {
+	mu       sync.Mutex
+	handlers map[string]func(params json.RawMessage) (interface{}, error)
+	calls    map[string]int
+	url      string // also serves Jito block engine methods
+}
+
+func newFakeRPC(t *testing.T) (*fakeRPC, *wraprpc.Client) {
+	t.Helper()
+	f := &fakeRPC{
+		handlers: make(map[string]func(params json.RawMessage) (interface{}, error)),
+		calls:    make(map[string]int),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(srv.Close)
+	f.url = srv.URL
+
+	cfg := config.DefaultRPCConfig()
+	cfg.RPCURL = srv.URL
+	cfg.RateLimit.RPS = 0
+	cfg.Retry.Enabled = false
+	return f, wraprpc.NewClient(cfg)
+}
+
+func (f *fakeRPC) handle(method string, h func(params json.RawMessage) (interface{}, error)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers[method] = h
+}
+
+func (f *fakeRPC) callCount(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[method]
+}
+
+func (f *fakeRPC) serve(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	f.calls[req.Method]++
+	handler := f.handlers[req.Method]
+	f.mu.Unlock()
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if handler == nil {
+		resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found: " + req.Method}
+	} else if result, err := handler(req.Params); err != nil {
+		resp["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+	} else {
+		resp["result"] = result
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// rpcContext wraps value in the {"context":..., "value":...} envelope.
+func rpcContext(value interface{}) map[string]interface{} {
+	return map[string]interface{}{"context": map[string]interface{}{"slot": 1}, "value": value}
+}
+
+// testTx returns a signed single-transfer transaction.
+func testTx(t *testing.T) *solana.Transaction {
+	t.Helper()
+	key, _ := solana.NewRandomPrivateKey()
+	payer := key.PublicKey()
+	ix := solana.NewInstruction(solana.SystemProgramID, solana.AccountMetaSlice{
+		solana.NewAccountMeta(payer, true, true),
+	}, []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0})
+	tx, err := solana.NewTransaction([]solana.Instruction{ix}, solana.Hash{1}, solana.TransactionPayer(payer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Sign(func(pk solana.PublicKey) *solana.PrivateKey {
+		if pk.Equals(payer) {
+			return &key
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
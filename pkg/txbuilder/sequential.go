@@ -0,0 +1,123 @@
+package txbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// SequentialSimulation is the result of simulating one transaction of a
+// sequence with SimulateSequential.
+type SequentialSimulation struct {
+	// Err is the decoded simulation failure (*types.ProgramError or
+	// *types.SimulationError), or nil if the transaction succeeded.
+	Err error
+	// UnitsConsumed is the CU the transaction consumed, up to any failure.
+	UnitsConsumed uint64
+	Logs          []string
+	// DependsOnPrior reports that the transaction uses a non-signer account
+	// an earlier transaction of the sequence writes. Its simulation ran
+	// against chain state without those writes, so Err may be an artifact
+	// of the approximation (e.g. AccountNotFound for an ATA created
+	// earlier) rather than a real failure.
+	DependsOnPrior bool
+	// Accounts holds the simulated post-state of every account written so
+	// far in the sequence, accumulated up to and including this transaction.
+	Accounts map[solana.PublicKey]*solanarpc.Account
+}
+
+// SimulateSequential simulates txs one after another, e.g. a create+buy
+// bundle before paying its Jito tip when the block engine's simulateBundle
+// is unavailable. Each transaction is simulated with
+// replaceRecentBlockhash, so unsigned or stale ones can be checked, and the
+// post-state of its writable accounts is accumulated into the results.
+//
+// This approximates bundle semantics only: standard RPC nodes cannot apply
+// account overrides, so every transaction is simulated against current chain
+// state rather than its predecessors' effects, and nothing is atomic.
+// Transactions that use accounts written earlier in the sequence are flagged
+// with DependsOnPrior. The returned error is only for RPC failures; program
+// failures are reported per transaction.
+//
+// Example:
+//
+//	sims, err := builder.SimulateSequential(ctx, []*solana.Transaction{createTx, buyTx})
+//	for i, s := range sims {
+//	    if s.Err != nil && !s.DependsOnPrior {
+//	        return fmt.Errorf("tx %d would fail: %w", i, s.Err)
+//	    }
+//	}
+func (b *Builder) SimulateSequential(ctx context.Context, txs []*solana.Transaction) ([]SequentialSimulation, error) {
+	if b.client == nil {
+		return nil, fmt.Errorf("rpc client is nil")
+	}
+	if len(txs) == 0 {
+		return nil, fmt.Errorf("no transactions to simulate")
+	}
+
+	state := make(map[solana.PublicKey]*solanarpc.Account)
+	out := make([]SequentialSimulation, 0, len(txs))
+	for i, tx := range txs {
+		if tx == nil {
+			return nil, fmt.Errorf("transaction %d is nil", i)
+		}
+		writable := writableMessageAccounts(tx)
+		res, err := b.client.SimulateTransaction(ctx, tx, &solanarpc.SimulateTransactionOpts{
+			ReplaceRecentBlockhash: true,
+			Commitment:             b.commitment,
+			Accounts: &solanarpc.SimulateTransactionAccountsOpts{
+				Encoding:  solana.EncodingBase64,
+				Addresses: writable,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("simulate transaction %d: %w", i, err)
+		}
+		if res == nil || res.Value == nil {
+			return nil, fmt.Errorf("simulate transaction %d: empty response", i)
+		}
+
+		sim := SequentialSimulation{Logs: res.Value.Logs}
+		if res.Value.UnitsConsumed != nil {
+			sim.UnitsConsumed = *res.Value.UnitsConsumed
+		}
+		for _, key := range tx.Message.AccountKeys {
+			// A shared fee payer is written by every transaction.
+			if _, ok := state[key]; ok && !tx.Message.IsSigner(key) {
+				sim.DependsOnPrior = true
+				break
+			}
+		}
+		if res.Value.Err != nil {
+			sim.Err = types.ParseSimulationErrorWithInstructions(res.Value.Err, res.Value.Logs, messageInstructions(tx))
+		} else {
+			for j, acc := range res.Value.Accounts {
+				if j < len(writable) {
+					state[writable[j]] = acc
+				}
+			}
+		}
+		sim.Accounts = make(map[solana.PublicKey]*solanarpc.Account, len(state))
+		for k, v := range state {
+			sim.Accounts[k] = v
+		}
+		b.log.Debug().Int("tx", i).Uint64("units", sim.UnitsConsumed).Bool("depends_on_prior", sim.DependsOnPrior).Err(sim.Err).Msg("sequential simulation")
+		out = append(out, sim)
+	}
+	return out, nil
+}
+
+// writableMessageAccounts returns the static account keys tx write-locks.
+func writableMessageAccounts(tx *solana.Transaction) []solana.PublicKey {
+	var out []solana.PublicKey
+	for _, key := range tx.Message.AccountKeys {
+		if w, err := tx.Message.IsWritable(key); err == nil && w {
+			out = append(out, key)
+		}
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// touchTx returns an unsigned transaction from payer that writes account.
+func touchTx(t *testing.T, payer, account solana.PublicKey) *solana.Transaction {
+	t.Helper()
+	ix := solana.NewInstruction(solana.SystemProgramID, solana.AccountMetaSlice{
+		solana.NewAccountMeta(payer, true, true),
+		solana.NewAccountMeta(account, true, false),
+	}, []byte{0})
+	tx, err := solana.NewTransaction([]solana.Instruction{ix}, solana.Hash{1}, solana.TransactionPayer(payer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func TestSimulateSequential(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	payer := solana.NewWallet().PublicKey()
+	ata := solana.NewWallet().PublicKey()
+	other := solana.NewWallet().PublicKey()
+
+	var call int
+	fake.handle("simulateTransaction", func(params json.RawMessage) (interface{}, error) {
+		var p []json.RawMessage
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		var cfg struct {
+			ReplaceRecentBlockhash bool `json:"replaceRecentBlockhash"`
+			Accounts               struct {
+				Addresses []string `json:"addresses"`
+			} `json:"accounts"`
+		}
+		if err := json.Unmarshal(p[1], &cfg); err != nil {
+			return nil, err
+		}
+		if !cfg.ReplaceRecentBlockhash {
+			t.Error("replaceRecentBlockhash not set")
+		}
+		accounts := make([]interface{}, len(cfg.Accounts.Addresses))
+		for i := range accounts {
+			accounts[i] = map[string]interface{}{
+				"data": []string{"", "base64"}, "executable": false, "lamports": 7,
+				"owner": solana.SystemProgramID.String(), "rentEpoch": 0,
+			}
+		}
+		call++
+		value := map[string]interface{}{"unitsConsumed": 1000 * call, "logs": []string{}, "accounts": accounts}
+		if call == 2 {
+			value["err"] = "AccountNotFound"
+		}
+		return rpcContext(value), nil
+	})
+
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed)
+	sims, err := b.SimulateSequential(context.Background(), []*solana.Transaction{
+		touchTx(t, payer, ata),
+		touchTx(t, payer, ata),
+		touchTx(t, payer, other),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sims) != 3 {
+		t.Fatalf("got %d results, want 3", len(sims))
+	}
+	if sims[0].Err != nil || sims[0].DependsOnPrior || sims[0].UnitsConsumed != 1000 {
+		t.Errorf("tx 0 = %+v", sims[0])
+	}
+	if acc := sims[0].Accounts[ata]; acc == nil || acc.Lamports != 7 {
+		t.Errorf("tx 0 post-state of ata = %+v", acc)
+	}
+	var simErr *types.SimulationError
+	if !errors.As(sims[1].Err, &simErr) || !sims[1].DependsOnPrior || sims[1].UnitsConsumed != 2000 {
+		t.Errorf("tx 1 = %+v, want a dependent AccountNotFound failure", sims[1])
+	}
+	if sims[2].Err != nil || sims[2].DependsOnPrior {
+		t.Errorf("tx 2 = %+v, want independent success (shared payer only)", sims[2])
+	}
+	if _, ok := sims[2].Accounts[other]; !ok || len(sims[2].Accounts) != 3 {
+		t.Errorf("tx 2 accumulated %d accounts, want payer, ata and other", len(sims[2].Accounts))
+	}
+}
@@ -0,0 +1,80 @@
+package txbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// WithPreflightSimulation makes Send (and everything built on it) run a
+// sig-verified simulation first and abort without sending if it fails.
+// Unlike RPC preflight, the failure is returned as a decoded
+// *types.ProgramError (or types.SimulationError) with the program logs.
+func (b *Builder) WithPreflightSimulation(enabled bool) *Builder {
+	b.preflightSim = enabled
+	return b
+}
+
+// SimulateAndSend simulates a signed transaction and, only if the simulation
+// succeeds, sends it and waits for confirmation at level. A failed simulation
+// returns a decoded *types.ProgramError (or types.SimulationError) and nothing
+// is sent, so no fee is paid for a doomed transaction.
+func (b *Builder) SimulateAndSend(ctx context.Context, tx *solana.Transaction, level ConfirmationLevel) (solana.Signature, error) {
+	if err := b.simulateSigned(ctx, tx); err != nil {
+		return solana.Signature{}, err
+	}
+	sig, err := b.send(ctx, tx)
+	if err != nil {
+		return solana.Signature{}, err
+	}
+	if err = b.WaitForConfirmation(ctx, sig, level); err != nil {
+		return sig, fmt.Errorf("confirmation failed: %w, sig: %v", err, sig)
+	}
+	return sig, nil
+}
+
+// simulateSigned runs a sig-verified simulation of tx and decodes any failure.
+func (b *Builder) simulateSigned(ctx context.Context, tx *solana.Transaction) error {
+	if b.client == nil {
+		return fmt.Errorf("rpc client is nil")
+	}
+	res, err := b.client.SimulateTransaction(ctx, tx, &solanarpc.SimulateTransactionOpts{
+		SigVerify:  true,
+		Commitment: b.commitment,
+	})
+	if err != nil {
+		return fmt.Errorf("simulate transaction: %w", err)
+	}
+	if res == nil || res.Value == nil {
+		return fmt.Errorf("simulate transaction: empty response")
+	}
+	if res.Value.Err != nil {
+		b.log.Debug().Interface("err", res.Value.Err).Strs("logs", res.Value.Logs).Msg("tx simulation failed")
+		return types.ParseSimulationErrorWithInstructions(res.Value.Err, res.Value.Logs, messageInstructions(tx))
+	}
+	b.log.Debug().Msg("tx simulation ok")
+	return nil
+}
+
+// messageInstructions decompiles the instructions of tx, so simulation
+// errors can name the failing one. It returns nil if any cannot be resolved.
+func messageInstructions(tx *solana.Transaction) []solana.Instruction {
+	msg := tx.Message
+	out := make([]solana.Instruction, 0, len(msg.Instructions))
+	for _, ix := range msg.Instructions {
+		program, err := msg.Program(ix.ProgramIDIndex)
+		if err != nil {
+			return nil
+		}
+		accounts, err := ix.ResolveInstructionAccounts(&msg)
+		if err != nil {
+			return nil
+		}
+		out = append(out, solana.NewInstruction(program, accounts, ix.Data))
+	}
+	return out
+}
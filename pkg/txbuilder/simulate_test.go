@@ -0,0 +1,64 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+func TestSimulateAndSendAbortsOnProgramError(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("simulateTransaction", func(json.RawMessage) (interface{}, error) {
+		return rpcContext(map[string]interface{}{
+			"err":  map[string]interface{}{"InstructionError": []interface{}{0, map[string]interface{}{"Custom": 6003}}},
+			"logs": []string{"Program log: AnchorError occurred. Error Code: TooMuchSolRequired."},
+		}), nil
+	})
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed)
+
+	_, err := b.SimulateAndSend(context.Background(), testTx(t), ConfirmationConfirmed)
+	var progErr *types.ProgramError
+	if !errors.As(err, &progErr) {
+		t.Fatalf("expected *types.ProgramError, got %v", err)
+	}
+	if progErr.Code != 6003 || len(progErr.Logs) != 1 {
+		t.Fatalf("unexpected program error: %+v", progErr)
+	}
+	if n := fake.callCount("sendTransaction"); n != 0 {
+		t.Fatalf("doomed transaction was sent %d times", n)
+	}
+}
+
+func TestPreflightSimulationGatesSend(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("simulateTransaction", func(json.RawMessage) (interface{}, error) {
+		return rpcContext(map[string]interface{}{"err": "AccountNotFound", "logs": []string{}}), nil
+	})
+	tx := testTx(t)
+	fake.handle("sendTransaction", func(json.RawMessage) (interface{}, error) {
+		return tx.Signatures[0].String(), nil
+	})
+
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed)
+	if _, err := b.Send(context.Background(), tx); err != nil {
+		t.Fatalf("send without preflight simulation: %v", err)
+	}
+	if fake.callCount("simulateTransaction") != 0 {
+		t.Fatal("simulation must be opt-in")
+	}
+
+	b.WithPreflightSimulation(true)
+	_, err := b.Send(context.Background(), tx)
+	var simErr *types.SimulationError
+	if !errors.As(err, &simErr) {
+		t.Fatalf("expected *types.SimulationError, got %v", err)
+	}
+	if fake.callCount("sendTransaction") != 1 {
+		t.Fatal("failed simulation must not send")
+	}
+}
@@ -0,0 +1,42 @@
+package txbuilder
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// MaxTransactionSize is the Solana packet limit for a serialized
+// transaction, signatures included.
+const MaxTransactionSize = 1232
+
+// WithMaxTransactionSize sets the serialized size above which
+// BuildTransaction fails with types.ErrTransactionTooLarge instead of
+// returning a transaction no node will accept. bytes <= 0 restores the
+// default, MaxTransactionSize.
+func (b *Builder) WithMaxTransactionSize(bytes int) *Builder {
+	b.maxTxSize = bytes
+	return b
+}
+
+// checkSize returns types.ErrTransactionTooLarge if tx, once signed, would
+// exceed the builder's size limit.
+func (b *Builder) checkSize(tx *solana.Transaction) error {
+	limit := b.maxTxSize
+	if limit <= 0 {
+		limit = MaxTransactionSize
+	}
+	msg, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("encode message: %w", err)
+	}
+	// compact-u16 signature count (one byte below 128) and the signatures.
+	size := len(msg) + 1 + int(tx.Message.Header.NumRequiredSignatures)*solana.SignatureLength
+	if size > limit {
+		return fmt.Errorf("%w: %d bytes with %d instructions and %d accounts, limit %d",
+			types.ErrTransactionTooLarge, size, len(tx.Message.Instructions), len(tx.Message.AccountKeys), limit)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+func TestBuildTransactionTooLarge(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("getLatestBlockhash", func(json.RawMessage) (interface{}, error) {
+		return rpcContext(map[string]interface{}{"blockhash": solana.Hash{7}.String(), "lastValidBlockHeight": 500}), nil
+	})
+	payer := solana.NewWallet().PublicKey()
+	memo := func(n int) solana.Instruction {
+		return solana.NewInstruction(constants.MemoProgramID, solana.AccountMetaSlice{}, make([]byte, n))
+	}
+
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed)
+	if _, err := b.BuildTransaction(context.Background(), payer, memo(400), memo(400)); err != nil {
+		t.Fatalf("transaction under the limit: %v", err)
+	}
+	_, err := b.BuildTransaction(context.Background(), payer, memo(400), memo(400), memo(400))
+	if !errors.Is(err, types.ErrTransactionTooLarge) {
+		t.Fatalf("expected ErrTransactionTooLarge, got %v", err)
+	}
+	if types.ClassifyError(err) != types.ErrorClassValidation {
+		t.Fatalf("oversized transaction classified as %s", types.ClassifyError(err))
+	}
+
+	b.WithMaxTransactionSize(600)
+	if _, err := b.BuildTransaction(context.Background(), payer, memo(400), memo(400)); !errors.Is(err, types.ErrTransactionTooLarge) {
+		t.Fatalf("expected the configured limit to apply, got %v", err)
+	}
+}
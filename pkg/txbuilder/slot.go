@@ -0,0 +1,160 @@
+package txbuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+)
+
+// DefaultSlotLead is how many slots before the target SendAtSlot sends, to
+// cover the time the transaction takes to reach the leader.
+const DefaultSlotLead = 1
+
+// slotPollInterval is how often SendAtSlot polls the current slot, about a
+// quarter of a slot.
+const slotPollInterval = 100 * time.Millisecond
+
+var (
+	// ErrTargetSlotPassed is returned by SendAtSlot when the cluster is
+	// already past the target slot.
+	ErrTargetSlotPassed = errors.New("target slot has already passed")
+	// ErrBlockhashExpiresBeforeSlot is returned by SendAtSlot when the
+	// transaction's blockhash may expire before the target slot.
+	ErrBlockhashExpiresBeforeSlot = errors.New("blockhash may expire before target slot")
+)
+
+// WithSlotLead sets how many slots before the target SendAtSlot sends
+// (0 = DefaultSlotLead).
+func (b *Builder) WithSlotLead(slots uint64) *Builder {
+	b.slotLead = slots
+	return b
+}
+
+// WithSlotDualSend makes SendAtSlot send over RPC and, when a Jito client is
+// configured, to the block engine at the same time, instead of the single
+// path Send would use.
+func (b *Builder) WithSlotDualSend(enabled bool) *Builder {
+	b.slotDualSend = enabled
+	return b
+}
+
+// SendAtSlot holds a signed transaction until the cluster reaches targetSlot
+// less the slot lead (see WithSlotLead), then sends it. The current slot is
+// polled at processed commitment about four times per slot.
+//
+// Timing is approximate: slots last about 400ms but vary, the polled slot
+// lags the leader by the RPC node's own delay, and the send still has to
+// reach the leader, so the transaction can land a few slots before or after
+// the target. Use the lead to trade landing early against landing late.
+//
+// ErrTargetSlotPassed is returned if the cluster is already past
+// targetSlot, before or while waiting. For transactions built by this
+// builder, ErrBlockhashExpiresBeforeSlot is returned upfront if the
+// blockhash could expire before targetSlot. With preflight simulation
+// enabled the transaction is simulated before waiting, not at the target.
+//
+// Example:
+//
+//	tx, _ := builder.BuildTransaction(ctx, payer, instrs...)
+//	// sign tx
+//	sig, err := builder.WithSlotLead(2).SendAtSlot(ctx, tx, launchSlot)
+func (b *Builder) SendAtSlot(ctx context.Context, tx *solana.Transaction, targetSlot uint64) (solana.Signature, error) {
+	if b.client == nil {
+		return solana.Signature{}, fmt.Errorf("rpc client is nil")
+	}
+	if tx == nil || len(tx.Signatures) == 0 || tx.Signatures[0].IsZero() {
+		return solana.Signature{}, fmt.Errorf("transaction must be signed")
+	}
+	lead := b.slotLead
+	if lead == 0 {
+		lead = DefaultSlotLead
+	}
+	sendFrom := targetSlot - min(lead, targetSlot)
+
+	slot, err := b.client.GetSlot(ctx, solanarpc.CommitmentProcessed)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("get slot: %w", err)
+	}
+	if slot > targetSlot {
+		return solana.Signature{}, fmt.Errorf("%w: current slot %d, target %d", ErrTargetSlotPassed, slot, targetSlot)
+	}
+	if lastValid, ok := b.LastValidBlockHeight(tx); ok {
+		height, err := b.client.GetBlockHeight(ctx, solanarpc.CommitmentProcessed)
+		if err != nil {
+			return solana.Signature{}, fmt.Errorf("get block height: %w", err)
+		}
+		// At most one block is produced per slot.
+		if height+(targetSlot-slot) > lastValid {
+			return solana.Signature{}, fmt.Errorf("%w: block height %d, %d slots to target, last valid %d", ErrBlockhashExpiresBeforeSlot, height, targetSlot-slot, lastValid)
+		}
+	}
+	if b.preflightSim {
+		if err := b.simulateSigned(ctx, tx); err != nil {
+			return solana.Signature{}, err
+		}
+	}
+
+	b.log.Debug().Uint64("slot", slot).Uint64("target_slot", targetSlot).Uint64("lead", lead).Msg("waiting for target slot")
+	ticker := time.NewTicker(slotPollInterval)
+	defer ticker.Stop()
+	for slot < sendFrom {
+		select {
+		case <-ctx.Done():
+			return solana.Signature{}, ctx.Err()
+		case <-ticker.C:
+		}
+		if slot, err = b.client.GetSlot(ctx, solanarpc.CommitmentProcessed); err != nil {
+			return solana.Signature{}, fmt.Errorf("get slot: %w", err)
+		}
+	}
+	if slot > targetSlot {
+		return solana.Signature{}, fmt.Errorf("%w: current slot %d, target %d", ErrTargetSlotPassed, slot, targetSlot)
+	}
+
+	b.log.Debug().Uint64("slot", slot).Uint64("target_slot", targetSlot).Msg("sending at slot")
+	if b.slotDualSend && b.jitoClient != nil {
+		return b.dualSend(ctx, tx)
+	}
+	return b.send(ctx, tx)
+}
+
+// dualSend sends tx over RPC and to Jito concurrently and succeeds if either
+// accepts it. Both carry the same signature, so it lands at most once.
+func (b *Builder) dualSend(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
+	release, pending, err := b.guardDuplicate(tx)
+	if err != nil {
+		return pending, err
+	}
+	errs := make(chan error, 2)
+	go func() {
+		_, err := b.client.SendTransaction(ctx, tx, solanarpc.TransactionOpts{SkipPreflight: true})
+		if err != nil {
+			err = fmt.Errorf("rpc: %w", err)
+		}
+		errs <- err
+	}()
+	go func() {
+		_, err := b.jitoClient.SendTransactionWithBundleID(ctx, tx)
+		if err != nil {
+			err = fmt.Errorf("jito: %w", err)
+		}
+		errs <- err
+	}()
+	var failed []error
+	for range 2 {
+		if err := <-errs; err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) == 2 {
+		release()
+		return solana.Signature{}, fmt.Errorf("send transaction: %w", errors.Join(failed...))
+	}
+	b.expiry.bindSignature(tx)
+	b.log.Debug().Stringer("sig", tx.Signatures[0]).Int("failed_paths", len(failed)).Msg("tx sent (rpc+jito)")
+	return tx.Signatures[0], nil
+}
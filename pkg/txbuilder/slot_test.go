@@ -0,0 +1,67 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+)
+
+func TestSendAtSlotWaitsForLead(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	tx := testTx(t)
+	var slot atomic.Uint64
+	slot.Store(100)
+	var sentAt atomic.Uint64
+	fake.handle("getSlot", func(json.RawMessage) (interface{}, error) {
+		return slot.Add(1) - 1, nil
+	})
+	fake.handle("sendTransaction", func(json.RawMessage) (interface{}, error) {
+		sentAt.Store(slot.Load() - 1)
+		return tx.Signatures[0].String(), nil
+	})
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed).WithSlotLead(2)
+
+	sig, err := b.SendAtSlot(context.Background(), tx, 104)
+	if err != nil {
+		t.Fatalf("SendAtSlot: %v", err)
+	}
+	if sig != tx.Signatures[0] {
+		t.Fatalf("signature = %s, want %s", sig, tx.Signatures[0])
+	}
+	if got := sentAt.Load(); got != 102 {
+		t.Fatalf("sent at slot %d, want 102 (target 104 less lead 2)", got)
+	}
+}
+
+func TestSendAtSlotRejectsPassedTarget(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	fake.handle("getSlot", func(json.RawMessage) (interface{}, error) { return 200, nil })
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed)
+
+	if _, err := b.SendAtSlot(context.Background(), testTx(t), 150); !errors.Is(err, ErrTargetSlotPassed) {
+		t.Fatalf("expected ErrTargetSlotPassed, got %v", err)
+	}
+	if n := fake.callCount("sendTransaction"); n != 0 {
+		t.Fatalf("expected no send, got %d", n)
+	}
+}
+
+func TestSendAtSlotRejectsExpiringBlockhash(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	tx := testTx(t)
+	fake.handle("getSlot", func(json.RawMessage) (interface{}, error) { return 1_000, nil })
+	fake.handle("getBlockHeight", func(json.RawMessage) (interface{}, error) { return 900, nil })
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed)
+	b.expiry.recordBlockhash(tx.Message.RecentBlockhash, 1_000, 0)
+
+	if _, err := b.SendAtSlot(context.Background(), tx, 1_200); !errors.Is(err, ErrBlockhashExpiresBeforeSlot) {
+		t.Fatalf("expected ErrBlockhashExpiresBeforeSlot, got %v", err)
+	}
+	if n := fake.callCount("sendTransaction"); n != 0 {
+		t.Fatalf("expected no send, got %d", n)
+	}
+}
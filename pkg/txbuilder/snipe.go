@@ -0,0 +1,159 @@
+package txbuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+)
+
+// snipeRebroadcastInterval is how often SnipeSend re-sends over RPC while
+// waiting, roughly once per slot.
+const snipeRebroadcastInterval = 400 * time.Millisecond
+
+// Snipe send paths reported in SnipeResult.Via.
+const (
+	SnipeViaRPC  = "rpc"
+	SnipeViaJito = "jito"
+)
+
+// SnipeResult describes a SnipeSend that confirmed.
+type SnipeResult struct {
+	// Signature is the transaction signature; both paths carry the same one.
+	Signature solana.Signature
+	// Via is the path that observed confirmation first (SnipeViaRPC or
+	// SnipeViaJito). The transaction lands at most once either way.
+	Via string
+	// BundleID is the Jito bundle ID, if the Jito path was used.
+	BundleID string
+	// Broadcasts is the number of RPC sends, including re-broadcasts.
+	Broadcasts int
+}
+
+// SnipeSend dual-sends a signed transaction for launch sniping: over RPC with
+// preflight skipped, re-broadcast about once per slot, and concurrently to
+// the Jito block engine (as a bundle with jitoTipTx when non-nil, otherwise
+// as a single transaction). It returns as soon as either path confirms at
+// the confirmed level, or when both have failed, or the blockhash expires.
+//
+// The transaction should already carry the desired priority fee; it is sent
+// as-is. Dual-send is only safe for idempotent, single-shot transactions
+// signed once: both paths submit the same signature, so it can land at most
+// once, but do not re-sign or rebuild it between attempts.
+//
+// Example:
+//
+//	res, err := builder.SnipeSend(ctx, buyTx, tipTx)
+//	if err == nil {
+//	    fmt.Println("landed via", res.Via, res.Signature)
+//	}
+func (b *Builder) SnipeSend(ctx context.Context, tx, jitoTipTx *solana.Transaction) (*SnipeResult, error) {
+	if tx == nil || len(tx.Signatures) == 0 || tx.Signatures[0].IsZero() {
+		return nil, fmt.Errorf("transaction must be signed")
+	}
+	if b.client == nil && b.jitoClient == nil {
+		return nil, fmt.Errorf("rpc client and jito client are both nil")
+	}
+	release, pending, err := b.guardDuplicate(tx)
+	if err != nil {
+		return &SnipeResult{Signature: pending}, err
+	}
+	sig := tx.Signatures[0]
+	b.expiry.bindSignature(tx)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type outcome struct {
+		via string
+		err error
+	}
+	results := make(chan outcome, 2)
+	broadcasts := make(chan int, 1)
+	bundleID := make(chan string, 1)
+	paths := 0
+
+	if b.client != nil {
+		paths++
+		go func() { broadcasts <- b.rebroadcast(ctx, tx) }()
+		go func() {
+			results <- outcome{via: SnipeViaRPC, err: b.WaitForConfirmation(ctx, sig, ConfirmationConfirmed)}
+		}()
+	}
+	if b.jitoClient != nil {
+		paths++
+		go func() {
+			id, err := b.snipeJito(ctx, tx, jitoTipTx)
+			bundleID <- id
+			if err == nil {
+				err = b.jitoClient.WaitForBundleConfirmation(ctx, id)
+			}
+			results <- outcome{via: SnipeViaJito, err: err}
+		}()
+	}
+
+	// finish stops the remaining path and collects per-path details.
+	res := &SnipeResult{Signature: sig}
+	finish := func() {
+		cancel()
+		if b.client != nil {
+			res.Broadcasts = <-broadcasts
+		}
+		if b.jitoClient != nil {
+			res.BundleID = <-bundleID
+		}
+	}
+
+	var errs []error
+	for i := 0; i < paths; i++ {
+		out := <-results
+		if out.err == nil {
+			finish()
+			b.settleDuplicate(sig)
+			res.Via = out.via
+			b.log.Debug().Stringer("sig", sig).Str("via", out.via).Int("broadcasts", res.Broadcasts).Msg("snipe confirmed")
+			return res, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", out.via, out.err))
+	}
+	finish()
+	release()
+	return res, fmt.Errorf("snipe send: %w", errors.Join(errs...))
+}
+
+// rebroadcast sends tx over RPC with preflight skipped until ctx is done and
+// returns the number of successful sends.
+func (b *Builder) rebroadcast(ctx context.Context, tx *solana.Transaction) int {
+	opts := solanarpc.TransactionOpts{SkipPreflight: true}
+	ticker := time.NewTicker(snipeRebroadcastInterval)
+	defer ticker.Stop()
+	sent := 0
+	for {
+		if _, err := b.client.SendTransaction(ctx, tx, opts); err == nil {
+			sent++
+		} else if ctx.Err() == nil {
+			b.log.Debug().Err(err).Msg("snipe rebroadcast failed (rpc)")
+		}
+		select {
+		case <-ctx.Done():
+			return sent
+		case <-ticker.C:
+		}
+	}
+}
+
+// snipeJito submits tx (with the tip transaction, if any) to Jito and
+// returns the bundle ID.
+func (b *Builder) snipeJito(ctx context.Context, tx, tipTx *solana.Transaction) (string, error) {
+	if tipTx != nil {
+		return b.SendBundleViaJito(ctx, []*solana.Transaction{tx, tipTx})
+	}
+	result, err := b.jitoClient.SendTransactionWithBundleID(ctx, tx)
+	if err != nil {
+		return "", fmt.Errorf("jito send transaction: %w", err)
+	}
+	return result.BundleID, nil
+}
@@ -0,0 +1,61 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+)
+
+func TestSnipeSendRebroadcastsUntilConfirmed(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	tx := testTx(t)
+	fake.handle("sendTransaction", func(params json.RawMessage) (interface{}, error) {
+		var p []json.RawMessage
+		_ = json.Unmarshal(params, &p)
+		var opts struct {
+			SkipPreflight bool `json:"skipPreflight"`
+		}
+		if len(p) > 1 {
+			_ = json.Unmarshal(p[1], &opts)
+		}
+		if !opts.SkipPreflight {
+			t.Error("snipe sends must skip preflight")
+		}
+		return tx.Signatures[0].String(), nil
+	})
+	fake.handle("getSignatureStatuses", func(params json.RawMessage) (interface{}, error) {
+		if fake.callCount("sendTransaction") < 2 {
+			return rpcContext([]interface{}{nil}), nil
+		}
+		return rpcContext([]interface{}{map[string]interface{}{
+			"slot": 1, "confirmations": nil, "err": nil, "confirmationStatus": "confirmed",
+		}}), nil
+	})
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed).
+		WithConfirmPolling(5*time.Millisecond, -1).
+		WithConfirmMaxElapsed(5 * time.Second)
+
+	res, err := b.SnipeSend(context.Background(), tx, nil)
+	if err != nil {
+		t.Fatalf("SnipeSend: %v", err)
+	}
+	if res.Via != SnipeViaRPC || res.Signature != tx.Signatures[0] {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if res.Broadcasts < 2 {
+		t.Fatalf("expected a re-broadcast, got %d sends", res.Broadcasts)
+	}
+}
+
+func TestSnipeSendRequiresSignedTx(t *testing.T) {
+	_, client := newFakeRPC(t)
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed)
+	tx := testTx(t)
+	tx.Signatures = nil
+	if _, err := b.SnipeSend(context.Background(), tx, nil); err == nil {
+		t.Fatal("expected error for unsigned transaction")
+	}
+}
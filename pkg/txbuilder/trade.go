@@ -0,0 +1,73 @@
+package txbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+	"github.com/ninja0404/pump-go-sdk/pkg/wallet"
+)
+
+// TradeResult is what SendTrade knows about a landed trade.
+type TradeResult struct {
+	Signature solana.Signature
+	// Status is the confirmation level the transaction reached.
+	Status ConfirmationLevel
+	// Slot, Fee and the fills below are read from the confirmed
+	// transaction. They are left zero when Events is nil because the
+	// transaction could not be fetched after confirming.
+	Slot   uint64
+	Fee    uint64
+	Events *TransactionEvents
+
+	// PumpTrades are the bonding curve buys and sells, in emission order.
+	PumpTrades []*pump.TradeEvent
+	// AmmBuys and AmmSells are the pump_amm fills, in emission order.
+	AmmBuys  []*pumpamm.BuyEvent
+	AmmSells []*pumpamm.SellEvent
+}
+
+// SendTrade builds, signs and sends instructions, waits for the builder's
+// commitment and then fetches the landed transaction to report its slot,
+// fee and decoded pump and pump_amm fills. signers[0] pays the fee.
+//
+// A failure to fetch the confirmed transaction is not an error: the trade
+// landed, so the result is returned with Events nil.
+//
+// Example:
+//
+//	res, err := builder.SendTrade(ctx, []wallet.Signer{signer}, instrs...)
+//	for _, fill := range res.PumpTrades {
+//	    log.Printf("%s: %d tokens for %d lamports (fee %d)", res.Signature, fill.TokenAmount, fill.SolAmount, res.Fee)
+//	}
+func (b *Builder) SendTrade(ctx context.Context, signers []wallet.Signer, instructions ...solana.Instruction) (*TradeResult, error) {
+	if len(signers) == 0 || signers[0] == nil {
+		return nil, fmt.Errorf("fee payer is required")
+	}
+	level := ConfirmationLevel(b.commitment)
+	sig, err := b.BuildSignSendAndConfirm(ctx, signers[0], signers[1:], level, instructions...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &TradeResult{Signature: sig, Status: level}
+	evs, err := b.FetchTransactionEvents(ctx, sig, level)
+	if err != nil {
+		b.log.Debug().Err(err).Stringer("sig", sig).Msg("trade landed but its transaction could not be fetched")
+		return res, nil
+	}
+	res.Slot, res.Fee, res.Events = evs.Slot, evs.Fee, evs
+	for _, data := range evs.EventData {
+		if ev, err := pump.DecodeTradeEvent(data); err == nil {
+			res.PumpTrades = append(res.PumpTrades, ev)
+		} else if ev, err := pumpamm.DecodeBuyEvent(data); err == nil {
+			res.AmmBuys = append(res.AmmBuys, ev)
+		} else if ev, err := pumpamm.DecodeSellEvent(data); err == nil {
+			res.AmmSells = append(res.AmmSells, ev)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,103 @@
+package txbuilder
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+	"github.com/ninja0404/pump-go-sdk/pkg/wallet"
+)
+
+func encodeTestEvent(t *testing.T, disc []byte, v interface{}) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.Write(disc)
+	if err := bin.NewBorshEncoder(&buf).Encode(v); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSendTrade(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	key, _ := solana.NewRandomPrivateKey()
+	signer := wallet.NewLocalFromPrivateKey(key)
+	payer := signer.PublicKey()
+
+	var sent *solana.Transaction
+	fake.handle("getLatestBlockhash", func(json.RawMessage) (interface{}, error) {
+		return rpcContext(map[string]interface{}{"blockhash": solana.Hash{7}.String(), "lastValidBlockHeight": 500}), nil
+	})
+	fake.handle("sendTransaction", func(params json.RawMessage) (interface{}, error) {
+		var p []json.RawMessage
+		var raw string
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(p[0], &raw); err != nil {
+			return nil, err
+		}
+		tx, err := solana.TransactionFromBase64(raw)
+		if err != nil {
+			return nil, err
+		}
+		sent = tx
+		return tx.Signatures[0].String(), nil
+	})
+	fake.handle("getSignatureStatuses", func(json.RawMessage) (interface{}, error) {
+		return rpcContext([]interface{}{map[string]interface{}{
+			"slot": 88, "confirmations": nil, "err": nil, "confirmationStatus": "confirmed",
+		}}), nil
+	})
+
+	trade := pump.TradeEvent{SolAmount: 100_000_000, TokenAmount: 3_500_000_000, IsBuy: true, User: payer}
+	sell := pumpamm.SellEvent{BaseAmountIn: 1_000_000, UserQuoteAmountOut: 42_000}
+	fake.handle("getTransaction", func(json.RawMessage) (interface{}, error) {
+		raw, _ := sent.MarshalBinary()
+		return map[string]interface{}{
+			"slot": 88,
+			"meta": map[string]interface{}{
+				"err":          nil,
+				"fee":          15_000,
+				"preBalances":  []uint64{},
+				"postBalances": []uint64{},
+				"logMessages": []string{
+					"Program data: " + base64.StdEncoding.EncodeToString(encodeTestEvent(t, pump.TradeEventDiscriminator, trade)),
+					"Program data: " + base64.StdEncoding.EncodeToString(encodeTestEvent(t, pumpamm.SellEventDiscriminator, sell)),
+					"Program data: " + base64.StdEncoding.EncodeToString([]byte{1, 2, 3, 4, 5, 6, 7, 8}),
+				},
+			},
+			"transaction": []string{base64.StdEncoding.EncodeToString(raw), "base64"},
+		}, nil
+	})
+
+	b := NewBuilder(client, solanarpc.CommitmentConfirmed)
+	ix := solana.NewInstruction(solana.SystemProgramID, solana.AccountMetaSlice{
+		solana.NewAccountMeta(payer, true, true),
+	}, []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0})
+	res, err := b.SendTrade(context.Background(), []wallet.Signer{signer}, ix)
+	if err != nil {
+		t.Fatalf("SendTrade: %v", err)
+	}
+	if res.Signature != sent.Signatures[0] || res.Status != ConfirmationConfirmed || res.Slot != 88 || res.Fee != 15_000 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	if len(res.PumpTrades) != 1 || res.PumpTrades[0].TokenAmount != trade.TokenAmount || !res.PumpTrades[0].IsBuy {
+		t.Fatalf("pump fills %+v", res.PumpTrades)
+	}
+	if len(res.AmmSells) != 1 || res.AmmSells[0].UserQuoteAmountOut != sell.UserQuoteAmountOut || len(res.AmmBuys) != 0 {
+		t.Fatalf("amm fills %+v, %+v", res.AmmBuys, res.AmmSells)
+	}
+
+	if _, err := b.SendTrade(context.Background(), nil, ix); err == nil {
+		t.Fatal("expected an error without a fee payer")
+	}
+}
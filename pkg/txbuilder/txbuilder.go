@@ -7,6 +7,7 @@ import (
 
 	"github.com/gagliardetto/solana-go"
 	solanarpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/rs/zerolog"
 
 	"github.com/ninja0404/pump-go-sdk/pkg/jito"
 	wraprpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
@@ -27,7 +28,27 @@ type Builder struct {
 	client        *wraprpc.Client
 	commitment    solanarpc.CommitmentType
 	skipPreflight bool
-	jitoClient    *jito.Client
+	jitoClient    jito.Sender
+	log           zerolog.Logger
+	preflightSim  bool
+	dedupe        *dedupeCache
+	expiry        *expiryTracker
+
+	confirmInterval   time.Duration
+	confirmJitter     time.Duration
+	confirmMaxElapsed time.Duration
+
+	autoFeePercentile float64
+	autoFeeFallback   uint64
+
+	jitoConfirm        bool
+	jitoConfirmTimeout time.Duration
+
+	slotLead     uint64
+	slotDualSend bool
+
+	maxTxSize   int
+	lookupTable solana.PublicKey
 }
 
 // NewBuilder constructs a builder with the provided client and commitment.
@@ -35,7 +56,18 @@ func NewBuilder(client *wraprpc.Client, commitment solanarpc.CommitmentType) *Bu
 	if commitment == "" {
 		commitment = solanarpc.CommitmentConfirmed
 	}
-	return &Builder{client: client, commitment: commitment}
+	log := zerolog.Nop()
+	if client != nil {
+		log = client.Logger()
+	}
+	return &Builder{client: client, commitment: commitment, log: log, expiry: newExpiryTracker()}
+}
+
+// WithLogger sets the logger used for build/sign/send/confirm milestones
+// (debug level). Defaults to the RPC client's logger.
+func (b *Builder) WithLogger(log zerolog.Logger) *Builder {
+	b.log = log
+	return b
 }
 
 // WithSkipPreflight configures whether to skip preflight.
@@ -44,9 +76,13 @@ func (b *Builder) WithSkipPreflight(skip bool) *Builder {
 	return b
 }
 
-// WithJito configures Jito client for MEV-protected transactions.
+// WithJito configures Jito client for MEV-protected transactions: a
+// jito.Client (JSON-RPC over HTTP) or a jito.GRPCClient (lower-latency gRPC).
 // Pass nil to disable Jito and use standard RPC.
-func (b *Builder) WithJito(jitoClient *jito.Client) *Builder {
+func (b *Builder) WithJito(jitoClient jito.Sender) *Builder {
+	if jitoClient != nil && jitoClient.IsNil() {
+		jitoClient = nil
+	}
 	b.jitoClient = jitoClient
 	return b
 }
@@ -56,12 +92,23 @@ func (b *Builder) HasJito() bool {
 	return b.jitoClient != nil
 }
 
-// JitoClient returns the configured Jito client, or nil if not configured.
+// JitoClient returns the configured JSON-RPC Jito client, or nil if Jito is
+// not configured or uses another transport such as jito.GRPCClient.
 func (b *Builder) JitoClient() *jito.Client {
+	c, _ := b.jitoClient.(*jito.Client)
+	return c
+}
+
+// JitoSender returns the configured Jito transport, or nil if not configured.
+func (b *Builder) JitoSender() jito.Sender {
 	return b.jitoClient
 }
 
-// BuildTransaction builds a transaction with fresh blockhash.
+// BuildTransaction builds a transaction with fresh blockhash. If the signed
+// legacy transaction would exceed the size limit (see WithMaxTransactionSize),
+// it is rebuilt as v0 against the WithAutoLookupTable table, or fails with
+// types.ErrTransactionTooLarge when no table is set or the v0 transaction is
+// still too large.
 func (b *Builder) BuildTransaction(ctx context.Context, feePayer solana.PublicKey, instructions ...solana.Instruction) (*solana.Transaction, error) {
 	if b.client == nil {
 		return nil, fmt.Errorf("rpc client is nil")
@@ -70,10 +117,13 @@ func (b *Builder) BuildTransaction(ctx context.Context, feePayer solana.PublicKe
 		return nil, fmt.Errorf("requires at least one instruction")
 	}
 
+	instructions = b.applyAutoPriorityFee(ctx, instructions)
+
 	latest, err := b.client.GetLatestBlockhash(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("get latest blockhash: %w", err)
 	}
+	b.expiry.recordBlockhash(latest.Value.Blockhash, latest.Value.LastValidBlockHeight, latest.Context.Slot)
 
 	builder := solana.NewTransactionBuilder().
 		SetRecentBlockHash(latest.Value.Blockhash).
@@ -87,6 +137,20 @@ func (b *Builder) BuildTransaction(ctx context.Context, feePayer solana.PublicKe
 	if err != nil {
 		return nil, fmt.Errorf("build transaction: %w", err)
 	}
+	if err := b.checkSize(tx); err != nil {
+		if b.lookupTable.IsZero() {
+			return nil, fmt.Errorf("%w; split the instructions or set WithAutoLookupTable to build a v0 transaction", err)
+		}
+		if tx, err = b.buildV0(ctx, feePayer, latest.Value.Blockhash, instructions); err != nil {
+			return nil, err
+		}
+	}
+	b.log.Debug().
+		Stringer("fee_payer", feePayer).
+		Int("instructions", len(instructions)).
+		Stringer("blockhash", latest.Value.Blockhash).
+		Uint64("last_valid_block_height", latest.Value.LastValidBlockHeight).
+		Msg("tx built")
 	return tx, nil
 }
 
@@ -132,6 +196,15 @@ func SignTransaction(ctx context.Context, tx *solana.Transaction, signers ...wal
 // Send sends a signed transaction.
 // If Jito client is configured, uses Jito Block Engine; otherwise uses standard RPC.
 func (b *Builder) Send(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
+	if b.preflightSim {
+		if err := b.simulateSigned(ctx, tx); err != nil {
+			return solana.Signature{}, err
+		}
+	}
+	return b.send(ctx, tx)
+}
+
+func (b *Builder) send(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
 	// Use Jito if configured
 	if b.jitoClient != nil {
 		return b.SendViaJito(ctx, tx)
@@ -144,28 +217,50 @@ func (b *Builder) SendViaRPC(ctx context.Context, tx *solana.Transaction) (solan
 	if b.client == nil {
 		return solana.Signature{}, fmt.Errorf("rpc client is nil")
 	}
+	release, pending, err := b.guardDuplicate(tx)
+	if err != nil {
+		return pending, err
+	}
 	opts := solanarpc.TransactionOpts{
 		SkipPreflight:       b.skipPreflight,
 		PreflightCommitment: b.commitment,
 	}
 	sig, err := b.client.SendTransaction(ctx, tx, opts)
 	if err != nil {
+		release()
+		b.log.Debug().Err(err).Msg("tx send failed (rpc)")
 		return solana.Signature{}, fmt.Errorf("send transaction: %w", err)
 	}
+	b.expiry.bindSignature(tx)
+	b.log.Debug().Stringer("sig", sig).Bool("skip_preflight", b.skipPreflight).Msg("tx sent (rpc)")
 	return sig, nil
 }
 
 // SendViaJito sends a signed transaction via Jito Block Engine.
 // This provides MEV protection and potentially faster inclusion.
 func (b *Builder) SendViaJito(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
+	res, err := b.sendViaJito(ctx, tx)
+	return res.Signature, err
+}
+
+// sendViaJito is SendViaJito that also returns the bundle ID.
+func (b *Builder) sendViaJito(ctx context.Context, tx *solana.Transaction) (jito.SendResult, error) {
 	if b.jitoClient == nil {
-		return solana.Signature{}, fmt.Errorf("jito client is not configured")
+		return jito.SendResult{}, fmt.Errorf("jito client is not configured")
 	}
-	sig, err := b.jitoClient.SendTransaction(ctx, tx)
+	release, pending, err := b.guardDuplicate(tx)
 	if err != nil {
-		return solana.Signature{}, fmt.Errorf("jito send transaction: %w", err)
+		return jito.SendResult{Signature: pending}, err
 	}
-	return sig, nil
+	res, err := b.jitoClient.SendTransactionWithBundleID(ctx, tx)
+	if err != nil {
+		release()
+		b.log.Debug().Err(err).Msg("tx send failed (jito)")
+		return jito.SendResult{}, fmt.Errorf("jito send transaction: %w", err)
+	}
+	b.expiry.bindSignature(tx)
+	b.log.Debug().Stringer("sig", res.Signature).Str("bundle_id", res.BundleID).Msg("tx sent (jito)")
+	return res, nil
 }
 
 // SendViaJitoAndConfirm sends via Jito and waits for bundle confirmation.
@@ -192,8 +287,10 @@ func (b *Builder) SendBundleViaJito(ctx context.Context, txs []*solana.Transacti
 	}
 	bundleID, err := b.jitoClient.SendBundle(ctx, txs)
 	if err != nil {
+		b.log.Debug().Err(err).Int("txs", len(txs)).Msg("bundle send failed (jito)")
 		return "", fmt.Errorf("jito send bundle: %w", err)
 	}
+	b.log.Debug().Str("bundle_id", bundleID).Int("txs", len(txs)).Msg("bundle sent (jito)")
 	return bundleID, nil
 }
 
@@ -210,13 +307,17 @@ func (b *Builder) BuildSignSend(ctx context.Context, feePayer wallet.Signer, sig
 	if err := SignTransaction(ctx, tx, allSigners...); err != nil {
 		return solana.Signature{}, err
 	}
+	b.logSigned(tx)
 	return b.Send(ctx, tx)
 }
 
 // SendAndConfirm sends a signed transaction and waits for confirmation.
-// Uses Jito for sending if configured, but always uses standard RPC for confirmation
-// (Jito's GetBundleStatuses is unreliable).
+// Uses Jito for sending if configured, but uses standard RPC for confirmation
+// (Jito's GetBundleStatuses is unreliable) unless WithJitoConfirmation is set.
 func (b *Builder) SendAndConfirm(ctx context.Context, tx *solana.Transaction, level ConfirmationLevel) (solana.Signature, error) {
+	if b.jitoConfirm && b.jitoClient != nil && level != ConfirmationFinalized {
+		return b.sendAndConfirmViaJito(ctx, tx, level)
+	}
 	// Send via Jito or RPC
 	sig, err := b.Send(ctx, tx)
 	if err != nil {
@@ -242,51 +343,60 @@ func (b *Builder) BuildSignSendAndConfirm(ctx context.Context, feePayer wallet.S
 	if err = SignTransaction(ctx, tx, allSigners...); err != nil {
 		return solana.Signature{}, err
 	}
+	b.logSigned(tx)
 	return b.SendAndConfirm(ctx, tx, level)
 }
 
-// WaitForConfirmation polls transaction status until confirmed or timeout.
+// WaitForConfirmation polls transaction status until confirmed, failed or
+// timed out. Polling interval, jitter and the max-elapsed deadline are set
+// with WithConfirmPolling and WithConfirmMaxElapsed; the deadline defaults to
+// the client's ConfirmTimeout and yields types.ErrConfirmationTimeout.
+//
+// For transactions built and sent by this builder, the blockhash's last valid
+// block height is known and types.ErrBlockhashExpired is returned as soon as
+// the chain passes it without the signature landing.
 func (b *Builder) WaitForConfirmation(ctx context.Context, sig solana.Signature, level ConfirmationLevel) error {
-	if b.client == nil {
-		return fmt.Errorf("rpc client is nil")
-	}
-
-	ticker := time.NewTicker(100 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-ticker.C:
-			resp, err := b.client.Raw().GetSignatureStatuses(ctx, true, sig)
-			if err != nil {
-				continue // retry on transient errors
-			}
-			if resp == nil || len(resp.Value) == 0 || resp.Value[0] == nil {
-				continue // not yet visible
-			}
-			status := resp.Value[0]
-			if status.Err != nil {
-				return fmt.Errorf("transaction failed: %v", status.Err)
-			}
-			// check confirmation level
-			switch level {
-			case ConfirmationProcessed:
-				return nil // any status means processed
-			case ConfirmationConfirmed:
-				if status.ConfirmationStatus == solanarpc.ConfirmationStatusConfirmed ||
-					status.ConfirmationStatus == solanarpc.ConfirmationStatusFinalized {
-					return nil
-				}
-			case ConfirmationFinalized:
-				if status.ConfirmationStatus == solanarpc.ConfirmationStatusFinalized {
-					return nil
-				}
-			default:
-				return nil
-			}
-		}
+	return b.WaitForConfirmationUntil(ctx, sig, level, b.expiry.forSignature(sig))
+}
+
+// reached reports whether status satisfies the requested confirmation level.
+func reached(status solanarpc.ConfirmationStatusType, level ConfirmationLevel) bool {
+	switch level {
+	case ConfirmationProcessed:
+		return true // any status means processed
+	case ConfirmationConfirmed:
+		return status == solanarpc.ConfirmationStatusConfirmed ||
+			status == solanarpc.ConfirmationStatusFinalized
+	case ConfirmationFinalized:
+		return status == solanarpc.ConfirmationStatusFinalized
+	default:
+		return true
+	}
+}
+
+// guardDuplicate applies WithDedupe to tx. On success it returns a func that
+// releases the reservation if the send fails.
+func (b *Builder) guardDuplicate(tx *solana.Transaction) (func(), solana.Signature, error) {
+	if b.dedupe == nil {
+		return func() {}, solana.Signature{}, nil
+	}
+	key, pending, err := b.dedupe.reserve(tx)
+	if err != nil {
+		b.log.Debug().Stringer("pending_sig", pending).Msg("tx send deduplicated")
+		return nil, pending, err
+	}
+	return func() { b.dedupe.release(key) }, solana.Signature{}, nil
+}
+
+func (b *Builder) settleDuplicate(sig solana.Signature) {
+	if b.dedupe != nil {
+		b.dedupe.settle(sig)
+	}
+}
+
+func (b *Builder) logSigned(tx *solana.Transaction) {
+	if e := b.log.Debug(); e.Enabled() && len(tx.Signatures) > 0 {
+		e.Stringer("sig", tx.Signatures[0]).Int("signatures", len(tx.Signatures)).Msg("tx signed")
 	}
 }
 
@@ -0,0 +1,208 @@
+package txbuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/rs/zerolog"
+
+	wraprpc "github.com/ninja0404/pump-go-sdk/pkg/rpc"
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+// maxSignatureStatuses is the getSignatureStatuses per-request signature limit.
+const maxSignatureStatuses = 256
+
+// ConfirmationEvent reports the outcome of one watched signature.
+type ConfirmationEvent struct {
+	Signature solana.Signature
+	// Slot and Status are those of the last status seen; both are zero for
+	// a signature that timed out without ever being seen.
+	Slot   uint64
+	Status solanarpc.ConfirmationStatusType
+	// Err is nil once the signature reached the watcher's level. An
+	// on-chain failure wraps types.ErrTransactionFailed and, for custom
+	// program errors, a decoded *types.ProgramError; a signature not
+	// confirmed in time gets types.ErrConfirmationTimeout.
+	Err error
+}
+
+// ConfirmationWatcher confirms many signatures at once. Signatures sent to
+// Signatures are polled together, in getSignatureStatuses calls of up to 256
+// signatures each, and each one yields exactly one ConfirmationEvent. RPC
+// errors are logged and the batch is retried on the next poll, so a node
+// hiccup delays events instead of dropping signatures.
+//
+// Example:
+//
+//	w := builder.NewConfirmationWatcher(txbuilder.ConfirmationConfirmed)
+//	go w.Run(ctx)
+//	for _, tx := range txs {
+//	    sig, _ := builder.Send(ctx, tx)
+//	    w.Signatures() <- sig
+//	}
+//	close(w.Signatures())
+//	for ev := range w.Events() {
+//	    log.Printf("%s: %v", ev.Signature, ev.Err)
+//	}
+type ConfirmationWatcher struct {
+	client   *wraprpc.Client
+	log      zerolog.Logger
+	level    ConfirmationLevel
+	interval time.Duration
+	timeout  time.Duration
+	settle   func(solana.Signature)
+
+	in     chan solana.Signature
+	events chan ConfirmationEvent
+}
+
+// watchedSignature is a signature awaiting its event.
+type watchedSignature struct {
+	added  time.Time
+	slot   uint64
+	status solanarpc.ConfirmationStatusType
+}
+
+// NewConfirmationWatcher returns a watcher confirming signatures to level
+// through the builder's client. It polls at the builder's confirmation
+// interval (WithConfirmPolling) and times signatures out after
+// WithConfirmMaxElapsed, or the client's ConfirmTimeout, from when they were
+// received.
+func (b *Builder) NewConfirmationWatcher(level ConfirmationLevel) *ConfirmationWatcher {
+	interval := b.confirmInterval
+	if interval <= 0 {
+		interval = DefaultConfirmInterval
+	}
+	timeout := b.confirmMaxElapsed
+	if timeout <= 0 && b.client != nil {
+		timeout = b.client.ConfirmTimeout()
+	}
+	return &ConfirmationWatcher{
+		client:   b.client,
+		log:      b.log,
+		level:    level,
+		interval: interval,
+		timeout:  timeout,
+		settle:   b.settleDuplicate,
+		in:       make(chan solana.Signature, maxSignatureStatuses),
+		events:   make(chan ConfirmationEvent, maxSignatureStatuses),
+	}
+}
+
+// Signatures returns the channel to send signatures to. Close it once no
+// more will be sent; Run then returns when the last one is resolved.
+func (w *ConfirmationWatcher) Signatures() chan<- solana.Signature {
+	return w.in
+}
+
+// Events returns the channel of outcomes, one per signature, closed when Run
+// returns.
+func (w *ConfirmationWatcher) Events() <-chan ConfirmationEvent {
+	return w.events
+}
+
+// Run polls until Signatures is closed and every signature received has an
+// event, or until ctx is done. It must be called exactly once.
+func (w *ConfirmationWatcher) Run(ctx context.Context) error {
+	defer close(w.events)
+	if w.client == nil {
+		return fmt.Errorf("rpc client is nil")
+	}
+
+	pending := make(map[solana.Signature]*watchedSignature)
+	in := w.in
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for in != nil || len(pending) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case sig, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+			if _, dup := pending[sig]; !dup {
+				pending[sig] = &watchedSignature{added: time.Now()}
+			}
+		case <-ticker.C:
+			if err := w.poll(ctx, pending); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// poll fetches the statuses of all pending signatures and emits events for
+// those that are resolved, removing them from pending.
+func (w *ConfirmationWatcher) poll(ctx context.Context, pending map[solana.Signature]*watchedSignature) error {
+	sigs := make([]solana.Signature, 0, len(pending))
+	for sig := range pending {
+		sigs = append(sigs, sig)
+	}
+	now := time.Now()
+	for start := 0; start < len(sigs); start += maxSignatureStatuses {
+		batch := sigs[start:min(start+maxSignatureStatuses, len(sigs))]
+		resp, err := w.client.Raw().GetSignatureStatuses(ctx, true, batch...)
+		if err != nil || resp == nil {
+			w.log.Debug().Err(err).Int("signatures", len(batch)).Msg("signature status batch failed")
+			continue
+		}
+		for i, sig := range batch {
+			if i < len(resp.Value) && resp.Value[i] != nil {
+				status := resp.Value[i]
+				p := pending[sig]
+				p.slot, p.status = status.Slot, status.ConfirmationStatus
+				if status.Err != nil {
+					if err := w.emit(ctx, pending, sig, statusError(status.Err)); err != nil {
+						return err
+					}
+					continue
+				}
+				if reached(status.ConfirmationStatus, w.level) {
+					if err := w.emit(ctx, pending, sig, nil); err != nil {
+						return err
+					}
+					continue
+				}
+			}
+			if w.timeout > 0 && now.Sub(pending[sig].added) > w.timeout {
+				err := fmt.Errorf("%w: %s not %s after %s", types.ErrConfirmationTimeout, sig, w.level, w.timeout)
+				if err := w.emit(ctx, pending, sig, err); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// emit sends the event for sig and stops watching it.
+func (w *ConfirmationWatcher) emit(ctx context.Context, pending map[solana.Signature]*watchedSignature, sig solana.Signature, err error) error {
+	p := pending[sig]
+	delete(pending, sig)
+	if !errors.Is(err, types.ErrConfirmationTimeout) {
+		w.settle(sig)
+	}
+	select {
+	case w.events <- ConfirmationEvent{Signature: sig, Slot: p.slot, Status: p.status, Err: err}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// statusError decodes the err of a failed signature status.
+func statusError(errVal interface{}) error {
+	var progErr *types.ProgramError
+	if errors.As(types.ParseSimulationError(errVal, nil), &progErr) {
+		return fmt.Errorf("%w: %w", types.ErrTransactionFailed, progErr)
+	}
+	return fmt.Errorf("%w: %v", types.ErrTransactionFailed, errVal)
+}
@@ -0,0 +1,99 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	solanarpc "github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/types"
+)
+
+func TestConfirmationWatcherBatches(t *testing.T) {
+	fake, client := newFakeRPC(t)
+	const n = 300
+	sigs := make([]solana.Signature, n)
+	for i := range sigs {
+		sigs[i][0], sigs[i][1] = byte(i), byte(i>>8)
+	}
+	failed := sigs[17].String()
+
+	var mu sync.Mutex
+	var batches []int
+	fake.handle("getSignatureStatuses", func(params json.RawMessage) (interface{}, error) {
+		var p []json.RawMessage
+		var batch []string
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(p[0], &batch); err != nil {
+			return nil, err
+		}
+		mu.Lock()
+		batches = append(batches, len(batch))
+		mu.Unlock()
+		value := make([]interface{}, len(batch))
+		for i, sig := range batch {
+			status := map[string]interface{}{"slot": 42, "confirmations": nil, "err": nil, "confirmationStatus": "confirmed"}
+			if sig == failed {
+				status["err"] = map[string]interface{}{"InstructionError": []interface{}{2, map[string]interface{}{"Custom": 6002}}}
+			}
+			value[i] = status
+		}
+		return rpcContext(value), nil
+	})
+
+	w := NewBuilder(client, solanarpc.CommitmentConfirmed).
+		WithConfirmPolling(20*time.Millisecond, -1).
+		NewConfirmationWatcher(ConfirmationConfirmed)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+	go func() {
+		for _, sig := range sigs {
+			w.Signatures() <- sig
+		}
+		close(w.Signatures())
+	}()
+
+	seen := make(map[solana.Signature]bool)
+	for ev := range w.Events() {
+		seen[ev.Signature] = true
+		if ev.Signature.String() == failed {
+			var progErr *types.ProgramError
+			if !errors.Is(ev.Err, types.ErrTransactionFailed) || !errors.As(ev.Err, &progErr) {
+				t.Fatalf("failed signature error = %v, want a decoded program error", ev.Err)
+			}
+			if progErr.Code != 6002 {
+				t.Fatalf("program error code = %d, want 6002", progErr.Code)
+			}
+			continue
+		}
+		if ev.Err != nil || ev.Slot != 42 {
+			t.Fatalf("%s: slot %d, err %v", ev.Signature, ev.Slot, ev.Err)
+		}
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != n {
+		t.Fatalf("got events for %d signatures, want %d", len(seen), n)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, size := range batches {
+		if size > maxSignatureStatuses {
+			t.Fatalf("batch of %d signatures exceeds %d", size, maxSignatureStatuses)
+		}
+	}
+	if len(batches) > 10 {
+		t.Fatalf("%d getSignatureStatuses calls for %d signatures, want them batched", len(batches), n)
+	}
+}
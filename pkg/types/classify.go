@@ -0,0 +1,210 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+)
+
+// ErrorClass groups errors by how a sender should react to them.
+type ErrorClass int
+
+const (
+	// ErrorClassNone is the class of a nil error.
+	ErrorClassNone ErrorClass = iota
+	// ErrorClassUnknown is an unrecognized error. It is retried, to keep
+	// liveness when a node reports something unexpected.
+	ErrorClassUnknown
+	// ErrorClassTransient covers network failures, timeouts, unavailable or
+	// lagging nodes and failed simulation RPCs.
+	ErrorClassTransient
+	// ErrorClassRateLimited is HTTP 429 or a provider rate limit; retry after
+	// a backoff.
+	ErrorClassRateLimited
+	// ErrorClassBlockhash means the blockhash was not found or expired;
+	// retry with a freshly built transaction.
+	ErrorClassBlockhash
+	// ErrorClassCanceled is a canceled context.
+	ErrorClassCanceled
+	// ErrorClassValidation is invalid input to the SDK.
+	ErrorClassValidation
+	// ErrorClassInsufficientFunds means the payer lacks SOL or tokens.
+	ErrorClassInsufficientFunds
+	// ErrorClassSlippage means the price moved past the slippage limit.
+	ErrorClassSlippage
+	// ErrorClassInvalidAccount is a missing, uninitialized or wrong account.
+	ErrorClassInvalidAccount
+	// ErrorClassProgram is any other on-chain program error.
+	ErrorClassProgram
+)
+
+var errorClassNames = [...]string{
+	ErrorClassNone:              "none",
+	ErrorClassUnknown:           "unknown",
+	ErrorClassTransient:         "transient",
+	ErrorClassRateLimited:       "rate_limited",
+	ErrorClassBlockhash:         "blockhash",
+	ErrorClassCanceled:          "canceled",
+	ErrorClassValidation:        "validation",
+	ErrorClassInsufficientFunds: "insufficient_funds",
+	ErrorClassSlippage:          "slippage",
+	ErrorClassInvalidAccount:    "invalid_account",
+	ErrorClassProgram:           "program",
+}
+
+func (c ErrorClass) String() string {
+	if c >= 0 && int(c) < len(errorClassNames) {
+		return errorClassNames[c]
+	}
+	return "unknown"
+}
+
+// Retryable reports whether an error of this class may succeed when tried
+// again: unknown, transient, rate-limited and blockhash errors.
+func (c ErrorClass) Retryable() bool {
+	switch c {
+	case ErrorClassUnknown, ErrorClassTransient, ErrorClassRateLimited, ErrorClassBlockhash:
+		return true
+	}
+	return false
+}
+
+// Message fragments, lower case, matched against error text and program logs.
+var (
+	rateLimitMarkers = []string{"status code: 429", "too many requests", "rate limit"}
+	blockhashMarkers = []string{"blockhash not found", "blockhashnotfound", "block height exceeded", ErrBlockhashExpired.Error()}
+	fundsMarkers     = []string{"insufficient funds", "insufficient lamports", "insufficientfunds"}
+	slippageMarkers  = []string{"slippage", "toolittlesolreceived", "toomuchsolrequired"}
+	accountMarkers   = []string{"invalid account", "accountnotfound", "account not found", "could not find account", "invalidaccountdata", "not initialized"}
+	transientMarkers = []string{"connection reset", "connection refused", "broken pipe", "unexpected eof", "timeout", "timed out", "node is behind", "node unhealthy", "service unavailable", "bad gateway", "temporarily unavailable"}
+)
+
+// ClassifyError returns the class of err, inspecting sentinel errors,
+// SDK error types, JSON-RPC and HTTP errors and finally the error text.
+// Deterministic failures are recognized before transient ones, so a
+// preflight error reporting insufficient funds is not retried.
+//
+// Example:
+//
+//	if class := types.ClassifyError(err); class.Retryable() {
+//	    // back off and resend
+//	}
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassNone
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrorClassCanceled
+	}
+	if class, ok := classifySentinel(err); ok {
+		return class
+	}
+
+	var validationErr ValidationError
+	if errors.As(err, &validationErr) {
+		return ErrorClassValidation
+	}
+	var progErr *ProgramError
+	if errors.As(err, &progErr) {
+		if class, ok := classifyText(progErr.Message, progErr.Logs); ok && !class.Retryable() {
+			return class
+		}
+		return ErrorClassProgram
+	}
+	var simErr *SimulationError
+	if errors.As(err, &simErr) {
+		if class, ok := classifyText(simErrText(simErr), simErr.Logs); ok {
+			return class
+		}
+		return ErrorClassUnknown
+	}
+
+	var httpErr *jsonrpc.HTTPError
+	if errors.As(err, &httpErr) {
+		switch {
+		case httpErr.Code == 429:
+			return ErrorClassRateLimited
+		case httpErr.Code >= 500:
+			return ErrorClassTransient
+		}
+	}
+	var rpcErr *jsonrpc.RPCError
+	if errors.As(err, &rpcErr) {
+		// -32005: node is behind; -32004: block not available yet.
+		if rpcErr.Code == -32005 || rpcErr.Code == -32004 {
+			return ErrorClassTransient
+		}
+	}
+
+	if class, ok := classifyText(err.Error(), nil); ok {
+		return class
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassTransient
+	}
+	return ErrorClassUnknown
+}
+
+// classifySentinel maps the SDK's sentinel errors to a class.
+func classifySentinel(err error) (ErrorClass, bool) {
+	switch {
+	case errors.Is(err, ErrSimulationFailed), errors.Is(err, ErrNodeBehind):
+		return ErrorClassTransient, true
+	case errors.Is(err, ErrBlockhashExpired):
+		return ErrorClassBlockhash, true
+	case errors.Is(err, ErrInsufficientBalance), errors.Is(err, ErrNotEnoughTokensToSell):
+		return ErrorClassInsufficientFunds, true
+	case errors.Is(err, ErrSlippageExceeded):
+		return ErrorClassSlippage, true
+	}
+	for _, target := range []error{ErrAccountNotFound, ErrAccountNotInitialized, ErrMintNotFound, ErrPoolNotFound,
+		ErrBondingCurveNotFound, ErrATANotFound, ErrGlobalConfigNotFound, ErrFeeConfigNotFound, ErrFeeRecipientNotFound, ErrQuoteNotWSOL} {
+		if errors.Is(err, target) {
+			return ErrorClassInvalidAccount, true
+		}
+	}
+	for _, target := range []error{ErrNilRPC, ErrNilSigner, ErrNilFeePayer, ErrZeroAmount, ErrZeroMaxCost,
+		ErrZeroMinOutput, ErrInvalidSlippage, ErrInvalidPublicKey, ErrNoInstructions, ErrTransactionTooLarge} {
+		if errors.Is(err, target) {
+			return ErrorClassValidation, true
+		}
+	}
+	return ErrorClassNone, false
+}
+
+// classifyText matches msg and logs against the known message fragments.
+func classifyText(msg string, logs []string) (ErrorClass, bool) {
+	text := strings.ToLower(msg + "\n" + strings.Join(logs, "\n"))
+	for _, m := range []struct {
+		class   ErrorClass
+		markers []string
+	}{
+		{ErrorClassInsufficientFunds, fundsMarkers},
+		{ErrorClassSlippage, slippageMarkers},
+		{ErrorClassInvalidAccount, accountMarkers},
+		{ErrorClassBlockhash, blockhashMarkers},
+		{ErrorClassRateLimited, rateLimitMarkers},
+		{ErrorClassTransient, transientMarkers},
+	} {
+		for _, marker := range m.markers {
+			if strings.Contains(text, marker) {
+				return m.class, true
+			}
+		}
+	}
+	return ErrorClassNone, false
+}
+
+// simErrText renders a raw simulation error, e.g. "BlockhashNotFound" or
+// {"InstructionError":[0,"InvalidAccountData"]}, for classifyText.
+func simErrText(e *SimulationError) string {
+	if s, ok := e.Err.(string); ok {
+		return s
+	}
+	return e.Error()
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+)
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want ErrorClass
+	}{
+		{"nil", nil, ErrorClassNone},
+		{"canceled", fmt.Errorf("send: %w", context.Canceled), ErrorClassCanceled},
+		{"deadline", fmt.Errorf("getBalance: %w", context.DeadlineExceeded), ErrorClassTransient},
+		{"http 429", jsonrpc.NewHTTPError(429, errors.New("rpc call getSlot() status code: 429")), ErrorClassRateLimited},
+		{"http 503", jsonrpc.NewHTTPError(503, errors.New("unavailable")), ErrorClassTransient},
+		{"node behind", &jsonrpc.RPCError{Code: -32005, Message: "Node is behind by 42 slots"}, ErrorClassTransient},
+		{"connection reset", errors.New("read tcp: connection reset by peer"), ErrorClassTransient},
+		{"blockhash not found", &jsonrpc.RPCError{Code: -32002, Message: "Transaction simulation failed: Blockhash not found"}, ErrorClassBlockhash},
+		{"blockhash expired", fmt.Errorf("confirm: %w", ErrBlockhashExpired), ErrorClassBlockhash},
+		{"preflight custom error", &jsonrpc.RPCError{Code: -32002, Message: "Transaction simulation failed: Error processing Instruction 2: custom program error: 0x1"}, ErrorClassUnknown},
+		{"insufficient lamports", errors.New("Transfer: insufficient lamports 10, need 20"), ErrorClassInsufficientFunds},
+		{"insufficient balance", fmt.Errorf("%w: no tokens", ErrInsufficientBalance), ErrorClassInsufficientFunds},
+		{"slippage", ParsePumpError(6003), ErrorClassSlippage},
+		{"amm slippage", &ProgramError{Code: 6004, Message: "Exceeded Slippage"}, ErrorClassSlippage},
+		{"other program error", ParsePumpError(6005), ErrorClassProgram},
+		{"account not found", &SimulationError{Err: "AccountNotFound"}, ErrorClassInvalidAccount},
+		{"quote not wsol", fmt.Errorf("%w: pool x", ErrQuoteNotWSOL), ErrorClassInvalidAccount},
+		{"validation", NewValidationError("amount", "must be greater than 0"), ErrorClassValidation},
+		{"simulation failed", fmt.Errorf("%w: rpc", ErrSimulationFailed), ErrorClassTransient},
+		{"unknown", errors.New("something odd"), ErrorClassUnknown},
+	}
+	for _, tc := range cases {
+		if got := ClassifyError(tc.err); got != tc.want {
+			t.Errorf("%s: class = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	retryable := []error{
+		errors.New("i/o timeout"),
+		jsonrpc.NewHTTPError(429, errors.New("too many requests")),
+		errors.New("Blockhash not found"),
+		errors.New("something odd"),
+	}
+	for _, err := range retryable {
+		if !IsRetryableError(err) {
+			t.Errorf("%v: expected retryable", err)
+		}
+	}
+	final := []error{
+		nil,
+		context.Canceled,
+		ParsePumpError(6002),
+		errors.New("Attempt to debit an account but found no record of a prior credit: insufficient funds"),
+		NewValidationError("slippageBps", "too high"),
+	}
+	for _, err := range final {
+		if IsRetryableError(err) {
+			t.Errorf("%v: expected not retryable", err)
+		}
+	}
+}
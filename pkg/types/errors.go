@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/gagliardetto/solana-go"
+
 	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
 	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
 )
@@ -31,6 +33,11 @@ var (
 	ErrGlobalConfigNotFound  = errors.New("global config not found")
 	ErrFeeConfigNotFound     = errors.New("fee config not found")
 	ErrFeeRecipientNotFound  = errors.New("fee recipient not found")
+	// ErrQuoteNotWSOL is returned by SOL-denominated pool entry points
+	// (PumpAmmBuyWithSol, PumpAmmSellAllForSol) when the pool is not quoted
+	// in WSOL; trade it with PumpAmmBuy or PumpAmmBuyExactQuoteIn in the
+	// pool's own quote token instead.
+	ErrQuoteNotWSOL = errors.New("pool quote mint is not WSOL")
 
 	// Transaction errors
 	ErrInsufficientBalance   = errors.New("insufficient balance")
@@ -39,6 +46,12 @@ var (
 	ErrTransactionFailed     = errors.New("transaction failed")
 	ErrSimulationFailed      = errors.New("simulation failed")
 	ErrConfirmationTimeout   = errors.New("confirmation timeout")
+	ErrBlockhashExpired      = errors.New("blockhash expired before confirmation")
+	ErrTransactionTooLarge   = errors.New("transaction too large")
+
+	// ErrNodeBehind is returned when an RPC node lags the cluster and may
+	// serve stale state, e.g. report a freshly created pool as missing.
+	ErrNodeBehind = errors.New("rpc node is behind")
 
 	// Program errors
 	ErrNotEnoughTokensToSell = errors.New("not enough tokens to sell")
@@ -81,6 +94,12 @@ type ProgramError struct {
 	Code    int
 	Message string
 	Logs    []string
+	// InstructionIndex is the index of the failing instruction in the
+	// transaction, or -1 when unknown.
+	InstructionIndex int
+	// Instruction names the failing instruction (e.g. "pump buy",
+	// "create ATA") when the instruction list was known.
+	Instruction string
 }
 
 func (e ProgramError) Error() string {
@@ -105,9 +124,10 @@ func ParsePumpError(code int) error {
 			msg = err.Name
 		}
 		return &ProgramError{
-			Program: "pump",
-			Code:    code,
-			Message: msg,
+			Program:          "pump",
+			Code:             code,
+			Message:          msg,
+			InstructionIndex: -1,
 		}
 	}
 	return fmt.Errorf("pump error code %d", code)
@@ -121,21 +141,32 @@ func ParsePumpAmmError(code int) error {
 			msg = err.Name
 		}
 		return &ProgramError{
-			Program: "pump_amm",
-			Code:    code,
-			Message: msg,
+			Program:          "pump_amm",
+			Code:             code,
+			Message:          msg,
+			InstructionIndex: -1,
 		}
 	}
 	return fmt.Errorf("pump_amm error code %d", code)
 }
 
 // ParseSimulationError extracts error details from simulation result.
+// Custom program errors become a *ProgramError whose message says which
+// instruction failed ("failed at instruction N").
 func ParseSimulationError(errVal interface{}, logs []string) error {
+	return ParseSimulationErrorWithInstructions(errVal, logs, nil)
+}
+
+// ParseSimulationErrorWithInstructions is ParseSimulationError for a
+// transaction built from instrs, in order: the failing instruction is also
+// named (e.g. "failed at instruction 2 (pump buy)"), and its program picks
+// the error table when pump and pump_amm codes overlap.
+func ParseSimulationErrorWithInstructions(errVal interface{}, logs []string, instrs []solana.Instruction) error {
 	if errVal == nil {
 		return nil
 	}
 
-	// Try to extract instruction error
+	// Try to extract instruction error: {"InstructionError": [index, {"Custom": code}]}
 	if errMap, ok := errVal.(map[string]interface{}); ok {
 		if instErr, exists := errMap["InstructionError"]; exists {
 			if errSlice, ok := instErr.([]interface{}); ok && len(errSlice) >= 2 {
@@ -143,14 +174,31 @@ func ParseSimulationError(errVal interface{}, logs []string) error {
 					if code, exists := customErr["Custom"]; exists {
 						if codeNum, ok := code.(float64); ok {
 							codeInt := int(codeNum)
+							index := -1
+							if idx, ok := errSlice[0].(float64); ok {
+								index = int(idx)
+							}
+							var name, program string
+							if index >= 0 && index < len(instrs) && instrs[index] != nil {
+								name, program = instructionName(instrs[index])
+							}
 							// Extract account name from logs
 							account := extractAccountFromLogs(logs)
 							// Parse error based on code
-							msg := parseErrorCode(codeInt, account)
+							msg := parseErrorCode(codeInt, account, program)
+							if index >= 0 {
+								msg = fmt.Sprintf("%s: failed at instruction %d", msg, index)
+								if name != "" {
+									msg += " (" + name + ")"
+								}
+							}
 							return &ProgramError{
-								Code:    codeInt,
-								Message: msg,
-								Logs:    logs,
+								Program:          program,
+								Code:             codeInt,
+								Message:          msg,
+								Logs:             logs,
+								InstructionIndex: index,
+								Instruction:      name,
 							}
 						}
 					}
@@ -186,8 +234,9 @@ func indexOf(s, substr string) int {
 	return -1
 }
 
-// parseErrorCode converts error code to human-readable message.
-func parseErrorCode(code int, account string) string {
+// parseErrorCode converts error code to human-readable message. program
+// ("pump" or "pump_amm", if known) selects the error table to try first.
+func parseErrorCode(code int, account, program string) string {
 	// Anchor system errors (0-3000 range)
 	switch code {
 	case 3012:
@@ -201,33 +250,35 @@ func parseErrorCode(code int, account string) string {
 		return "program ID was not as expected (wrong program)"
 	}
 
-	// Try pump_amm errors first (more common in AMM operations)
-	if err, ok := pumpamm.ErrorFromCode(uint32(code)); ok {
-		msg := err.Msg
-		if msg == "" {
-			msg = toReadableError(err.Name)
-		}
-		if account != "" && needsAccountContext(code) {
-			return fmt.Sprintf("%s (account: %s)", msg, account)
-		}
-		return msg
+	// Try pump_amm errors first (more common in AMM operations), unless the
+	// failing instruction is known to be pump's.
+	tables := []func(uint32) (pumpamm.ProgramError, bool){pumpamm.ErrorFromCode, pumpErrorFromCode}
+	if program == "pump" {
+		tables[0], tables[1] = tables[1], tables[0]
 	}
-
-	// Try pump errors
-	if err, ok := pump.ErrorFromCode(uint32(code)); ok {
-		msg := err.Msg
-		if msg == "" {
-			msg = toReadableError(err.Name)
-		}
-		if account != "" && needsAccountContext(code) {
-			return fmt.Sprintf("%s (account: %s)", msg, account)
+	for _, lookup := range tables {
+		if err, ok := lookup(uint32(code)); ok {
+			msg := err.Msg
+			if msg == "" {
+				msg = toReadableError(err.Name)
+			}
+			if account != "" && needsAccountContext(code) {
+				return fmt.Sprintf("%s (account: %s)", msg, account)
+			}
+			return msg
 		}
-		return msg
 	}
 
 	return fmt.Sprintf("error code %d", code)
 }
 
+// pumpErrorFromCode adapts pump.ErrorFromCode to pump_amm's error type so
+// both tables can be tried in turn.
+func pumpErrorFromCode(code uint32) (pumpamm.ProgramError, bool) {
+	err, ok := pump.ErrorFromCode(code)
+	return pumpamm.ProgramError(err), ok
+}
+
 // needsAccountContext returns true if the error message should include account context.
 func needsAccountContext(code int) bool {
 	// Errors that benefit from knowing which account caused them
@@ -254,19 +305,8 @@ func toReadableError(name string) string {
 	return string(result)
 }
 
-// IsRetryableError checks if an error is retryable.
+// IsRetryableError checks if an error is retryable: see ClassifyError and
+// ErrorClass.Retryable.
 func IsRetryableError(err error) bool {
-	if err == nil {
-		return false
-	}
-	// Network/transient errors are retryable
-	if errors.Is(err, ErrSimulationFailed) {
-		return true
-	}
-	// Program errors are not retryable
-	var progErr *ProgramError
-	if errors.As(err, &progErr) {
-		return false
-	}
-	return true
+	return ClassifyError(err).Retryable()
 }
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+)
+
+func TestParseSimulationErrorInstructionIndex(t *testing.T) {
+	errVal := map[string]interface{}{
+		"InstructionError": []interface{}{float64(2), map[string]interface{}{"Custom": float64(6003)}},
+	}
+	instrs := []solana.Instruction{
+		solana.NewInstruction(constants.ComputeBudgetProgramID, nil, []byte{2, 0, 0, 0, 0}),
+		solana.NewInstruction(solana.SPLAssociatedTokenAccountProgramID, nil, []byte{1}),
+		solana.NewInstruction(pump.ProgramKey, nil, append(append([]byte{}, pump.SellDiscriminator...), make([]byte, 16)...)),
+	}
+
+	var progErr *ProgramError
+	if !errors.As(ParseSimulationErrorWithInstructions(errVal, nil, instrs), &progErr) {
+		t.Fatal("expected *ProgramError")
+	}
+	if progErr.InstructionIndex != 2 || progErr.Instruction != "pump sell" || progErr.Program != "pump" {
+		t.Fatalf("got index %d, instruction %q, program %q", progErr.InstructionIndex, progErr.Instruction, progErr.Program)
+	}
+	// 6003 is TooLittleSolReceived for pump but a liquidity error for
+	// pump_amm; the failing instruction's program picks the table.
+	if !strings.Contains(progErr.Message, "Too little SOL received") {
+		t.Errorf("message %q is not pump's 6003", progErr.Message)
+	}
+	if !strings.HasSuffix(progErr.Message, "failed at instruction 2 (pump sell)") {
+		t.Errorf("message %q does not name the failing instruction", progErr.Message)
+	}
+
+	if !errors.As(ParseSimulationError(errVal, nil), &progErr) {
+		t.Fatal("expected *ProgramError")
+	}
+	if progErr.InstructionIndex != 2 || progErr.Instruction != "" || !strings.HasSuffix(progErr.Message, "failed at instruction 2") {
+		t.Errorf("without instructions: index %d, instruction %q, message %q", progErr.InstructionIndex, progErr.Instruction, progErr.Message)
+	}
+}
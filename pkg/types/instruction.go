@@ -0,0 +1,114 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pumpamm"
+)
+
+// InstructionKind is what an instruction does, as far as the SDK's own
+// transactions are concerned.
+type InstructionKind string
+
+// Instruction kinds returned by ClassifyInstruction.
+const (
+	InstructionKindComputeBudget InstructionKind = "compute_budget"
+	InstructionKindCreateATA     InstructionKind = "create_ata"
+	InstructionKindTransfer      InstructionKind = "transfer"      // system transfer
+	InstructionKindSyncNative    InstructionKind = "sync_native"   // completes a WSOL wrap
+	InstructionKindCloseAccount  InstructionKind = "close_account" // unwraps WSOL or reclaims ATA rent
+	InstructionKindPump          InstructionKind = "pump"
+	InstructionKindPumpAmm       InstructionKind = "pump_amm"
+	InstructionKindOther         InstructionKind = "other"
+)
+
+// ClassifyInstruction returns the kind of ix from its program and, for
+// System and SPL Token instructions, its instruction tag.
+func ClassifyInstruction(ix solana.Instruction) InstructionKind {
+	data, _ := ix.Data()
+	switch id := ix.ProgramID(); {
+	case id.Equals(constants.ComputeBudgetProgramID):
+		return InstructionKindComputeBudget
+	case id.Equals(constants.AssociatedTokenProgramID):
+		return InstructionKindCreateATA
+	case id.Equals(pump.ProgramKey):
+		return InstructionKindPump
+	case id.Equals(pumpamm.ProgramKey):
+		return InstructionKindPumpAmm
+	case id.Equals(constants.SystemProgramID):
+		if len(data) >= 4 && binary.LittleEndian.Uint32(data) == 2 {
+			return InstructionKindTransfer
+		}
+	case id.Equals(constants.TokenProgramID), id.Equals(constants.Token2022ProgramID):
+		if len(data) > 0 {
+			switch data[0] {
+			case 9:
+				return InstructionKindCloseAccount
+			case 17:
+				return InstructionKindSyncNative
+			}
+		}
+	}
+	return InstructionKindOther
+}
+
+// instructionName describes ix for error messages, e.g. "pump buy" or
+// "create ATA", and returns "pump" or "pump_amm" as program for the pump
+// programs' instructions.
+func instructionName(ix solana.Instruction) (name, program string) {
+	data, _ := ix.Data()
+	switch kind := ClassifyInstruction(ix); kind {
+	case InstructionKindPump:
+		return "pump " + discriminatorName(data, pumpInstructionNames), "pump"
+	case InstructionKindPumpAmm:
+		return "pump_amm " + discriminatorName(data, pumpAmmInstructionNames), "pump_amm"
+	case InstructionKindComputeBudget:
+		return "compute budget", ""
+	case InstructionKindCreateATA:
+		return "create ATA", ""
+	case InstructionKindTransfer:
+		return "transfer", ""
+	case InstructionKindCloseAccount:
+		return "close account", ""
+	case InstructionKindSyncNative:
+		return "sync native", ""
+	default:
+		return ix.ProgramID().String(), ""
+	}
+}
+
+type namedDiscriminator struct {
+	name string
+	disc []byte
+}
+
+// Trading instructions most likely to fail; others are reported by program.
+var (
+	pumpInstructionNames = []namedDiscriminator{
+		{"buy", pump.BuyDiscriminator},
+		{"buy_exact_sol_in", pump.BuyExactSolInDiscriminator},
+		{"sell", pump.SellDiscriminator},
+		{"create", pump.CreateDiscriminator},
+		{"create_v2", pump.CreateV2Discriminator},
+	}
+	pumpAmmInstructionNames = []namedDiscriminator{
+		{"buy", pumpamm.BuyDiscriminator},
+		{"buy_exact_quote_in", pumpamm.BuyExactQuoteInDiscriminator},
+		{"sell", pumpamm.SellDiscriminator},
+		{"create_pool", pumpamm.CreatePoolDiscriminator},
+	}
+)
+
+func discriminatorName(data []byte, names []namedDiscriminator) string {
+	for _, n := range names {
+		if bytes.HasPrefix(data, n.disc) {
+			return n.name
+		}
+	}
+	return "instruction"
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/ninja0404/pump-go-sdk/pkg/constants"
+	"github.com/ninja0404/pump-go-sdk/pkg/program/pump"
+)
+
+func TestClassifyInstruction(t *testing.T) {
+	tests := []struct {
+		ix   solana.Instruction
+		kind InstructionKind
+		name string
+	}{
+		{solana.NewInstruction(constants.ComputeBudgetProgramID, nil, []byte{2, 0, 0, 0, 0}), InstructionKindComputeBudget, "compute budget"},
+		{solana.NewInstruction(constants.AssociatedTokenProgramID, nil, []byte{1}), InstructionKindCreateATA, "create ATA"},
+		{solana.NewInstruction(constants.SystemProgramID, nil, []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}), InstructionKindTransfer, "transfer"},
+		{solana.NewInstruction(constants.TokenProgramID, nil, []byte{17}), InstructionKindSyncNative, "sync native"},
+		{solana.NewInstruction(constants.Token2022ProgramID, nil, []byte{9}), InstructionKindCloseAccount, "close account"},
+		{solana.NewInstruction(pump.ProgramKey, nil, pump.SellDiscriminator), InstructionKindPump, "pump sell"},
+		{solana.NewInstruction(constants.SystemProgramID, nil, []byte{0, 0, 0, 0}), InstructionKindOther, constants.SystemProgramID.String()},
+	}
+	for _, tt := range tests {
+		if kind := ClassifyInstruction(tt.ix); kind != tt.kind {
+			t.Errorf("ClassifyInstruction(%s) = %q, want %q", tt.name, kind, tt.kind)
+		}
+		if name, _ := instructionName(tt.ix); name != tt.name {
+			t.Errorf("instructionName = %q, want %q", name, tt.name)
+		}
+	}
+}
@@ -4,6 +4,7 @@ package vanity
 import (
 	"context"
 	"fmt"
+	"math"
 	"runtime"
 	"strings"
 	"sync"
@@ -19,6 +20,11 @@ type Result struct {
 	PublicKey  solana.PublicKey
 	Attempts   uint64
 	Duration   time.Duration
+
+	// Pattern is the pattern that matched (see GenerateAny).
+	Pattern Options
+	// PatternIndex is the index of Pattern in the slice passed to GenerateAny.
+	PatternIndex int
 }
 
 // Options configures vanity address generation.
@@ -41,28 +47,48 @@ type Options struct {
 //	fmt.Printf("Found: %s (attempts: %d, time: %s)\n",
 //	    result.PublicKey, result.Attempts, result.Duration)
 func Generate(ctx context.Context, opts Options) (*Result, error) {
-	if opts.Prefix == "" && opts.Suffix == "" {
-		return nil, fmt.Errorf("prefix or suffix is required")
-	}
+	return GenerateAny(ctx, []Options{opts})
+}
 
-	workers := opts.Workers
+// GenerateAny searches for the first keypair matching any of the supplied patterns.
+// Result.Pattern and Result.PatternIndex report which pattern matched.
+// Workers and Timeout apply to the whole search and may be set on any
+// pattern; patterns setting different non-zero values are rejected.
+//
+// Example:
+//
+//	result, err := vanity.GenerateAny(ctx, []vanity.Options{
+//	    {Suffix: "pump"},
+//	    {Suffix: "moon"},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Found: %s (matched suffix %q)\n", result.PublicKey, result.Pattern.Suffix)
+func GenerateAny(ctx context.Context, patterns []Options) (*Result, error) {
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("at least one pattern is required")
+	}
+	matchers := make([]matcher, len(patterns))
+	for i, p := range patterns {
+		if p.Prefix == "" && p.Suffix == "" {
+			return nil, fmt.Errorf("pattern %d: prefix or suffix is required", i)
+		}
+		matchers[i] = newMatcher(p)
+	}
+	workers, timeout, err := searchSettings(patterns)
+	if err != nil {
+		return nil, err
+	}
 	if workers <= 0 {
 		workers = runtime.NumCPU()
 	}
 
-	// Normalize for case-insensitive matching (if enabled)
-	prefix := opts.Prefix
-	suffix := opts.Suffix
-	if opts.CaseInsensitive {
-		prefix = strings.ToLower(prefix)
-		suffix = strings.ToLower(suffix)
-	}
-
 	// Create context with timeout if specified
 	searchCtx := ctx
-	if opts.Timeout > 0 {
+	if timeout > 0 {
 		var cancel context.CancelFunc
-		searchCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		searchCtx, cancel = context.WithTimeout(ctx, timeout)
 		defer cancel()
 	}
 
@@ -97,28 +123,23 @@ func Generate(ctx context.Context, opts Options) (*Result, error) {
 				attempts.Add(1)
 				addr := key.PublicKey().String()
 
-				// Check match
-				checkAddr := addr
-				if opts.CaseInsensitive {
-					checkAddr = strings.ToLower(addr)
+				idx := matchAny(matchers, addr)
+				if idx < 0 {
+					continue
 				}
-
-				matchPrefix := prefix == "" || strings.HasPrefix(checkAddr, prefix)
-				matchSuffix := suffix == "" || strings.HasSuffix(checkAddr, suffix)
-
-				if matchPrefix && matchSuffix {
-					if found.CompareAndSwap(false, true) {
-						resultMu.Lock()
-						result = &Result{
-							PrivateKey: key,
-							PublicKey:  key.PublicKey(),
-							Attempts:   attempts.Load(),
-							Duration:   time.Since(startTime),
-						}
-						resultMu.Unlock()
+				if found.CompareAndSwap(false, true) {
+					resultMu.Lock()
+					result = &Result{
+						PrivateKey:   key,
+						PublicKey:    key.PublicKey(),
+						Attempts:     attempts.Load(),
+						Duration:     time.Since(startTime),
+						Pattern:      patterns[idx],
+						PatternIndex: idx,
 					}
-					return
+					resultMu.Unlock()
 				}
+				return
 			}
 		}()
 	}
@@ -136,6 +157,152 @@ func Generate(ctx context.Context, opts Options) (*Result, error) {
 	return nil, fmt.Errorf("search failed after %d attempts", attempts.Load())
 }
 
+// searchSettings returns the Workers and Timeout set on patterns, or an
+// error if two patterns set different non-zero values.
+func searchSettings(patterns []Options) (workers int, timeout time.Duration, err error) {
+	for i, p := range patterns {
+		if p.Workers != 0 {
+			if workers != 0 && p.Workers != workers {
+				return 0, 0, fmt.Errorf("pattern %d: workers %d conflicts with %d set on an earlier pattern", i, p.Workers, workers)
+			}
+			workers = p.Workers
+		}
+		if p.Timeout != 0 {
+			if timeout != 0 && p.Timeout != timeout {
+				return 0, 0, fmt.Errorf("pattern %d: timeout %s conflicts with %s set on an earlier pattern", i, p.Timeout, timeout)
+			}
+			timeout = p.Timeout
+		}
+	}
+	return workers, timeout, nil
+}
+
+// Stream keeps generating keypairs matching opts and pushes each match to out
+// until ctx is cancelled (or opts.Timeout elapses). It blocks until all workers
+// have exited, then closes out and returns the context error that stopped it.
+// Attempts and Duration on each Result are cumulative since the stream started.
+//
+// Example:
+//
+//	mints := make(chan vanity.Result, 16)
+//	go vanity.Stream(ctx, vanity.Options{Suffix: "pump"}, mints)
+//	// later, when a create transaction needs a mint:
+//	r, ok := <-mints
+func Stream(ctx context.Context, opts Options, out chan<- Result) error {
+	defer close(out)
+
+	if opts.Prefix == "" && opts.Suffix == "" {
+		return fmt.Errorf("prefix or suffix is required")
+	}
+	m := newMatcher(opts)
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	searchCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		searchCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var (
+		attempts atomic.Uint64
+		wg       sync.WaitGroup
+	)
+
+	startTime := time.Now()
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-searchCtx.Done():
+					return
+				default:
+				}
+
+				key, err := solana.NewRandomPrivateKey()
+				if err != nil {
+					continue
+				}
+
+				n := attempts.Add(1)
+				addr := key.PublicKey().String()
+				lowerAddr := addr
+				if m.caseInsensitive {
+					lowerAddr = strings.ToLower(addr)
+				}
+				if !m.match(addr, lowerAddr) {
+					continue
+				}
+
+				res := Result{
+					PrivateKey: key,
+					PublicKey:  key.PublicKey(),
+					Attempts:   n,
+					Duration:   time.Since(startTime),
+					Pattern:    opts,
+				}
+				select {
+				case out <- res:
+				case <-searchCtx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	return searchCtx.Err()
+}
+
+// matcher is a pattern normalized for matching.
+type matcher struct {
+	prefix          string
+	suffix          string
+	caseInsensitive bool
+}
+
+func newMatcher(opts Options) matcher {
+	m := matcher{prefix: opts.Prefix, suffix: opts.Suffix, caseInsensitive: opts.CaseInsensitive}
+	// Normalize for case-insensitive matching (if enabled)
+	if m.caseInsensitive {
+		m.prefix = strings.ToLower(m.prefix)
+		m.suffix = strings.ToLower(m.suffix)
+	}
+	return m
+}
+
+func (m matcher) match(addr, lowerAddr string) bool {
+	checkAddr := addr
+	if m.caseInsensitive {
+		checkAddr = lowerAddr
+	}
+	matchPrefix := m.prefix == "" || strings.HasPrefix(checkAddr, m.prefix)
+	matchSuffix := m.suffix == "" || strings.HasSuffix(checkAddr, m.suffix)
+	return matchPrefix && matchSuffix
+}
+
+// matchAny returns the index of the first matcher that accepts addr, or -1.
+func matchAny(matchers []matcher, addr string) int {
+	var lowerAddr string
+	for i, m := range matchers {
+		if m.caseInsensitive && lowerAddr == "" {
+			lowerAddr = strings.ToLower(addr)
+		}
+		if m.match(addr, lowerAddr) {
+			return i
+		}
+	}
+	return -1
+}
+
 // GenerateWithSuffix is a convenience function to generate an address with specific suffix.
 func GenerateWithSuffix(ctx context.Context, suffix string) (*Result, error) {
 	return Generate(ctx, Options{Suffix: suffix})
@@ -162,3 +329,46 @@ func EstimateDifficulty(prefixLen, suffixLen int) uint64 {
 	}
 	return result
 }
+
+// EstimateDifficultyAny estimates the average attempts needed to match any of
+// the given patterns. The per-pattern probabilities are summed, which slightly
+// overestimates the odds when patterns overlap (e.g. "pu" and "pump").
+// Case-insensitive patterns count every base58 character that matches each
+// pattern character, so a letter usually matches 2 of the 58. It returns 0
+// when no pattern can match.
+func EstimateDifficultyAny(patterns []Options) uint64 {
+	var p float64
+	for _, opts := range patterns {
+		p += 1 / expectedAttempts(opts)
+	}
+	if p == 0 {
+		return 0
+	}
+	if p >= 1 {
+		return 1
+	}
+	// Trim the rounding error of 1/(1/n) so an exact count is not rounded up.
+	return uint64(math.Ceil(1 / p * (1 - 1e-12)))
+}
+
+// base58Alphabet is the alphabet of Solana addresses.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// expectedAttempts returns the average attempts for a random address to
+// match opts, or +Inf if no address can.
+func expectedAttempts(opts Options) float64 {
+	attempts := 1.0
+	for _, c := range opts.Prefix + opts.Suffix {
+		n := 0
+		for _, a := range base58Alphabet {
+			if a == c || opts.CaseInsensitive && strings.EqualFold(string(a), string(c)) {
+				n++
+			}
+		}
+		if n == 0 {
+			return math.Inf(1)
+		}
+		attempts *= 58 / float64(n)
+	}
+	return attempts
+}
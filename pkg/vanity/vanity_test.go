@@ -0,0 +1,147 @@
+package vanity
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// drained fails t unless out gets closed, reading off any buffered results.
+func drained(t *testing.T, out <-chan Result) {
+	t.Helper()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-out:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("out not closed")
+		}
+	}
+}
+
+func TestStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan Result, 4)
+	done := make(chan error, 1)
+	go func() { done <- Stream(ctx, Options{Suffix: "a", Workers: 2}, out) }()
+
+	r := <-out
+	if !strings.HasSuffix(r.PublicKey.String(), "a") || r.Attempts == 0 {
+		t.Fatalf("unexpected result %s after %d attempts", r.PublicKey, r.Attempts)
+	}
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Stream returned %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Stream did not return after cancel")
+	}
+	drained(t, out)
+}
+
+func TestStreamTimeout(t *testing.T) {
+	out := make(chan Result, 1)
+	err := Stream(context.Background(), Options{Suffix: "a", Workers: 2, Timeout: 50 * time.Millisecond}, out)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Stream returned %v, want context.DeadlineExceeded", err)
+	}
+	drained(t, out)
+}
+
+func TestStreamBlockedSendDoesNotLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan Result) // never read: every worker blocks on its first match
+	done := make(chan error, 1)
+	go func() { done <- Stream(ctx, Options{Suffix: "a", Workers: 4}, out) }()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Stream returned %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Stream did not return with workers blocked on send")
+	}
+	if _, ok := <-out; ok {
+		t.Fatal("out not closed")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines running after Stream returned, %d before", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestStreamEmptyPattern(t *testing.T) {
+	out := make(chan Result)
+	if err := Stream(context.Background(), Options{}, out); err == nil {
+		t.Fatal("expected an error for an empty pattern")
+	}
+	if _, ok := <-out; ok {
+		t.Fatal("out not closed")
+	}
+}
+
+func TestGenerateAny(t *testing.T) {
+	ctx := context.Background()
+	// "0" is not a base58 character, so only the second pattern can match.
+	patterns := []Options{{Prefix: "0", Workers: 2}, {Suffix: "a", Timeout: 5 * time.Second}}
+	r, err := GenerateAny(ctx, patterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.PatternIndex != 1 || r.Pattern.Suffix != "a" || !strings.HasSuffix(r.PublicKey.String(), "a") {
+		t.Fatalf("matched pattern %d (%+v) with %s, want pattern 1", r.PatternIndex, r.Pattern, r.PublicKey)
+	}
+	if !r.PrivateKey.PublicKey().Equals(r.PublicKey) {
+		t.Fatal("private key does not match public key")
+	}
+
+	if _, err := GenerateAny(ctx, nil); err == nil {
+		t.Fatal("expected an error without patterns")
+	}
+	if _, err := GenerateAny(ctx, []Options{{Suffix: "a"}, {}}); err == nil || !strings.Contains(err.Error(), "pattern 1") {
+		t.Fatalf("expected a pattern 1 validation error, got %v", err)
+	}
+	if _, err := GenerateAny(ctx, []Options{{Suffix: "a", Workers: 2}, {Suffix: "b", Workers: 4}}); err == nil || !strings.Contains(err.Error(), "workers") {
+		t.Fatalf("expected a conflicting workers error, got %v", err)
+	}
+	if _, err := GenerateAny(ctx, []Options{{Suffix: "a", Timeout: time.Second}, {Suffix: "b", Timeout: time.Minute}}); err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Fatalf("expected a conflicting timeout error, got %v", err)
+	}
+}
+
+func TestEstimateDifficultyAny(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []Options
+		want     uint64
+	}{
+		{"single", []Options{{Prefix: "ab", Suffix: "c"}}, EstimateDifficulty(2, 1)},
+		{"two patterns", []Options{{Suffix: "a"}, {Suffix: "b"}}, EstimateDifficulty(0, 1) / 2},
+		{"case-insensitive letter", []Options{{Suffix: "a", CaseInsensitive: true}}, 29},
+		{"case-insensitive digit", []Options{{Suffix: "7", CaseInsensitive: true}}, 58},
+		{"case-insensitive o has one form", []Options{{Suffix: "O", CaseInsensitive: true}}, 58},
+		{"impossible", []Options{{Suffix: "0"}}, 0},
+		{"none", nil, 0},
+	}
+	for _, tt := range tests {
+		if got := EstimateDifficultyAny(tt.patterns); got != tt.want {
+			t.Errorf("%s: EstimateDifficultyAny = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
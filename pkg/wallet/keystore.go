@@ -0,0 +1,153 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/gagliardetto/solana-go"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// ErrInvalidPassphrase is returned when an encrypted keystore cannot be
+// decrypted with the supplied passphrase.
+var ErrInvalidPassphrase = errors.New("invalid passphrase")
+
+const (
+	keystoreVersion = 1
+	keystoreKDF     = "scrypt"
+
+	// scrypt parameters recommended for interactive logins (2017).
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+
+	// Bounds on the scrypt parameters accepted from a keystore file, so a
+	// crafted file cannot make decryption allocate or compute without limit.
+	// scrypt needs 128*N*r bytes of memory.
+	maxScryptN      = 1 << 20
+	maxScryptR      = 32
+	maxScryptP      = 16
+	maxScryptMemory = 1 << 30
+
+	saltLength  = 32
+	nonceLength = 24
+	keyLength   = 32
+)
+
+// encryptedKeystore is the on-disk format written by SaveEncrypted.
+type encryptedKeystore struct {
+	Version    int    `json:"version"`
+	PublicKey  string `json:"public_key"`
+	KDF        string `json:"kdf"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// NewLocalFromEncryptedFile loads a keypair written by SaveEncrypted.
+// Returns ErrInvalidPassphrase if the passphrase does not decrypt the file.
+func NewLocalFromEncryptedFile(path string, passphrase []byte) (Local, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Local{}, fmt.Errorf("read keystore: %w", err)
+	}
+	var ks encryptedKeystore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return Local{}, fmt.Errorf("decode keystore: %w", err)
+	}
+	if ks.Version != keystoreVersion {
+		return Local{}, fmt.Errorf("unsupported keystore version %d", ks.Version)
+	}
+	if ks.KDF != keystoreKDF {
+		return Local{}, fmt.Errorf("unsupported keystore kdf %q", ks.KDF)
+	}
+	if len(ks.Nonce) != nonceLength {
+		return Local{}, fmt.Errorf("invalid keystore nonce length: got %d", len(ks.Nonce))
+	}
+
+	if err := validateScryptParams(ks.N, ks.R, ks.P); err != nil {
+		return Local{}, err
+	}
+
+	secret, err := scrypt.Key(passphrase, ks.Salt, ks.N, ks.R, ks.P, keyLength)
+	if err != nil {
+		return Local{}, fmt.Errorf("derive key: %w", err)
+	}
+	var (
+		boxKey [keyLength]byte
+		nonce  [nonceLength]byte
+	)
+	copy(boxKey[:], secret)
+	copy(nonce[:], ks.Nonce)
+
+	plain, ok := secretbox.Open(nil, ks.Ciphertext, &nonce, &boxKey)
+	if !ok {
+		return Local{}, ErrInvalidPassphrase
+	}
+	if len(plain) != 64 {
+		return Local{}, fmt.Errorf("invalid private key length: got %d", len(plain))
+	}
+	key := solana.PrivateKey(plain)
+	if ks.PublicKey != "" && key.PublicKey().String() != ks.PublicKey {
+		return Local{}, fmt.Errorf("keystore public key mismatch")
+	}
+	return Local{key: key}, nil
+}
+
+// validateScryptParams rejects keystore scrypt parameters that are invalid
+// or exceed the maxScrypt bounds.
+func validateScryptParams(n, r, p int) error {
+	if n < 2 || n > maxScryptN || n&(n-1) != 0 || r < 1 || r > maxScryptR || p < 1 || p > maxScryptP || 128*n*r > maxScryptMemory {
+		return fmt.Errorf("unsupported keystore scrypt parameters n=%d r=%d p=%d", n, r, p)
+	}
+	return nil
+}
+
+// SaveEncrypted writes key to path encrypted with a scrypt-derived
+// nacl/secretbox key. The file is created with 0600 permissions.
+func SaveEncrypted(key solana.PrivateKey, path string, passphrase []byte) error {
+	if len(key) != 64 {
+		return fmt.Errorf("invalid private key length: got %d", len(key))
+	}
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("generate salt: %w", err)
+	}
+	var nonce [nonceLength]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return fmt.Errorf("generate nonce: %w", err)
+	}
+	secret, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, keyLength)
+	if err != nil {
+		return fmt.Errorf("derive key: %w", err)
+	}
+	var boxKey [keyLength]byte
+	copy(boxKey[:], secret)
+
+	ks := encryptedKeystore{
+		Version:    keystoreVersion,
+		PublicKey:  key.PublicKey().String(),
+		KDF:        keystoreKDF,
+		N:          scryptN,
+		R:          scryptR,
+		P:          scryptP,
+		Salt:       salt,
+		Nonce:      nonce[:],
+		Ciphertext: secretbox.Seal(nil, key, &nonce, &boxKey),
+	}
+	data, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode keystore: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write keystore: %w", err)
+	}
+	return nil
+}
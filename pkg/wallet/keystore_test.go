@@ -0,0 +1,98 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestEncryptedKeystoreRoundTrip(t *testing.T) {
+	key, err := solana.NewRandomPrivateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "key.json")
+	passphrase := []byte("correct horse battery staple")
+
+	if err := SaveEncrypted(key, path, passphrase); err != nil {
+		t.Fatalf("SaveEncrypted: %v", err)
+	}
+	local, err := NewLocalFromEncryptedFile(path, passphrase)
+	if err != nil {
+		t.Fatalf("NewLocalFromEncryptedFile: %v", err)
+	}
+	if !local.PublicKey().Equals(key.PublicKey()) {
+		t.Fatalf("public key mismatch: got %s, want %s", local.PublicKey(), key.PublicKey())
+	}
+	if local.key.String() != key.String() {
+		t.Fatalf("private key mismatch after round trip")
+	}
+}
+
+func TestEncryptedKeystoreWrongPassphrase(t *testing.T) {
+	key, err := solana.NewRandomPrivateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "key.json")
+
+	if err := SaveEncrypted(key, path, []byte("right")); err != nil {
+		t.Fatalf("SaveEncrypted: %v", err)
+	}
+	_, err = NewLocalFromEncryptedFile(path, []byte("wrong"))
+	if !errors.Is(err, ErrInvalidPassphrase) {
+		t.Fatalf("expected ErrInvalidPassphrase, got %v", err)
+	}
+}
+
+func TestEncryptedKeystoreScryptBounds(t *testing.T) {
+	key, err := solana.NewRandomPrivateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "key.json")
+	if err := SaveEncrypted(key, path, []byte("pass")); err != nil {
+		t.Fatalf("SaveEncrypted: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved encryptedKeystore
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		n, r, p int
+	}{
+		{"n too large", 1 << 40, 8, 1},
+		{"n not a power of two", 3 << 10, 8, 1},
+		{"n zero", 0, 8, 1},
+		{"r zero", 1 << 15, 0, 1},
+		{"r too large", 1 << 15, 1 << 20, 1},
+		{"p too large", 1 << 15, 8, 1 << 20},
+		{"memory too large", 1 << 20, 16, 1},
+	}
+	for _, tc := range cases {
+		ks := saved
+		ks.N, ks.R, ks.P = tc.n, tc.r, tc.p
+		data, err := json.Marshal(ks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		_, err = NewLocalFromEncryptedFile(path, []byte("pass"))
+		if err == nil || !strings.Contains(err.Error(), "scrypt parameters") {
+			t.Errorf("%s: expected a scrypt parameters error, got %v", tc.name, err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package wallet
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DefaultDerivationPath is the Solana account path used by Phantom, Solflare
+// and solana-keygen for the first account.
+const DefaultDerivationPath = "m/44'/501'/0'/0'"
+
+// hardenedOffset marks a SLIP-0010 child index as hardened.
+const hardenedOffset = 0x80000000
+
+// NewLocalFromMnemonic derives an ed25519 keypair from a BIP39 mnemonic using
+// SLIP-0010. derivationPath defaults to DefaultDerivationPath when empty; use
+// m/44'/501'/x'/0' to select account x. Only hardened segments are supported,
+// as required by SLIP-0010 for ed25519.
+func NewLocalFromMnemonic(mnemonic, passphrase, derivationPath string) (Local, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return Local{}, fmt.Errorf("invalid mnemonic: bad word or checksum")
+	}
+	if derivationPath == "" {
+		derivationPath = DefaultDerivationPath
+	}
+	path, err := parseDerivationPath(derivationPath)
+	if err != nil {
+		return Local{}, err
+	}
+	seed := bip39.NewSeed(mnemonic, passphrase)
+	key := deriveEd25519(seed, path)
+	return Local{key: solana.PrivateKey(ed25519.NewKeyFromSeed(key))}, nil
+}
+
+// parseDerivationPath parses a path like m/44'/501'/0'/0' into hardened indexes.
+func parseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q: must start with m", path)
+	}
+	out := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		if !strings.HasSuffix(part, "'") && !strings.HasSuffix(part, "h") {
+			return nil, fmt.Errorf("invalid derivation path %q: segment %q must be hardened", path, part)
+		}
+		idx, err := strconv.ParseUint(part[:len(part)-1], 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %q: segment %q: %w", path, part, err)
+		}
+		out = append(out, uint32(idx)+hardenedOffset)
+	}
+	return out, nil
+}
+
+// deriveEd25519 walks a SLIP-0010 ed25519 path and returns the 32-byte private seed.
+func deriveEd25519(seed []byte, path []uint32) []byte {
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chain := sum[:32], sum[32:]
+
+	for _, idx := range path {
+		data := make([]byte, 0, 37)
+		data = append(data, 0)
+		data = append(data, key...)
+		data = binary.BigEndian.AppendUint32(data, idx)
+
+		mac = hmac.New(sha512.New, chain)
+		mac.Write(data)
+		sum = mac.Sum(nil)
+		key, chain = sum[:32], sum[32:]
+	}
+	return key
+}
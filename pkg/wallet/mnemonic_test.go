@@ -0,0 +1,46 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestDeriveEd25519SLIP10Vector(t *testing.T) {
+	// SLIP-0010 test vector 1 for ed25519, chain m/0H/1H.
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	got := hex.EncodeToString(deriveEd25519(seed, []uint32{hardenedOffset, 1 + hardenedOffset}))
+	want := "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"
+	if got != want {
+		t.Fatalf("derived key mismatch: got %s, want %s", got, want)
+	}
+}
+
+func TestNewLocalFromMnemonic(t *testing.T) {
+	local, err := NewLocalFromMnemonic(testMnemonic, "", "")
+	if err != nil {
+		t.Fatalf("NewLocalFromMnemonic: %v", err)
+	}
+	want := "HAgk14JpMQLgt6rVgv7cBQFJWFto5Dqxi472uT3DKpqk"
+	if got := local.PublicKey().String(); got != want {
+		t.Fatalf("public key mismatch: got %s, want %s", got, want)
+	}
+
+	second, err := NewLocalFromMnemonic(testMnemonic, "", "m/44'/501'/1'/0'")
+	if err != nil {
+		t.Fatalf("NewLocalFromMnemonic account 1: %v", err)
+	}
+	if second.PublicKey().Equals(local.PublicKey()) {
+		t.Fatalf("account 1 should differ from account 0")
+	}
+}
+
+func TestNewLocalFromMnemonicInvalid(t *testing.T) {
+	if _, err := NewLocalFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "", ""); err == nil {
+		t.Fatal("expected checksum error")
+	}
+	if _, err := NewLocalFromMnemonic(testMnemonic, "", "m/44'/501'/0/0"); err == nil {
+		t.Fatal("expected error for non-hardened path")
+	}
+}
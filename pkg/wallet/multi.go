@@ -0,0 +1,131 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// MultiSigner groups several signers that co-sign the same transactions
+// (e.g. treasury operations requiring a fee payer plus authorities).
+//
+// MultiSigner itself implements Signer using the first signer as the primary
+// (fee payer) key, so it can be passed wherever a single payer is expected.
+// Use SignAll or SignTransaction to collect every signature in parallel.
+type MultiSigner struct {
+	signers []Signer
+}
+
+// NewMultiSigner constructs a MultiSigner. The first signer is the primary.
+func NewMultiSigner(signers ...Signer) *MultiSigner {
+	return &MultiSigner{signers: signers}
+}
+
+// Signers returns the underlying signers in construction order.
+func (m *MultiSigner) Signers() []Signer {
+	out := make([]Signer, len(m.signers))
+	copy(out, m.signers)
+	return out
+}
+
+// PublicKey returns the primary signer's public key.
+func (m *MultiSigner) PublicKey() solana.PublicKey {
+	if len(m.signers) == 0 {
+		return solana.PublicKey{}
+	}
+	return m.signers[0].PublicKey()
+}
+
+// SignMessage signs with the primary signer only.
+func (m *MultiSigner) SignMessage(ctx context.Context, message []byte) (solana.Signature, error) {
+	if len(m.signers) == 0 {
+		return solana.Signature{}, fmt.Errorf("multi signer has no signers")
+	}
+	return m.signers[0].SignMessage(ctx, message)
+}
+
+// SignAll dispatches message to every signer in parallel.
+// The returned signatures are in the same order as the signers passed to
+// NewMultiSigner, regardless of which signer finishes first. If any signer
+// fails, the errors from all failing signers are joined and no signatures
+// are returned.
+func (m *MultiSigner) SignAll(ctx context.Context, message []byte) ([]solana.Signature, error) {
+	if len(m.signers) == 0 {
+		return nil, fmt.Errorf("multi signer has no signers")
+	}
+	return signParallel(ctx, m.signers, message)
+}
+
+// SignTransaction fills tx.Signatures for every required signer slot.
+// Signatures are placed by account-key index, matching the order the runtime
+// expects. All required signers must be present in the MultiSigner; extra
+// signers are ignored. On error tx.Signatures is left untouched.
+func (m *MultiSigner) SignTransaction(ctx context.Context, tx *solana.Transaction) error {
+	if tx == nil {
+		return fmt.Errorf("transaction is nil")
+	}
+	required := int(tx.Message.Header.NumRequiredSignatures)
+	if len(tx.Message.AccountKeys) < required {
+		return fmt.Errorf("not enough account keys for required signatures")
+	}
+
+	byKey := make(map[solana.PublicKey]Signer, len(m.signers))
+	for _, s := range m.signers {
+		byKey[s.PublicKey()] = s
+	}
+	ordered := make([]Signer, required)
+	var missing []error
+	for i := 0; i < required; i++ {
+		pk := tx.Message.AccountKeys[i]
+		s, ok := byKey[pk]
+		if !ok {
+			missing = append(missing, fmt.Errorf("missing signer for %s", pk))
+			continue
+		}
+		ordered[i] = s
+	}
+	if len(missing) > 0 {
+		return errors.Join(missing...)
+	}
+
+	messageBytes, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("encode message: %w", err)
+	}
+	sigs, err := signParallel(ctx, ordered, messageBytes)
+	if err != nil {
+		return err
+	}
+	tx.Signatures = sigs
+	return nil
+}
+
+// signParallel signs message with each signer concurrently and returns the
+// signatures in signer order, or the joined errors of all failed signers.
+func signParallel(ctx context.Context, signers []Signer, message []byte) ([]solana.Signature, error) {
+	sigs := make([]solana.Signature, len(signers))
+	errs := make([]error, len(signers))
+
+	var wg sync.WaitGroup
+	for i, s := range signers {
+		wg.Add(1)
+		go func(i int, s Signer) {
+			defer wg.Done()
+			sig, err := s.SignMessage(ctx, message)
+			if err != nil {
+				errs[i] = fmt.Errorf("sign message for %s: %w", s.PublicKey(), err)
+				return
+			}
+			sigs[i] = sig
+		}(i, s)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return sigs, nil
+}
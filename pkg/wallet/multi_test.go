@@ -0,0 +1,49 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestMultiSignerSignAllOrder(t *testing.T) {
+	var signers []Signer
+	for i := 0; i < 4; i++ {
+		key, _ := solana.NewRandomPrivateKey()
+		signers = append(signers, NewLocalFromPrivateKey(key))
+	}
+	ms := NewMultiSigner(signers...)
+	msg := []byte("treasury")
+
+	sigs, err := ms.SignAll(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("SignAll: %v", err)
+	}
+	for i, s := range signers {
+		if !sigs[i].Verify(s.PublicKey(), msg) {
+			t.Fatalf("signature %d does not belong to signer %d", i, i)
+		}
+	}
+}
+
+func TestMultiSignerAggregatesErrors(t *testing.T) {
+	key, _ := solana.NewRandomPrivateKey()
+	failA := NewRemoteSigner(solana.NewWallet().PublicKey(), func(ctx context.Context, message []byte) ([]byte, error) {
+		return nil, errors.New("offline A")
+	})
+	failB := NewRemoteSigner(solana.NewWallet().PublicKey(), func(ctx context.Context, message []byte) ([]byte, error) {
+		return nil, errors.New("offline B")
+	})
+	ms := NewMultiSigner(NewLocalFromPrivateKey(key), failA, failB)
+
+	_, err := ms.SignAll(context.Background(), []byte("msg"))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "offline A") || !strings.Contains(err.Error(), "offline B") {
+		t.Fatalf("expected both failures in error, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package wallet
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// ReadTransactionFile reads a base64-encoded transaction, signed or not.
+func ReadTransactionFile(path string) (*solana.Transaction, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read transaction file: %w", err)
+	}
+	tx, err := solana.TransactionFromBase64(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("decode transaction file %s: %w", path, err)
+	}
+	return tx, nil
+}
+
+// WriteTransactionFile writes tx base64-encoded to path with mode 0600.
+func WriteTransactionFile(tx *solana.Transaction, path string) error {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("encode transaction: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(raw)+"\n"), 0o600); err != nil {
+		return fmt.Errorf("write transaction file: %w", err)
+	}
+	return nil
+}
+
+// SignToFile signs the transaction in inPath with signers and writes it to
+// outPath, for air-gapped signing. Only the slots of the given signers are
+// filled; signatures already present are kept, so several parties can sign
+// the same file in turn. At least one signer must be a required signer.
+//
+// A recent blockhash expires about a minute after it is fetched, so
+// transactions signed offline should use a durable nonce: the first
+// instruction advances a nonce account and the nonce is the blockhash.
+//
+// Example:
+//
+//	tx, err := wallet.SignToFile(ctx, "unsigned.b64", "signed.b64", signer)
+func SignToFile(ctx context.Context, inPath, outPath string, signers ...Signer) (*solana.Transaction, error) {
+	tx, err := ReadTransactionFile(inPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := SignPartial(ctx, tx, signers...); err != nil {
+		return nil, err
+	}
+	if err := WriteTransactionFile(tx, outPath); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// SignPartial fills the signature slots of tx that belong to signers,
+// keeping any signatures already present. It fails if none of signers is a
+// required signer of tx.
+func SignPartial(ctx context.Context, tx *solana.Transaction, signers ...Signer) error {
+	if tx == nil {
+		return fmt.Errorf("transaction is nil")
+	}
+	required := int(tx.Message.Header.NumRequiredSignatures)
+	if len(tx.Message.AccountKeys) < required {
+		return fmt.Errorf("not enough account keys for required signatures")
+	}
+	messageBytes, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("encode message: %w", err)
+	}
+
+	sigs := make([]solana.Signature, required)
+	copy(sigs, tx.Signatures)
+	signed := 0
+	for _, s := range signers {
+		for i := 0; i < required; i++ {
+			if !tx.Message.AccountKeys[i].Equals(s.PublicKey()) {
+				continue
+			}
+			sig, err := s.SignMessage(ctx, messageBytes)
+			if err != nil {
+				return fmt.Errorf("sign message for %s: %w", s.PublicKey(), err)
+			}
+			sigs[i] = sig
+			signed++
+		}
+	}
+	if signed == 0 {
+		return fmt.Errorf("no signer matches a required signer of the transaction")
+	}
+	tx.Signatures = sigs
+	return nil
+}
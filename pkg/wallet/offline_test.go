@@ -0,0 +1,55 @@
+package wallet
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestSignToFileTwoParties(t *testing.T) {
+	payerKey, _ := solana.NewRandomPrivateKey()
+	otherKey, _ := solana.NewRandomPrivateKey()
+	payer, other := NewLocalFromPrivateKey(payerKey), NewLocalFromPrivateKey(otherKey)
+
+	ix := solana.NewInstruction(solana.SystemProgramID, solana.AccountMetaSlice{
+		solana.NewAccountMeta(payer.PublicKey(), true, true),
+		solana.NewAccountMeta(other.PublicKey(), true, true),
+	}, []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0})
+	tx, err := solana.NewTransaction([]solana.Instruction{ix}, solana.Hash{1}, solana.TransactionPayer(payer.PublicKey()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	unsigned := filepath.Join(dir, "unsigned.b64")
+	half := filepath.Join(dir, "half.b64")
+	signed := filepath.Join(dir, "signed.b64")
+	if err := WriteTransactionFile(tx, unsigned); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, err := SignToFile(ctx, unsigned, half, other); err != nil {
+		t.Fatalf("first signer: %v", err)
+	}
+	out, err := SignToFile(ctx, half, signed, payer)
+	if err != nil {
+		t.Fatalf("second signer: %v", err)
+	}
+	if err := out.VerifySignatures(); err != nil {
+		t.Fatalf("signatures do not verify: %v", err)
+	}
+	back, err := ReadTransactionFile(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.Signatures[0] != out.Signatures[0] || back.Signatures[1] != out.Signatures[1] {
+		t.Fatal("file signatures differ from returned transaction")
+	}
+
+	stranger, _ := solana.NewRandomPrivateKey()
+	if _, err := SignToFile(ctx, unsigned, signed, NewLocalFromPrivateKey(stranger)); err == nil {
+		t.Fatal("expected error for a signer that is not required")
+	}
+}
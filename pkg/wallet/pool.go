@@ -0,0 +1,126 @@
+package wallet
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// PoolOption configures a Pool.
+type PoolOption func(*Pool)
+
+// WithLeastBusy makes Next and Acquire pick the signer with the fewest
+// in-flight transactions (ties broken round-robin) instead of plain round-robin.
+// In-flight counts are only tracked for signers handed out by Acquire.
+func WithLeastBusy() PoolOption {
+	return func(p *Pool) {
+		p.leastBusy = true
+	}
+}
+
+// Pool rotates between several fee payers so a bot can spread transactions
+// across accounts. It is safe for concurrent use.
+type Pool struct {
+	signers   []Signer
+	byKey     map[solana.PublicKey]int
+	inFlight  []atomic.Int64
+	next      atomic.Uint64
+	leastBusy bool
+	mu        sync.Mutex // serializes least-busy selection
+}
+
+// NewPool constructs a signer pool. Duplicate public keys keep the first signer.
+func NewPool(signers []Signer, opts ...PoolOption) *Pool {
+	p := &Pool{byKey: make(map[solana.PublicKey]int, len(signers))}
+	for _, s := range signers {
+		if s == nil {
+			continue
+		}
+		if _, dup := p.byKey[s.PublicKey()]; dup {
+			continue
+		}
+		p.byKey[s.PublicKey()] = len(p.signers)
+		p.signers = append(p.signers, s)
+	}
+	p.inFlight = make([]atomic.Int64, len(p.signers))
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Len returns the number of signers in the pool.
+func (p *Pool) Len() int {
+	return len(p.signers)
+}
+
+// Next returns the next signer, or nil if the pool is empty.
+func (p *Pool) Next() Signer {
+	idx := p.pick()
+	if idx < 0 {
+		return nil
+	}
+	return p.signers[idx]
+}
+
+// Acquire returns the next signer and marks it in-flight until release is
+// called. release is safe to call more than once.
+//
+// Example:
+//
+//	payer, release := pool.Acquire()
+//	defer release()
+//	sig, err := builder.BuildSignSend(ctx, payer, nil, instrs...)
+func (p *Pool) Acquire() (Signer, func()) {
+	idx := p.pick()
+	if idx < 0 {
+		return nil, func() {}
+	}
+	p.inFlight[idx].Add(1)
+	var once sync.Once
+	return p.signers[idx], func() {
+		once.Do(func() { p.inFlight[idx].Add(-1) })
+	}
+}
+
+// Signer looks up a signer by public key.
+func (p *Pool) Signer(pub solana.PublicKey) (Signer, bool) {
+	idx, ok := p.byKey[pub]
+	if !ok {
+		return nil, false
+	}
+	return p.signers[idx], true
+}
+
+// InFlight returns the number of outstanding Acquire calls for pub.
+func (p *Pool) InFlight(pub solana.PublicKey) int {
+	idx, ok := p.byKey[pub]
+	if !ok {
+		return 0
+	}
+	return int(p.inFlight[idx].Load())
+}
+
+func (p *Pool) pick() int {
+	n := len(p.signers)
+	if n == 0 {
+		return -1
+	}
+	start := int((p.next.Add(1) - 1) % uint64(n))
+	if !p.leastBusy {
+		return start
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	best := start
+	bestLoad := p.inFlight[start].Load()
+	for i := 1; i < n && bestLoad > 0; i++ {
+		idx := (start + i) % n
+		if load := p.inFlight[idx].Load(); load < bestLoad {
+			best, bestLoad = idx, load
+		}
+	}
+	return best
+}
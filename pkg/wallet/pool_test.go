@@ -0,0 +1,74 @@
+package wallet
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func newTestSigners(n int) []Signer {
+	out := make([]Signer, n)
+	for i := range out {
+		key, _ := solana.NewRandomPrivateKey()
+		out[i] = NewLocalFromPrivateKey(key)
+	}
+	return out
+}
+
+func TestPoolRoundRobin(t *testing.T) {
+	signers := newTestSigners(3)
+	pool := NewPool(signers)
+	for i := 0; i < 6; i++ {
+		got := pool.Next()
+		if !got.PublicKey().Equals(signers[i%3].PublicKey()) {
+			t.Fatalf("call %d: got %s, want %s", i, got.PublicKey(), signers[i%3].PublicKey())
+		}
+	}
+	if s, ok := pool.Signer(signers[1].PublicKey()); !ok || !s.PublicKey().Equals(signers[1].PublicKey()) {
+		t.Fatal("lookup by pubkey failed")
+	}
+}
+
+func TestPoolLeastBusy(t *testing.T) {
+	signers := newTestSigners(3)
+	pool := NewPool(signers, WithLeastBusy())
+
+	a, releaseA := pool.Acquire()
+	b, releaseB := pool.Acquire()
+	c, _ := pool.Acquire()
+	if a.PublicKey().Equals(b.PublicKey()) || b.PublicKey().Equals(c.PublicKey()) {
+		t.Fatal("expected distinct signers while all are busy")
+	}
+	releaseB()
+	releaseB() // idempotent
+	if got := pool.InFlight(b.PublicKey()); got != 0 {
+		t.Fatalf("in-flight after release: got %d, want 0", got)
+	}
+	if next := pool.Next(); !next.PublicKey().Equals(b.PublicKey()) {
+		t.Fatalf("expected least busy signer %s, got %s", b.PublicKey(), next.PublicKey())
+	}
+	releaseA()
+}
+
+func TestPoolConcurrent(t *testing.T) {
+	pool := NewPool(newTestSigners(4), WithLeastBusy())
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, release := pool.Acquire()
+			defer release()
+			if s == nil {
+				t.Error("nil signer")
+			}
+		}()
+	}
+	wg.Wait()
+	for _, s := range pool.signers {
+		if n := pool.InFlight(s.PublicKey()); n != 0 {
+			t.Fatalf("in-flight leak for %s: %d", s.PublicKey(), n)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// maxSignerResponse caps the response body read from a remote signer.
+const maxSignerResponse = 4096
+
+// HTTPSignerOption configures an HTTP remote signer.
+type HTTPSignerOption func(*httpSigner)
+
+// WithAuthHeader sets a header sent with every sign request,
+// e.g. WithAuthHeader("Authorization", "Bearer <token>").
+func WithAuthHeader(name, value string) HTTPSignerOption {
+	return func(h *httpSigner) {
+		h.headers.Set(name, value)
+	}
+}
+
+// WithHTTPClient overrides the HTTP client (default: 10s timeout).
+func WithHTTPClient(client *http.Client) HTTPSignerOption {
+	return func(h *httpSigner) {
+		if client != nil {
+			h.client = client
+		}
+	}
+}
+
+type httpSigner struct {
+	endpoint string
+	pub      solana.PublicKey
+	client   *http.Client
+	headers  http.Header
+}
+
+// NewHTTPRemoteSigner constructs a remote signer that POSTs the raw message
+// bytes (application/octet-stream) to endpoint. The endpoint must answer 200
+// with the base64 signature, either as the plain body or as
+// {"signature":"<base64>"}. The signature is verified against the message and
+// pubkey before it is returned.
+//
+// Example:
+//
+//	signer := wallet.NewHTTPRemoteSigner("https://signer.internal/sign", pub,
+//	    wallet.WithAuthHeader("Authorization", "Bearer "+token),
+//	)
+func NewHTTPRemoteSigner(endpoint string, pubkey solana.PublicKey, opts ...HTTPSignerOption) RemoteSigner {
+	h := &httpSigner{
+		endpoint: endpoint,
+		pub:      pubkey,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		headers:  make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return NewRemoteSigner(pubkey, h.sign)
+}
+
+func (h *httpSigner) sign(ctx context.Context, message []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.endpoint, bytes.NewReader(message))
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Signer-Pubkey", h.pub.String())
+	for name, values := range h.headers {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("post sign request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSignerResponse))
+	if err != nil {
+		return nil, fmt.Errorf("read sign response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("signer returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	encoded := strings.TrimSpace(string(body))
+	if strings.HasPrefix(encoded, "{") {
+		var payload struct {
+			Signature string `json:"signature"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("decode sign response: %w", err)
+		}
+		encoded = payload.Signature
+	}
+	sig, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode signature: %w", err)
+	}
+	if len(sig) != solana.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length: got %d", len(sig))
+	}
+	return sig, nil
+}
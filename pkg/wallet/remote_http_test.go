@@ -0,0 +1,72 @@
+package wallet
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func newTestSignServer(t *testing.T, key solana.PrivateKey, token string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		msg, _ := io.ReadAll(r.Body)
+		sig, err := key.Sign(msg)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, _ = io.WriteString(w, base64.StdEncoding.EncodeToString(sig[:]))
+	}))
+}
+
+func TestHTTPRemoteSigner(t *testing.T) {
+	key, _ := solana.NewRandomPrivateKey()
+	srv := newTestSignServer(t, key, "secret")
+	defer srv.Close()
+
+	signer := NewHTTPRemoteSigner(srv.URL, key.PublicKey(), WithAuthHeader("Authorization", "Bearer secret"))
+	msg := []byte("hello pump")
+	sig, err := signer.SignMessage(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("SignMessage: %v", err)
+	}
+	if !sig.Verify(key.PublicKey(), msg) {
+		t.Fatal("returned signature does not verify")
+	}
+}
+
+func TestHTTPRemoteSignerUnauthorized(t *testing.T) {
+	key, _ := solana.NewRandomPrivateKey()
+	srv := newTestSignServer(t, key, "secret")
+	defer srv.Close()
+
+	signer := NewHTTPRemoteSigner(srv.URL, key.PublicKey())
+	if _, err := signer.SignMessage(context.Background(), []byte("msg")); err == nil {
+		t.Fatal("expected error without auth header")
+	}
+}
+
+func TestHTTPRemoteSignerRejectsWrongKey(t *testing.T) {
+	key, _ := solana.NewRandomPrivateKey()
+	other, _ := solana.NewRandomPrivateKey()
+	srv := newTestSignServer(t, other, "secret")
+	defer srv.Close()
+
+	signer := NewHTTPRemoteSigner(srv.URL, key.PublicKey(), WithAuthHeader("Authorization", "Bearer secret"))
+	if _, err := signer.SignMessage(context.Background(), []byte("msg")); err == nil {
+		t.Fatal("expected verification error for signature from another key")
+	}
+}
@@ -2,11 +2,17 @@ package wallet
 
 import (
 	"context"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
 
 	"github.com/gagliardetto/solana-go"
 )
 
+// ErrSignatureMismatch is returned when a remote signer's signature does not
+// verify against its public key.
+var ErrSignatureMismatch = errors.New("signature does not verify")
+
 // Signer performs detached signatures for transaction messages.
 type Signer interface {
 	PublicKey() solana.PublicKey
@@ -66,7 +72,8 @@ type RemoteSigner struct {
 	SignFunc func(ctx context.Context, message []byte) ([]byte, error)
 }
 
-// NewRemoteSigner constructs a remote signer.
+// NewRemoteSigner constructs a remote signer. Every signature returned by fn
+// is verified against pub, so a faulty signer fails here rather than on-chain.
 func NewRemoteSigner(pub solana.PublicKey, fn func(ctx context.Context, message []byte) ([]byte, error)) RemoteSigner {
 	return RemoteSigner{
 		pub:      pub,
@@ -79,7 +86,7 @@ func (r RemoteSigner) PublicKey() solana.PublicKey {
 	return r.pub
 }
 
-// SignMessage obtains a signature from the remote function.
+// SignMessage obtains a signature from the remote function and verifies it.
 func (r RemoteSigner) SignMessage(ctx context.Context, message []byte) (solana.Signature, error) {
 	if r.SignFunc == nil {
 		return solana.Signature{}, fmt.Errorf("sign func not set")
@@ -91,6 +98,9 @@ func (r RemoteSigner) SignMessage(ctx context.Context, message []byte) (solana.S
 	if len(raw) != solana.SignatureLength {
 		return solana.Signature{}, fmt.Errorf("invalid signature length: got %d", len(raw))
 	}
+	if !ed25519.Verify(ed25519.PublicKey(r.pub[:]), message, raw) {
+		return solana.Signature{}, fmt.Errorf("remote sign for %s: %w", r.pub, ErrSignatureMismatch)
+	}
 	var sig solana.Signature
 	copy(sig[:], raw)
 	return sig, nil
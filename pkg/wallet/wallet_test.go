@@ -0,0 +1,29 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestRemoteSignerVerifiesSignature(t *testing.T) {
+	key, _ := solana.NewRandomPrivateKey()
+	msg := []byte("hello pump")
+
+	good := NewRemoteSigner(key.PublicKey(), func(ctx context.Context, message []byte) ([]byte, error) {
+		sig, err := key.Sign(message)
+		return sig[:], err
+	})
+	if _, err := good.SignMessage(context.Background(), msg); err != nil {
+		t.Fatalf("valid signature rejected: %v", err)
+	}
+
+	garbage := NewRemoteSigner(key.PublicKey(), func(ctx context.Context, message []byte) ([]byte, error) {
+		return make([]byte, solana.SignatureLength), nil
+	})
+	if _, err := garbage.SignMessage(context.Background(), msg); !errors.Is(err, ErrSignatureMismatch) {
+		t.Fatalf("err = %v, want ErrSignatureMismatch", err)
+	}
+}